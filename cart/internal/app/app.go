@@ -7,15 +7,16 @@ import (
 	"net/http"
 	"net/http/pprof"
 
+	"route256/cart/internal/app/handlers/change_cart_item_handler"
 	"route256/cart/internal/app/handlers/checkout_handler"
 	"route256/cart/internal/app/handlers/create_cart_item_handler"
 	"route256/cart/internal/app/handlers/delete_cart_handler"
 	"route256/cart/internal/app/handlers/delete_cart_item_handler"
 	"route256/cart/internal/app/handlers/get_cart_items_handler"
-	"route256/cart/internal/domain/cart/repository"
+	"route256/cart/internal/app/handlers/update_cart_item_handler"
 	"route256/cart/internal/domain/cart/service"
 	"route256/cart/internal/domain/loms"
-	"route256/cart/internal/domain/products"
+	"route256/cart/internal/infra/breaker"
 	"route256/cart/internal/infra/cart_config"
 	"route256/cart/internal/infra/logger"
 	"route256/cart/internal/infra/sre"
@@ -76,18 +77,20 @@ func bootstrapHandler(ctx context.Context, config *cart_config.Config) http.Hand
 	ctx, span := otel.Tracer("initialize").Start(ctx, "bootstrap")
 	defer span.End()
 
-	productClient := products.NewProductsClient(config)
-	orderClient := loms.NewOrderClient(config)
-	cartRepository := repository.NewCartRepository()
-	cartService := service.NewCartService(cartRepository, productClient, orderClient)
+	productClient := initializeProductService(config)
+	lomsBreaker := breaker.NewCircuitBreaker("loms", breaker.ConfigFrom(config))
+	orderClient := loms.NewOrderClient(config, lomsBreaker)
+	stocksClient := loms.NewStocksClient(config, lomsBreaker)
+	cartRepository := initializeCartRepository(ctx, config)
+	cartService := service.NewCartService(cartRepository, productClient, orderClient, stocksClient)
 	mux := http.NewServeMux()
 
-	go repository.StartCollectingRepositoryStats(ctx, cartRepository)
-
 	mux.Handle("GET /user/{user_id}/cart", get_cart_items_handler.New(cartService))
 	mux.Handle("DELETE /user/{user_id}/cart", delete_cart_handler.New(cartService))
 
 	mux.Handle("POST /user/{user_id}/cart/{sku_id}", create_cart_item_handler.New(cartService))
+	mux.Handle("PUT /user/{user_id}/cart/{sku_id}", update_cart_item_handler.New(cartService))
+	mux.Handle("PATCH /user/{user_id}/cart/{sku_id}", change_cart_item_handler.New(cartService))
 	mux.Handle("DELETE /user/{user_id}/cart/{sku_id}", delete_cart_item_handler.New(cartService))
 
 	mux.Handle("POST /checkout/{user_id}", checkout_handler.New(cartService))
@@ -0,0 +1,91 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"route256/cart/internal/domain/cart/repository"
+	"route256/cart/internal/domain/cart/repository_pg"
+	"route256/cart/internal/domain/cart/service"
+	"route256/cart/internal/domain/products"
+	"route256/cart/internal/infra/cart_config"
+	"route256/cart/internal/infra/logger"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+)
+
+func initializeCartRepository(ctx context.Context, config *cart_config.Config) service.CartRepository {
+	ctx, span := otel.Tracer("initialize").Start(ctx, "initialize.cart_repository")
+	defer span.End()
+
+	if config.Server.IsInMemory {
+		cartRepository := repository.NewCartRepository()
+		go repository.StartCollectingRepositoryStats(ctx, cartRepository)
+
+		return cartRepository
+	}
+
+	pool, err := connectToDatabase(ctx, config)
+	if err != nil {
+		logger.Fatal("Failed to connect to database", "error", err)
+	}
+
+	return repository_pg.NewCartRepository(pool)
+}
+
+func initializeProductService(config *cart_config.Config) service.ProductService {
+	productClient := products.NewProductsClient(config)
+	if config.Products.Cache.Size == 0 {
+		return productClient
+	}
+
+	return products.NewCachedProductsClient(productClient, config)
+}
+
+func connectToDatabase(ctx context.Context, config *cart_config.Config) (*pgxpool.Pool, error) {
+	const addressTemplate = "postgresql://%s:%s@%s:%s/%s?sslmode=disable"
+
+	poolCfg, err := pgxpool.ParseConfig(fmt.Sprintf(addressTemplate,
+		config.Db.User, config.Db.Password, config.Db.Host,
+		config.Db.Port, config.Db.DbName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse db config: %w", err)
+	}
+
+	logger.Info("Connecting to db", "db", config.Db.DbName)
+	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to db: %w", err)
+	}
+
+	_, span := otel.Tracer("initialize").Start(ctx, "db.connect")
+	defer span.End()
+
+	bootCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if err := waitForDatabase(bootCtx, pool); err != nil {
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+func waitForDatabase(ctx context.Context, pool *pgxpool.Pool) error {
+	times := 0
+	for {
+		err := pool.Ping(ctx)
+		if err == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("context cancelled while waiting for db: %w", ctx.Err())
+		}
+		logger.Info("Waiting for db to be ready", "times", times)
+		time.Sleep(time.Second)
+		times += 1
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package change_cart_item_handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"route256/cart/internal/domain/model"
+	"route256/cart/internal/infra/infra_http"
+	"route256/cart/pkg/route_http"
+
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel"
+)
+
+type CartService interface {
+	ChangeCount(ctx context.Context, userId int64, skuId int64, delta int64) (uint32, error)
+}
+
+type ChangeCartItemHandler struct {
+	service   CartService
+	validator *validator.Validate
+}
+
+func New(service CartService) *ChangeCartItemHandler {
+	return &ChangeCartItemHandler{
+		service:   service,
+		validator: validator.New(validator.WithRequiredStructEnabled()),
+	}
+}
+
+func (handler *ChangeCartItemHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("handler").Start(r.Context(), "change_cart_item_handler.ServeHTTP")
+	defer span.End()
+	defer r.Body.Close()
+
+	userId, err := infra_http.GetInt64PathValueGt0(r, "user_id")
+	if err != nil {
+		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	skuId, err := infra_http.GetInt64PathValueGt0(r, "sku_id")
+	if err != nil {
+		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	var changeItemRequest ChangeCartItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&changeItemRequest); err != nil {
+		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	if err := handler.validator.Struct(&changeItemRequest); err != nil {
+		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	count, err := handler.service.ChangeCount(ctx, userId, skuId, *changeItemRequest.Delta)
+	if err != nil {
+		if errors.Is(err, model.ErrProductDoesNotExist) || errors.Is(err, model.ErrNotEnoughItemsInStock) {
+			route_http.WriteErrorJson(w, http.StatusPreconditionFailed, err)
+		} else if errors.Is(err, model.ErrCartLocked) {
+			route_http.WriteErrorJson(w, http.StatusConflict, err)
+		} else {
+			route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+		}
+
+		return
+	}
+
+	route_http.WriteJson(w, http.StatusOK, &ChangeCartItemResponse{Count: count})
+}
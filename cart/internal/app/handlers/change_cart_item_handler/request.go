@@ -0,0 +1,5 @@
+package change_cart_item_handler
+
+type ChangeCartItemRequest struct {
+	Delta *int64 `json:"delta" validate:"required"`
+}
@@ -0,0 +1,5 @@
+package change_cart_item_handler
+
+type ChangeCartItemResponse struct {
+	Count uint32 `json:"count"`
+}
@@ -3,6 +3,7 @@ package checkout_handler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"route256/cart/internal/domain/model"
 	"route256/cart/internal/infra/infra_http"
@@ -12,9 +13,12 @@ import (
 )
 
 type CartService interface {
-	Checkout(ctx context.Context, userId int64) (int64, error)
+	Checkout(ctx context.Context, userId int64, idempotencyKey string, expectedVersion int64) (int64, error)
 }
 
+const IdempotencyKeyHeader = "Idempotency-Key"
+const maxIdempotencyKeyLength = 128
+
 type CheckoutHandler struct {
 	cartService CartService
 }
@@ -34,8 +38,35 @@ func (h *CheckoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	orderId, err := h.cartService.Checkout(ctx, userId)
+	idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		route_http.WriteErrorJson(w, http.StatusBadRequest,
+			fmt.Errorf("%s header is longer than %d", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+
+		return
+	}
+
+	expectedVersion, err := infra_http.GetIfMatchVersion(r)
 	if err != nil {
+		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	orderId, err := h.cartService.Checkout(ctx, userId, idempotencyKey, expectedVersion)
+	if err != nil {
+		if errors.Is(err, model.ErrCartChanged) || errors.Is(err, model.ErrCartLocked) {
+			route_http.WriteErrorJson(w, http.StatusConflict, err)
+
+			return
+		}
+
+		if errors.Is(err, model.ErrIdempotencyKeyReused) {
+			route_http.WriteErrorJson(w, http.StatusUnprocessableEntity, err)
+
+			return
+		}
+
 		if errors.Is(err, model.ErrCreateOrderPreconditionFailed) {
 			route_http.WriteErrorJson(w, http.StatusPreconditionFailed, err)
 
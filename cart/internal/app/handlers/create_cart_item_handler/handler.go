@@ -69,6 +69,8 @@ func (handler *CreateCartItemHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 	if err != nil {
 		if errors.Is(err, model.ErrProductDoesNotExist) || errors.Is(err, model.ErrNotEnoughItemsInStock) {
 			route_http.WriteErrorJson(w, http.StatusPreconditionFailed, err)
+		} else if errors.Is(err, model.ErrCartLocked) {
+			route_http.WriteErrorJson(w, http.StatusConflict, err)
 		} else {
 			route_http.WriteErrorJson(w, http.StatusBadRequest, err)
 		}
@@ -2,7 +2,9 @@ package delete_cart_handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"route256/cart/internal/domain/model"
 	"route256/cart/internal/infra/infra_http"
 	"route256/cart/pkg/route_http"
 
@@ -35,7 +37,11 @@ func (h *ClearCartHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.DeleteAll(ctx, userId); err != nil {
-		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+		if errors.Is(err, model.ErrCartLocked) {
+			route_http.WriteErrorJson(w, http.StatusConflict, err)
+		} else {
+			route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+		}
 
 		return
 	}
@@ -2,7 +2,9 @@ package delete_cart_item_handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"route256/cart/internal/domain/model"
 	"route256/cart/internal/infra/infra_http"
 	"route256/cart/pkg/route_http"
 
@@ -42,7 +44,11 @@ func (handler *DeleteCartItemHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 	}
 
 	if err := handler.service.DeleteBySkuId(ctx, userId, skuId); err != nil {
-		route_http.WriteErrorJson(w, http.StatusInternalServerError, err)
+		if errors.Is(err, model.ErrCartLocked) {
+			route_http.WriteErrorJson(w, http.StatusConflict, err)
+		} else {
+			route_http.WriteErrorJson(w, http.StatusInternalServerError, err)
+		}
 
 		return
 	}
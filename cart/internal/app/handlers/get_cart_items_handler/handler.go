@@ -55,6 +55,7 @@ func (handler *GetCartItemBySkuHandler) ServeHTTP(w http.ResponseWriter, r *http
 		}
 	}
 
+	w.Header().Set(infra_http.ETagHeader, infra_http.FormatETag(items.Version))
 	route_http.WriteJson(w, http.StatusOK, GetCartItemsResponse{
 		Items:      responseItems,
 		TotalPrice: items.Total,
@@ -0,0 +1,78 @@
+package update_cart_item_handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"route256/cart/internal/domain/model"
+	"route256/cart/internal/infra/infra_http"
+	"route256/cart/pkg/route_http"
+
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel"
+)
+
+type CartService interface {
+	UpdateCount(ctx context.Context, userId int64, skuId int64, count uint32) error
+}
+
+type UpdateCartItemHandler struct {
+	service   CartService
+	validator *validator.Validate
+}
+
+func New(service CartService) *UpdateCartItemHandler {
+	return &UpdateCartItemHandler{
+		service:   service,
+		validator: validator.New(validator.WithRequiredStructEnabled()),
+	}
+}
+
+func (handler *UpdateCartItemHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := otel.Tracer("handler").Start(r.Context(), "update_cart_item_handler.ServeHTTP")
+	defer span.End()
+	defer r.Body.Close()
+
+	userId, err := infra_http.GetInt64PathValueGt0(r, "user_id")
+	if err != nil {
+		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	skuId, err := infra_http.GetInt64PathValueGt0(r, "sku_id")
+	if err != nil {
+		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	var updateItemRequest UpdateCartItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&updateItemRequest); err != nil {
+		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	if err := handler.validator.Struct(&updateItemRequest); err != nil {
+		route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	err = handler.service.UpdateCount(ctx, userId, skuId, *updateItemRequest.Count)
+	if err != nil {
+		if errors.Is(err, model.ErrProductDoesNotExist) || errors.Is(err, model.ErrNotEnoughItemsInStock) {
+			route_http.WriteErrorJson(w, http.StatusPreconditionFailed, err)
+		} else if errors.Is(err, model.ErrCartLocked) {
+			route_http.WriteErrorJson(w, http.StatusConflict, err)
+		} else {
+			route_http.WriteErrorJson(w, http.StatusBadRequest, err)
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
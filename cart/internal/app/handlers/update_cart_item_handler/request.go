@@ -0,0 +1,5 @@
+package update_cart_item_handler
+
+type UpdateCartItemRequest struct {
+	Count *uint32 `json:"count" validate:"required"`
+}
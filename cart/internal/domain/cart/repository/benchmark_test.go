@@ -34,6 +34,6 @@ func BenchmarkGetAllOrderBySku(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_ = c.GetAllOrderBySku(context.Background(), userId)
+		_, _ = c.GetAllOrderBySku(context.Background(), userId)
 	}
 }
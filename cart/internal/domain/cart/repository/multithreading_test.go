@@ -2,6 +2,7 @@ package repository_test
 
 import (
 	"context"
+	"math"
 	"route256/cart/internal/domain/cart/repository"
 	"route256/cart/internal/domain/model"
 	"sync"
@@ -82,6 +83,41 @@ func TestCartRepository_ConcurrentWrites(t *testing.T) {
 		}
 	})
 
+	t.Run("Should perform concurrent count changes", func(t *testing.T) {
+		const (
+			singleSkuId  = 600
+			changesCount = 50
+		)
+
+		var wg sync.WaitGroup
+		wg.Add(changesCount * 2)
+
+		for range changesCount {
+			go func() {
+				defer wg.Done()
+				_, err := repo.ChangeItemCount(context.Background(), userId, singleSkuId, 2, math.MaxUint32)
+				require.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := repo.ChangeItemCount(context.Background(), userId, singleSkuId, 1, math.MaxUint32)
+				require.NoError(t, err)
+			}()
+		}
+
+		wg.Wait()
+
+		items := repo.GetByUserId(context.Background(), userId)
+		for _, item := range items {
+			if item.SkuId == singleSkuId {
+				require.Equal(t, uint32(changesCount*3), item.Count)
+			}
+		}
+
+		_, err := repo.ChangeItemCount(context.Background(), userId, singleSkuId, -changesCount*3, math.MaxUint32)
+		require.NoError(t, err)
+	})
+
 	t.Run("Should perform concurrent deletions", func(t *testing.T) {
 		var wg sync.WaitGroup
 		wg.Add(numGoroutines / 2)
@@ -159,7 +195,8 @@ func TestCartRepository_ConcurrentReads(t *testing.T) {
 		for range numReaders {
 			go func() {
 				defer wg.Done()
-				sortedItems := repo.GetAllOrderBySku(context.Background(), userId)
+				sortedItems, err := repo.GetAllOrderBySku(context.Background(), userId)
+				require.NoError(t, err)
 				require.Len(t, sortedItems, numItems)
 
 				for j := 1; j < len(sortedItems); j++ {
@@ -270,7 +307,7 @@ func TestCartRepository_ConcurrentReadWrites(t *testing.T) {
 			go func() {
 				defer wg.Done()
 				_ = repo.GetByUserId(context.Background(), userId)
-				_ = repo.GetAllOrderBySku(context.Background(), userId)
+				_, _ = repo.GetAllOrderBySku(context.Background(), userId)
 			}()
 		}
 
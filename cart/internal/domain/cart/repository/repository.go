@@ -2,23 +2,31 @@ package repository
 
 import (
 	"context"
+	"maps"
 	"math"
 	"route256/cart/internal/domain/model"
 	"slices"
 	"sort"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel"
 )
 
 type CartRepository struct {
-	cartItems map[int64][]*model.CartItemModel
-	lock      sync.RWMutex
+	cartItems   map[int64][]*model.CartItemModel
+	versions    map[int64]int64
+	lockedUntil map[int64]time.Time
+	checkouts   map[int64]map[string]model.CheckoutModel
+	lock        sync.RWMutex
 }
 
 func NewCartRepository() *CartRepository {
 	return &CartRepository{
-		cartItems: make(map[int64][]*model.CartItemModel),
+		cartItems:   make(map[int64][]*model.CartItemModel),
+		versions:    make(map[int64]int64),
+		lockedUntil: make(map[int64]time.Time),
+		checkouts:   make(map[int64]map[string]model.CheckoutModel),
 	}
 }
 
@@ -30,22 +38,114 @@ func (c *CartRepository) CreateItem(ctx context.Context, item *model.CartItemMod
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
+	if err := c.ensureUnlocked(item.UserId); err != nil {
+		return false, err
+	}
+
 	cartItems, hasEntry := c.cartItems[item.UserId]
 	if !hasEntry {
+		c.bumpVersion(item.UserId)
 		return c.createNewUserEntry(item)
 	}
 
-	return c.addToExistingCart(cartItems, item)
+	created, err := c.addToExistingCart(cartItems, item)
+	if err == nil {
+		c.bumpVersion(item.UserId)
+	}
+
+	return created, err
+}
+
+// SetItemCount implements CartRepository.
+func (c *CartRepository) SetItemCount(ctx context.Context, item *model.CartItemModel) error {
+	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.SetItemCount")
+	defer span.End()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.ensureUnlocked(item.UserId); err != nil {
+		return err
+	}
+
+	c.bumpVersion(item.UserId)
+	cartItems := c.cartItems[item.UserId]
+	var itemIndex = slices.IndexFunc(cartItems, func(currItem *model.CartItemModel) bool {
+		return currItem.SkuId == item.SkuId
+	})
+
+	if itemIndex >= 0 {
+		cartItems[itemIndex].Count = item.Count
+		return nil
+	}
+
+	newItem := *item
+	c.cartItems[item.UserId] = append(cartItems, &newItem)
+	return nil
+}
+
+// ChangeItemCount implements CartRepository.
+// Adds the delta to the count under the repository lock, a line brought to zero is removed.
+// Growing the count above maxCount fails with ErrNotEnoughItemsInStock.
+func (c *CartRepository) ChangeItemCount(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32) (uint32, error) {
+	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.ChangeItemCount")
+	defer span.End()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.ensureUnlocked(userId); err != nil {
+		return 0, err
+	}
+
+	cartItems := c.cartItems[userId]
+	var itemIndex = slices.IndexFunc(cartItems, func(currItem *model.CartItemModel) bool {
+		return currItem.SkuId == skuId
+	})
+
+	var inCart uint32
+	if itemIndex >= 0 {
+		inCart = cartItems[itemIndex].Count
+	}
+
+	newCount, err := model.ApplyCountDelta(inCart, delta, maxCount)
+	if err != nil {
+		return 0, err
+	}
+
+	c.bumpVersion(userId)
+	switch {
+	case newCount == 0 && itemIndex >= 0:
+		cartItems = slices.Delete(cartItems, itemIndex, itemIndex+1)
+		if len(cartItems) == 0 {
+			delete(c.cartItems, userId)
+		} else {
+			c.cartItems[userId] = cartItems
+		}
+	case newCount == 0:
+		// zero delta on a missing line, nothing to remove
+	case itemIndex >= 0:
+		cartItems[itemIndex].Count = newCount
+	default:
+		c.cartItems[userId] = append(cartItems, &model.CartItemModel{UserId: userId, SkuId: skuId, Count: newCount})
+	}
+
+	return newCount, nil
 }
 
 // DeleteBySku implements CartRepository.
-func (c *CartRepository) DeleteBySku(ctx context.Context, userId int64, skuId int64) {
+func (c *CartRepository) DeleteBySku(ctx context.Context, userId int64, skuId int64) error {
 	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.DeleteBySku")
 	defer span.End()
 
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
+	if err := c.ensureUnlocked(userId); err != nil {
+		return err
+	}
+
+	c.bumpVersion(userId)
 	cartItems, hasEntry := c.cartItems[userId]
 	if hasEntry {
 		var item = slices.IndexFunc(cartItems, func(item *model.CartItemModel) bool {
@@ -53,7 +153,7 @@ func (c *CartRepository) DeleteBySku(ctx context.Context, userId int64, skuId in
 		})
 
 		if item == -1 {
-			return
+			return nil
 		}
 
 		cartItems = slices.Delete(cartItems, item, item+1)
@@ -64,34 +164,173 @@ func (c *CartRepository) DeleteBySku(ctx context.Context, userId int64, skuId in
 			c.cartItems[userId] = cartItems
 		}
 	}
+
+	return nil
 }
 
 // DeleteAll implements CartRepository.
-func (c *CartRepository) DeleteAll(ctx context.Context, userId int64) {
+func (c *CartRepository) DeleteAll(ctx context.Context, userId int64) error {
 	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.DeleteAll")
 	defer span.End()
 
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
+	if err := c.ensureUnlocked(userId); err != nil {
+		return err
+	}
+
+	c.bumpVersion(userId)
 	delete(c.cartItems, userId)
+	return nil
+}
+
+// GetSnapshot implements CartRepository.
+func (c *CartRepository) GetSnapshot(ctx context.Context, userId int64) (model.CartSnapshotModel, error) {
+	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.GetSnapshot")
+	defer span.End()
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	items := c.copyItems(userId)
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].SkuId < items[j].SkuId
+	})
+
+	return model.CartSnapshotModel{
+		UserId:  userId,
+		Version: c.versions[userId],
+		Items:   items,
+	}, nil
+}
+
+// Lock implements CartRepository.
+// The pending checkout is recorded under the same lock, so a retry never sees a locked cart without it.
+func (c *CartRepository) Lock(ctx context.Context, userId int64, version int64, ttl time.Duration,
+	checkout *model.CheckoutModel) error {
+	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.Lock")
+	defer span.End()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.versions[userId] != version {
+		return model.ErrCartChanged
+	}
+
+	if err := c.ensureUnlocked(userId); err != nil {
+		return err
+	}
+
+	c.lockedUntil[userId] = time.Now().Add(ttl)
+	if checkout != nil {
+		c.saveCheckout(*checkout)
+	}
+
+	return nil
+}
+
+// Unlock implements CartRepository.
+// Pending checkouts of the user are dropped, their orders were not created.
+func (c *CartRepository) Unlock(ctx context.Context, userId int64) error {
+	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.Unlock")
+	defer span.End()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	maps.DeleteFunc(c.checkouts[userId], func(_ string, checkout model.CheckoutModel) bool {
+		return checkout.OrderId == 0
+	})
+
+	delete(c.lockedUntil, userId)
+	return nil
+}
+
+// DeleteSnapshot implements CartRepository.
+// Removes only the lines that are still exactly as in the snapshot and unlocks the cart.
+func (c *CartRepository) DeleteSnapshot(ctx context.Context, snapshot model.CartSnapshotModel) error {
+	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.DeleteSnapshot")
+	defer span.End()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	cartItems := slices.DeleteFunc(c.cartItems[snapshot.UserId], func(item *model.CartItemModel) bool {
+		return slices.Contains(snapshot.Items, *item)
+	})
+
+	if len(cartItems) == 0 {
+		delete(c.cartItems, snapshot.UserId)
+	} else {
+		c.cartItems[snapshot.UserId] = cartItems
+	}
+
+	delete(c.lockedUntil, snapshot.UserId)
+	c.bumpVersion(snapshot.UserId)
+	return nil
+}
+
+// GetCheckout implements CartRepository.
+func (c *CartRepository) GetCheckout(ctx context.Context, userId int64, idempotencyKey string) (model.CheckoutModel, bool, error) {
+	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.GetCheckout")
+	defer span.End()
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	checkout, found := c.checkouts[userId][idempotencyKey]
+	return checkout, found, nil
+}
+
+// SaveCheckout implements CartRepository.
+// Completes the pending checkout, the first order saved with the idempotency key wins.
+func (c *CartRepository) SaveCheckout(ctx context.Context, checkout model.CheckoutModel) error {
+	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.SaveCheckout")
+	defer span.End()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.saveCheckout(checkout)
+	return nil
+}
+
+func (c *CartRepository) saveCheckout(checkout model.CheckoutModel) {
+	userCheckouts, hasEntry := c.checkouts[checkout.UserId]
+	if !hasEntry {
+		userCheckouts = make(map[string]model.CheckoutModel)
+		c.checkouts[checkout.UserId] = userCheckouts
+	}
+
+	saved, found := userCheckouts[checkout.IdempotencyKey]
+	if !found {
+		userCheckouts[checkout.IdempotencyKey] = checkout
+		return
+	}
+
+	if saved.OrderId == 0 {
+		saved.OrderId = checkout.OrderId
+		userCheckouts[checkout.IdempotencyKey] = saved
+	}
 }
 
 // GetAllOrderBySku implements CartRepository.
-func (c *CartRepository) GetAllOrderBySku(ctx context.Context, userId int64) []model.CartItemModel {
+func (c *CartRepository) GetAllOrderBySku(ctx context.Context, userId int64) ([]model.CartItemModel, error) {
 	_, span := otel.Tracer("repository").Start(ctx, "cart_repository.GetAllOrderBySku")
 	defer span.End()
 
 	var items []model.CartItemModel = c.GetByUserId(ctx, userId)
 	if items == nil {
-		return nil
+		return nil, nil
 	}
 
 	sort.Slice(items, func(i, j int) bool {
 		return items[i].SkuId < items[j].SkuId
 	})
 
-	return items
+	return items, nil
 }
 
 func (c *CartRepository) GetByUserId(ctx context.Context, userId int64) []model.CartItemModel {
@@ -101,6 +340,10 @@ func (c *CartRepository) GetByUserId(ctx context.Context, userId int64) []model.
 	c.lock.RLock()
 	defer c.lock.RUnlock()
 
+	return c.copyItems(userId)
+}
+
+func (c *CartRepository) copyItems(userId int64) []model.CartItemModel {
 	cartItems, hasEntry := c.cartItems[userId]
 	if !hasEntry {
 		return nil
@@ -114,6 +357,18 @@ func (c *CartRepository) GetByUserId(ctx context.Context, userId int64) []model.
 	return items
 }
 
+func (c *CartRepository) ensureUnlocked(userId int64) error {
+	if lockedUntil, ok := c.lockedUntil[userId]; ok && time.Now().Before(lockedUntil) {
+		return model.ErrCartLocked
+	}
+
+	return nil
+}
+
+func (c *CartRepository) bumpVersion(userId int64) {
+	c.versions[userId]++
+}
+
 func (c *CartRepository) getTotalItemCount() int64 {
 	c.lock.RLock()
 	defer c.lock.RUnlock()
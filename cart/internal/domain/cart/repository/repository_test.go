@@ -2,11 +2,13 @@ package repository_test
 
 import (
 	"context"
+	"errors"
 	"math"
 	"reflect"
 	"route256/cart/internal/domain/cart/repository"
 	"route256/cart/internal/domain/model"
 	"testing"
+	"time"
 )
 
 func TestCartRepository_CreateItem(t *testing.T) {
@@ -168,7 +170,151 @@ func TestCartRepository_DeleteBySku(t *testing.T) {
 				c.DeleteBySku(context.Background(), attempt.userId, attempt.skuId)
 			}
 
-			if items := c.GetAllOrderBySku(context.Background(), tt.userId); !compareCartItems(items, tt.has) {
+			if items, _ := c.GetAllOrderBySku(context.Background(), tt.userId); !compareCartItems(items, tt.has) {
+				t.Errorf("CartRepository.GetAllOrderBySku() = %v, want %v", items, tt.has)
+			}
+		})
+	}
+}
+
+func TestCartRepository_SetItemCount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		having []model.CartItemModel
+		set    model.CartItemModel
+		has    []model.CartItemModel
+	}{
+		{
+			name:   "should create missing item",
+			having: []model.CartItemModel{},
+			set:    model.CartItemModel{UserId: 1, SkuId: 1, Count: 3},
+			has:    []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 3}},
+		},
+		{
+			name: "should overwrite count of the existing item",
+			having: []model.CartItemModel{
+				{UserId: 1, SkuId: 1, Count: 5},
+				{UserId: 1, SkuId: 2, Count: 1},
+			},
+			set: model.CartItemModel{UserId: 1, SkuId: 1, Count: 2},
+			has: []model.CartItemModel{
+				{UserId: 1, SkuId: 1, Count: 2},
+				{UserId: 1, SkuId: 2, Count: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := repository.NewCartRepository()
+			for _, item := range tt.having {
+				_, err := c.CreateItem(context.Background(), &item)
+				if err != nil {
+					t.Errorf("CartRepository.CreateItem() error = %v", err)
+				}
+			}
+
+			if err := c.SetItemCount(context.Background(), &tt.set); err != nil {
+				t.Errorf("CartRepository.SetItemCount() error = %v", err)
+			}
+
+			if items, _ := c.GetAllOrderBySku(context.Background(), tt.set.UserId); !compareCartItems(items, tt.has) {
+				t.Errorf("CartRepository.GetAllOrderBySku() = %v, want %v", items, tt.has)
+			}
+		})
+	}
+}
+
+func TestCartRepository_ChangeItemCount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		having   []model.CartItemModel
+		delta    int64
+		maxCount uint32
+		want     uint32
+		wantErr  error
+		has      []model.CartItemModel
+	}{
+		{
+			name:     "should create missing item",
+			having:   []model.CartItemModel{},
+			delta:    3,
+			maxCount: 3,
+			want:     3,
+			has:      []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 3}},
+		},
+		{
+			name:     "should add delta to the existing item",
+			having:   []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 2}},
+			delta:    3,
+			maxCount: 10,
+			want:     5,
+			has:      []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 5}},
+		},
+		{
+			name:     "should remove item brought to zero",
+			having:   []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 2}, {UserId: 1, SkuId: 2, Count: 1}},
+			delta:    -2,
+			maxCount: 0,
+			want:     0,
+			has:      []model.CartItemModel{{UserId: 1, SkuId: 2, Count: 1}},
+		},
+		{
+			name:     "should lower count above the max count",
+			having:   []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 5}},
+			delta:    -1,
+			maxCount: 2,
+			want:     4,
+			has:      []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 4}},
+		},
+		{
+			name:     "should fail to grow count above the max count",
+			having:   []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 2}},
+			delta:    1,
+			maxCount: 2,
+			wantErr:  model.ErrNotEnoughItemsInStock,
+			has:      []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 2}},
+		},
+		{
+			name:     "should fail to bring count below zero",
+			having:   []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 2}},
+			delta:    -3,
+			maxCount: math.MaxUint32,
+			wantErr:  model.ErrNegativeItemCount,
+			has:      []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 2}},
+		},
+		{
+			name:     "should fail on count overflow",
+			having:   []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 2}},
+			delta:    math.MaxUint32,
+			maxCount: math.MaxUint32,
+			wantErr:  model.ErrTotalCountExceeded,
+			has:      []model.CartItemModel{{UserId: 1, SkuId: 1, Count: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := repository.NewCartRepository()
+			for _, item := range tt.having {
+				_, err := c.CreateItem(context.Background(), &item)
+				if err != nil {
+					t.Errorf("CartRepository.CreateItem() error = %v", err)
+				}
+			}
+
+			got, err := c.ChangeItemCount(context.Background(), 1, 1, tt.delta, tt.maxCount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CartRepository.ChangeItemCount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("CartRepository.ChangeItemCount() = %v, want %v", got, tt.want)
+			}
+
+			if items, _ := c.GetAllOrderBySku(context.Background(), 1); !compareCartItems(items, tt.has) {
 				t.Errorf("CartRepository.GetAllOrderBySku() = %v, want %v", items, tt.has)
 			}
 		})
@@ -212,7 +358,7 @@ func TestCartRepository_DeleteAll(t *testing.T) {
 			}
 
 			c.DeleteAll(context.Background(), tt.args.userId)
-			items := c.GetAllOrderBySku(context.Background(), tt.args.userId)
+			items, _ := c.GetAllOrderBySku(context.Background(), tt.args.userId)
 
 			if len(items) != 0 {
 				t.Errorf("CartRepository.GetAllOrderBySku() = having %v, want 0", items)
@@ -275,13 +421,185 @@ func TestCartRepository_GetAllOrderBySku(t *testing.T) {
 				}
 			}
 
-			if got := c.GetAllOrderBySku(context.Background(), tt.args.userId); !compareCartItems(got, tt.want) {
+			if got, _ := c.GetAllOrderBySku(context.Background(), tt.args.userId); !compareCartItems(got, tt.want) {
 				t.Errorf("CartRepository.GetAllOrderBySku() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestCartRepository_Lock(t *testing.T) {
+	t.Parallel()
+	var ctx = context.Background()
+
+	t.Run("should reject modifications of the locked cart", func(t *testing.T) {
+		t.Parallel()
+		c := repository.NewCartRepository()
+		_, err := c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1})
+		if err != nil {
+			t.Fatalf("CartRepository.CreateItem() error = %v", err)
+		}
+
+		snapshot, _ := c.GetSnapshot(ctx, 1)
+		if err := c.Lock(ctx, 1, snapshot.Version, time.Minute, nil); err != nil {
+			t.Fatalf("CartRepository.Lock() error = %v", err)
+		}
+
+		if _, err := c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 2, Count: 1}); !errors.Is(err, model.ErrCartLocked) {
+			t.Errorf("CartRepository.CreateItem() error = %v, want %v", err, model.ErrCartLocked)
+		}
+
+		if err := c.DeleteAll(ctx, 1); !errors.Is(err, model.ErrCartLocked) {
+			t.Errorf("CartRepository.DeleteAll() error = %v, want %v", err, model.ErrCartLocked)
+		}
+
+		if err := c.Lock(ctx, 1, snapshot.Version, time.Minute, nil); !errors.Is(err, model.ErrCartLocked) {
+			t.Errorf("CartRepository.Lock() error = %v, want %v", err, model.ErrCartLocked)
+		}
+
+		if err := c.Unlock(ctx, 1); err != nil {
+			t.Fatalf("CartRepository.Unlock() error = %v", err)
+		}
+
+		if _, err := c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 2, Count: 1}); err != nil {
+			t.Errorf("CartRepository.CreateItem() error = %v", err)
+		}
+	})
+
+	t.Run("should return changed if the version is stale", func(t *testing.T) {
+		t.Parallel()
+		c := repository.NewCartRepository()
+		_, _ = c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1})
+		snapshot, _ := c.GetSnapshot(ctx, 1)
+		_, _ = c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1})
+
+		if err := c.Lock(ctx, 1, snapshot.Version, time.Minute, nil); !errors.Is(err, model.ErrCartChanged) {
+			t.Errorf("CartRepository.Lock() error = %v, want %v", err, model.ErrCartChanged)
+		}
+	})
+
+	t.Run("should allow modifications after the lock expired", func(t *testing.T) {
+		t.Parallel()
+		c := repository.NewCartRepository()
+		_, _ = c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1})
+		snapshot, _ := c.GetSnapshot(ctx, 1)
+
+		if err := c.Lock(ctx, 1, snapshot.Version, -time.Second, nil); err != nil {
+			t.Fatalf("CartRepository.Lock() error = %v", err)
+		}
+
+		if err := c.DeleteBySku(ctx, 1, 1); err != nil {
+			t.Errorf("CartRepository.DeleteBySku() error = %v", err)
+		}
+	})
+}
+
+func TestCartRepository_DeleteSnapshot(t *testing.T) {
+	t.Parallel()
+	var ctx = context.Background()
+	c := repository.NewCartRepository()
+	_, _ = c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1})
+	_, _ = c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 2, Count: 1})
+
+	snapshot, _ := c.GetSnapshot(ctx, 1)
+	// the line was changed after the snapshot was taken, so it has to survive the checkout
+	_, _ = c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 2, Count: 1})
+	_, _ = c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 3, Count: 1})
+	current, _ := c.GetSnapshot(ctx, 1)
+
+	if err := c.Lock(ctx, 1, current.Version, time.Minute, nil); err != nil {
+		t.Fatalf("CartRepository.Lock() error = %v", err)
+	}
+
+	if err := c.DeleteSnapshot(ctx, snapshot); err != nil {
+		t.Fatalf("CartRepository.DeleteSnapshot() error = %v", err)
+	}
+
+	got, _ := c.GetSnapshot(ctx, 1)
+	want := []model.CartItemModel{
+		{UserId: 1, SkuId: 2, Count: 2},
+		{UserId: 1, SkuId: 3, Count: 1},
+	}
+	if !compareCartItems(got.Items, want) {
+		t.Errorf("CartRepository.GetSnapshot() = %v, want %v", got.Items, want)
+	}
+
+	if got.Version <= current.Version {
+		t.Errorf("CartRepository.GetSnapshot() version = %d, want greater than %d", got.Version, current.Version)
+	}
+
+	if _, err := c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 4, Count: 1}); err != nil {
+		t.Errorf("CartRepository.CreateItem() error = %v, want unlocked cart", err)
+	}
+}
+
+func TestCartRepository_Checkout(t *testing.T) {
+	t.Parallel()
+	var ctx = context.Background()
+	c := repository.NewCartRepository()
+
+	if _, found, _ := c.GetCheckout(ctx, 1, "key"); found {
+		t.Errorf("CartRepository.GetCheckout() found unknown checkout")
+	}
+
+	var first = model.CheckoutModel{UserId: 1, IdempotencyKey: "key", ExpectedVersion: 3, OrderId: 10}
+	if err := c.SaveCheckout(ctx, first); err != nil {
+		t.Fatalf("CartRepository.SaveCheckout() error = %v", err)
+	}
+
+	if err := c.SaveCheckout(ctx, model.CheckoutModel{UserId: 1, IdempotencyKey: "key", OrderId: 11}); err != nil {
+		t.Fatalf("CartRepository.SaveCheckout() error = %v", err)
+	}
+
+	if got, found, _ := c.GetCheckout(ctx, 1, "key"); !found || !reflect.DeepEqual(got, first) {
+		t.Errorf("CartRepository.GetCheckout() = %v, want %v", got, first)
+	}
+
+	if _, found, _ := c.GetCheckout(ctx, 2, "key"); found {
+		t.Errorf("CartRepository.GetCheckout() found checkout of another user")
+	}
+}
+
+func TestCartRepository_PendingCheckout(t *testing.T) {
+	t.Parallel()
+	var ctx = context.Background()
+	c := repository.NewCartRepository()
+	_, err := c.CreateItem(ctx, &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1})
+	if err != nil {
+		t.Fatalf("CartRepository.CreateItem() error = %v", err)
+	}
+
+	snapshot, _ := c.GetSnapshot(ctx, 1)
+	var pending = model.CheckoutModel{UserId: 1, IdempotencyKey: "key", Items: snapshot.Items}
+	if err := c.Lock(ctx, 1, snapshot.Version, time.Minute, &pending); err != nil {
+		t.Fatalf("CartRepository.Lock() error = %v", err)
+	}
+
+	if got, found, _ := c.GetCheckout(ctx, 1, "key"); !found || !reflect.DeepEqual(got, pending) {
+		t.Errorf("CartRepository.GetCheckout() = %v, want %v", got, pending)
+	}
+
+	if err := c.Unlock(ctx, 1); err != nil {
+		t.Fatalf("CartRepository.Unlock() error = %v", err)
+	}
+
+	if _, found, _ := c.GetCheckout(ctx, 1, "key"); found {
+		t.Errorf("CartRepository.GetCheckout() found pending checkout after unlock")
+	}
+
+	if err := c.Lock(ctx, 1, snapshot.Version, time.Minute, &pending); err != nil {
+		t.Fatalf("CartRepository.Lock() error = %v", err)
+	}
+
+	if err := c.SaveCheckout(ctx, model.CheckoutModel{UserId: 1, IdempotencyKey: "key", OrderId: 10}); err != nil {
+		t.Fatalf("CartRepository.SaveCheckout() error = %v", err)
+	}
+
+	if got, _, _ := c.GetCheckout(ctx, 1, "key"); got.OrderId != 10 {
+		t.Errorf("CartRepository.GetCheckout() order = %v, want %v", got.OrderId, 10)
+	}
+}
+
 func compareCartItems(a, b []model.CartItemModel) bool {
 	if len(a) != len(b) {
 		return false
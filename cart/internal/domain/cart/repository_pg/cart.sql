@@ -0,0 +1,116 @@
+-- name: AddItem :one
+insert into cart_items (user_id, sku, count)
+values ($1, $2, $3)
+on conflict (user_id, sku) do update
+set count = cart_items.count + excluded.count,
+    updated_at = now()
+returning (xmax = 0)::boolean as created;
+
+-- name: SetItemCount :exec
+insert into cart_items (user_id, sku, count)
+values ($1, $2, $3)
+on conflict (user_id, sku) do update
+set count = excluded.count,
+    updated_at = now();
+
+-- name: GetByUserId :many
+select user_id,
+    sku,
+    count
+from cart_items
+where user_id = $1
+order by sku asc;
+
+-- name: DeleteAll :exec
+delete from cart_items
+where user_id = $1;
+
+-- name: DeleteBySku :exec
+delete from cart_items
+where user_id = $1
+    and sku = $2;
+
+-- name: BumpVersion :one
+insert into carts (user_id)
+values ($1)
+on conflict (user_id) do update
+set version = carts.version + 1,
+    updated_at = now()
+where carts.locked_until is null
+    or carts.locked_until < now()
+returning version;
+
+-- name: GetCartState :one
+select version,
+    (locked_until is not null and locked_until >= now())::boolean as locked
+from carts
+where user_id = $1;
+
+-- name: LockCart :execrows
+update carts
+set locked_until = now() + make_interval(secs => @ttl_seconds::float8)
+where user_id = @user_id
+    and version = @version
+    and (
+        locked_until is null
+        or locked_until < now()
+    );
+
+-- name: UnlockCart :exec
+update carts
+set locked_until = null
+where user_id = $1;
+
+-- name: DeleteSnapshotItems :exec
+delete from cart_items
+where user_id = @user_id
+    and (sku, count) in (
+        select unnest(@skus::bigint[]),
+            unnest(@counts::bigint[])
+    );
+
+-- name: CompleteCheckout :exec
+update carts
+set version = version + 1,
+    locked_until = null,
+    updated_at = now()
+where user_id = $1;
+
+-- name: GetItemCount :one
+select count
+from cart_items
+where user_id = $1
+    and sku = $2
+for update;
+
+-- name: GetCheckout :one
+select expected_version,
+    order_id,
+    skus,
+    counts
+from cart_checkouts
+where user_id = $1
+    and idempotency_key = $2;
+
+-- name: SaveCheckout :exec
+insert into cart_checkouts (user_id, idempotency_key, expected_version, order_id)
+values ($1, $2, $3, $4)
+on conflict (user_id, idempotency_key) do update
+set order_id = excluded.order_id
+where cart_checkouts.order_id is null;
+
+-- name: SavePendingCheckout :exec
+insert into cart_checkouts (user_id, idempotency_key, expected_version, skus, counts)
+values (
+        @user_id,
+        @idempotency_key,
+        @expected_version,
+        @skus::bigint[],
+        @counts::bigint[]
+    )
+on conflict (user_id, idempotency_key) do nothing;
+
+-- name: DeletePendingCheckouts :exec
+delete from cart_checkouts
+where user_id = $1
+    and order_id is null;
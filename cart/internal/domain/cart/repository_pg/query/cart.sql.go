@@ -0,0 +1,341 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: cart.sql
+
+package query
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const addItem = `-- name: AddItem :one
+insert into cart_items (user_id, sku, count)
+values ($1, $2, $3)
+on conflict (user_id, sku) do update
+set count = cart_items.count + excluded.count,
+    updated_at = now()
+returning (xmax = 0)::boolean as created
+`
+
+type AddItemParams struct {
+	UserID int64
+	Sku    int64
+	Count  int64
+}
+
+func (q *Queries) AddItem(ctx context.Context, arg AddItemParams) (bool, error) {
+	row := q.db.QueryRow(ctx, addItem, arg.UserID, arg.Sku, arg.Count)
+	var created bool
+	err := row.Scan(&created)
+	return created, err
+}
+
+const bumpVersion = `-- name: BumpVersion :one
+insert into carts (user_id)
+values ($1)
+on conflict (user_id) do update
+set version = carts.version + 1,
+    updated_at = now()
+where carts.locked_until is null
+    or carts.locked_until < now()
+returning version
+`
+
+func (q *Queries) BumpVersion(ctx context.Context, userID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, bumpVersion, userID)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
+const completeCheckout = `-- name: CompleteCheckout :exec
+update carts
+set version = version + 1,
+    locked_until = null,
+    updated_at = now()
+where user_id = $1
+`
+
+func (q *Queries) CompleteCheckout(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, completeCheckout, userID)
+	return err
+}
+
+const deleteAll = `-- name: DeleteAll :exec
+delete from cart_items
+where user_id = $1
+`
+
+func (q *Queries) DeleteAll(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, deleteAll, userID)
+	return err
+}
+
+const deleteBySku = `-- name: DeleteBySku :exec
+delete from cart_items
+where user_id = $1
+    and sku = $2
+`
+
+type DeleteBySkuParams struct {
+	UserID int64
+	Sku    int64
+}
+
+func (q *Queries) DeleteBySku(ctx context.Context, arg DeleteBySkuParams) error {
+	_, err := q.db.Exec(ctx, deleteBySku, arg.UserID, arg.Sku)
+	return err
+}
+
+const deletePendingCheckouts = `-- name: DeletePendingCheckouts :exec
+delete from cart_checkouts
+where user_id = $1
+    and order_id is null
+`
+
+func (q *Queries) DeletePendingCheckouts(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, deletePendingCheckouts, userID)
+	return err
+}
+
+const deleteSnapshotItems = `-- name: DeleteSnapshotItems :exec
+delete from cart_items
+where user_id = $1
+    and (sku, count) in (
+        select unnest($2::bigint[]),
+            unnest($3::bigint[])
+    )
+`
+
+type DeleteSnapshotItemsParams struct {
+	UserID int64
+	Skus   []int64
+	Counts []int64
+}
+
+func (q *Queries) DeleteSnapshotItems(ctx context.Context, arg DeleteSnapshotItemsParams) error {
+	_, err := q.db.Exec(ctx, deleteSnapshotItems, arg.UserID, arg.Skus, arg.Counts)
+	return err
+}
+
+const getByUserId = `-- name: GetByUserId :many
+select user_id,
+    sku,
+    count
+from cart_items
+where user_id = $1
+order by sku asc
+`
+
+type GetByUserIdRow struct {
+	UserID int64
+	Sku    int64
+	Count  int64
+}
+
+func (q *Queries) GetByUserId(ctx context.Context, userID int64) ([]GetByUserIdRow, error) {
+	rows, err := q.db.Query(ctx, getByUserId, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetByUserIdRow
+	for rows.Next() {
+		var i GetByUserIdRow
+		if err := rows.Scan(&i.UserID, &i.Sku, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCartState = `-- name: GetCartState :one
+select version,
+    (locked_until is not null and locked_until >= now())::boolean as locked
+from carts
+where user_id = $1
+`
+
+type GetCartStateRow struct {
+	Version int64
+	Locked  bool
+}
+
+func (q *Queries) GetCartState(ctx context.Context, userID int64) (GetCartStateRow, error) {
+	row := q.db.QueryRow(ctx, getCartState, userID)
+	var i GetCartStateRow
+	err := row.Scan(&i.Version, &i.Locked)
+	return i, err
+}
+
+const getCheckout = `-- name: GetCheckout :one
+select expected_version,
+    order_id,
+    skus,
+    counts
+from cart_checkouts
+where user_id = $1
+    and idempotency_key = $2
+`
+
+type GetCheckoutParams struct {
+	UserID         int64
+	IdempotencyKey string
+}
+
+type GetCheckoutRow struct {
+	ExpectedVersion int64
+	OrderID         pgtype.Int8
+	Skus            []int64
+	Counts          []int64
+}
+
+func (q *Queries) GetCheckout(ctx context.Context, arg GetCheckoutParams) (GetCheckoutRow, error) {
+	row := q.db.QueryRow(ctx, getCheckout, arg.UserID, arg.IdempotencyKey)
+	var i GetCheckoutRow
+	err := row.Scan(
+		&i.ExpectedVersion,
+		&i.OrderID,
+		&i.Skus,
+		&i.Counts,
+	)
+	return i, err
+}
+
+const getItemCount = `-- name: GetItemCount :one
+select count
+from cart_items
+where user_id = $1
+    and sku = $2
+for update
+`
+
+type GetItemCountParams struct {
+	UserID int64
+	Sku    int64
+}
+
+func (q *Queries) GetItemCount(ctx context.Context, arg GetItemCountParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getItemCount, arg.UserID, arg.Sku)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const lockCart = `-- name: LockCart :execrows
+update carts
+set locked_until = now() + make_interval(secs => $1::float8)
+where user_id = $2
+    and version = $3
+    and (
+        locked_until is null
+        or locked_until < now()
+    )
+`
+
+type LockCartParams struct {
+	TtlSeconds float64
+	UserID     int64
+	Version    int64
+}
+
+func (q *Queries) LockCart(ctx context.Context, arg LockCartParams) (int64, error) {
+	result, err := q.db.Exec(ctx, lockCart, arg.TtlSeconds, arg.UserID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const saveCheckout = `-- name: SaveCheckout :exec
+insert into cart_checkouts (user_id, idempotency_key, expected_version, order_id)
+values ($1, $2, $3, $4)
+on conflict (user_id, idempotency_key) do update
+set order_id = excluded.order_id
+where cart_checkouts.order_id is null
+`
+
+type SaveCheckoutParams struct {
+	UserID          int64
+	IdempotencyKey  string
+	ExpectedVersion int64
+	OrderID         pgtype.Int8
+}
+
+func (q *Queries) SaveCheckout(ctx context.Context, arg SaveCheckoutParams) error {
+	_, err := q.db.Exec(ctx, saveCheckout,
+		arg.UserID,
+		arg.IdempotencyKey,
+		arg.ExpectedVersion,
+		arg.OrderID,
+	)
+	return err
+}
+
+const savePendingCheckout = `-- name: SavePendingCheckout :exec
+insert into cart_checkouts (user_id, idempotency_key, expected_version, skus, counts)
+values (
+        $1,
+        $2,
+        $3,
+        $4::bigint[],
+        $5::bigint[]
+    )
+on conflict (user_id, idempotency_key) do nothing
+`
+
+type SavePendingCheckoutParams struct {
+	UserID          int64
+	IdempotencyKey  string
+	ExpectedVersion int64
+	Skus            []int64
+	Counts          []int64
+}
+
+func (q *Queries) SavePendingCheckout(ctx context.Context, arg SavePendingCheckoutParams) error {
+	_, err := q.db.Exec(ctx, savePendingCheckout,
+		arg.UserID,
+		arg.IdempotencyKey,
+		arg.ExpectedVersion,
+		arg.Skus,
+		arg.Counts,
+	)
+	return err
+}
+
+const setItemCount = `-- name: SetItemCount :exec
+insert into cart_items (user_id, sku, count)
+values ($1, $2, $3)
+on conflict (user_id, sku) do update
+set count = excluded.count,
+    updated_at = now()
+`
+
+type SetItemCountParams struct {
+	UserID int64
+	Sku    int64
+	Count  int64
+}
+
+func (q *Queries) SetItemCount(ctx context.Context, arg SetItemCountParams) error {
+	_, err := q.db.Exec(ctx, setItemCount, arg.UserID, arg.Sku, arg.Count)
+	return err
+}
+
+const unlockCart = `-- name: UnlockCart :exec
+update carts
+set locked_until = null
+where user_id = $1
+`
+
+func (q *Queries) UnlockCart(ctx context.Context, userID int64) error {
+	_, err := q.db.Exec(ctx, unlockCart, userID)
+	return err
+}
@@ -0,0 +1,35 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+
+package query
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type Cart struct {
+	UserID      int64
+	Version     int64
+	LockedUntil pgtype.Timestamptz
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+}
+
+type CartCheckout struct {
+	UserID          int64
+	IdempotencyKey  string
+	ExpectedVersion int64
+	OrderID         pgtype.Int8
+	CreatedAt       pgtype.Timestamp
+	Skus            []int64
+	Counts          []int64
+}
+
+type CartItem struct {
+	UserID    int64
+	Sku       int64
+	Count     int64
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
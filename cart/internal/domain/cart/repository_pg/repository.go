@@ -0,0 +1,454 @@
+package repository_pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"route256/cart/internal/domain/cart/repository_pg/query"
+	"route256/cart/internal/domain/model"
+	"route256/cart/internal/infra/sre"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/otel"
+)
+
+const ConstrainCountUint32 = "cart_items_count_uint32"
+
+type CartRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCartRepository(pool *pgxpool.Pool) *CartRepository {
+	return &CartRepository{
+		pool: pool,
+	}
+}
+
+// CreateItem implements service.CartRepository.
+func (r *CartRepository) CreateItem(ctx context.Context, item *model.CartItemModel) (bool, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.CreateItem")
+	defer span.End()
+
+	var created bool
+	err := r.modify(ctx, item.UserId, func(repository *query.Queries) error {
+		var err error
+		startTime := time.Now()
+		created, err = repository.AddItem(ctx, query.AddItemParams{
+			UserID: item.UserId,
+			Sku:    item.SkuId,
+			Count:  int64(item.Count),
+		})
+		sre.TrackDbRequest("cart_add_item", "insert", err, startTime)
+		return err
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == ConstrainCountUint32 {
+			return false, model.ErrTotalCountExceeded
+		}
+
+		return false, fmt.Errorf("failed to add db cart item: %w", err)
+	}
+
+	return created, nil
+}
+
+// SetItemCount implements service.CartRepository.
+func (r *CartRepository) SetItemCount(ctx context.Context, item *model.CartItemModel) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.SetItemCount")
+	defer span.End()
+
+	err := r.modify(ctx, item.UserId, func(repository *query.Queries) error {
+		startTime := time.Now()
+		err := repository.SetItemCount(ctx, query.SetItemCountParams{
+			UserID: item.UserId,
+			Sku:    item.SkuId,
+			Count:  int64(item.Count),
+		})
+		sre.TrackDbRequest("cart_set_item_count", "insert", err, startTime)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set db cart item count: %w", err)
+	}
+
+	return nil
+}
+
+// ChangeItemCount implements service.CartRepository.
+// The count is read and written in the modify transaction, so concurrent deltas of the user are applied one by one.
+// Growing the count above maxCount fails with ErrNotEnoughItemsInStock.
+func (r *CartRepository) ChangeItemCount(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32) (uint32, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.ChangeItemCount")
+	defer span.End()
+
+	var newCount uint32
+	err := r.modify(ctx, userId, func(repository *query.Queries) error {
+		startTime := time.Now()
+		inCart, err := repository.GetItemCount(ctx, query.GetItemCountParams{
+			UserID: userId,
+			Sku:    skuId,
+		})
+		sre.TrackDbRequest("cart_get_item_count", "select", err, startTime)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		newCount, err = model.ApplyCountDelta(uint32(inCart), delta, maxCount)
+		if err != nil {
+			return err
+		}
+
+		if newCount == 0 {
+			startTime = time.Now()
+			err = repository.DeleteBySku(ctx, query.DeleteBySkuParams{
+				UserID: userId,
+				Sku:    skuId,
+			})
+			sre.TrackDbRequest("cart_delete_by_sku", "delete", err, startTime)
+			return err
+		}
+
+		startTime = time.Now()
+		err = repository.SetItemCount(ctx, query.SetItemCountParams{
+			UserID: userId,
+			Sku:    skuId,
+			Count:  int64(newCount),
+		})
+		sre.TrackDbRequest("cart_set_item_count", "insert", err, startTime)
+		return err
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to change db cart item count: %w", err)
+	}
+
+	return newCount, nil
+}
+
+// GetAllOrderBySku implements service.CartRepository.
+func (r *CartRepository) GetAllOrderBySku(ctx context.Context, userId int64) ([]model.CartItemModel, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.GetAllOrderBySku")
+	defer span.End()
+
+	repository := query.New(r.pool)
+
+	startTime := time.Now()
+	rows, err := repository.GetByUserId(ctx, userId)
+	sre.TrackDbRequest("cart_get_by_user_id", "select", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get db cart items: %w", err)
+	}
+
+	return toCartItems(rows), nil
+}
+
+// GetSnapshot implements service.CartRepository.
+// Version and items are read in one repeatable read transaction, so they are consistent with each other.
+func (r *CartRepository) GetSnapshot(ctx context.Context, userId int64) (model.CartSnapshotModel, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.GetSnapshot")
+	defer span.End()
+
+	var snapshot = model.CartSnapshotModel{UserId: userId}
+	err := pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		repository := query.New(tx)
+
+		startTime := time.Now()
+		state, err := repository.GetCartState(ctx, userId)
+		sre.TrackDbRequest("cart_get_state", "select", err, startTime)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		snapshot.Version = state.Version
+
+		startTime = time.Now()
+		rows, err := repository.GetByUserId(ctx, userId)
+		sre.TrackDbRequest("cart_get_by_user_id", "select", err, startTime)
+		if err != nil {
+			return err
+		}
+		snapshot.Items = toCartItems(rows)
+
+		return nil
+	})
+	if err != nil {
+		return model.CartSnapshotModel{}, fmt.Errorf("failed to get db cart snapshot: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// Lock implements service.CartRepository.
+// The pending checkout is recorded in the same transaction, so a retry never sees a locked cart without it.
+func (r *CartRepository) Lock(ctx context.Context, userId int64, version int64, ttl time.Duration,
+	checkout *model.CheckoutModel) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.Lock")
+	defer span.End()
+
+	var locked int64
+	err := pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		repository := query.New(tx)
+
+		var err error
+		startTime := time.Now()
+		locked, err = repository.LockCart(ctx, query.LockCartParams{
+			UserID:     userId,
+			Version:    version,
+			TtlSeconds: ttl.Seconds(),
+		})
+		sre.TrackDbRequest("cart_lock", "update", err, startTime)
+		if err != nil || locked == 0 || checkout == nil {
+			return err
+		}
+
+		var skus = make([]int64, len(checkout.Items))
+		var counts = make([]int64, len(checkout.Items))
+		for i, item := range checkout.Items {
+			skus[i] = item.SkuId
+			counts[i] = int64(item.Count)
+		}
+
+		startTime = time.Now()
+		err = repository.SavePendingCheckout(ctx, query.SavePendingCheckoutParams{
+			UserID:          userId,
+			IdempotencyKey:  checkout.IdempotencyKey,
+			ExpectedVersion: checkout.ExpectedVersion,
+			Skus:            skus,
+			Counts:          counts,
+		})
+		sre.TrackDbRequest("cart_save_pending_checkout", "insert", err, startTime)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lock db cart: %w", err)
+	}
+
+	if locked > 0 {
+		return nil
+	}
+
+	// nothing was updated, find out whether the cart was changed or is locked by someone else
+	repository := query.New(r.pool)
+
+	startTime := time.Now()
+	state, err := repository.GetCartState(ctx, userId)
+	sre.TrackDbRequest("cart_get_state", "select", err, startTime)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get db cart state: %w", err)
+	}
+
+	if state.Version != version {
+		return model.ErrCartChanged
+	}
+
+	if state.Locked {
+		return model.ErrCartLocked
+	}
+
+	return model.ErrCartChanged
+}
+
+// Unlock implements service.CartRepository.
+// Pending checkouts of the user are dropped, their orders were not created.
+func (r *CartRepository) Unlock(ctx context.Context, userId int64) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.Unlock")
+	defer span.End()
+
+	err := pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		repository := query.New(tx)
+
+		startTime := time.Now()
+		err := repository.DeletePendingCheckouts(ctx, userId)
+		sre.TrackDbRequest("cart_delete_pending_checkouts", "delete", err, startTime)
+		if err != nil {
+			return err
+		}
+
+		startTime = time.Now()
+		err = repository.UnlockCart(ctx, userId)
+		sre.TrackDbRequest("cart_unlock", "update", err, startTime)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unlock db cart: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSnapshot implements service.CartRepository.
+// Removes only the lines that are still exactly as in the snapshot and unlocks the cart.
+func (r *CartRepository) DeleteSnapshot(ctx context.Context, snapshot model.CartSnapshotModel) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.DeleteSnapshot")
+	defer span.End()
+
+	var skus = make([]int64, len(snapshot.Items))
+	var counts = make([]int64, len(snapshot.Items))
+	for i, item := range snapshot.Items {
+		skus[i] = item.SkuId
+		counts[i] = int64(item.Count)
+	}
+
+	err := pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		repository := query.New(tx)
+
+		startTime := time.Now()
+		err := repository.DeleteSnapshotItems(ctx, query.DeleteSnapshotItemsParams{
+			UserID: snapshot.UserId,
+			Skus:   skus,
+			Counts: counts,
+		})
+		sre.TrackDbRequest("cart_delete_snapshot_items", "delete", err, startTime)
+		if err != nil {
+			return err
+		}
+
+		startTime = time.Now()
+		err = repository.CompleteCheckout(ctx, snapshot.UserId)
+		sre.TrackDbRequest("cart_complete_checkout", "update", err, startTime)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete db cart snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// GetCheckout implements service.CartRepository.
+func (r *CartRepository) GetCheckout(ctx context.Context, userId int64, idempotencyKey string) (model.CheckoutModel, bool, error) {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.GetCheckout")
+	defer span.End()
+
+	repository := query.New(r.pool)
+
+	startTime := time.Now()
+	row, err := repository.GetCheckout(ctx, query.GetCheckoutParams{
+		UserID:         userId,
+		IdempotencyKey: idempotencyKey,
+	})
+	sre.TrackDbRequest("cart_get_checkout", "select", err, startTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.CheckoutModel{}, false, nil
+	}
+
+	if err != nil {
+		return model.CheckoutModel{}, false, fmt.Errorf("failed to get db cart checkout: %w", err)
+	}
+
+	var items = make([]model.CartItemModel, len(row.Skus))
+	for i, sku := range row.Skus {
+		items[i] = model.CartItemModel{UserId: userId, SkuId: sku, Count: uint32(row.Counts[i])}
+	}
+
+	return model.CheckoutModel{
+		UserId:          userId,
+		IdempotencyKey:  idempotencyKey,
+		ExpectedVersion: row.ExpectedVersion,
+		OrderId:         row.OrderID.Int64,
+		Items:           items,
+	}, true, nil
+}
+
+// SaveCheckout implements service.CartRepository.
+// Completes the pending checkout, the first order saved with the idempotency key wins.
+func (r *CartRepository) SaveCheckout(ctx context.Context, checkout model.CheckoutModel) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.SaveCheckout")
+	defer span.End()
+
+	repository := query.New(r.pool)
+
+	startTime := time.Now()
+	err := repository.SaveCheckout(ctx, query.SaveCheckoutParams{
+		UserID:          checkout.UserId,
+		IdempotencyKey:  checkout.IdempotencyKey,
+		ExpectedVersion: checkout.ExpectedVersion,
+		OrderID:         pgtype.Int8{Int64: checkout.OrderId, Valid: true},
+	})
+	sre.TrackDbRequest("cart_save_checkout", "insert", err, startTime)
+	if err != nil {
+		return fmt.Errorf("failed to save db cart checkout: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteAll implements service.CartRepository.
+func (r *CartRepository) DeleteAll(ctx context.Context, userId int64) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.DeleteAll")
+	defer span.End()
+
+	err := r.modify(ctx, userId, func(repository *query.Queries) error {
+		startTime := time.Now()
+		err := repository.DeleteAll(ctx, userId)
+		sre.TrackDbRequest("cart_delete_all", "delete", err, startTime)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete db cart: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteBySku implements service.CartRepository.
+func (r *CartRepository) DeleteBySku(ctx context.Context, userId int64, skuId int64) error {
+	ctx, span := otel.Tracer("repository").Start(ctx, "cart_repository_pg.DeleteBySku")
+	defer span.End()
+
+	err := r.modify(ctx, userId, func(repository *query.Queries) error {
+		startTime := time.Now()
+		err := repository.DeleteBySku(ctx, query.DeleteBySkuParams{
+			UserID: userId,
+			Sku:    skuId,
+		})
+		sre.TrackDbRequest("cart_delete_by_sku", "delete", err, startTime)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete db cart item: %w", err)
+	}
+
+	return nil
+}
+
+// modify runs the cart modification in a transaction together with the cart version increment.
+// The version row is locked till the end of the transaction, so a concurrent checkout lock waits for it.
+func (r *CartRepository) modify(ctx context.Context, userId int64, modification func(repository *query.Queries) error) error {
+	return pgx.BeginTxFunc(ctx, r.pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		repository := query.New(tx)
+
+		startTime := time.Now()
+		_, err := repository.BumpVersion(ctx, userId)
+		sre.TrackDbRequest("cart_bump_version", "insert", err, startTime)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ErrCartLocked
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return modification(repository)
+	})
+}
+
+func toCartItems(rows []query.GetByUserIdRow) []model.CartItemModel {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	items := make([]model.CartItemModel, len(rows))
+	for i, row := range rows {
+		items[i] = model.CartItemModel{
+			UserId: row.UserID,
+			SkuId:  row.Sku,
+			Count:  uint32(row.Count),
+		}
+	}
+
+	return items
+}
@@ -9,6 +9,7 @@ import (
 	"route256/cart/internal/domain/model"
 	"sync"
 	mm_atomic "sync/atomic"
+	"time"
 	mm_time "time"
 
 	"github.com/gojuno/minimock/v3"
@@ -19,6 +20,13 @@ type CartRepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
+	funcChangeItemCount          func(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32) (u1 uint32, err error)
+	funcChangeItemCountOrigin    string
+	inspectFuncChangeItemCount   func(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32)
+	afterChangeItemCountCounter  uint64
+	beforeChangeItemCountCounter uint64
+	ChangeItemCountMock          mCartRepositoryMockChangeItemCount
+
 	funcCreateItem          func(ctx context.Context, item *model.CartItemModel) (b1 bool, err error)
 	funcCreateItemOrigin    string
 	inspectFuncCreateItem   func(ctx context.Context, item *model.CartItemModel)
@@ -26,26 +34,75 @@ type CartRepositoryMock struct {
 	beforeCreateItemCounter uint64
 	CreateItemMock          mCartRepositoryMockCreateItem
 
-	funcDeleteAll          func(ctx context.Context, userId int64)
+	funcDeleteAll          func(ctx context.Context, userId int64) (err error)
 	funcDeleteAllOrigin    string
 	inspectFuncDeleteAll   func(ctx context.Context, userId int64)
 	afterDeleteAllCounter  uint64
 	beforeDeleteAllCounter uint64
 	DeleteAllMock          mCartRepositoryMockDeleteAll
 
-	funcDeleteBySku          func(ctx context.Context, userId int64, skuId int64)
+	funcDeleteBySku          func(ctx context.Context, userId int64, skuId int64) (err error)
 	funcDeleteBySkuOrigin    string
 	inspectFuncDeleteBySku   func(ctx context.Context, userId int64, skuId int64)
 	afterDeleteBySkuCounter  uint64
 	beforeDeleteBySkuCounter uint64
 	DeleteBySkuMock          mCartRepositoryMockDeleteBySku
 
-	funcGetAllOrderBySku          func(ctx context.Context, userId int64) (ca1 []model.CartItemModel)
+	funcDeleteSnapshot          func(ctx context.Context, snapshot model.CartSnapshotModel) (err error)
+	funcDeleteSnapshotOrigin    string
+	inspectFuncDeleteSnapshot   func(ctx context.Context, snapshot model.CartSnapshotModel)
+	afterDeleteSnapshotCounter  uint64
+	beforeDeleteSnapshotCounter uint64
+	DeleteSnapshotMock          mCartRepositoryMockDeleteSnapshot
+
+	funcGetAllOrderBySku          func(ctx context.Context, userId int64) (ca1 []model.CartItemModel, err error)
 	funcGetAllOrderBySkuOrigin    string
 	inspectFuncGetAllOrderBySku   func(ctx context.Context, userId int64)
 	afterGetAllOrderBySkuCounter  uint64
 	beforeGetAllOrderBySkuCounter uint64
 	GetAllOrderBySkuMock          mCartRepositoryMockGetAllOrderBySku
+
+	funcGetCheckout          func(ctx context.Context, userId int64, idempotencyKey string) (c2 model.CheckoutModel, b1 bool, err error)
+	funcGetCheckoutOrigin    string
+	inspectFuncGetCheckout   func(ctx context.Context, userId int64, idempotencyKey string)
+	afterGetCheckoutCounter  uint64
+	beforeGetCheckoutCounter uint64
+	GetCheckoutMock          mCartRepositoryMockGetCheckout
+
+	funcGetSnapshot          func(ctx context.Context, userId int64) (c2 model.CartSnapshotModel, err error)
+	funcGetSnapshotOrigin    string
+	inspectFuncGetSnapshot   func(ctx context.Context, userId int64)
+	afterGetSnapshotCounter  uint64
+	beforeGetSnapshotCounter uint64
+	GetSnapshotMock          mCartRepositoryMockGetSnapshot
+
+	funcLock          func(ctx context.Context, userId int64, version int64, ttl time.Duration, checkout *model.CheckoutModel) (err error)
+	funcLockOrigin    string
+	inspectFuncLock   func(ctx context.Context, userId int64, version int64, ttl time.Duration, checkout *model.CheckoutModel)
+	afterLockCounter  uint64
+	beforeLockCounter uint64
+	LockMock          mCartRepositoryMockLock
+
+	funcSaveCheckout          func(ctx context.Context, checkout model.CheckoutModel) (err error)
+	funcSaveCheckoutOrigin    string
+	inspectFuncSaveCheckout   func(ctx context.Context, checkout model.CheckoutModel)
+	afterSaveCheckoutCounter  uint64
+	beforeSaveCheckoutCounter uint64
+	SaveCheckoutMock          mCartRepositoryMockSaveCheckout
+
+	funcSetItemCount          func(ctx context.Context, item *model.CartItemModel) (err error)
+	funcSetItemCountOrigin    string
+	inspectFuncSetItemCount   func(ctx context.Context, item *model.CartItemModel)
+	afterSetItemCountCounter  uint64
+	beforeSetItemCountCounter uint64
+	SetItemCountMock          mCartRepositoryMockSetItemCount
+
+	funcUnlock          func(ctx context.Context, userId int64) (err error)
+	funcUnlockOrigin    string
+	inspectFuncUnlock   func(ctx context.Context, userId int64)
+	afterUnlockCounter  uint64
+	beforeUnlockCounter uint64
+	UnlockMock          mCartRepositoryMockUnlock
 }
 
 // NewCartRepositoryMock returns a mock for CartRepository
@@ -56,6 +113,9 @@ func NewCartRepositoryMock(t minimock.Tester) *CartRepositoryMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.ChangeItemCountMock = mCartRepositoryMockChangeItemCount{mock: m}
+	m.ChangeItemCountMock.callArgs = []*CartRepositoryMockChangeItemCountParams{}
+
 	m.CreateItemMock = mCartRepositoryMockCreateItem{mock: m}
 	m.CreateItemMock.callArgs = []*CartRepositoryMockCreateItemParams{}
 
@@ -65,14 +125,471 @@ func NewCartRepositoryMock(t minimock.Tester) *CartRepositoryMock {
 	m.DeleteBySkuMock = mCartRepositoryMockDeleteBySku{mock: m}
 	m.DeleteBySkuMock.callArgs = []*CartRepositoryMockDeleteBySkuParams{}
 
+	m.DeleteSnapshotMock = mCartRepositoryMockDeleteSnapshot{mock: m}
+	m.DeleteSnapshotMock.callArgs = []*CartRepositoryMockDeleteSnapshotParams{}
+
 	m.GetAllOrderBySkuMock = mCartRepositoryMockGetAllOrderBySku{mock: m}
 	m.GetAllOrderBySkuMock.callArgs = []*CartRepositoryMockGetAllOrderBySkuParams{}
 
+	m.GetCheckoutMock = mCartRepositoryMockGetCheckout{mock: m}
+	m.GetCheckoutMock.callArgs = []*CartRepositoryMockGetCheckoutParams{}
+
+	m.GetSnapshotMock = mCartRepositoryMockGetSnapshot{mock: m}
+	m.GetSnapshotMock.callArgs = []*CartRepositoryMockGetSnapshotParams{}
+
+	m.LockMock = mCartRepositoryMockLock{mock: m}
+	m.LockMock.callArgs = []*CartRepositoryMockLockParams{}
+
+	m.SaveCheckoutMock = mCartRepositoryMockSaveCheckout{mock: m}
+	m.SaveCheckoutMock.callArgs = []*CartRepositoryMockSaveCheckoutParams{}
+
+	m.SetItemCountMock = mCartRepositoryMockSetItemCount{mock: m}
+	m.SetItemCountMock.callArgs = []*CartRepositoryMockSetItemCountParams{}
+
+	m.UnlockMock = mCartRepositoryMockUnlock{mock: m}
+	m.UnlockMock.callArgs = []*CartRepositoryMockUnlockParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
 }
 
+type mCartRepositoryMockChangeItemCount struct {
+	optional           bool
+	mock               *CartRepositoryMock
+	defaultExpectation *CartRepositoryMockChangeItemCountExpectation
+	expectations       []*CartRepositoryMockChangeItemCountExpectation
+
+	callArgs []*CartRepositoryMockChangeItemCountParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// CartRepositoryMockChangeItemCountExpectation specifies expectation struct of the CartRepository.ChangeItemCount
+type CartRepositoryMockChangeItemCountExpectation struct {
+	mock               *CartRepositoryMock
+	params             *CartRepositoryMockChangeItemCountParams
+	paramPtrs          *CartRepositoryMockChangeItemCountParamPtrs
+	expectationOrigins CartRepositoryMockChangeItemCountExpectationOrigins
+	results            *CartRepositoryMockChangeItemCountResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// CartRepositoryMockChangeItemCountParams contains parameters of the CartRepository.ChangeItemCount
+type CartRepositoryMockChangeItemCountParams struct {
+	ctx      context.Context
+	userId   int64
+	skuId    int64
+	delta    int64
+	maxCount uint32
+}
+
+// CartRepositoryMockChangeItemCountParamPtrs contains pointers to parameters of the CartRepository.ChangeItemCount
+type CartRepositoryMockChangeItemCountParamPtrs struct {
+	ctx      *context.Context
+	userId   *int64
+	skuId    *int64
+	delta    *int64
+	maxCount *uint32
+}
+
+// CartRepositoryMockChangeItemCountResults contains results of the CartRepository.ChangeItemCount
+type CartRepositoryMockChangeItemCountResults struct {
+	u1  uint32
+	err error
+}
+
+// CartRepositoryMockChangeItemCountOrigins contains origins of expectations of the CartRepository.ChangeItemCount
+type CartRepositoryMockChangeItemCountExpectationOrigins struct {
+	origin         string
+	originCtx      string
+	originUserId   string
+	originSkuId    string
+	originDelta    string
+	originMaxCount string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) Optional() *mCartRepositoryMockChangeItemCount {
+	mmChangeItemCount.optional = true
+	return mmChangeItemCount
+}
+
+// Expect sets up expected params for CartRepository.ChangeItemCount
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) Expect(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32) *mCartRepositoryMockChangeItemCount {
+	if mmChangeItemCount.mock.funcChangeItemCount != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Set")
+	}
+
+	if mmChangeItemCount.defaultExpectation == nil {
+		mmChangeItemCount.defaultExpectation = &CartRepositoryMockChangeItemCountExpectation{}
+	}
+
+	if mmChangeItemCount.defaultExpectation.paramPtrs != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by ExpectParams functions")
+	}
+
+	mmChangeItemCount.defaultExpectation.params = &CartRepositoryMockChangeItemCountParams{ctx, userId, skuId, delta, maxCount}
+	mmChangeItemCount.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmChangeItemCount.expectations {
+		if minimock.Equal(e.params, mmChangeItemCount.defaultExpectation.params) {
+			mmChangeItemCount.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmChangeItemCount.defaultExpectation.params)
+		}
+	}
+
+	return mmChangeItemCount
+}
+
+// ExpectCtxParam1 sets up expected param ctx for CartRepository.ChangeItemCount
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) ExpectCtxParam1(ctx context.Context) *mCartRepositoryMockChangeItemCount {
+	if mmChangeItemCount.mock.funcChangeItemCount != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Set")
+	}
+
+	if mmChangeItemCount.defaultExpectation == nil {
+		mmChangeItemCount.defaultExpectation = &CartRepositoryMockChangeItemCountExpectation{}
+	}
+
+	if mmChangeItemCount.defaultExpectation.params != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Expect")
+	}
+
+	if mmChangeItemCount.defaultExpectation.paramPtrs == nil {
+		mmChangeItemCount.defaultExpectation.paramPtrs = &CartRepositoryMockChangeItemCountParamPtrs{}
+	}
+	mmChangeItemCount.defaultExpectation.paramPtrs.ctx = &ctx
+	mmChangeItemCount.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmChangeItemCount
+}
+
+// ExpectUserIdParam2 sets up expected param userId for CartRepository.ChangeItemCount
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) ExpectUserIdParam2(userId int64) *mCartRepositoryMockChangeItemCount {
+	if mmChangeItemCount.mock.funcChangeItemCount != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Set")
+	}
+
+	if mmChangeItemCount.defaultExpectation == nil {
+		mmChangeItemCount.defaultExpectation = &CartRepositoryMockChangeItemCountExpectation{}
+	}
+
+	if mmChangeItemCount.defaultExpectation.params != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Expect")
+	}
+
+	if mmChangeItemCount.defaultExpectation.paramPtrs == nil {
+		mmChangeItemCount.defaultExpectation.paramPtrs = &CartRepositoryMockChangeItemCountParamPtrs{}
+	}
+	mmChangeItemCount.defaultExpectation.paramPtrs.userId = &userId
+	mmChangeItemCount.defaultExpectation.expectationOrigins.originUserId = minimock.CallerInfo(1)
+
+	return mmChangeItemCount
+}
+
+// ExpectSkuIdParam3 sets up expected param skuId for CartRepository.ChangeItemCount
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) ExpectSkuIdParam3(skuId int64) *mCartRepositoryMockChangeItemCount {
+	if mmChangeItemCount.mock.funcChangeItemCount != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Set")
+	}
+
+	if mmChangeItemCount.defaultExpectation == nil {
+		mmChangeItemCount.defaultExpectation = &CartRepositoryMockChangeItemCountExpectation{}
+	}
+
+	if mmChangeItemCount.defaultExpectation.params != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Expect")
+	}
+
+	if mmChangeItemCount.defaultExpectation.paramPtrs == nil {
+		mmChangeItemCount.defaultExpectation.paramPtrs = &CartRepositoryMockChangeItemCountParamPtrs{}
+	}
+	mmChangeItemCount.defaultExpectation.paramPtrs.skuId = &skuId
+	mmChangeItemCount.defaultExpectation.expectationOrigins.originSkuId = minimock.CallerInfo(1)
+
+	return mmChangeItemCount
+}
+
+// ExpectDeltaParam4 sets up expected param delta for CartRepository.ChangeItemCount
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) ExpectDeltaParam4(delta int64) *mCartRepositoryMockChangeItemCount {
+	if mmChangeItemCount.mock.funcChangeItemCount != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Set")
+	}
+
+	if mmChangeItemCount.defaultExpectation == nil {
+		mmChangeItemCount.defaultExpectation = &CartRepositoryMockChangeItemCountExpectation{}
+	}
+
+	if mmChangeItemCount.defaultExpectation.params != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Expect")
+	}
+
+	if mmChangeItemCount.defaultExpectation.paramPtrs == nil {
+		mmChangeItemCount.defaultExpectation.paramPtrs = &CartRepositoryMockChangeItemCountParamPtrs{}
+	}
+	mmChangeItemCount.defaultExpectation.paramPtrs.delta = &delta
+	mmChangeItemCount.defaultExpectation.expectationOrigins.originDelta = minimock.CallerInfo(1)
+
+	return mmChangeItemCount
+}
+
+// ExpectMaxCountParam5 sets up expected param maxCount for CartRepository.ChangeItemCount
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) ExpectMaxCountParam5(maxCount uint32) *mCartRepositoryMockChangeItemCount {
+	if mmChangeItemCount.mock.funcChangeItemCount != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Set")
+	}
+
+	if mmChangeItemCount.defaultExpectation == nil {
+		mmChangeItemCount.defaultExpectation = &CartRepositoryMockChangeItemCountExpectation{}
+	}
+
+	if mmChangeItemCount.defaultExpectation.params != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Expect")
+	}
+
+	if mmChangeItemCount.defaultExpectation.paramPtrs == nil {
+		mmChangeItemCount.defaultExpectation.paramPtrs = &CartRepositoryMockChangeItemCountParamPtrs{}
+	}
+	mmChangeItemCount.defaultExpectation.paramPtrs.maxCount = &maxCount
+	mmChangeItemCount.defaultExpectation.expectationOrigins.originMaxCount = minimock.CallerInfo(1)
+
+	return mmChangeItemCount
+}
+
+// Inspect accepts an inspector function that has same arguments as the CartRepository.ChangeItemCount
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) Inspect(f func(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32)) *mCartRepositoryMockChangeItemCount {
+	if mmChangeItemCount.mock.inspectFuncChangeItemCount != nil {
+		mmChangeItemCount.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.ChangeItemCount")
+	}
+
+	mmChangeItemCount.mock.inspectFuncChangeItemCount = f
+
+	return mmChangeItemCount
+}
+
+// Return sets up results that will be returned by CartRepository.ChangeItemCount
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) Return(u1 uint32, err error) *CartRepositoryMock {
+	if mmChangeItemCount.mock.funcChangeItemCount != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Set")
+	}
+
+	if mmChangeItemCount.defaultExpectation == nil {
+		mmChangeItemCount.defaultExpectation = &CartRepositoryMockChangeItemCountExpectation{mock: mmChangeItemCount.mock}
+	}
+	mmChangeItemCount.defaultExpectation.results = &CartRepositoryMockChangeItemCountResults{u1, err}
+	mmChangeItemCount.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmChangeItemCount.mock
+}
+
+// Set uses given function f to mock the CartRepository.ChangeItemCount method
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) Set(f func(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32) (u1 uint32, err error)) *CartRepositoryMock {
+	if mmChangeItemCount.defaultExpectation != nil {
+		mmChangeItemCount.mock.t.Fatalf("Default expectation is already set for the CartRepository.ChangeItemCount method")
+	}
+
+	if len(mmChangeItemCount.expectations) > 0 {
+		mmChangeItemCount.mock.t.Fatalf("Some expectations are already set for the CartRepository.ChangeItemCount method")
+	}
+
+	mmChangeItemCount.mock.funcChangeItemCount = f
+	mmChangeItemCount.mock.funcChangeItemCountOrigin = minimock.CallerInfo(1)
+	return mmChangeItemCount.mock
+}
+
+// When sets expectation for the CartRepository.ChangeItemCount which will trigger the result defined by the following
+// Then helper
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) When(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32) *CartRepositoryMockChangeItemCountExpectation {
+	if mmChangeItemCount.mock.funcChangeItemCount != nil {
+		mmChangeItemCount.mock.t.Fatalf("CartRepositoryMock.ChangeItemCount mock is already set by Set")
+	}
+
+	expectation := &CartRepositoryMockChangeItemCountExpectation{
+		mock:               mmChangeItemCount.mock,
+		params:             &CartRepositoryMockChangeItemCountParams{ctx, userId, skuId, delta, maxCount},
+		expectationOrigins: CartRepositoryMockChangeItemCountExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmChangeItemCount.expectations = append(mmChangeItemCount.expectations, expectation)
+	return expectation
+}
+
+// Then sets up CartRepository.ChangeItemCount return parameters for the expectation previously defined by the When method
+func (e *CartRepositoryMockChangeItemCountExpectation) Then(u1 uint32, err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockChangeItemCountResults{u1, err}
+	return e.mock
+}
+
+// Times sets number of times CartRepository.ChangeItemCount should be invoked
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) Times(n uint64) *mCartRepositoryMockChangeItemCount {
+	if n == 0 {
+		mmChangeItemCount.mock.t.Fatalf("Times of CartRepositoryMock.ChangeItemCount mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmChangeItemCount.expectedInvocations, n)
+	mmChangeItemCount.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmChangeItemCount
+}
+
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) invocationsDone() bool {
+	if len(mmChangeItemCount.expectations) == 0 && mmChangeItemCount.defaultExpectation == nil && mmChangeItemCount.mock.funcChangeItemCount == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmChangeItemCount.mock.afterChangeItemCountCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmChangeItemCount.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ChangeItemCount implements CartRepository
+func (mmChangeItemCount *CartRepositoryMock) ChangeItemCount(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32) (u1 uint32, err error) {
+	mm_atomic.AddUint64(&mmChangeItemCount.beforeChangeItemCountCounter, 1)
+	defer mm_atomic.AddUint64(&mmChangeItemCount.afterChangeItemCountCounter, 1)
+
+	mmChangeItemCount.t.Helper()
+
+	if mmChangeItemCount.inspectFuncChangeItemCount != nil {
+		mmChangeItemCount.inspectFuncChangeItemCount(ctx, userId, skuId, delta, maxCount)
+	}
+
+	mm_params := CartRepositoryMockChangeItemCountParams{ctx, userId, skuId, delta, maxCount}
+
+	// Record call args
+	mmChangeItemCount.ChangeItemCountMock.mutex.Lock()
+	mmChangeItemCount.ChangeItemCountMock.callArgs = append(mmChangeItemCount.ChangeItemCountMock.callArgs, &mm_params)
+	mmChangeItemCount.ChangeItemCountMock.mutex.Unlock()
+
+	for _, e := range mmChangeItemCount.ChangeItemCountMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.u1, e.results.err
+		}
+	}
+
+	if mmChangeItemCount.ChangeItemCountMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmChangeItemCount.ChangeItemCountMock.defaultExpectation.Counter, 1)
+		mm_want := mmChangeItemCount.ChangeItemCountMock.defaultExpectation.params
+		mm_want_ptrs := mmChangeItemCount.ChangeItemCountMock.defaultExpectation.paramPtrs
+
+		mm_got := CartRepositoryMockChangeItemCountParams{ctx, userId, skuId, delta, maxCount}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmChangeItemCount.t.Errorf("CartRepositoryMock.ChangeItemCount got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmChangeItemCount.ChangeItemCountMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.userId != nil && !minimock.Equal(*mm_want_ptrs.userId, mm_got.userId) {
+				mmChangeItemCount.t.Errorf("CartRepositoryMock.ChangeItemCount got unexpected parameter userId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmChangeItemCount.ChangeItemCountMock.defaultExpectation.expectationOrigins.originUserId, *mm_want_ptrs.userId, mm_got.userId, minimock.Diff(*mm_want_ptrs.userId, mm_got.userId))
+			}
+
+			if mm_want_ptrs.skuId != nil && !minimock.Equal(*mm_want_ptrs.skuId, mm_got.skuId) {
+				mmChangeItemCount.t.Errorf("CartRepositoryMock.ChangeItemCount got unexpected parameter skuId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmChangeItemCount.ChangeItemCountMock.defaultExpectation.expectationOrigins.originSkuId, *mm_want_ptrs.skuId, mm_got.skuId, minimock.Diff(*mm_want_ptrs.skuId, mm_got.skuId))
+			}
+
+			if mm_want_ptrs.delta != nil && !minimock.Equal(*mm_want_ptrs.delta, mm_got.delta) {
+				mmChangeItemCount.t.Errorf("CartRepositoryMock.ChangeItemCount got unexpected parameter delta, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmChangeItemCount.ChangeItemCountMock.defaultExpectation.expectationOrigins.originDelta, *mm_want_ptrs.delta, mm_got.delta, minimock.Diff(*mm_want_ptrs.delta, mm_got.delta))
+			}
+
+			if mm_want_ptrs.maxCount != nil && !minimock.Equal(*mm_want_ptrs.maxCount, mm_got.maxCount) {
+				mmChangeItemCount.t.Errorf("CartRepositoryMock.ChangeItemCount got unexpected parameter maxCount, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmChangeItemCount.ChangeItemCountMock.defaultExpectation.expectationOrigins.originMaxCount, *mm_want_ptrs.maxCount, mm_got.maxCount, minimock.Diff(*mm_want_ptrs.maxCount, mm_got.maxCount))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmChangeItemCount.t.Errorf("CartRepositoryMock.ChangeItemCount got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmChangeItemCount.ChangeItemCountMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmChangeItemCount.ChangeItemCountMock.defaultExpectation.results
+		if mm_results == nil {
+			mmChangeItemCount.t.Fatal("No results are set for the CartRepositoryMock.ChangeItemCount")
+		}
+		return (*mm_results).u1, (*mm_results).err
+	}
+	if mmChangeItemCount.funcChangeItemCount != nil {
+		return mmChangeItemCount.funcChangeItemCount(ctx, userId, skuId, delta, maxCount)
+	}
+	mmChangeItemCount.t.Fatalf("Unexpected call to CartRepositoryMock.ChangeItemCount. %v %v %v %v %v", ctx, userId, skuId, delta, maxCount)
+	return
+}
+
+// ChangeItemCountAfterCounter returns a count of finished CartRepositoryMock.ChangeItemCount invocations
+func (mmChangeItemCount *CartRepositoryMock) ChangeItemCountAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmChangeItemCount.afterChangeItemCountCounter)
+}
+
+// ChangeItemCountBeforeCounter returns a count of CartRepositoryMock.ChangeItemCount invocations
+func (mmChangeItemCount *CartRepositoryMock) ChangeItemCountBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmChangeItemCount.beforeChangeItemCountCounter)
+}
+
+// Calls returns a list of arguments used in each call to CartRepositoryMock.ChangeItemCount.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmChangeItemCount *mCartRepositoryMockChangeItemCount) Calls() []*CartRepositoryMockChangeItemCountParams {
+	mmChangeItemCount.mutex.RLock()
+
+	argCopy := make([]*CartRepositoryMockChangeItemCountParams, len(mmChangeItemCount.callArgs))
+	copy(argCopy, mmChangeItemCount.callArgs)
+
+	mmChangeItemCount.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockChangeItemCountDone returns true if the count of the ChangeItemCount invocations corresponds
+// the number of defined expectations
+func (m *CartRepositoryMock) MinimockChangeItemCountDone() bool {
+	if m.ChangeItemCountMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ChangeItemCountMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ChangeItemCountMock.invocationsDone()
+}
+
+// MinimockChangeItemCountInspect logs each unmet expectation
+func (m *CartRepositoryMock) MinimockChangeItemCountInspect() {
+	for _, e := range m.ChangeItemCountMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to CartRepositoryMock.ChangeItemCount at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterChangeItemCountCounter := mm_atomic.LoadUint64(&m.afterChangeItemCountCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ChangeItemCountMock.defaultExpectation != nil && afterChangeItemCountCounter < 1 {
+		if m.ChangeItemCountMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to CartRepositoryMock.ChangeItemCount at\n%s", m.ChangeItemCountMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to CartRepositoryMock.ChangeItemCount at\n%s with params: %#v", m.ChangeItemCountMock.defaultExpectation.expectationOrigins.origin, *m.ChangeItemCountMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcChangeItemCount != nil && afterChangeItemCountCounter < 1 {
+		m.t.Errorf("Expected call to CartRepositoryMock.ChangeItemCount at\n%s", m.funcChangeItemCountOrigin)
+	}
+
+	if !m.ChangeItemCountMock.invocationsDone() && afterChangeItemCountCounter > 0 {
+		m.t.Errorf("Expected %d calls to CartRepositoryMock.ChangeItemCount at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ChangeItemCountMock.expectedInvocations), m.ChangeItemCountMock.expectedInvocationsOrigin, afterChangeItemCountCounter)
+	}
+}
+
 type mCartRepositoryMockCreateItem struct {
 	optional           bool
 	mock               *CartRepositoryMock
@@ -435,9 +952,9 @@ type CartRepositoryMockDeleteAllExpectation struct {
 	params             *CartRepositoryMockDeleteAllParams
 	paramPtrs          *CartRepositoryMockDeleteAllParamPtrs
 	expectationOrigins CartRepositoryMockDeleteAllExpectationOrigins
-
-	returnOrigin string
-	Counter      uint64
+	results            *CartRepositoryMockDeleteAllResults
+	returnOrigin       string
+	Counter            uint64
 }
 
 // CartRepositoryMockDeleteAllParams contains parameters of the CartRepository.DeleteAll
@@ -452,6 +969,11 @@ type CartRepositoryMockDeleteAllParamPtrs struct {
 	userId *int64
 }
 
+// CartRepositoryMockDeleteAllResults contains results of the CartRepository.DeleteAll
+type CartRepositoryMockDeleteAllResults struct {
+	err error
+}
+
 // CartRepositoryMockDeleteAllOrigins contains origins of expectations of the CartRepository.DeleteAll
 type CartRepositoryMockDeleteAllExpectationOrigins struct {
 	origin       string
@@ -552,7 +1074,7 @@ func (mmDeleteAll *mCartRepositoryMockDeleteAll) Inspect(f func(ctx context.Cont
 }
 
 // Return sets up results that will be returned by CartRepository.DeleteAll
-func (mmDeleteAll *mCartRepositoryMockDeleteAll) Return() *CartRepositoryMock {
+func (mmDeleteAll *mCartRepositoryMockDeleteAll) Return(err error) *CartRepositoryMock {
 	if mmDeleteAll.mock.funcDeleteAll != nil {
 		mmDeleteAll.mock.t.Fatalf("CartRepositoryMock.DeleteAll mock is already set by Set")
 	}
@@ -560,13 +1082,13 @@ func (mmDeleteAll *mCartRepositoryMockDeleteAll) Return() *CartRepositoryMock {
 	if mmDeleteAll.defaultExpectation == nil {
 		mmDeleteAll.defaultExpectation = &CartRepositoryMockDeleteAllExpectation{mock: mmDeleteAll.mock}
 	}
-
+	mmDeleteAll.defaultExpectation.results = &CartRepositoryMockDeleteAllResults{err}
 	mmDeleteAll.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
 	return mmDeleteAll.mock
 }
 
 // Set uses given function f to mock the CartRepository.DeleteAll method
-func (mmDeleteAll *mCartRepositoryMockDeleteAll) Set(f func(ctx context.Context, userId int64)) *CartRepositoryMock {
+func (mmDeleteAll *mCartRepositoryMockDeleteAll) Set(f func(ctx context.Context, userId int64) (err error)) *CartRepositoryMock {
 	if mmDeleteAll.defaultExpectation != nil {
 		mmDeleteAll.mock.t.Fatalf("Default expectation is already set for the CartRepository.DeleteAll method")
 	}
@@ -597,8 +1119,8 @@ func (mmDeleteAll *mCartRepositoryMockDeleteAll) When(ctx context.Context, userI
 }
 
 // Then sets up CartRepository.DeleteAll return parameters for the expectation previously defined by the When method
-
-func (e *CartRepositoryMockDeleteAllExpectation) Then() *CartRepositoryMock {
+func (e *CartRepositoryMockDeleteAllExpectation) Then(err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockDeleteAllResults{err}
 	return e.mock
 }
 
@@ -624,7 +1146,7 @@ func (mmDeleteAll *mCartRepositoryMockDeleteAll) invocationsDone() bool {
 }
 
 // DeleteAll implements CartRepository
-func (mmDeleteAll *CartRepositoryMock) DeleteAll(ctx context.Context, userId int64) {
+func (mmDeleteAll *CartRepositoryMock) DeleteAll(ctx context.Context, userId int64) (err error) {
 	mm_atomic.AddUint64(&mmDeleteAll.beforeDeleteAllCounter, 1)
 	defer mm_atomic.AddUint64(&mmDeleteAll.afterDeleteAllCounter, 1)
 
@@ -644,7 +1166,7 @@ func (mmDeleteAll *CartRepositoryMock) DeleteAll(ctx context.Context, userId int
 	for _, e := range mmDeleteAll.DeleteAllMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return
+			return e.results.err
 		}
 	}
 
@@ -672,15 +1194,17 @@ func (mmDeleteAll *CartRepositoryMock) DeleteAll(ctx context.Context, userId int
 				mmDeleteAll.DeleteAllMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		return
-
+		mm_results := mmDeleteAll.DeleteAllMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteAll.t.Fatal("No results are set for the CartRepositoryMock.DeleteAll")
+		}
+		return (*mm_results).err
 	}
 	if mmDeleteAll.funcDeleteAll != nil {
-		mmDeleteAll.funcDeleteAll(ctx, userId)
-		return
+		return mmDeleteAll.funcDeleteAll(ctx, userId)
 	}
 	mmDeleteAll.t.Fatalf("Unexpected call to CartRepositoryMock.DeleteAll. %v %v", ctx, userId)
-
+	return
 }
 
 // DeleteAllAfterCounter returns a count of finished CartRepositoryMock.DeleteAll invocations
@@ -770,9 +1294,9 @@ type CartRepositoryMockDeleteBySkuExpectation struct {
 	params             *CartRepositoryMockDeleteBySkuParams
 	paramPtrs          *CartRepositoryMockDeleteBySkuParamPtrs
 	expectationOrigins CartRepositoryMockDeleteBySkuExpectationOrigins
-
-	returnOrigin string
-	Counter      uint64
+	results            *CartRepositoryMockDeleteBySkuResults
+	returnOrigin       string
+	Counter            uint64
 }
 
 // CartRepositoryMockDeleteBySkuParams contains parameters of the CartRepository.DeleteBySku
@@ -789,6 +1313,11 @@ type CartRepositoryMockDeleteBySkuParamPtrs struct {
 	skuId  *int64
 }
 
+// CartRepositoryMockDeleteBySkuResults contains results of the CartRepository.DeleteBySku
+type CartRepositoryMockDeleteBySkuResults struct {
+	err error
+}
+
 // CartRepositoryMockDeleteBySkuOrigins contains origins of expectations of the CartRepository.DeleteBySku
 type CartRepositoryMockDeleteBySkuExpectationOrigins struct {
 	origin       string
@@ -913,7 +1442,7 @@ func (mmDeleteBySku *mCartRepositoryMockDeleteBySku) Inspect(f func(ctx context.
 }
 
 // Return sets up results that will be returned by CartRepository.DeleteBySku
-func (mmDeleteBySku *mCartRepositoryMockDeleteBySku) Return() *CartRepositoryMock {
+func (mmDeleteBySku *mCartRepositoryMockDeleteBySku) Return(err error) *CartRepositoryMock {
 	if mmDeleteBySku.mock.funcDeleteBySku != nil {
 		mmDeleteBySku.mock.t.Fatalf("CartRepositoryMock.DeleteBySku mock is already set by Set")
 	}
@@ -921,13 +1450,13 @@ func (mmDeleteBySku *mCartRepositoryMockDeleteBySku) Return() *CartRepositoryMoc
 	if mmDeleteBySku.defaultExpectation == nil {
 		mmDeleteBySku.defaultExpectation = &CartRepositoryMockDeleteBySkuExpectation{mock: mmDeleteBySku.mock}
 	}
-
+	mmDeleteBySku.defaultExpectation.results = &CartRepositoryMockDeleteBySkuResults{err}
 	mmDeleteBySku.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
 	return mmDeleteBySku.mock
 }
 
 // Set uses given function f to mock the CartRepository.DeleteBySku method
-func (mmDeleteBySku *mCartRepositoryMockDeleteBySku) Set(f func(ctx context.Context, userId int64, skuId int64)) *CartRepositoryMock {
+func (mmDeleteBySku *mCartRepositoryMockDeleteBySku) Set(f func(ctx context.Context, userId int64, skuId int64) (err error)) *CartRepositoryMock {
 	if mmDeleteBySku.defaultExpectation != nil {
 		mmDeleteBySku.mock.t.Fatalf("Default expectation is already set for the CartRepository.DeleteBySku method")
 	}
@@ -958,8 +1487,8 @@ func (mmDeleteBySku *mCartRepositoryMockDeleteBySku) When(ctx context.Context, u
 }
 
 // Then sets up CartRepository.DeleteBySku return parameters for the expectation previously defined by the When method
-
-func (e *CartRepositoryMockDeleteBySkuExpectation) Then() *CartRepositoryMock {
+func (e *CartRepositoryMockDeleteBySkuExpectation) Then(err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockDeleteBySkuResults{err}
 	return e.mock
 }
 
@@ -985,7 +1514,7 @@ func (mmDeleteBySku *mCartRepositoryMockDeleteBySku) invocationsDone() bool {
 }
 
 // DeleteBySku implements CartRepository
-func (mmDeleteBySku *CartRepositoryMock) DeleteBySku(ctx context.Context, userId int64, skuId int64) {
+func (mmDeleteBySku *CartRepositoryMock) DeleteBySku(ctx context.Context, userId int64, skuId int64) (err error) {
 	mm_atomic.AddUint64(&mmDeleteBySku.beforeDeleteBySkuCounter, 1)
 	defer mm_atomic.AddUint64(&mmDeleteBySku.afterDeleteBySkuCounter, 1)
 
@@ -1005,7 +1534,7 @@ func (mmDeleteBySku *CartRepositoryMock) DeleteBySku(ctx context.Context, userId
 	for _, e := range mmDeleteBySku.DeleteBySkuMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return
+			return e.results.err
 		}
 	}
 
@@ -1038,15 +1567,17 @@ func (mmDeleteBySku *CartRepositoryMock) DeleteBySku(ctx context.Context, userId
 				mmDeleteBySku.DeleteBySkuMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		return
-
+		mm_results := mmDeleteBySku.DeleteBySkuMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteBySku.t.Fatal("No results are set for the CartRepositoryMock.DeleteBySku")
+		}
+		return (*mm_results).err
 	}
 	if mmDeleteBySku.funcDeleteBySku != nil {
-		mmDeleteBySku.funcDeleteBySku(ctx, userId, skuId)
-		return
+		return mmDeleteBySku.funcDeleteBySku(ctx, userId, skuId)
 	}
 	mmDeleteBySku.t.Fatalf("Unexpected call to CartRepositoryMock.DeleteBySku. %v %v %v", ctx, userId, skuId)
-
+	return
 }
 
 // DeleteBySkuAfterCounter returns a count of finished CartRepositoryMock.DeleteBySku invocations
@@ -1117,33 +1648,375 @@ func (m *CartRepositoryMock) MinimockDeleteBySkuInspect() {
 	}
 }
 
-type mCartRepositoryMockGetAllOrderBySku struct {
+type mCartRepositoryMockDeleteSnapshot struct {
 	optional           bool
 	mock               *CartRepositoryMock
-	defaultExpectation *CartRepositoryMockGetAllOrderBySkuExpectation
-	expectations       []*CartRepositoryMockGetAllOrderBySkuExpectation
+	defaultExpectation *CartRepositoryMockDeleteSnapshotExpectation
+	expectations       []*CartRepositoryMockDeleteSnapshotExpectation
 
-	callArgs []*CartRepositoryMockGetAllOrderBySkuParams
+	callArgs []*CartRepositoryMockDeleteSnapshotParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// CartRepositoryMockGetAllOrderBySkuExpectation specifies expectation struct of the CartRepository.GetAllOrderBySku
-type CartRepositoryMockGetAllOrderBySkuExpectation struct {
+// CartRepositoryMockDeleteSnapshotExpectation specifies expectation struct of the CartRepository.DeleteSnapshot
+type CartRepositoryMockDeleteSnapshotExpectation struct {
 	mock               *CartRepositoryMock
-	params             *CartRepositoryMockGetAllOrderBySkuParams
-	paramPtrs          *CartRepositoryMockGetAllOrderBySkuParamPtrs
-	expectationOrigins CartRepositoryMockGetAllOrderBySkuExpectationOrigins
-	results            *CartRepositoryMockGetAllOrderBySkuResults
+	params             *CartRepositoryMockDeleteSnapshotParams
+	paramPtrs          *CartRepositoryMockDeleteSnapshotParamPtrs
+	expectationOrigins CartRepositoryMockDeleteSnapshotExpectationOrigins
+	results            *CartRepositoryMockDeleteSnapshotResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// CartRepositoryMockGetAllOrderBySkuParams contains parameters of the CartRepository.GetAllOrderBySku
-type CartRepositoryMockGetAllOrderBySkuParams struct {
-	ctx    context.Context
+// CartRepositoryMockDeleteSnapshotParams contains parameters of the CartRepository.DeleteSnapshot
+type CartRepositoryMockDeleteSnapshotParams struct {
+	ctx      context.Context
+	snapshot model.CartSnapshotModel
+}
+
+// CartRepositoryMockDeleteSnapshotParamPtrs contains pointers to parameters of the CartRepository.DeleteSnapshot
+type CartRepositoryMockDeleteSnapshotParamPtrs struct {
+	ctx      *context.Context
+	snapshot *model.CartSnapshotModel
+}
+
+// CartRepositoryMockDeleteSnapshotResults contains results of the CartRepository.DeleteSnapshot
+type CartRepositoryMockDeleteSnapshotResults struct {
+	err error
+}
+
+// CartRepositoryMockDeleteSnapshotOrigins contains origins of expectations of the CartRepository.DeleteSnapshot
+type CartRepositoryMockDeleteSnapshotExpectationOrigins struct {
+	origin         string
+	originCtx      string
+	originSnapshot string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) Optional() *mCartRepositoryMockDeleteSnapshot {
+	mmDeleteSnapshot.optional = true
+	return mmDeleteSnapshot
+}
+
+// Expect sets up expected params for CartRepository.DeleteSnapshot
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) Expect(ctx context.Context, snapshot model.CartSnapshotModel) *mCartRepositoryMockDeleteSnapshot {
+	if mmDeleteSnapshot.mock.funcDeleteSnapshot != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("CartRepositoryMock.DeleteSnapshot mock is already set by Set")
+	}
+
+	if mmDeleteSnapshot.defaultExpectation == nil {
+		mmDeleteSnapshot.defaultExpectation = &CartRepositoryMockDeleteSnapshotExpectation{}
+	}
+
+	if mmDeleteSnapshot.defaultExpectation.paramPtrs != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("CartRepositoryMock.DeleteSnapshot mock is already set by ExpectParams functions")
+	}
+
+	mmDeleteSnapshot.defaultExpectation.params = &CartRepositoryMockDeleteSnapshotParams{ctx, snapshot}
+	mmDeleteSnapshot.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmDeleteSnapshot.expectations {
+		if minimock.Equal(e.params, mmDeleteSnapshot.defaultExpectation.params) {
+			mmDeleteSnapshot.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteSnapshot.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteSnapshot
+}
+
+// ExpectCtxParam1 sets up expected param ctx for CartRepository.DeleteSnapshot
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) ExpectCtxParam1(ctx context.Context) *mCartRepositoryMockDeleteSnapshot {
+	if mmDeleteSnapshot.mock.funcDeleteSnapshot != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("CartRepositoryMock.DeleteSnapshot mock is already set by Set")
+	}
+
+	if mmDeleteSnapshot.defaultExpectation == nil {
+		mmDeleteSnapshot.defaultExpectation = &CartRepositoryMockDeleteSnapshotExpectation{}
+	}
+
+	if mmDeleteSnapshot.defaultExpectation.params != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("CartRepositoryMock.DeleteSnapshot mock is already set by Expect")
+	}
+
+	if mmDeleteSnapshot.defaultExpectation.paramPtrs == nil {
+		mmDeleteSnapshot.defaultExpectation.paramPtrs = &CartRepositoryMockDeleteSnapshotParamPtrs{}
+	}
+	mmDeleteSnapshot.defaultExpectation.paramPtrs.ctx = &ctx
+	mmDeleteSnapshot.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmDeleteSnapshot
+}
+
+// ExpectSnapshotParam2 sets up expected param snapshot for CartRepository.DeleteSnapshot
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) ExpectSnapshotParam2(snapshot model.CartSnapshotModel) *mCartRepositoryMockDeleteSnapshot {
+	if mmDeleteSnapshot.mock.funcDeleteSnapshot != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("CartRepositoryMock.DeleteSnapshot mock is already set by Set")
+	}
+
+	if mmDeleteSnapshot.defaultExpectation == nil {
+		mmDeleteSnapshot.defaultExpectation = &CartRepositoryMockDeleteSnapshotExpectation{}
+	}
+
+	if mmDeleteSnapshot.defaultExpectation.params != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("CartRepositoryMock.DeleteSnapshot mock is already set by Expect")
+	}
+
+	if mmDeleteSnapshot.defaultExpectation.paramPtrs == nil {
+		mmDeleteSnapshot.defaultExpectation.paramPtrs = &CartRepositoryMockDeleteSnapshotParamPtrs{}
+	}
+	mmDeleteSnapshot.defaultExpectation.paramPtrs.snapshot = &snapshot
+	mmDeleteSnapshot.defaultExpectation.expectationOrigins.originSnapshot = minimock.CallerInfo(1)
+
+	return mmDeleteSnapshot
+}
+
+// Inspect accepts an inspector function that has same arguments as the CartRepository.DeleteSnapshot
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) Inspect(f func(ctx context.Context, snapshot model.CartSnapshotModel)) *mCartRepositoryMockDeleteSnapshot {
+	if mmDeleteSnapshot.mock.inspectFuncDeleteSnapshot != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.DeleteSnapshot")
+	}
+
+	mmDeleteSnapshot.mock.inspectFuncDeleteSnapshot = f
+
+	return mmDeleteSnapshot
+}
+
+// Return sets up results that will be returned by CartRepository.DeleteSnapshot
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) Return(err error) *CartRepositoryMock {
+	if mmDeleteSnapshot.mock.funcDeleteSnapshot != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("CartRepositoryMock.DeleteSnapshot mock is already set by Set")
+	}
+
+	if mmDeleteSnapshot.defaultExpectation == nil {
+		mmDeleteSnapshot.defaultExpectation = &CartRepositoryMockDeleteSnapshotExpectation{mock: mmDeleteSnapshot.mock}
+	}
+	mmDeleteSnapshot.defaultExpectation.results = &CartRepositoryMockDeleteSnapshotResults{err}
+	mmDeleteSnapshot.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmDeleteSnapshot.mock
+}
+
+// Set uses given function f to mock the CartRepository.DeleteSnapshot method
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) Set(f func(ctx context.Context, snapshot model.CartSnapshotModel) (err error)) *CartRepositoryMock {
+	if mmDeleteSnapshot.defaultExpectation != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("Default expectation is already set for the CartRepository.DeleteSnapshot method")
+	}
+
+	if len(mmDeleteSnapshot.expectations) > 0 {
+		mmDeleteSnapshot.mock.t.Fatalf("Some expectations are already set for the CartRepository.DeleteSnapshot method")
+	}
+
+	mmDeleteSnapshot.mock.funcDeleteSnapshot = f
+	mmDeleteSnapshot.mock.funcDeleteSnapshotOrigin = minimock.CallerInfo(1)
+	return mmDeleteSnapshot.mock
+}
+
+// When sets expectation for the CartRepository.DeleteSnapshot which will trigger the result defined by the following
+// Then helper
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) When(ctx context.Context, snapshot model.CartSnapshotModel) *CartRepositoryMockDeleteSnapshotExpectation {
+	if mmDeleteSnapshot.mock.funcDeleteSnapshot != nil {
+		mmDeleteSnapshot.mock.t.Fatalf("CartRepositoryMock.DeleteSnapshot mock is already set by Set")
+	}
+
+	expectation := &CartRepositoryMockDeleteSnapshotExpectation{
+		mock:               mmDeleteSnapshot.mock,
+		params:             &CartRepositoryMockDeleteSnapshotParams{ctx, snapshot},
+		expectationOrigins: CartRepositoryMockDeleteSnapshotExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmDeleteSnapshot.expectations = append(mmDeleteSnapshot.expectations, expectation)
+	return expectation
+}
+
+// Then sets up CartRepository.DeleteSnapshot return parameters for the expectation previously defined by the When method
+func (e *CartRepositoryMockDeleteSnapshotExpectation) Then(err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockDeleteSnapshotResults{err}
+	return e.mock
+}
+
+// Times sets number of times CartRepository.DeleteSnapshot should be invoked
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) Times(n uint64) *mCartRepositoryMockDeleteSnapshot {
+	if n == 0 {
+		mmDeleteSnapshot.mock.t.Fatalf("Times of CartRepositoryMock.DeleteSnapshot mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDeleteSnapshot.expectedInvocations, n)
+	mmDeleteSnapshot.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmDeleteSnapshot
+}
+
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) invocationsDone() bool {
+	if len(mmDeleteSnapshot.expectations) == 0 && mmDeleteSnapshot.defaultExpectation == nil && mmDeleteSnapshot.mock.funcDeleteSnapshot == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDeleteSnapshot.mock.afterDeleteSnapshotCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeleteSnapshot.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DeleteSnapshot implements CartRepository
+func (mmDeleteSnapshot *CartRepositoryMock) DeleteSnapshot(ctx context.Context, snapshot model.CartSnapshotModel) (err error) {
+	mm_atomic.AddUint64(&mmDeleteSnapshot.beforeDeleteSnapshotCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteSnapshot.afterDeleteSnapshotCounter, 1)
+
+	mmDeleteSnapshot.t.Helper()
+
+	if mmDeleteSnapshot.inspectFuncDeleteSnapshot != nil {
+		mmDeleteSnapshot.inspectFuncDeleteSnapshot(ctx, snapshot)
+	}
+
+	mm_params := CartRepositoryMockDeleteSnapshotParams{ctx, snapshot}
+
+	// Record call args
+	mmDeleteSnapshot.DeleteSnapshotMock.mutex.Lock()
+	mmDeleteSnapshot.DeleteSnapshotMock.callArgs = append(mmDeleteSnapshot.DeleteSnapshotMock.callArgs, &mm_params)
+	mmDeleteSnapshot.DeleteSnapshotMock.mutex.Unlock()
+
+	for _, e := range mmDeleteSnapshot.DeleteSnapshotMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDeleteSnapshot.DeleteSnapshotMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteSnapshot.DeleteSnapshotMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteSnapshot.DeleteSnapshotMock.defaultExpectation.params
+		mm_want_ptrs := mmDeleteSnapshot.DeleteSnapshotMock.defaultExpectation.paramPtrs
+
+		mm_got := CartRepositoryMockDeleteSnapshotParams{ctx, snapshot}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDeleteSnapshot.t.Errorf("CartRepositoryMock.DeleteSnapshot got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteSnapshot.DeleteSnapshotMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.snapshot != nil && !minimock.Equal(*mm_want_ptrs.snapshot, mm_got.snapshot) {
+				mmDeleteSnapshot.t.Errorf("CartRepositoryMock.DeleteSnapshot got unexpected parameter snapshot, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmDeleteSnapshot.DeleteSnapshotMock.defaultExpectation.expectationOrigins.originSnapshot, *mm_want_ptrs.snapshot, mm_got.snapshot, minimock.Diff(*mm_want_ptrs.snapshot, mm_got.snapshot))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteSnapshot.t.Errorf("CartRepositoryMock.DeleteSnapshot got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmDeleteSnapshot.DeleteSnapshotMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteSnapshot.DeleteSnapshotMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteSnapshot.t.Fatal("No results are set for the CartRepositoryMock.DeleteSnapshot")
+		}
+		return (*mm_results).err
+	}
+	if mmDeleteSnapshot.funcDeleteSnapshot != nil {
+		return mmDeleteSnapshot.funcDeleteSnapshot(ctx, snapshot)
+	}
+	mmDeleteSnapshot.t.Fatalf("Unexpected call to CartRepositoryMock.DeleteSnapshot. %v %v", ctx, snapshot)
+	return
+}
+
+// DeleteSnapshotAfterCounter returns a count of finished CartRepositoryMock.DeleteSnapshot invocations
+func (mmDeleteSnapshot *CartRepositoryMock) DeleteSnapshotAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteSnapshot.afterDeleteSnapshotCounter)
+}
+
+// DeleteSnapshotBeforeCounter returns a count of CartRepositoryMock.DeleteSnapshot invocations
+func (mmDeleteSnapshot *CartRepositoryMock) DeleteSnapshotBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteSnapshot.beforeDeleteSnapshotCounter)
+}
+
+// Calls returns a list of arguments used in each call to CartRepositoryMock.DeleteSnapshot.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteSnapshot *mCartRepositoryMockDeleteSnapshot) Calls() []*CartRepositoryMockDeleteSnapshotParams {
+	mmDeleteSnapshot.mutex.RLock()
+
+	argCopy := make([]*CartRepositoryMockDeleteSnapshotParams, len(mmDeleteSnapshot.callArgs))
+	copy(argCopy, mmDeleteSnapshot.callArgs)
+
+	mmDeleteSnapshot.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeleteSnapshotDone returns true if the count of the DeleteSnapshot invocations corresponds
+// the number of defined expectations
+func (m *CartRepositoryMock) MinimockDeleteSnapshotDone() bool {
+	if m.DeleteSnapshotMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.DeleteSnapshotMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DeleteSnapshotMock.invocationsDone()
+}
+
+// MinimockDeleteSnapshotInspect logs each unmet expectation
+func (m *CartRepositoryMock) MinimockDeleteSnapshotInspect() {
+	for _, e := range m.DeleteSnapshotMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to CartRepositoryMock.DeleteSnapshot at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterDeleteSnapshotCounter := mm_atomic.LoadUint64(&m.afterDeleteSnapshotCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteSnapshotMock.defaultExpectation != nil && afterDeleteSnapshotCounter < 1 {
+		if m.DeleteSnapshotMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to CartRepositoryMock.DeleteSnapshot at\n%s", m.DeleteSnapshotMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to CartRepositoryMock.DeleteSnapshot at\n%s with params: %#v", m.DeleteSnapshotMock.defaultExpectation.expectationOrigins.origin, *m.DeleteSnapshotMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteSnapshot != nil && afterDeleteSnapshotCounter < 1 {
+		m.t.Errorf("Expected call to CartRepositoryMock.DeleteSnapshot at\n%s", m.funcDeleteSnapshotOrigin)
+	}
+
+	if !m.DeleteSnapshotMock.invocationsDone() && afterDeleteSnapshotCounter > 0 {
+		m.t.Errorf("Expected %d calls to CartRepositoryMock.DeleteSnapshot at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.DeleteSnapshotMock.expectedInvocations), m.DeleteSnapshotMock.expectedInvocationsOrigin, afterDeleteSnapshotCounter)
+	}
+}
+
+type mCartRepositoryMockGetAllOrderBySku struct {
+	optional           bool
+	mock               *CartRepositoryMock
+	defaultExpectation *CartRepositoryMockGetAllOrderBySkuExpectation
+	expectations       []*CartRepositoryMockGetAllOrderBySkuExpectation
+
+	callArgs []*CartRepositoryMockGetAllOrderBySkuParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// CartRepositoryMockGetAllOrderBySkuExpectation specifies expectation struct of the CartRepository.GetAllOrderBySku
+type CartRepositoryMockGetAllOrderBySkuExpectation struct {
+	mock               *CartRepositoryMock
+	params             *CartRepositoryMockGetAllOrderBySkuParams
+	paramPtrs          *CartRepositoryMockGetAllOrderBySkuParamPtrs
+	expectationOrigins CartRepositoryMockGetAllOrderBySkuExpectationOrigins
+	results            *CartRepositoryMockGetAllOrderBySkuResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// CartRepositoryMockGetAllOrderBySkuParams contains parameters of the CartRepository.GetAllOrderBySku
+type CartRepositoryMockGetAllOrderBySkuParams struct {
+	ctx    context.Context
 	userId int64
 }
 
@@ -1156,6 +2029,7 @@ type CartRepositoryMockGetAllOrderBySkuParamPtrs struct {
 // CartRepositoryMockGetAllOrderBySkuResults contains results of the CartRepository.GetAllOrderBySku
 type CartRepositoryMockGetAllOrderBySkuResults struct {
 	ca1 []model.CartItemModel
+	err error
 }
 
 // CartRepositoryMockGetAllOrderBySkuOrigins contains origins of expectations of the CartRepository.GetAllOrderBySku
@@ -1258,7 +2132,7 @@ func (mmGetAllOrderBySku *mCartRepositoryMockGetAllOrderBySku) Inspect(f func(ct
 }
 
 // Return sets up results that will be returned by CartRepository.GetAllOrderBySku
-func (mmGetAllOrderBySku *mCartRepositoryMockGetAllOrderBySku) Return(ca1 []model.CartItemModel) *CartRepositoryMock {
+func (mmGetAllOrderBySku *mCartRepositoryMockGetAllOrderBySku) Return(ca1 []model.CartItemModel, err error) *CartRepositoryMock {
 	if mmGetAllOrderBySku.mock.funcGetAllOrderBySku != nil {
 		mmGetAllOrderBySku.mock.t.Fatalf("CartRepositoryMock.GetAllOrderBySku mock is already set by Set")
 	}
@@ -1266,13 +2140,13 @@ func (mmGetAllOrderBySku *mCartRepositoryMockGetAllOrderBySku) Return(ca1 []mode
 	if mmGetAllOrderBySku.defaultExpectation == nil {
 		mmGetAllOrderBySku.defaultExpectation = &CartRepositoryMockGetAllOrderBySkuExpectation{mock: mmGetAllOrderBySku.mock}
 	}
-	mmGetAllOrderBySku.defaultExpectation.results = &CartRepositoryMockGetAllOrderBySkuResults{ca1}
+	mmGetAllOrderBySku.defaultExpectation.results = &CartRepositoryMockGetAllOrderBySkuResults{ca1, err}
 	mmGetAllOrderBySku.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
 	return mmGetAllOrderBySku.mock
 }
 
 // Set uses given function f to mock the CartRepository.GetAllOrderBySku method
-func (mmGetAllOrderBySku *mCartRepositoryMockGetAllOrderBySku) Set(f func(ctx context.Context, userId int64) (ca1 []model.CartItemModel)) *CartRepositoryMock {
+func (mmGetAllOrderBySku *mCartRepositoryMockGetAllOrderBySku) Set(f func(ctx context.Context, userId int64) (ca1 []model.CartItemModel, err error)) *CartRepositoryMock {
 	if mmGetAllOrderBySku.defaultExpectation != nil {
 		mmGetAllOrderBySku.mock.t.Fatalf("Default expectation is already set for the CartRepository.GetAllOrderBySku method")
 	}
@@ -1303,8 +2177,8 @@ func (mmGetAllOrderBySku *mCartRepositoryMockGetAllOrderBySku) When(ctx context.
 }
 
 // Then sets up CartRepository.GetAllOrderBySku return parameters for the expectation previously defined by the When method
-func (e *CartRepositoryMockGetAllOrderBySkuExpectation) Then(ca1 []model.CartItemModel) *CartRepositoryMock {
-	e.results = &CartRepositoryMockGetAllOrderBySkuResults{ca1}
+func (e *CartRepositoryMockGetAllOrderBySkuExpectation) Then(ca1 []model.CartItemModel, err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockGetAllOrderBySkuResults{ca1, err}
 	return e.mock
 }
 
@@ -1330,7 +2204,7 @@ func (mmGetAllOrderBySku *mCartRepositoryMockGetAllOrderBySku) invocationsDone()
 }
 
 // GetAllOrderBySku implements CartRepository
-func (mmGetAllOrderBySku *CartRepositoryMock) GetAllOrderBySku(ctx context.Context, userId int64) (ca1 []model.CartItemModel) {
+func (mmGetAllOrderBySku *CartRepositoryMock) GetAllOrderBySku(ctx context.Context, userId int64) (ca1 []model.CartItemModel, err error) {
 	mm_atomic.AddUint64(&mmGetAllOrderBySku.beforeGetAllOrderBySkuCounter, 1)
 	defer mm_atomic.AddUint64(&mmGetAllOrderBySku.afterGetAllOrderBySkuCounter, 1)
 
@@ -1350,7 +2224,7 @@ func (mmGetAllOrderBySku *CartRepositoryMock) GetAllOrderBySku(ctx context.Conte
 	for _, e := range mmGetAllOrderBySku.GetAllOrderBySkuMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.ca1
+			return e.results.ca1, e.results.err
 		}
 	}
 
@@ -1382,7 +2256,7 @@ func (mmGetAllOrderBySku *CartRepositoryMock) GetAllOrderBySku(ctx context.Conte
 		if mm_results == nil {
 			mmGetAllOrderBySku.t.Fatal("No results are set for the CartRepositoryMock.GetAllOrderBySku")
 		}
-		return (*mm_results).ca1
+		return (*mm_results).ca1, (*mm_results).err
 	}
 	if mmGetAllOrderBySku.funcGetAllOrderBySku != nil {
 		return mmGetAllOrderBySku.funcGetAllOrderBySku(ctx, userId)
@@ -1459,17 +2333,2212 @@ func (m *CartRepositoryMock) MinimockGetAllOrderBySkuInspect() {
 	}
 }
 
-// MinimockFinish checks that all mocked methods have been called the expected number of times
-func (m *CartRepositoryMock) MinimockFinish() {
-	m.finishOnce.Do(func() {
-		if !m.minimockDone() {
-			m.MinimockCreateItemInspect()
+type mCartRepositoryMockGetCheckout struct {
+	optional           bool
+	mock               *CartRepositoryMock
+	defaultExpectation *CartRepositoryMockGetCheckoutExpectation
+	expectations       []*CartRepositoryMockGetCheckoutExpectation
 
-			m.MinimockDeleteAllInspect()
+	callArgs []*CartRepositoryMockGetCheckoutParams
+	mutex    sync.RWMutex
 
-			m.MinimockDeleteBySkuInspect()
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
 
-			m.MinimockGetAllOrderBySkuInspect()
+// CartRepositoryMockGetCheckoutExpectation specifies expectation struct of the CartRepository.GetCheckout
+type CartRepositoryMockGetCheckoutExpectation struct {
+	mock               *CartRepositoryMock
+	params             *CartRepositoryMockGetCheckoutParams
+	paramPtrs          *CartRepositoryMockGetCheckoutParamPtrs
+	expectationOrigins CartRepositoryMockGetCheckoutExpectationOrigins
+	results            *CartRepositoryMockGetCheckoutResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// CartRepositoryMockGetCheckoutParams contains parameters of the CartRepository.GetCheckout
+type CartRepositoryMockGetCheckoutParams struct {
+	ctx            context.Context
+	userId         int64
+	idempotencyKey string
+}
+
+// CartRepositoryMockGetCheckoutParamPtrs contains pointers to parameters of the CartRepository.GetCheckout
+type CartRepositoryMockGetCheckoutParamPtrs struct {
+	ctx            *context.Context
+	userId         *int64
+	idempotencyKey *string
+}
+
+// CartRepositoryMockGetCheckoutResults contains results of the CartRepository.GetCheckout
+type CartRepositoryMockGetCheckoutResults struct {
+	c2  model.CheckoutModel
+	b1  bool
+	err error
+}
+
+// CartRepositoryMockGetCheckoutOrigins contains origins of expectations of the CartRepository.GetCheckout
+type CartRepositoryMockGetCheckoutExpectationOrigins struct {
+	origin               string
+	originCtx            string
+	originUserId         string
+	originIdempotencyKey string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) Optional() *mCartRepositoryMockGetCheckout {
+	mmGetCheckout.optional = true
+	return mmGetCheckout
+}
+
+// Expect sets up expected params for CartRepository.GetCheckout
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) Expect(ctx context.Context, userId int64, idempotencyKey string) *mCartRepositoryMockGetCheckout {
+	if mmGetCheckout.mock.funcGetCheckout != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by Set")
+	}
+
+	if mmGetCheckout.defaultExpectation == nil {
+		mmGetCheckout.defaultExpectation = &CartRepositoryMockGetCheckoutExpectation{}
+	}
+
+	if mmGetCheckout.defaultExpectation.paramPtrs != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by ExpectParams functions")
+	}
+
+	mmGetCheckout.defaultExpectation.params = &CartRepositoryMockGetCheckoutParams{ctx, userId, idempotencyKey}
+	mmGetCheckout.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetCheckout.expectations {
+		if minimock.Equal(e.params, mmGetCheckout.defaultExpectation.params) {
+			mmGetCheckout.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetCheckout.defaultExpectation.params)
+		}
+	}
+
+	return mmGetCheckout
+}
+
+// ExpectCtxParam1 sets up expected param ctx for CartRepository.GetCheckout
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) ExpectCtxParam1(ctx context.Context) *mCartRepositoryMockGetCheckout {
+	if mmGetCheckout.mock.funcGetCheckout != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by Set")
+	}
+
+	if mmGetCheckout.defaultExpectation == nil {
+		mmGetCheckout.defaultExpectation = &CartRepositoryMockGetCheckoutExpectation{}
+	}
+
+	if mmGetCheckout.defaultExpectation.params != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by Expect")
+	}
+
+	if mmGetCheckout.defaultExpectation.paramPtrs == nil {
+		mmGetCheckout.defaultExpectation.paramPtrs = &CartRepositoryMockGetCheckoutParamPtrs{}
+	}
+	mmGetCheckout.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetCheckout.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetCheckout
+}
+
+// ExpectUserIdParam2 sets up expected param userId for CartRepository.GetCheckout
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) ExpectUserIdParam2(userId int64) *mCartRepositoryMockGetCheckout {
+	if mmGetCheckout.mock.funcGetCheckout != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by Set")
+	}
+
+	if mmGetCheckout.defaultExpectation == nil {
+		mmGetCheckout.defaultExpectation = &CartRepositoryMockGetCheckoutExpectation{}
+	}
+
+	if mmGetCheckout.defaultExpectation.params != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by Expect")
+	}
+
+	if mmGetCheckout.defaultExpectation.paramPtrs == nil {
+		mmGetCheckout.defaultExpectation.paramPtrs = &CartRepositoryMockGetCheckoutParamPtrs{}
+	}
+	mmGetCheckout.defaultExpectation.paramPtrs.userId = &userId
+	mmGetCheckout.defaultExpectation.expectationOrigins.originUserId = minimock.CallerInfo(1)
+
+	return mmGetCheckout
+}
+
+// ExpectIdempotencyKeyParam3 sets up expected param idempotencyKey for CartRepository.GetCheckout
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) ExpectIdempotencyKeyParam3(idempotencyKey string) *mCartRepositoryMockGetCheckout {
+	if mmGetCheckout.mock.funcGetCheckout != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by Set")
+	}
+
+	if mmGetCheckout.defaultExpectation == nil {
+		mmGetCheckout.defaultExpectation = &CartRepositoryMockGetCheckoutExpectation{}
+	}
+
+	if mmGetCheckout.defaultExpectation.params != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by Expect")
+	}
+
+	if mmGetCheckout.defaultExpectation.paramPtrs == nil {
+		mmGetCheckout.defaultExpectation.paramPtrs = &CartRepositoryMockGetCheckoutParamPtrs{}
+	}
+	mmGetCheckout.defaultExpectation.paramPtrs.idempotencyKey = &idempotencyKey
+	mmGetCheckout.defaultExpectation.expectationOrigins.originIdempotencyKey = minimock.CallerInfo(1)
+
+	return mmGetCheckout
+}
+
+// Inspect accepts an inspector function that has same arguments as the CartRepository.GetCheckout
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) Inspect(f func(ctx context.Context, userId int64, idempotencyKey string)) *mCartRepositoryMockGetCheckout {
+	if mmGetCheckout.mock.inspectFuncGetCheckout != nil {
+		mmGetCheckout.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.GetCheckout")
+	}
+
+	mmGetCheckout.mock.inspectFuncGetCheckout = f
+
+	return mmGetCheckout
+}
+
+// Return sets up results that will be returned by CartRepository.GetCheckout
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) Return(c2 model.CheckoutModel, b1 bool, err error) *CartRepositoryMock {
+	if mmGetCheckout.mock.funcGetCheckout != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by Set")
+	}
+
+	if mmGetCheckout.defaultExpectation == nil {
+		mmGetCheckout.defaultExpectation = &CartRepositoryMockGetCheckoutExpectation{mock: mmGetCheckout.mock}
+	}
+	mmGetCheckout.defaultExpectation.results = &CartRepositoryMockGetCheckoutResults{c2, b1, err}
+	mmGetCheckout.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetCheckout.mock
+}
+
+// Set uses given function f to mock the CartRepository.GetCheckout method
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) Set(f func(ctx context.Context, userId int64, idempotencyKey string) (c2 model.CheckoutModel, b1 bool, err error)) *CartRepositoryMock {
+	if mmGetCheckout.defaultExpectation != nil {
+		mmGetCheckout.mock.t.Fatalf("Default expectation is already set for the CartRepository.GetCheckout method")
+	}
+
+	if len(mmGetCheckout.expectations) > 0 {
+		mmGetCheckout.mock.t.Fatalf("Some expectations are already set for the CartRepository.GetCheckout method")
+	}
+
+	mmGetCheckout.mock.funcGetCheckout = f
+	mmGetCheckout.mock.funcGetCheckoutOrigin = minimock.CallerInfo(1)
+	return mmGetCheckout.mock
+}
+
+// When sets expectation for the CartRepository.GetCheckout which will trigger the result defined by the following
+// Then helper
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) When(ctx context.Context, userId int64, idempotencyKey string) *CartRepositoryMockGetCheckoutExpectation {
+	if mmGetCheckout.mock.funcGetCheckout != nil {
+		mmGetCheckout.mock.t.Fatalf("CartRepositoryMock.GetCheckout mock is already set by Set")
+	}
+
+	expectation := &CartRepositoryMockGetCheckoutExpectation{
+		mock:               mmGetCheckout.mock,
+		params:             &CartRepositoryMockGetCheckoutParams{ctx, userId, idempotencyKey},
+		expectationOrigins: CartRepositoryMockGetCheckoutExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetCheckout.expectations = append(mmGetCheckout.expectations, expectation)
+	return expectation
+}
+
+// Then sets up CartRepository.GetCheckout return parameters for the expectation previously defined by the When method
+func (e *CartRepositoryMockGetCheckoutExpectation) Then(c2 model.CheckoutModel, b1 bool, err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockGetCheckoutResults{c2, b1, err}
+	return e.mock
+}
+
+// Times sets number of times CartRepository.GetCheckout should be invoked
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) Times(n uint64) *mCartRepositoryMockGetCheckout {
+	if n == 0 {
+		mmGetCheckout.mock.t.Fatalf("Times of CartRepositoryMock.GetCheckout mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetCheckout.expectedInvocations, n)
+	mmGetCheckout.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetCheckout
+}
+
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) invocationsDone() bool {
+	if len(mmGetCheckout.expectations) == 0 && mmGetCheckout.defaultExpectation == nil && mmGetCheckout.mock.funcGetCheckout == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetCheckout.mock.afterGetCheckoutCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetCheckout.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetCheckout implements CartRepository
+func (mmGetCheckout *CartRepositoryMock) GetCheckout(ctx context.Context, userId int64, idempotencyKey string) (c2 model.CheckoutModel, b1 bool, err error) {
+	mm_atomic.AddUint64(&mmGetCheckout.beforeGetCheckoutCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetCheckout.afterGetCheckoutCounter, 1)
+
+	mmGetCheckout.t.Helper()
+
+	if mmGetCheckout.inspectFuncGetCheckout != nil {
+		mmGetCheckout.inspectFuncGetCheckout(ctx, userId, idempotencyKey)
+	}
+
+	mm_params := CartRepositoryMockGetCheckoutParams{ctx, userId, idempotencyKey}
+
+	// Record call args
+	mmGetCheckout.GetCheckoutMock.mutex.Lock()
+	mmGetCheckout.GetCheckoutMock.callArgs = append(mmGetCheckout.GetCheckoutMock.callArgs, &mm_params)
+	mmGetCheckout.GetCheckoutMock.mutex.Unlock()
+
+	for _, e := range mmGetCheckout.GetCheckoutMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.c2, e.results.b1, e.results.err
+		}
+	}
+
+	if mmGetCheckout.GetCheckoutMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetCheckout.GetCheckoutMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetCheckout.GetCheckoutMock.defaultExpectation.params
+		mm_want_ptrs := mmGetCheckout.GetCheckoutMock.defaultExpectation.paramPtrs
+
+		mm_got := CartRepositoryMockGetCheckoutParams{ctx, userId, idempotencyKey}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetCheckout.t.Errorf("CartRepositoryMock.GetCheckout got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetCheckout.GetCheckoutMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.userId != nil && !minimock.Equal(*mm_want_ptrs.userId, mm_got.userId) {
+				mmGetCheckout.t.Errorf("CartRepositoryMock.GetCheckout got unexpected parameter userId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetCheckout.GetCheckoutMock.defaultExpectation.expectationOrigins.originUserId, *mm_want_ptrs.userId, mm_got.userId, minimock.Diff(*mm_want_ptrs.userId, mm_got.userId))
+			}
+
+			if mm_want_ptrs.idempotencyKey != nil && !minimock.Equal(*mm_want_ptrs.idempotencyKey, mm_got.idempotencyKey) {
+				mmGetCheckout.t.Errorf("CartRepositoryMock.GetCheckout got unexpected parameter idempotencyKey, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetCheckout.GetCheckoutMock.defaultExpectation.expectationOrigins.originIdempotencyKey, *mm_want_ptrs.idempotencyKey, mm_got.idempotencyKey, minimock.Diff(*mm_want_ptrs.idempotencyKey, mm_got.idempotencyKey))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetCheckout.t.Errorf("CartRepositoryMock.GetCheckout got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetCheckout.GetCheckoutMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetCheckout.GetCheckoutMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetCheckout.t.Fatal("No results are set for the CartRepositoryMock.GetCheckout")
+		}
+		return (*mm_results).c2, (*mm_results).b1, (*mm_results).err
+	}
+	if mmGetCheckout.funcGetCheckout != nil {
+		return mmGetCheckout.funcGetCheckout(ctx, userId, idempotencyKey)
+	}
+	mmGetCheckout.t.Fatalf("Unexpected call to CartRepositoryMock.GetCheckout. %v %v %v", ctx, userId, idempotencyKey)
+	return
+}
+
+// GetCheckoutAfterCounter returns a count of finished CartRepositoryMock.GetCheckout invocations
+func (mmGetCheckout *CartRepositoryMock) GetCheckoutAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetCheckout.afterGetCheckoutCounter)
+}
+
+// GetCheckoutBeforeCounter returns a count of CartRepositoryMock.GetCheckout invocations
+func (mmGetCheckout *CartRepositoryMock) GetCheckoutBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetCheckout.beforeGetCheckoutCounter)
+}
+
+// Calls returns a list of arguments used in each call to CartRepositoryMock.GetCheckout.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetCheckout *mCartRepositoryMockGetCheckout) Calls() []*CartRepositoryMockGetCheckoutParams {
+	mmGetCheckout.mutex.RLock()
+
+	argCopy := make([]*CartRepositoryMockGetCheckoutParams, len(mmGetCheckout.callArgs))
+	copy(argCopy, mmGetCheckout.callArgs)
+
+	mmGetCheckout.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetCheckoutDone returns true if the count of the GetCheckout invocations corresponds
+// the number of defined expectations
+func (m *CartRepositoryMock) MinimockGetCheckoutDone() bool {
+	if m.GetCheckoutMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetCheckoutMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetCheckoutMock.invocationsDone()
+}
+
+// MinimockGetCheckoutInspect logs each unmet expectation
+func (m *CartRepositoryMock) MinimockGetCheckoutInspect() {
+	for _, e := range m.GetCheckoutMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to CartRepositoryMock.GetCheckout at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetCheckoutCounter := mm_atomic.LoadUint64(&m.afterGetCheckoutCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetCheckoutMock.defaultExpectation != nil && afterGetCheckoutCounter < 1 {
+		if m.GetCheckoutMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to CartRepositoryMock.GetCheckout at\n%s", m.GetCheckoutMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to CartRepositoryMock.GetCheckout at\n%s with params: %#v", m.GetCheckoutMock.defaultExpectation.expectationOrigins.origin, *m.GetCheckoutMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetCheckout != nil && afterGetCheckoutCounter < 1 {
+		m.t.Errorf("Expected call to CartRepositoryMock.GetCheckout at\n%s", m.funcGetCheckoutOrigin)
+	}
+
+	if !m.GetCheckoutMock.invocationsDone() && afterGetCheckoutCounter > 0 {
+		m.t.Errorf("Expected %d calls to CartRepositoryMock.GetCheckout at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetCheckoutMock.expectedInvocations), m.GetCheckoutMock.expectedInvocationsOrigin, afterGetCheckoutCounter)
+	}
+}
+
+type mCartRepositoryMockGetSnapshot struct {
+	optional           bool
+	mock               *CartRepositoryMock
+	defaultExpectation *CartRepositoryMockGetSnapshotExpectation
+	expectations       []*CartRepositoryMockGetSnapshotExpectation
+
+	callArgs []*CartRepositoryMockGetSnapshotParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// CartRepositoryMockGetSnapshotExpectation specifies expectation struct of the CartRepository.GetSnapshot
+type CartRepositoryMockGetSnapshotExpectation struct {
+	mock               *CartRepositoryMock
+	params             *CartRepositoryMockGetSnapshotParams
+	paramPtrs          *CartRepositoryMockGetSnapshotParamPtrs
+	expectationOrigins CartRepositoryMockGetSnapshotExpectationOrigins
+	results            *CartRepositoryMockGetSnapshotResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// CartRepositoryMockGetSnapshotParams contains parameters of the CartRepository.GetSnapshot
+type CartRepositoryMockGetSnapshotParams struct {
+	ctx    context.Context
+	userId int64
+}
+
+// CartRepositoryMockGetSnapshotParamPtrs contains pointers to parameters of the CartRepository.GetSnapshot
+type CartRepositoryMockGetSnapshotParamPtrs struct {
+	ctx    *context.Context
+	userId *int64
+}
+
+// CartRepositoryMockGetSnapshotResults contains results of the CartRepository.GetSnapshot
+type CartRepositoryMockGetSnapshotResults struct {
+	c2  model.CartSnapshotModel
+	err error
+}
+
+// CartRepositoryMockGetSnapshotOrigins contains origins of expectations of the CartRepository.GetSnapshot
+type CartRepositoryMockGetSnapshotExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originUserId string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) Optional() *mCartRepositoryMockGetSnapshot {
+	mmGetSnapshot.optional = true
+	return mmGetSnapshot
+}
+
+// Expect sets up expected params for CartRepository.GetSnapshot
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) Expect(ctx context.Context, userId int64) *mCartRepositoryMockGetSnapshot {
+	if mmGetSnapshot.mock.funcGetSnapshot != nil {
+		mmGetSnapshot.mock.t.Fatalf("CartRepositoryMock.GetSnapshot mock is already set by Set")
+	}
+
+	if mmGetSnapshot.defaultExpectation == nil {
+		mmGetSnapshot.defaultExpectation = &CartRepositoryMockGetSnapshotExpectation{}
+	}
+
+	if mmGetSnapshot.defaultExpectation.paramPtrs != nil {
+		mmGetSnapshot.mock.t.Fatalf("CartRepositoryMock.GetSnapshot mock is already set by ExpectParams functions")
+	}
+
+	mmGetSnapshot.defaultExpectation.params = &CartRepositoryMockGetSnapshotParams{ctx, userId}
+	mmGetSnapshot.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetSnapshot.expectations {
+		if minimock.Equal(e.params, mmGetSnapshot.defaultExpectation.params) {
+			mmGetSnapshot.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetSnapshot.defaultExpectation.params)
+		}
+	}
+
+	return mmGetSnapshot
+}
+
+// ExpectCtxParam1 sets up expected param ctx for CartRepository.GetSnapshot
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) ExpectCtxParam1(ctx context.Context) *mCartRepositoryMockGetSnapshot {
+	if mmGetSnapshot.mock.funcGetSnapshot != nil {
+		mmGetSnapshot.mock.t.Fatalf("CartRepositoryMock.GetSnapshot mock is already set by Set")
+	}
+
+	if mmGetSnapshot.defaultExpectation == nil {
+		mmGetSnapshot.defaultExpectation = &CartRepositoryMockGetSnapshotExpectation{}
+	}
+
+	if mmGetSnapshot.defaultExpectation.params != nil {
+		mmGetSnapshot.mock.t.Fatalf("CartRepositoryMock.GetSnapshot mock is already set by Expect")
+	}
+
+	if mmGetSnapshot.defaultExpectation.paramPtrs == nil {
+		mmGetSnapshot.defaultExpectation.paramPtrs = &CartRepositoryMockGetSnapshotParamPtrs{}
+	}
+	mmGetSnapshot.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetSnapshot.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetSnapshot
+}
+
+// ExpectUserIdParam2 sets up expected param userId for CartRepository.GetSnapshot
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) ExpectUserIdParam2(userId int64) *mCartRepositoryMockGetSnapshot {
+	if mmGetSnapshot.mock.funcGetSnapshot != nil {
+		mmGetSnapshot.mock.t.Fatalf("CartRepositoryMock.GetSnapshot mock is already set by Set")
+	}
+
+	if mmGetSnapshot.defaultExpectation == nil {
+		mmGetSnapshot.defaultExpectation = &CartRepositoryMockGetSnapshotExpectation{}
+	}
+
+	if mmGetSnapshot.defaultExpectation.params != nil {
+		mmGetSnapshot.mock.t.Fatalf("CartRepositoryMock.GetSnapshot mock is already set by Expect")
+	}
+
+	if mmGetSnapshot.defaultExpectation.paramPtrs == nil {
+		mmGetSnapshot.defaultExpectation.paramPtrs = &CartRepositoryMockGetSnapshotParamPtrs{}
+	}
+	mmGetSnapshot.defaultExpectation.paramPtrs.userId = &userId
+	mmGetSnapshot.defaultExpectation.expectationOrigins.originUserId = minimock.CallerInfo(1)
+
+	return mmGetSnapshot
+}
+
+// Inspect accepts an inspector function that has same arguments as the CartRepository.GetSnapshot
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) Inspect(f func(ctx context.Context, userId int64)) *mCartRepositoryMockGetSnapshot {
+	if mmGetSnapshot.mock.inspectFuncGetSnapshot != nil {
+		mmGetSnapshot.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.GetSnapshot")
+	}
+
+	mmGetSnapshot.mock.inspectFuncGetSnapshot = f
+
+	return mmGetSnapshot
+}
+
+// Return sets up results that will be returned by CartRepository.GetSnapshot
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) Return(c2 model.CartSnapshotModel, err error) *CartRepositoryMock {
+	if mmGetSnapshot.mock.funcGetSnapshot != nil {
+		mmGetSnapshot.mock.t.Fatalf("CartRepositoryMock.GetSnapshot mock is already set by Set")
+	}
+
+	if mmGetSnapshot.defaultExpectation == nil {
+		mmGetSnapshot.defaultExpectation = &CartRepositoryMockGetSnapshotExpectation{mock: mmGetSnapshot.mock}
+	}
+	mmGetSnapshot.defaultExpectation.results = &CartRepositoryMockGetSnapshotResults{c2, err}
+	mmGetSnapshot.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetSnapshot.mock
+}
+
+// Set uses given function f to mock the CartRepository.GetSnapshot method
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) Set(f func(ctx context.Context, userId int64) (c2 model.CartSnapshotModel, err error)) *CartRepositoryMock {
+	if mmGetSnapshot.defaultExpectation != nil {
+		mmGetSnapshot.mock.t.Fatalf("Default expectation is already set for the CartRepository.GetSnapshot method")
+	}
+
+	if len(mmGetSnapshot.expectations) > 0 {
+		mmGetSnapshot.mock.t.Fatalf("Some expectations are already set for the CartRepository.GetSnapshot method")
+	}
+
+	mmGetSnapshot.mock.funcGetSnapshot = f
+	mmGetSnapshot.mock.funcGetSnapshotOrigin = minimock.CallerInfo(1)
+	return mmGetSnapshot.mock
+}
+
+// When sets expectation for the CartRepository.GetSnapshot which will trigger the result defined by the following
+// Then helper
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) When(ctx context.Context, userId int64) *CartRepositoryMockGetSnapshotExpectation {
+	if mmGetSnapshot.mock.funcGetSnapshot != nil {
+		mmGetSnapshot.mock.t.Fatalf("CartRepositoryMock.GetSnapshot mock is already set by Set")
+	}
+
+	expectation := &CartRepositoryMockGetSnapshotExpectation{
+		mock:               mmGetSnapshot.mock,
+		params:             &CartRepositoryMockGetSnapshotParams{ctx, userId},
+		expectationOrigins: CartRepositoryMockGetSnapshotExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetSnapshot.expectations = append(mmGetSnapshot.expectations, expectation)
+	return expectation
+}
+
+// Then sets up CartRepository.GetSnapshot return parameters for the expectation previously defined by the When method
+func (e *CartRepositoryMockGetSnapshotExpectation) Then(c2 model.CartSnapshotModel, err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockGetSnapshotResults{c2, err}
+	return e.mock
+}
+
+// Times sets number of times CartRepository.GetSnapshot should be invoked
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) Times(n uint64) *mCartRepositoryMockGetSnapshot {
+	if n == 0 {
+		mmGetSnapshot.mock.t.Fatalf("Times of CartRepositoryMock.GetSnapshot mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetSnapshot.expectedInvocations, n)
+	mmGetSnapshot.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetSnapshot
+}
+
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) invocationsDone() bool {
+	if len(mmGetSnapshot.expectations) == 0 && mmGetSnapshot.defaultExpectation == nil && mmGetSnapshot.mock.funcGetSnapshot == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetSnapshot.mock.afterGetSnapshotCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetSnapshot.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetSnapshot implements CartRepository
+func (mmGetSnapshot *CartRepositoryMock) GetSnapshot(ctx context.Context, userId int64) (c2 model.CartSnapshotModel, err error) {
+	mm_atomic.AddUint64(&mmGetSnapshot.beforeGetSnapshotCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetSnapshot.afterGetSnapshotCounter, 1)
+
+	mmGetSnapshot.t.Helper()
+
+	if mmGetSnapshot.inspectFuncGetSnapshot != nil {
+		mmGetSnapshot.inspectFuncGetSnapshot(ctx, userId)
+	}
+
+	mm_params := CartRepositoryMockGetSnapshotParams{ctx, userId}
+
+	// Record call args
+	mmGetSnapshot.GetSnapshotMock.mutex.Lock()
+	mmGetSnapshot.GetSnapshotMock.callArgs = append(mmGetSnapshot.GetSnapshotMock.callArgs, &mm_params)
+	mmGetSnapshot.GetSnapshotMock.mutex.Unlock()
+
+	for _, e := range mmGetSnapshot.GetSnapshotMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.c2, e.results.err
+		}
+	}
+
+	if mmGetSnapshot.GetSnapshotMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetSnapshot.GetSnapshotMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetSnapshot.GetSnapshotMock.defaultExpectation.params
+		mm_want_ptrs := mmGetSnapshot.GetSnapshotMock.defaultExpectation.paramPtrs
+
+		mm_got := CartRepositoryMockGetSnapshotParams{ctx, userId}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetSnapshot.t.Errorf("CartRepositoryMock.GetSnapshot got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetSnapshot.GetSnapshotMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.userId != nil && !minimock.Equal(*mm_want_ptrs.userId, mm_got.userId) {
+				mmGetSnapshot.t.Errorf("CartRepositoryMock.GetSnapshot got unexpected parameter userId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetSnapshot.GetSnapshotMock.defaultExpectation.expectationOrigins.originUserId, *mm_want_ptrs.userId, mm_got.userId, minimock.Diff(*mm_want_ptrs.userId, mm_got.userId))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetSnapshot.t.Errorf("CartRepositoryMock.GetSnapshot got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetSnapshot.GetSnapshotMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetSnapshot.GetSnapshotMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetSnapshot.t.Fatal("No results are set for the CartRepositoryMock.GetSnapshot")
+		}
+		return (*mm_results).c2, (*mm_results).err
+	}
+	if mmGetSnapshot.funcGetSnapshot != nil {
+		return mmGetSnapshot.funcGetSnapshot(ctx, userId)
+	}
+	mmGetSnapshot.t.Fatalf("Unexpected call to CartRepositoryMock.GetSnapshot. %v %v", ctx, userId)
+	return
+}
+
+// GetSnapshotAfterCounter returns a count of finished CartRepositoryMock.GetSnapshot invocations
+func (mmGetSnapshot *CartRepositoryMock) GetSnapshotAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetSnapshot.afterGetSnapshotCounter)
+}
+
+// GetSnapshotBeforeCounter returns a count of CartRepositoryMock.GetSnapshot invocations
+func (mmGetSnapshot *CartRepositoryMock) GetSnapshotBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetSnapshot.beforeGetSnapshotCounter)
+}
+
+// Calls returns a list of arguments used in each call to CartRepositoryMock.GetSnapshot.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetSnapshot *mCartRepositoryMockGetSnapshot) Calls() []*CartRepositoryMockGetSnapshotParams {
+	mmGetSnapshot.mutex.RLock()
+
+	argCopy := make([]*CartRepositoryMockGetSnapshotParams, len(mmGetSnapshot.callArgs))
+	copy(argCopy, mmGetSnapshot.callArgs)
+
+	mmGetSnapshot.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetSnapshotDone returns true if the count of the GetSnapshot invocations corresponds
+// the number of defined expectations
+func (m *CartRepositoryMock) MinimockGetSnapshotDone() bool {
+	if m.GetSnapshotMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetSnapshotMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetSnapshotMock.invocationsDone()
+}
+
+// MinimockGetSnapshotInspect logs each unmet expectation
+func (m *CartRepositoryMock) MinimockGetSnapshotInspect() {
+	for _, e := range m.GetSnapshotMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to CartRepositoryMock.GetSnapshot at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetSnapshotCounter := mm_atomic.LoadUint64(&m.afterGetSnapshotCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetSnapshotMock.defaultExpectation != nil && afterGetSnapshotCounter < 1 {
+		if m.GetSnapshotMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to CartRepositoryMock.GetSnapshot at\n%s", m.GetSnapshotMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to CartRepositoryMock.GetSnapshot at\n%s with params: %#v", m.GetSnapshotMock.defaultExpectation.expectationOrigins.origin, *m.GetSnapshotMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetSnapshot != nil && afterGetSnapshotCounter < 1 {
+		m.t.Errorf("Expected call to CartRepositoryMock.GetSnapshot at\n%s", m.funcGetSnapshotOrigin)
+	}
+
+	if !m.GetSnapshotMock.invocationsDone() && afterGetSnapshotCounter > 0 {
+		m.t.Errorf("Expected %d calls to CartRepositoryMock.GetSnapshot at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetSnapshotMock.expectedInvocations), m.GetSnapshotMock.expectedInvocationsOrigin, afterGetSnapshotCounter)
+	}
+}
+
+type mCartRepositoryMockLock struct {
+	optional           bool
+	mock               *CartRepositoryMock
+	defaultExpectation *CartRepositoryMockLockExpectation
+	expectations       []*CartRepositoryMockLockExpectation
+
+	callArgs []*CartRepositoryMockLockParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// CartRepositoryMockLockExpectation specifies expectation struct of the CartRepository.Lock
+type CartRepositoryMockLockExpectation struct {
+	mock               *CartRepositoryMock
+	params             *CartRepositoryMockLockParams
+	paramPtrs          *CartRepositoryMockLockParamPtrs
+	expectationOrigins CartRepositoryMockLockExpectationOrigins
+	results            *CartRepositoryMockLockResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// CartRepositoryMockLockParams contains parameters of the CartRepository.Lock
+type CartRepositoryMockLockParams struct {
+	ctx      context.Context
+	userId   int64
+	version  int64
+	ttl      time.Duration
+	checkout *model.CheckoutModel
+}
+
+// CartRepositoryMockLockParamPtrs contains pointers to parameters of the CartRepository.Lock
+type CartRepositoryMockLockParamPtrs struct {
+	ctx      *context.Context
+	userId   *int64
+	version  *int64
+	ttl      *time.Duration
+	checkout **model.CheckoutModel
+}
+
+// CartRepositoryMockLockResults contains results of the CartRepository.Lock
+type CartRepositoryMockLockResults struct {
+	err error
+}
+
+// CartRepositoryMockLockOrigins contains origins of expectations of the CartRepository.Lock
+type CartRepositoryMockLockExpectationOrigins struct {
+	origin         string
+	originCtx      string
+	originUserId   string
+	originVersion  string
+	originTtl      string
+	originCheckout string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmLock *mCartRepositoryMockLock) Optional() *mCartRepositoryMockLock {
+	mmLock.optional = true
+	return mmLock
+}
+
+// Expect sets up expected params for CartRepository.Lock
+func (mmLock *mCartRepositoryMockLock) Expect(ctx context.Context, userId int64, version int64, ttl time.Duration, checkout *model.CheckoutModel) *mCartRepositoryMockLock {
+	if mmLock.mock.funcLock != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Set")
+	}
+
+	if mmLock.defaultExpectation == nil {
+		mmLock.defaultExpectation = &CartRepositoryMockLockExpectation{}
+	}
+
+	if mmLock.defaultExpectation.paramPtrs != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by ExpectParams functions")
+	}
+
+	mmLock.defaultExpectation.params = &CartRepositoryMockLockParams{ctx, userId, version, ttl, checkout}
+	mmLock.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmLock.expectations {
+		if minimock.Equal(e.params, mmLock.defaultExpectation.params) {
+			mmLock.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmLock.defaultExpectation.params)
+		}
+	}
+
+	return mmLock
+}
+
+// ExpectCtxParam1 sets up expected param ctx for CartRepository.Lock
+func (mmLock *mCartRepositoryMockLock) ExpectCtxParam1(ctx context.Context) *mCartRepositoryMockLock {
+	if mmLock.mock.funcLock != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Set")
+	}
+
+	if mmLock.defaultExpectation == nil {
+		mmLock.defaultExpectation = &CartRepositoryMockLockExpectation{}
+	}
+
+	if mmLock.defaultExpectation.params != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Expect")
+	}
+
+	if mmLock.defaultExpectation.paramPtrs == nil {
+		mmLock.defaultExpectation.paramPtrs = &CartRepositoryMockLockParamPtrs{}
+	}
+	mmLock.defaultExpectation.paramPtrs.ctx = &ctx
+	mmLock.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmLock
+}
+
+// ExpectUserIdParam2 sets up expected param userId for CartRepository.Lock
+func (mmLock *mCartRepositoryMockLock) ExpectUserIdParam2(userId int64) *mCartRepositoryMockLock {
+	if mmLock.mock.funcLock != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Set")
+	}
+
+	if mmLock.defaultExpectation == nil {
+		mmLock.defaultExpectation = &CartRepositoryMockLockExpectation{}
+	}
+
+	if mmLock.defaultExpectation.params != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Expect")
+	}
+
+	if mmLock.defaultExpectation.paramPtrs == nil {
+		mmLock.defaultExpectation.paramPtrs = &CartRepositoryMockLockParamPtrs{}
+	}
+	mmLock.defaultExpectation.paramPtrs.userId = &userId
+	mmLock.defaultExpectation.expectationOrigins.originUserId = minimock.CallerInfo(1)
+
+	return mmLock
+}
+
+// ExpectVersionParam3 sets up expected param version for CartRepository.Lock
+func (mmLock *mCartRepositoryMockLock) ExpectVersionParam3(version int64) *mCartRepositoryMockLock {
+	if mmLock.mock.funcLock != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Set")
+	}
+
+	if mmLock.defaultExpectation == nil {
+		mmLock.defaultExpectation = &CartRepositoryMockLockExpectation{}
+	}
+
+	if mmLock.defaultExpectation.params != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Expect")
+	}
+
+	if mmLock.defaultExpectation.paramPtrs == nil {
+		mmLock.defaultExpectation.paramPtrs = &CartRepositoryMockLockParamPtrs{}
+	}
+	mmLock.defaultExpectation.paramPtrs.version = &version
+	mmLock.defaultExpectation.expectationOrigins.originVersion = minimock.CallerInfo(1)
+
+	return mmLock
+}
+
+// ExpectTtlParam4 sets up expected param ttl for CartRepository.Lock
+func (mmLock *mCartRepositoryMockLock) ExpectTtlParam4(ttl time.Duration) *mCartRepositoryMockLock {
+	if mmLock.mock.funcLock != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Set")
+	}
+
+	if mmLock.defaultExpectation == nil {
+		mmLock.defaultExpectation = &CartRepositoryMockLockExpectation{}
+	}
+
+	if mmLock.defaultExpectation.params != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Expect")
+	}
+
+	if mmLock.defaultExpectation.paramPtrs == nil {
+		mmLock.defaultExpectation.paramPtrs = &CartRepositoryMockLockParamPtrs{}
+	}
+	mmLock.defaultExpectation.paramPtrs.ttl = &ttl
+	mmLock.defaultExpectation.expectationOrigins.originTtl = minimock.CallerInfo(1)
+
+	return mmLock
+}
+
+// ExpectCheckoutParam5 sets up expected param checkout for CartRepository.Lock
+func (mmLock *mCartRepositoryMockLock) ExpectCheckoutParam5(checkout *model.CheckoutModel) *mCartRepositoryMockLock {
+	if mmLock.mock.funcLock != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Set")
+	}
+
+	if mmLock.defaultExpectation == nil {
+		mmLock.defaultExpectation = &CartRepositoryMockLockExpectation{}
+	}
+
+	if mmLock.defaultExpectation.params != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Expect")
+	}
+
+	if mmLock.defaultExpectation.paramPtrs == nil {
+		mmLock.defaultExpectation.paramPtrs = &CartRepositoryMockLockParamPtrs{}
+	}
+	mmLock.defaultExpectation.paramPtrs.checkout = &checkout
+	mmLock.defaultExpectation.expectationOrigins.originCheckout = minimock.CallerInfo(1)
+
+	return mmLock
+}
+
+// Inspect accepts an inspector function that has same arguments as the CartRepository.Lock
+func (mmLock *mCartRepositoryMockLock) Inspect(f func(ctx context.Context, userId int64, version int64, ttl time.Duration, checkout *model.CheckoutModel)) *mCartRepositoryMockLock {
+	if mmLock.mock.inspectFuncLock != nil {
+		mmLock.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.Lock")
+	}
+
+	mmLock.mock.inspectFuncLock = f
+
+	return mmLock
+}
+
+// Return sets up results that will be returned by CartRepository.Lock
+func (mmLock *mCartRepositoryMockLock) Return(err error) *CartRepositoryMock {
+	if mmLock.mock.funcLock != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Set")
+	}
+
+	if mmLock.defaultExpectation == nil {
+		mmLock.defaultExpectation = &CartRepositoryMockLockExpectation{mock: mmLock.mock}
+	}
+	mmLock.defaultExpectation.results = &CartRepositoryMockLockResults{err}
+	mmLock.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmLock.mock
+}
+
+// Set uses given function f to mock the CartRepository.Lock method
+func (mmLock *mCartRepositoryMockLock) Set(f func(ctx context.Context, userId int64, version int64, ttl time.Duration, checkout *model.CheckoutModel) (err error)) *CartRepositoryMock {
+	if mmLock.defaultExpectation != nil {
+		mmLock.mock.t.Fatalf("Default expectation is already set for the CartRepository.Lock method")
+	}
+
+	if len(mmLock.expectations) > 0 {
+		mmLock.mock.t.Fatalf("Some expectations are already set for the CartRepository.Lock method")
+	}
+
+	mmLock.mock.funcLock = f
+	mmLock.mock.funcLockOrigin = minimock.CallerInfo(1)
+	return mmLock.mock
+}
+
+// When sets expectation for the CartRepository.Lock which will trigger the result defined by the following
+// Then helper
+func (mmLock *mCartRepositoryMockLock) When(ctx context.Context, userId int64, version int64, ttl time.Duration, checkout *model.CheckoutModel) *CartRepositoryMockLockExpectation {
+	if mmLock.mock.funcLock != nil {
+		mmLock.mock.t.Fatalf("CartRepositoryMock.Lock mock is already set by Set")
+	}
+
+	expectation := &CartRepositoryMockLockExpectation{
+		mock:               mmLock.mock,
+		params:             &CartRepositoryMockLockParams{ctx, userId, version, ttl, checkout},
+		expectationOrigins: CartRepositoryMockLockExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmLock.expectations = append(mmLock.expectations, expectation)
+	return expectation
+}
+
+// Then sets up CartRepository.Lock return parameters for the expectation previously defined by the When method
+func (e *CartRepositoryMockLockExpectation) Then(err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockLockResults{err}
+	return e.mock
+}
+
+// Times sets number of times CartRepository.Lock should be invoked
+func (mmLock *mCartRepositoryMockLock) Times(n uint64) *mCartRepositoryMockLock {
+	if n == 0 {
+		mmLock.mock.t.Fatalf("Times of CartRepositoryMock.Lock mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmLock.expectedInvocations, n)
+	mmLock.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmLock
+}
+
+func (mmLock *mCartRepositoryMockLock) invocationsDone() bool {
+	if len(mmLock.expectations) == 0 && mmLock.defaultExpectation == nil && mmLock.mock.funcLock == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmLock.mock.afterLockCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmLock.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Lock implements CartRepository
+func (mmLock *CartRepositoryMock) Lock(ctx context.Context, userId int64, version int64, ttl time.Duration, checkout *model.CheckoutModel) (err error) {
+	mm_atomic.AddUint64(&mmLock.beforeLockCounter, 1)
+	defer mm_atomic.AddUint64(&mmLock.afterLockCounter, 1)
+
+	mmLock.t.Helper()
+
+	if mmLock.inspectFuncLock != nil {
+		mmLock.inspectFuncLock(ctx, userId, version, ttl, checkout)
+	}
+
+	mm_params := CartRepositoryMockLockParams{ctx, userId, version, ttl, checkout}
+
+	// Record call args
+	mmLock.LockMock.mutex.Lock()
+	mmLock.LockMock.callArgs = append(mmLock.LockMock.callArgs, &mm_params)
+	mmLock.LockMock.mutex.Unlock()
+
+	for _, e := range mmLock.LockMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmLock.LockMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmLock.LockMock.defaultExpectation.Counter, 1)
+		mm_want := mmLock.LockMock.defaultExpectation.params
+		mm_want_ptrs := mmLock.LockMock.defaultExpectation.paramPtrs
+
+		mm_got := CartRepositoryMockLockParams{ctx, userId, version, ttl, checkout}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmLock.t.Errorf("CartRepositoryMock.Lock got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmLock.LockMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.userId != nil && !minimock.Equal(*mm_want_ptrs.userId, mm_got.userId) {
+				mmLock.t.Errorf("CartRepositoryMock.Lock got unexpected parameter userId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmLock.LockMock.defaultExpectation.expectationOrigins.originUserId, *mm_want_ptrs.userId, mm_got.userId, minimock.Diff(*mm_want_ptrs.userId, mm_got.userId))
+			}
+
+			if mm_want_ptrs.version != nil && !minimock.Equal(*mm_want_ptrs.version, mm_got.version) {
+				mmLock.t.Errorf("CartRepositoryMock.Lock got unexpected parameter version, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmLock.LockMock.defaultExpectation.expectationOrigins.originVersion, *mm_want_ptrs.version, mm_got.version, minimock.Diff(*mm_want_ptrs.version, mm_got.version))
+			}
+
+			if mm_want_ptrs.ttl != nil && !minimock.Equal(*mm_want_ptrs.ttl, mm_got.ttl) {
+				mmLock.t.Errorf("CartRepositoryMock.Lock got unexpected parameter ttl, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmLock.LockMock.defaultExpectation.expectationOrigins.originTtl, *mm_want_ptrs.ttl, mm_got.ttl, minimock.Diff(*mm_want_ptrs.ttl, mm_got.ttl))
+			}
+
+			if mm_want_ptrs.checkout != nil && !minimock.Equal(*mm_want_ptrs.checkout, mm_got.checkout) {
+				mmLock.t.Errorf("CartRepositoryMock.Lock got unexpected parameter checkout, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmLock.LockMock.defaultExpectation.expectationOrigins.originCheckout, *mm_want_ptrs.checkout, mm_got.checkout, minimock.Diff(*mm_want_ptrs.checkout, mm_got.checkout))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmLock.t.Errorf("CartRepositoryMock.Lock got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmLock.LockMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmLock.LockMock.defaultExpectation.results
+		if mm_results == nil {
+			mmLock.t.Fatal("No results are set for the CartRepositoryMock.Lock")
+		}
+		return (*mm_results).err
+	}
+	if mmLock.funcLock != nil {
+		return mmLock.funcLock(ctx, userId, version, ttl, checkout)
+	}
+	mmLock.t.Fatalf("Unexpected call to CartRepositoryMock.Lock. %v %v %v %v %v", ctx, userId, version, ttl, checkout)
+	return
+}
+
+// LockAfterCounter returns a count of finished CartRepositoryMock.Lock invocations
+func (mmLock *CartRepositoryMock) LockAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmLock.afterLockCounter)
+}
+
+// LockBeforeCounter returns a count of CartRepositoryMock.Lock invocations
+func (mmLock *CartRepositoryMock) LockBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmLock.beforeLockCounter)
+}
+
+// Calls returns a list of arguments used in each call to CartRepositoryMock.Lock.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmLock *mCartRepositoryMockLock) Calls() []*CartRepositoryMockLockParams {
+	mmLock.mutex.RLock()
+
+	argCopy := make([]*CartRepositoryMockLockParams, len(mmLock.callArgs))
+	copy(argCopy, mmLock.callArgs)
+
+	mmLock.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockLockDone returns true if the count of the Lock invocations corresponds
+// the number of defined expectations
+func (m *CartRepositoryMock) MinimockLockDone() bool {
+	if m.LockMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.LockMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.LockMock.invocationsDone()
+}
+
+// MinimockLockInspect logs each unmet expectation
+func (m *CartRepositoryMock) MinimockLockInspect() {
+	for _, e := range m.LockMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to CartRepositoryMock.Lock at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterLockCounter := mm_atomic.LoadUint64(&m.afterLockCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.LockMock.defaultExpectation != nil && afterLockCounter < 1 {
+		if m.LockMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to CartRepositoryMock.Lock at\n%s", m.LockMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to CartRepositoryMock.Lock at\n%s with params: %#v", m.LockMock.defaultExpectation.expectationOrigins.origin, *m.LockMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcLock != nil && afterLockCounter < 1 {
+		m.t.Errorf("Expected call to CartRepositoryMock.Lock at\n%s", m.funcLockOrigin)
+	}
+
+	if !m.LockMock.invocationsDone() && afterLockCounter > 0 {
+		m.t.Errorf("Expected %d calls to CartRepositoryMock.Lock at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.LockMock.expectedInvocations), m.LockMock.expectedInvocationsOrigin, afterLockCounter)
+	}
+}
+
+type mCartRepositoryMockSaveCheckout struct {
+	optional           bool
+	mock               *CartRepositoryMock
+	defaultExpectation *CartRepositoryMockSaveCheckoutExpectation
+	expectations       []*CartRepositoryMockSaveCheckoutExpectation
+
+	callArgs []*CartRepositoryMockSaveCheckoutParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// CartRepositoryMockSaveCheckoutExpectation specifies expectation struct of the CartRepository.SaveCheckout
+type CartRepositoryMockSaveCheckoutExpectation struct {
+	mock               *CartRepositoryMock
+	params             *CartRepositoryMockSaveCheckoutParams
+	paramPtrs          *CartRepositoryMockSaveCheckoutParamPtrs
+	expectationOrigins CartRepositoryMockSaveCheckoutExpectationOrigins
+	results            *CartRepositoryMockSaveCheckoutResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// CartRepositoryMockSaveCheckoutParams contains parameters of the CartRepository.SaveCheckout
+type CartRepositoryMockSaveCheckoutParams struct {
+	ctx      context.Context
+	checkout model.CheckoutModel
+}
+
+// CartRepositoryMockSaveCheckoutParamPtrs contains pointers to parameters of the CartRepository.SaveCheckout
+type CartRepositoryMockSaveCheckoutParamPtrs struct {
+	ctx      *context.Context
+	checkout *model.CheckoutModel
+}
+
+// CartRepositoryMockSaveCheckoutResults contains results of the CartRepository.SaveCheckout
+type CartRepositoryMockSaveCheckoutResults struct {
+	err error
+}
+
+// CartRepositoryMockSaveCheckoutOrigins contains origins of expectations of the CartRepository.SaveCheckout
+type CartRepositoryMockSaveCheckoutExpectationOrigins struct {
+	origin         string
+	originCtx      string
+	originCheckout string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) Optional() *mCartRepositoryMockSaveCheckout {
+	mmSaveCheckout.optional = true
+	return mmSaveCheckout
+}
+
+// Expect sets up expected params for CartRepository.SaveCheckout
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) Expect(ctx context.Context, checkout model.CheckoutModel) *mCartRepositoryMockSaveCheckout {
+	if mmSaveCheckout.mock.funcSaveCheckout != nil {
+		mmSaveCheckout.mock.t.Fatalf("CartRepositoryMock.SaveCheckout mock is already set by Set")
+	}
+
+	if mmSaveCheckout.defaultExpectation == nil {
+		mmSaveCheckout.defaultExpectation = &CartRepositoryMockSaveCheckoutExpectation{}
+	}
+
+	if mmSaveCheckout.defaultExpectation.paramPtrs != nil {
+		mmSaveCheckout.mock.t.Fatalf("CartRepositoryMock.SaveCheckout mock is already set by ExpectParams functions")
+	}
+
+	mmSaveCheckout.defaultExpectation.params = &CartRepositoryMockSaveCheckoutParams{ctx, checkout}
+	mmSaveCheckout.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmSaveCheckout.expectations {
+		if minimock.Equal(e.params, mmSaveCheckout.defaultExpectation.params) {
+			mmSaveCheckout.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSaveCheckout.defaultExpectation.params)
+		}
+	}
+
+	return mmSaveCheckout
+}
+
+// ExpectCtxParam1 sets up expected param ctx for CartRepository.SaveCheckout
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) ExpectCtxParam1(ctx context.Context) *mCartRepositoryMockSaveCheckout {
+	if mmSaveCheckout.mock.funcSaveCheckout != nil {
+		mmSaveCheckout.mock.t.Fatalf("CartRepositoryMock.SaveCheckout mock is already set by Set")
+	}
+
+	if mmSaveCheckout.defaultExpectation == nil {
+		mmSaveCheckout.defaultExpectation = &CartRepositoryMockSaveCheckoutExpectation{}
+	}
+
+	if mmSaveCheckout.defaultExpectation.params != nil {
+		mmSaveCheckout.mock.t.Fatalf("CartRepositoryMock.SaveCheckout mock is already set by Expect")
+	}
+
+	if mmSaveCheckout.defaultExpectation.paramPtrs == nil {
+		mmSaveCheckout.defaultExpectation.paramPtrs = &CartRepositoryMockSaveCheckoutParamPtrs{}
+	}
+	mmSaveCheckout.defaultExpectation.paramPtrs.ctx = &ctx
+	mmSaveCheckout.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmSaveCheckout
+}
+
+// ExpectCheckoutParam2 sets up expected param checkout for CartRepository.SaveCheckout
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) ExpectCheckoutParam2(checkout model.CheckoutModel) *mCartRepositoryMockSaveCheckout {
+	if mmSaveCheckout.mock.funcSaveCheckout != nil {
+		mmSaveCheckout.mock.t.Fatalf("CartRepositoryMock.SaveCheckout mock is already set by Set")
+	}
+
+	if mmSaveCheckout.defaultExpectation == nil {
+		mmSaveCheckout.defaultExpectation = &CartRepositoryMockSaveCheckoutExpectation{}
+	}
+
+	if mmSaveCheckout.defaultExpectation.params != nil {
+		mmSaveCheckout.mock.t.Fatalf("CartRepositoryMock.SaveCheckout mock is already set by Expect")
+	}
+
+	if mmSaveCheckout.defaultExpectation.paramPtrs == nil {
+		mmSaveCheckout.defaultExpectation.paramPtrs = &CartRepositoryMockSaveCheckoutParamPtrs{}
+	}
+	mmSaveCheckout.defaultExpectation.paramPtrs.checkout = &checkout
+	mmSaveCheckout.defaultExpectation.expectationOrigins.originCheckout = minimock.CallerInfo(1)
+
+	return mmSaveCheckout
+}
+
+// Inspect accepts an inspector function that has same arguments as the CartRepository.SaveCheckout
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) Inspect(f func(ctx context.Context, checkout model.CheckoutModel)) *mCartRepositoryMockSaveCheckout {
+	if mmSaveCheckout.mock.inspectFuncSaveCheckout != nil {
+		mmSaveCheckout.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.SaveCheckout")
+	}
+
+	mmSaveCheckout.mock.inspectFuncSaveCheckout = f
+
+	return mmSaveCheckout
+}
+
+// Return sets up results that will be returned by CartRepository.SaveCheckout
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) Return(err error) *CartRepositoryMock {
+	if mmSaveCheckout.mock.funcSaveCheckout != nil {
+		mmSaveCheckout.mock.t.Fatalf("CartRepositoryMock.SaveCheckout mock is already set by Set")
+	}
+
+	if mmSaveCheckout.defaultExpectation == nil {
+		mmSaveCheckout.defaultExpectation = &CartRepositoryMockSaveCheckoutExpectation{mock: mmSaveCheckout.mock}
+	}
+	mmSaveCheckout.defaultExpectation.results = &CartRepositoryMockSaveCheckoutResults{err}
+	mmSaveCheckout.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmSaveCheckout.mock
+}
+
+// Set uses given function f to mock the CartRepository.SaveCheckout method
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) Set(f func(ctx context.Context, checkout model.CheckoutModel) (err error)) *CartRepositoryMock {
+	if mmSaveCheckout.defaultExpectation != nil {
+		mmSaveCheckout.mock.t.Fatalf("Default expectation is already set for the CartRepository.SaveCheckout method")
+	}
+
+	if len(mmSaveCheckout.expectations) > 0 {
+		mmSaveCheckout.mock.t.Fatalf("Some expectations are already set for the CartRepository.SaveCheckout method")
+	}
+
+	mmSaveCheckout.mock.funcSaveCheckout = f
+	mmSaveCheckout.mock.funcSaveCheckoutOrigin = minimock.CallerInfo(1)
+	return mmSaveCheckout.mock
+}
+
+// When sets expectation for the CartRepository.SaveCheckout which will trigger the result defined by the following
+// Then helper
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) When(ctx context.Context, checkout model.CheckoutModel) *CartRepositoryMockSaveCheckoutExpectation {
+	if mmSaveCheckout.mock.funcSaveCheckout != nil {
+		mmSaveCheckout.mock.t.Fatalf("CartRepositoryMock.SaveCheckout mock is already set by Set")
+	}
+
+	expectation := &CartRepositoryMockSaveCheckoutExpectation{
+		mock:               mmSaveCheckout.mock,
+		params:             &CartRepositoryMockSaveCheckoutParams{ctx, checkout},
+		expectationOrigins: CartRepositoryMockSaveCheckoutExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmSaveCheckout.expectations = append(mmSaveCheckout.expectations, expectation)
+	return expectation
+}
+
+// Then sets up CartRepository.SaveCheckout return parameters for the expectation previously defined by the When method
+func (e *CartRepositoryMockSaveCheckoutExpectation) Then(err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockSaveCheckoutResults{err}
+	return e.mock
+}
+
+// Times sets number of times CartRepository.SaveCheckout should be invoked
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) Times(n uint64) *mCartRepositoryMockSaveCheckout {
+	if n == 0 {
+		mmSaveCheckout.mock.t.Fatalf("Times of CartRepositoryMock.SaveCheckout mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmSaveCheckout.expectedInvocations, n)
+	mmSaveCheckout.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmSaveCheckout
+}
+
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) invocationsDone() bool {
+	if len(mmSaveCheckout.expectations) == 0 && mmSaveCheckout.defaultExpectation == nil && mmSaveCheckout.mock.funcSaveCheckout == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmSaveCheckout.mock.afterSaveCheckoutCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSaveCheckout.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// SaveCheckout implements CartRepository
+func (mmSaveCheckout *CartRepositoryMock) SaveCheckout(ctx context.Context, checkout model.CheckoutModel) (err error) {
+	mm_atomic.AddUint64(&mmSaveCheckout.beforeSaveCheckoutCounter, 1)
+	defer mm_atomic.AddUint64(&mmSaveCheckout.afterSaveCheckoutCounter, 1)
+
+	mmSaveCheckout.t.Helper()
+
+	if mmSaveCheckout.inspectFuncSaveCheckout != nil {
+		mmSaveCheckout.inspectFuncSaveCheckout(ctx, checkout)
+	}
+
+	mm_params := CartRepositoryMockSaveCheckoutParams{ctx, checkout}
+
+	// Record call args
+	mmSaveCheckout.SaveCheckoutMock.mutex.Lock()
+	mmSaveCheckout.SaveCheckoutMock.callArgs = append(mmSaveCheckout.SaveCheckoutMock.callArgs, &mm_params)
+	mmSaveCheckout.SaveCheckoutMock.mutex.Unlock()
+
+	for _, e := range mmSaveCheckout.SaveCheckoutMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmSaveCheckout.SaveCheckoutMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSaveCheckout.SaveCheckoutMock.defaultExpectation.Counter, 1)
+		mm_want := mmSaveCheckout.SaveCheckoutMock.defaultExpectation.params
+		mm_want_ptrs := mmSaveCheckout.SaveCheckoutMock.defaultExpectation.paramPtrs
+
+		mm_got := CartRepositoryMockSaveCheckoutParams{ctx, checkout}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmSaveCheckout.t.Errorf("CartRepositoryMock.SaveCheckout got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSaveCheckout.SaveCheckoutMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.checkout != nil && !minimock.Equal(*mm_want_ptrs.checkout, mm_got.checkout) {
+				mmSaveCheckout.t.Errorf("CartRepositoryMock.SaveCheckout got unexpected parameter checkout, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSaveCheckout.SaveCheckoutMock.defaultExpectation.expectationOrigins.originCheckout, *mm_want_ptrs.checkout, mm_got.checkout, minimock.Diff(*mm_want_ptrs.checkout, mm_got.checkout))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSaveCheckout.t.Errorf("CartRepositoryMock.SaveCheckout got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmSaveCheckout.SaveCheckoutMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSaveCheckout.SaveCheckoutMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSaveCheckout.t.Fatal("No results are set for the CartRepositoryMock.SaveCheckout")
+		}
+		return (*mm_results).err
+	}
+	if mmSaveCheckout.funcSaveCheckout != nil {
+		return mmSaveCheckout.funcSaveCheckout(ctx, checkout)
+	}
+	mmSaveCheckout.t.Fatalf("Unexpected call to CartRepositoryMock.SaveCheckout. %v %v", ctx, checkout)
+	return
+}
+
+// SaveCheckoutAfterCounter returns a count of finished CartRepositoryMock.SaveCheckout invocations
+func (mmSaveCheckout *CartRepositoryMock) SaveCheckoutAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSaveCheckout.afterSaveCheckoutCounter)
+}
+
+// SaveCheckoutBeforeCounter returns a count of CartRepositoryMock.SaveCheckout invocations
+func (mmSaveCheckout *CartRepositoryMock) SaveCheckoutBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSaveCheckout.beforeSaveCheckoutCounter)
+}
+
+// Calls returns a list of arguments used in each call to CartRepositoryMock.SaveCheckout.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSaveCheckout *mCartRepositoryMockSaveCheckout) Calls() []*CartRepositoryMockSaveCheckoutParams {
+	mmSaveCheckout.mutex.RLock()
+
+	argCopy := make([]*CartRepositoryMockSaveCheckoutParams, len(mmSaveCheckout.callArgs))
+	copy(argCopy, mmSaveCheckout.callArgs)
+
+	mmSaveCheckout.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSaveCheckoutDone returns true if the count of the SaveCheckout invocations corresponds
+// the number of defined expectations
+func (m *CartRepositoryMock) MinimockSaveCheckoutDone() bool {
+	if m.SaveCheckoutMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.SaveCheckoutMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.SaveCheckoutMock.invocationsDone()
+}
+
+// MinimockSaveCheckoutInspect logs each unmet expectation
+func (m *CartRepositoryMock) MinimockSaveCheckoutInspect() {
+	for _, e := range m.SaveCheckoutMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to CartRepositoryMock.SaveCheckout at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterSaveCheckoutCounter := mm_atomic.LoadUint64(&m.afterSaveCheckoutCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SaveCheckoutMock.defaultExpectation != nil && afterSaveCheckoutCounter < 1 {
+		if m.SaveCheckoutMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to CartRepositoryMock.SaveCheckout at\n%s", m.SaveCheckoutMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to CartRepositoryMock.SaveCheckout at\n%s with params: %#v", m.SaveCheckoutMock.defaultExpectation.expectationOrigins.origin, *m.SaveCheckoutMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSaveCheckout != nil && afterSaveCheckoutCounter < 1 {
+		m.t.Errorf("Expected call to CartRepositoryMock.SaveCheckout at\n%s", m.funcSaveCheckoutOrigin)
+	}
+
+	if !m.SaveCheckoutMock.invocationsDone() && afterSaveCheckoutCounter > 0 {
+		m.t.Errorf("Expected %d calls to CartRepositoryMock.SaveCheckout at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.SaveCheckoutMock.expectedInvocations), m.SaveCheckoutMock.expectedInvocationsOrigin, afterSaveCheckoutCounter)
+	}
+}
+
+type mCartRepositoryMockSetItemCount struct {
+	optional           bool
+	mock               *CartRepositoryMock
+	defaultExpectation *CartRepositoryMockSetItemCountExpectation
+	expectations       []*CartRepositoryMockSetItemCountExpectation
+
+	callArgs []*CartRepositoryMockSetItemCountParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// CartRepositoryMockSetItemCountExpectation specifies expectation struct of the CartRepository.SetItemCount
+type CartRepositoryMockSetItemCountExpectation struct {
+	mock               *CartRepositoryMock
+	params             *CartRepositoryMockSetItemCountParams
+	paramPtrs          *CartRepositoryMockSetItemCountParamPtrs
+	expectationOrigins CartRepositoryMockSetItemCountExpectationOrigins
+	results            *CartRepositoryMockSetItemCountResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// CartRepositoryMockSetItemCountParams contains parameters of the CartRepository.SetItemCount
+type CartRepositoryMockSetItemCountParams struct {
+	ctx  context.Context
+	item *model.CartItemModel
+}
+
+// CartRepositoryMockSetItemCountParamPtrs contains pointers to parameters of the CartRepository.SetItemCount
+type CartRepositoryMockSetItemCountParamPtrs struct {
+	ctx  *context.Context
+	item **model.CartItemModel
+}
+
+// CartRepositoryMockSetItemCountResults contains results of the CartRepository.SetItemCount
+type CartRepositoryMockSetItemCountResults struct {
+	err error
+}
+
+// CartRepositoryMockSetItemCountOrigins contains origins of expectations of the CartRepository.SetItemCount
+type CartRepositoryMockSetItemCountExpectationOrigins struct {
+	origin     string
+	originCtx  string
+	originItem string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) Optional() *mCartRepositoryMockSetItemCount {
+	mmSetItemCount.optional = true
+	return mmSetItemCount
+}
+
+// Expect sets up expected params for CartRepository.SetItemCount
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) Expect(ctx context.Context, item *model.CartItemModel) *mCartRepositoryMockSetItemCount {
+	if mmSetItemCount.mock.funcSetItemCount != nil {
+		mmSetItemCount.mock.t.Fatalf("CartRepositoryMock.SetItemCount mock is already set by Set")
+	}
+
+	if mmSetItemCount.defaultExpectation == nil {
+		mmSetItemCount.defaultExpectation = &CartRepositoryMockSetItemCountExpectation{}
+	}
+
+	if mmSetItemCount.defaultExpectation.paramPtrs != nil {
+		mmSetItemCount.mock.t.Fatalf("CartRepositoryMock.SetItemCount mock is already set by ExpectParams functions")
+	}
+
+	mmSetItemCount.defaultExpectation.params = &CartRepositoryMockSetItemCountParams{ctx, item}
+	mmSetItemCount.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmSetItemCount.expectations {
+		if minimock.Equal(e.params, mmSetItemCount.defaultExpectation.params) {
+			mmSetItemCount.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetItemCount.defaultExpectation.params)
+		}
+	}
+
+	return mmSetItemCount
+}
+
+// ExpectCtxParam1 sets up expected param ctx for CartRepository.SetItemCount
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) ExpectCtxParam1(ctx context.Context) *mCartRepositoryMockSetItemCount {
+	if mmSetItemCount.mock.funcSetItemCount != nil {
+		mmSetItemCount.mock.t.Fatalf("CartRepositoryMock.SetItemCount mock is already set by Set")
+	}
+
+	if mmSetItemCount.defaultExpectation == nil {
+		mmSetItemCount.defaultExpectation = &CartRepositoryMockSetItemCountExpectation{}
+	}
+
+	if mmSetItemCount.defaultExpectation.params != nil {
+		mmSetItemCount.mock.t.Fatalf("CartRepositoryMock.SetItemCount mock is already set by Expect")
+	}
+
+	if mmSetItemCount.defaultExpectation.paramPtrs == nil {
+		mmSetItemCount.defaultExpectation.paramPtrs = &CartRepositoryMockSetItemCountParamPtrs{}
+	}
+	mmSetItemCount.defaultExpectation.paramPtrs.ctx = &ctx
+	mmSetItemCount.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmSetItemCount
+}
+
+// ExpectItemParam2 sets up expected param item for CartRepository.SetItemCount
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) ExpectItemParam2(item *model.CartItemModel) *mCartRepositoryMockSetItemCount {
+	if mmSetItemCount.mock.funcSetItemCount != nil {
+		mmSetItemCount.mock.t.Fatalf("CartRepositoryMock.SetItemCount mock is already set by Set")
+	}
+
+	if mmSetItemCount.defaultExpectation == nil {
+		mmSetItemCount.defaultExpectation = &CartRepositoryMockSetItemCountExpectation{}
+	}
+
+	if mmSetItemCount.defaultExpectation.params != nil {
+		mmSetItemCount.mock.t.Fatalf("CartRepositoryMock.SetItemCount mock is already set by Expect")
+	}
+
+	if mmSetItemCount.defaultExpectation.paramPtrs == nil {
+		mmSetItemCount.defaultExpectation.paramPtrs = &CartRepositoryMockSetItemCountParamPtrs{}
+	}
+	mmSetItemCount.defaultExpectation.paramPtrs.item = &item
+	mmSetItemCount.defaultExpectation.expectationOrigins.originItem = minimock.CallerInfo(1)
+
+	return mmSetItemCount
+}
+
+// Inspect accepts an inspector function that has same arguments as the CartRepository.SetItemCount
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) Inspect(f func(ctx context.Context, item *model.CartItemModel)) *mCartRepositoryMockSetItemCount {
+	if mmSetItemCount.mock.inspectFuncSetItemCount != nil {
+		mmSetItemCount.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.SetItemCount")
+	}
+
+	mmSetItemCount.mock.inspectFuncSetItemCount = f
+
+	return mmSetItemCount
+}
+
+// Return sets up results that will be returned by CartRepository.SetItemCount
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) Return(err error) *CartRepositoryMock {
+	if mmSetItemCount.mock.funcSetItemCount != nil {
+		mmSetItemCount.mock.t.Fatalf("CartRepositoryMock.SetItemCount mock is already set by Set")
+	}
+
+	if mmSetItemCount.defaultExpectation == nil {
+		mmSetItemCount.defaultExpectation = &CartRepositoryMockSetItemCountExpectation{mock: mmSetItemCount.mock}
+	}
+	mmSetItemCount.defaultExpectation.results = &CartRepositoryMockSetItemCountResults{err}
+	mmSetItemCount.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmSetItemCount.mock
+}
+
+// Set uses given function f to mock the CartRepository.SetItemCount method
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) Set(f func(ctx context.Context, item *model.CartItemModel) (err error)) *CartRepositoryMock {
+	if mmSetItemCount.defaultExpectation != nil {
+		mmSetItemCount.mock.t.Fatalf("Default expectation is already set for the CartRepository.SetItemCount method")
+	}
+
+	if len(mmSetItemCount.expectations) > 0 {
+		mmSetItemCount.mock.t.Fatalf("Some expectations are already set for the CartRepository.SetItemCount method")
+	}
+
+	mmSetItemCount.mock.funcSetItemCount = f
+	mmSetItemCount.mock.funcSetItemCountOrigin = minimock.CallerInfo(1)
+	return mmSetItemCount.mock
+}
+
+// When sets expectation for the CartRepository.SetItemCount which will trigger the result defined by the following
+// Then helper
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) When(ctx context.Context, item *model.CartItemModel) *CartRepositoryMockSetItemCountExpectation {
+	if mmSetItemCount.mock.funcSetItemCount != nil {
+		mmSetItemCount.mock.t.Fatalf("CartRepositoryMock.SetItemCount mock is already set by Set")
+	}
+
+	expectation := &CartRepositoryMockSetItemCountExpectation{
+		mock:               mmSetItemCount.mock,
+		params:             &CartRepositoryMockSetItemCountParams{ctx, item},
+		expectationOrigins: CartRepositoryMockSetItemCountExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmSetItemCount.expectations = append(mmSetItemCount.expectations, expectation)
+	return expectation
+}
+
+// Then sets up CartRepository.SetItemCount return parameters for the expectation previously defined by the When method
+func (e *CartRepositoryMockSetItemCountExpectation) Then(err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockSetItemCountResults{err}
+	return e.mock
+}
+
+// Times sets number of times CartRepository.SetItemCount should be invoked
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) Times(n uint64) *mCartRepositoryMockSetItemCount {
+	if n == 0 {
+		mmSetItemCount.mock.t.Fatalf("Times of CartRepositoryMock.SetItemCount mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmSetItemCount.expectedInvocations, n)
+	mmSetItemCount.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmSetItemCount
+}
+
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) invocationsDone() bool {
+	if len(mmSetItemCount.expectations) == 0 && mmSetItemCount.defaultExpectation == nil && mmSetItemCount.mock.funcSetItemCount == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmSetItemCount.mock.afterSetItemCountCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSetItemCount.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// SetItemCount implements CartRepository
+func (mmSetItemCount *CartRepositoryMock) SetItemCount(ctx context.Context, item *model.CartItemModel) (err error) {
+	mm_atomic.AddUint64(&mmSetItemCount.beforeSetItemCountCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetItemCount.afterSetItemCountCounter, 1)
+
+	mmSetItemCount.t.Helper()
+
+	if mmSetItemCount.inspectFuncSetItemCount != nil {
+		mmSetItemCount.inspectFuncSetItemCount(ctx, item)
+	}
+
+	mm_params := CartRepositoryMockSetItemCountParams{ctx, item}
+
+	// Record call args
+	mmSetItemCount.SetItemCountMock.mutex.Lock()
+	mmSetItemCount.SetItemCountMock.callArgs = append(mmSetItemCount.SetItemCountMock.callArgs, &mm_params)
+	mmSetItemCount.SetItemCountMock.mutex.Unlock()
+
+	for _, e := range mmSetItemCount.SetItemCountMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmSetItemCount.SetItemCountMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetItemCount.SetItemCountMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetItemCount.SetItemCountMock.defaultExpectation.params
+		mm_want_ptrs := mmSetItemCount.SetItemCountMock.defaultExpectation.paramPtrs
+
+		mm_got := CartRepositoryMockSetItemCountParams{ctx, item}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmSetItemCount.t.Errorf("CartRepositoryMock.SetItemCount got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetItemCount.SetItemCountMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.item != nil && !minimock.Equal(*mm_want_ptrs.item, mm_got.item) {
+				mmSetItemCount.t.Errorf("CartRepositoryMock.SetItemCount got unexpected parameter item, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmSetItemCount.SetItemCountMock.defaultExpectation.expectationOrigins.originItem, *mm_want_ptrs.item, mm_got.item, minimock.Diff(*mm_want_ptrs.item, mm_got.item))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetItemCount.t.Errorf("CartRepositoryMock.SetItemCount got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmSetItemCount.SetItemCountMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSetItemCount.SetItemCountMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSetItemCount.t.Fatal("No results are set for the CartRepositoryMock.SetItemCount")
+		}
+		return (*mm_results).err
+	}
+	if mmSetItemCount.funcSetItemCount != nil {
+		return mmSetItemCount.funcSetItemCount(ctx, item)
+	}
+	mmSetItemCount.t.Fatalf("Unexpected call to CartRepositoryMock.SetItemCount. %v %v", ctx, item)
+	return
+}
+
+// SetItemCountAfterCounter returns a count of finished CartRepositoryMock.SetItemCount invocations
+func (mmSetItemCount *CartRepositoryMock) SetItemCountAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetItemCount.afterSetItemCountCounter)
+}
+
+// SetItemCountBeforeCounter returns a count of CartRepositoryMock.SetItemCount invocations
+func (mmSetItemCount *CartRepositoryMock) SetItemCountBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetItemCount.beforeSetItemCountCounter)
+}
+
+// Calls returns a list of arguments used in each call to CartRepositoryMock.SetItemCount.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetItemCount *mCartRepositoryMockSetItemCount) Calls() []*CartRepositoryMockSetItemCountParams {
+	mmSetItemCount.mutex.RLock()
+
+	argCopy := make([]*CartRepositoryMockSetItemCountParams, len(mmSetItemCount.callArgs))
+	copy(argCopy, mmSetItemCount.callArgs)
+
+	mmSetItemCount.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetItemCountDone returns true if the count of the SetItemCount invocations corresponds
+// the number of defined expectations
+func (m *CartRepositoryMock) MinimockSetItemCountDone() bool {
+	if m.SetItemCountMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.SetItemCountMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.SetItemCountMock.invocationsDone()
+}
+
+// MinimockSetItemCountInspect logs each unmet expectation
+func (m *CartRepositoryMock) MinimockSetItemCountInspect() {
+	for _, e := range m.SetItemCountMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to CartRepositoryMock.SetItemCount at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterSetItemCountCounter := mm_atomic.LoadUint64(&m.afterSetItemCountCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetItemCountMock.defaultExpectation != nil && afterSetItemCountCounter < 1 {
+		if m.SetItemCountMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to CartRepositoryMock.SetItemCount at\n%s", m.SetItemCountMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to CartRepositoryMock.SetItemCount at\n%s with params: %#v", m.SetItemCountMock.defaultExpectation.expectationOrigins.origin, *m.SetItemCountMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetItemCount != nil && afterSetItemCountCounter < 1 {
+		m.t.Errorf("Expected call to CartRepositoryMock.SetItemCount at\n%s", m.funcSetItemCountOrigin)
+	}
+
+	if !m.SetItemCountMock.invocationsDone() && afterSetItemCountCounter > 0 {
+		m.t.Errorf("Expected %d calls to CartRepositoryMock.SetItemCount at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.SetItemCountMock.expectedInvocations), m.SetItemCountMock.expectedInvocationsOrigin, afterSetItemCountCounter)
+	}
+}
+
+type mCartRepositoryMockUnlock struct {
+	optional           bool
+	mock               *CartRepositoryMock
+	defaultExpectation *CartRepositoryMockUnlockExpectation
+	expectations       []*CartRepositoryMockUnlockExpectation
+
+	callArgs []*CartRepositoryMockUnlockParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// CartRepositoryMockUnlockExpectation specifies expectation struct of the CartRepository.Unlock
+type CartRepositoryMockUnlockExpectation struct {
+	mock               *CartRepositoryMock
+	params             *CartRepositoryMockUnlockParams
+	paramPtrs          *CartRepositoryMockUnlockParamPtrs
+	expectationOrigins CartRepositoryMockUnlockExpectationOrigins
+	results            *CartRepositoryMockUnlockResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// CartRepositoryMockUnlockParams contains parameters of the CartRepository.Unlock
+type CartRepositoryMockUnlockParams struct {
+	ctx    context.Context
+	userId int64
+}
+
+// CartRepositoryMockUnlockParamPtrs contains pointers to parameters of the CartRepository.Unlock
+type CartRepositoryMockUnlockParamPtrs struct {
+	ctx    *context.Context
+	userId *int64
+}
+
+// CartRepositoryMockUnlockResults contains results of the CartRepository.Unlock
+type CartRepositoryMockUnlockResults struct {
+	err error
+}
+
+// CartRepositoryMockUnlockOrigins contains origins of expectations of the CartRepository.Unlock
+type CartRepositoryMockUnlockExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originUserId string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmUnlock *mCartRepositoryMockUnlock) Optional() *mCartRepositoryMockUnlock {
+	mmUnlock.optional = true
+	return mmUnlock
+}
+
+// Expect sets up expected params for CartRepository.Unlock
+func (mmUnlock *mCartRepositoryMockUnlock) Expect(ctx context.Context, userId int64) *mCartRepositoryMockUnlock {
+	if mmUnlock.mock.funcUnlock != nil {
+		mmUnlock.mock.t.Fatalf("CartRepositoryMock.Unlock mock is already set by Set")
+	}
+
+	if mmUnlock.defaultExpectation == nil {
+		mmUnlock.defaultExpectation = &CartRepositoryMockUnlockExpectation{}
+	}
+
+	if mmUnlock.defaultExpectation.paramPtrs != nil {
+		mmUnlock.mock.t.Fatalf("CartRepositoryMock.Unlock mock is already set by ExpectParams functions")
+	}
+
+	mmUnlock.defaultExpectation.params = &CartRepositoryMockUnlockParams{ctx, userId}
+	mmUnlock.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmUnlock.expectations {
+		if minimock.Equal(e.params, mmUnlock.defaultExpectation.params) {
+			mmUnlock.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUnlock.defaultExpectation.params)
+		}
+	}
+
+	return mmUnlock
+}
+
+// ExpectCtxParam1 sets up expected param ctx for CartRepository.Unlock
+func (mmUnlock *mCartRepositoryMockUnlock) ExpectCtxParam1(ctx context.Context) *mCartRepositoryMockUnlock {
+	if mmUnlock.mock.funcUnlock != nil {
+		mmUnlock.mock.t.Fatalf("CartRepositoryMock.Unlock mock is already set by Set")
+	}
+
+	if mmUnlock.defaultExpectation == nil {
+		mmUnlock.defaultExpectation = &CartRepositoryMockUnlockExpectation{}
+	}
+
+	if mmUnlock.defaultExpectation.params != nil {
+		mmUnlock.mock.t.Fatalf("CartRepositoryMock.Unlock mock is already set by Expect")
+	}
+
+	if mmUnlock.defaultExpectation.paramPtrs == nil {
+		mmUnlock.defaultExpectation.paramPtrs = &CartRepositoryMockUnlockParamPtrs{}
+	}
+	mmUnlock.defaultExpectation.paramPtrs.ctx = &ctx
+	mmUnlock.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmUnlock
+}
+
+// ExpectUserIdParam2 sets up expected param userId for CartRepository.Unlock
+func (mmUnlock *mCartRepositoryMockUnlock) ExpectUserIdParam2(userId int64) *mCartRepositoryMockUnlock {
+	if mmUnlock.mock.funcUnlock != nil {
+		mmUnlock.mock.t.Fatalf("CartRepositoryMock.Unlock mock is already set by Set")
+	}
+
+	if mmUnlock.defaultExpectation == nil {
+		mmUnlock.defaultExpectation = &CartRepositoryMockUnlockExpectation{}
+	}
+
+	if mmUnlock.defaultExpectation.params != nil {
+		mmUnlock.mock.t.Fatalf("CartRepositoryMock.Unlock mock is already set by Expect")
+	}
+
+	if mmUnlock.defaultExpectation.paramPtrs == nil {
+		mmUnlock.defaultExpectation.paramPtrs = &CartRepositoryMockUnlockParamPtrs{}
+	}
+	mmUnlock.defaultExpectation.paramPtrs.userId = &userId
+	mmUnlock.defaultExpectation.expectationOrigins.originUserId = minimock.CallerInfo(1)
+
+	return mmUnlock
+}
+
+// Inspect accepts an inspector function that has same arguments as the CartRepository.Unlock
+func (mmUnlock *mCartRepositoryMockUnlock) Inspect(f func(ctx context.Context, userId int64)) *mCartRepositoryMockUnlock {
+	if mmUnlock.mock.inspectFuncUnlock != nil {
+		mmUnlock.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.Unlock")
+	}
+
+	mmUnlock.mock.inspectFuncUnlock = f
+
+	return mmUnlock
+}
+
+// Return sets up results that will be returned by CartRepository.Unlock
+func (mmUnlock *mCartRepositoryMockUnlock) Return(err error) *CartRepositoryMock {
+	if mmUnlock.mock.funcUnlock != nil {
+		mmUnlock.mock.t.Fatalf("CartRepositoryMock.Unlock mock is already set by Set")
+	}
+
+	if mmUnlock.defaultExpectation == nil {
+		mmUnlock.defaultExpectation = &CartRepositoryMockUnlockExpectation{mock: mmUnlock.mock}
+	}
+	mmUnlock.defaultExpectation.results = &CartRepositoryMockUnlockResults{err}
+	mmUnlock.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmUnlock.mock
+}
+
+// Set uses given function f to mock the CartRepository.Unlock method
+func (mmUnlock *mCartRepositoryMockUnlock) Set(f func(ctx context.Context, userId int64) (err error)) *CartRepositoryMock {
+	if mmUnlock.defaultExpectation != nil {
+		mmUnlock.mock.t.Fatalf("Default expectation is already set for the CartRepository.Unlock method")
+	}
+
+	if len(mmUnlock.expectations) > 0 {
+		mmUnlock.mock.t.Fatalf("Some expectations are already set for the CartRepository.Unlock method")
+	}
+
+	mmUnlock.mock.funcUnlock = f
+	mmUnlock.mock.funcUnlockOrigin = minimock.CallerInfo(1)
+	return mmUnlock.mock
+}
+
+// When sets expectation for the CartRepository.Unlock which will trigger the result defined by the following
+// Then helper
+func (mmUnlock *mCartRepositoryMockUnlock) When(ctx context.Context, userId int64) *CartRepositoryMockUnlockExpectation {
+	if mmUnlock.mock.funcUnlock != nil {
+		mmUnlock.mock.t.Fatalf("CartRepositoryMock.Unlock mock is already set by Set")
+	}
+
+	expectation := &CartRepositoryMockUnlockExpectation{
+		mock:               mmUnlock.mock,
+		params:             &CartRepositoryMockUnlockParams{ctx, userId},
+		expectationOrigins: CartRepositoryMockUnlockExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmUnlock.expectations = append(mmUnlock.expectations, expectation)
+	return expectation
+}
+
+// Then sets up CartRepository.Unlock return parameters for the expectation previously defined by the When method
+func (e *CartRepositoryMockUnlockExpectation) Then(err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockUnlockResults{err}
+	return e.mock
+}
+
+// Times sets number of times CartRepository.Unlock should be invoked
+func (mmUnlock *mCartRepositoryMockUnlock) Times(n uint64) *mCartRepositoryMockUnlock {
+	if n == 0 {
+		mmUnlock.mock.t.Fatalf("Times of CartRepositoryMock.Unlock mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmUnlock.expectedInvocations, n)
+	mmUnlock.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmUnlock
+}
+
+func (mmUnlock *mCartRepositoryMockUnlock) invocationsDone() bool {
+	if len(mmUnlock.expectations) == 0 && mmUnlock.defaultExpectation == nil && mmUnlock.mock.funcUnlock == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmUnlock.mock.afterUnlockCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmUnlock.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Unlock implements CartRepository
+func (mmUnlock *CartRepositoryMock) Unlock(ctx context.Context, userId int64) (err error) {
+	mm_atomic.AddUint64(&mmUnlock.beforeUnlockCounter, 1)
+	defer mm_atomic.AddUint64(&mmUnlock.afterUnlockCounter, 1)
+
+	mmUnlock.t.Helper()
+
+	if mmUnlock.inspectFuncUnlock != nil {
+		mmUnlock.inspectFuncUnlock(ctx, userId)
+	}
+
+	mm_params := CartRepositoryMockUnlockParams{ctx, userId}
+
+	// Record call args
+	mmUnlock.UnlockMock.mutex.Lock()
+	mmUnlock.UnlockMock.callArgs = append(mmUnlock.UnlockMock.callArgs, &mm_params)
+	mmUnlock.UnlockMock.mutex.Unlock()
+
+	for _, e := range mmUnlock.UnlockMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmUnlock.UnlockMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUnlock.UnlockMock.defaultExpectation.Counter, 1)
+		mm_want := mmUnlock.UnlockMock.defaultExpectation.params
+		mm_want_ptrs := mmUnlock.UnlockMock.defaultExpectation.paramPtrs
+
+		mm_got := CartRepositoryMockUnlockParams{ctx, userId}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmUnlock.t.Errorf("CartRepositoryMock.Unlock got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUnlock.UnlockMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.userId != nil && !minimock.Equal(*mm_want_ptrs.userId, mm_got.userId) {
+				mmUnlock.t.Errorf("CartRepositoryMock.Unlock got unexpected parameter userId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmUnlock.UnlockMock.defaultExpectation.expectationOrigins.originUserId, *mm_want_ptrs.userId, mm_got.userId, minimock.Diff(*mm_want_ptrs.userId, mm_got.userId))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmUnlock.t.Errorf("CartRepositoryMock.Unlock got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmUnlock.UnlockMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmUnlock.UnlockMock.defaultExpectation.results
+		if mm_results == nil {
+			mmUnlock.t.Fatal("No results are set for the CartRepositoryMock.Unlock")
+		}
+		return (*mm_results).err
+	}
+	if mmUnlock.funcUnlock != nil {
+		return mmUnlock.funcUnlock(ctx, userId)
+	}
+	mmUnlock.t.Fatalf("Unexpected call to CartRepositoryMock.Unlock. %v %v", ctx, userId)
+	return
+}
+
+// UnlockAfterCounter returns a count of finished CartRepositoryMock.Unlock invocations
+func (mmUnlock *CartRepositoryMock) UnlockAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUnlock.afterUnlockCounter)
+}
+
+// UnlockBeforeCounter returns a count of CartRepositoryMock.Unlock invocations
+func (mmUnlock *CartRepositoryMock) UnlockBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUnlock.beforeUnlockCounter)
+}
+
+// Calls returns a list of arguments used in each call to CartRepositoryMock.Unlock.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmUnlock *mCartRepositoryMockUnlock) Calls() []*CartRepositoryMockUnlockParams {
+	mmUnlock.mutex.RLock()
+
+	argCopy := make([]*CartRepositoryMockUnlockParams, len(mmUnlock.callArgs))
+	copy(argCopy, mmUnlock.callArgs)
+
+	mmUnlock.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockUnlockDone returns true if the count of the Unlock invocations corresponds
+// the number of defined expectations
+func (m *CartRepositoryMock) MinimockUnlockDone() bool {
+	if m.UnlockMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.UnlockMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.UnlockMock.invocationsDone()
+}
+
+// MinimockUnlockInspect logs each unmet expectation
+func (m *CartRepositoryMock) MinimockUnlockInspect() {
+	for _, e := range m.UnlockMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to CartRepositoryMock.Unlock at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterUnlockCounter := mm_atomic.LoadUint64(&m.afterUnlockCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.UnlockMock.defaultExpectation != nil && afterUnlockCounter < 1 {
+		if m.UnlockMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to CartRepositoryMock.Unlock at\n%s", m.UnlockMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to CartRepositoryMock.Unlock at\n%s with params: %#v", m.UnlockMock.defaultExpectation.expectationOrigins.origin, *m.UnlockMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcUnlock != nil && afterUnlockCounter < 1 {
+		m.t.Errorf("Expected call to CartRepositoryMock.Unlock at\n%s", m.funcUnlockOrigin)
+	}
+
+	if !m.UnlockMock.invocationsDone() && afterUnlockCounter > 0 {
+		m.t.Errorf("Expected %d calls to CartRepositoryMock.Unlock at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.UnlockMock.expectedInvocations), m.UnlockMock.expectedInvocationsOrigin, afterUnlockCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *CartRepositoryMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockChangeItemCountInspect()
+
+			m.MinimockCreateItemInspect()
+
+			m.MinimockDeleteAllInspect()
+
+			m.MinimockDeleteBySkuInspect()
+
+			m.MinimockDeleteSnapshotInspect()
+
+			m.MinimockGetAllOrderBySkuInspect()
+
+			m.MinimockGetCheckoutInspect()
+
+			m.MinimockGetSnapshotInspect()
+
+			m.MinimockLockInspect()
+
+			m.MinimockSaveCheckoutInspect()
+
+			m.MinimockSetItemCountInspect()
+
+			m.MinimockUnlockInspect()
 		}
 	})
 }
@@ -1493,8 +4562,16 @@ func (m *CartRepositoryMock) MinimockWait(timeout mm_time.Duration) {
 func (m *CartRepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
+		m.MinimockChangeItemCountDone() &&
 		m.MinimockCreateItemDone() &&
 		m.MinimockDeleteAllDone() &&
 		m.MinimockDeleteBySkuDone() &&
-		m.MinimockGetAllOrderBySkuDone()
+		m.MinimockDeleteSnapshotDone() &&
+		m.MinimockGetAllOrderBySkuDone() &&
+		m.MinimockGetCheckoutDone() &&
+		m.MinimockGetSnapshotDone() &&
+		m.MinimockLockDone() &&
+		m.MinimockSaveCheckoutDone() &&
+		m.MinimockSetItemCountDone() &&
+		m.MinimockUnlockDone()
 }
@@ -19,9 +19,9 @@ type OrdersClientMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
-	funcCreateOrder          func(ctx context.Context, userId int64, items []model.CartItemModel) (i1 int64, err error)
+	funcCreateOrder          func(ctx context.Context, userId int64, items []model.CartItemModel, idempotencyKey string) (i1 int64, err error)
 	funcCreateOrderOrigin    string
-	inspectFuncCreateOrder   func(ctx context.Context, userId int64, items []model.CartItemModel)
+	inspectFuncCreateOrder   func(ctx context.Context, userId int64, items []model.CartItemModel, idempotencyKey string)
 	afterCreateOrderCounter  uint64
 	beforeCreateOrderCounter uint64
 	CreateOrderMock          mOrdersClientMockCreateOrder
@@ -69,16 +69,18 @@ type OrdersClientMockCreateOrderExpectation struct {
 
 // OrdersClientMockCreateOrderParams contains parameters of the OrdersClient.CreateOrder
 type OrdersClientMockCreateOrderParams struct {
-	ctx    context.Context
-	userId int64
-	items  []model.CartItemModel
+	ctx            context.Context
+	userId         int64
+	items          []model.CartItemModel
+	idempotencyKey string
 }
 
 // OrdersClientMockCreateOrderParamPtrs contains pointers to parameters of the OrdersClient.CreateOrder
 type OrdersClientMockCreateOrderParamPtrs struct {
-	ctx    *context.Context
-	userId *int64
-	items  *[]model.CartItemModel
+	ctx            *context.Context
+	userId         *int64
+	items          *[]model.CartItemModel
+	idempotencyKey *string
 }
 
 // OrdersClientMockCreateOrderResults contains results of the OrdersClient.CreateOrder
@@ -89,10 +91,11 @@ type OrdersClientMockCreateOrderResults struct {
 
 // OrdersClientMockCreateOrderOrigins contains origins of expectations of the OrdersClient.CreateOrder
 type OrdersClientMockCreateOrderExpectationOrigins struct {
-	origin       string
-	originCtx    string
-	originUserId string
-	originItems  string
+	origin               string
+	originCtx            string
+	originUserId         string
+	originItems          string
+	originIdempotencyKey string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -106,7 +109,7 @@ func (mmCreateOrder *mOrdersClientMockCreateOrder) Optional() *mOrdersClientMock
 }
 
 // Expect sets up expected params for OrdersClient.CreateOrder
-func (mmCreateOrder *mOrdersClientMockCreateOrder) Expect(ctx context.Context, userId int64, items []model.CartItemModel) *mOrdersClientMockCreateOrder {
+func (mmCreateOrder *mOrdersClientMockCreateOrder) Expect(ctx context.Context, userId int64, items []model.CartItemModel, idempotencyKey string) *mOrdersClientMockCreateOrder {
 	if mmCreateOrder.mock.funcCreateOrder != nil {
 		mmCreateOrder.mock.t.Fatalf("OrdersClientMock.CreateOrder mock is already set by Set")
 	}
@@ -119,7 +122,7 @@ func (mmCreateOrder *mOrdersClientMockCreateOrder) Expect(ctx context.Context, u
 		mmCreateOrder.mock.t.Fatalf("OrdersClientMock.CreateOrder mock is already set by ExpectParams functions")
 	}
 
-	mmCreateOrder.defaultExpectation.params = &OrdersClientMockCreateOrderParams{ctx, userId, items}
+	mmCreateOrder.defaultExpectation.params = &OrdersClientMockCreateOrderParams{ctx, userId, items, idempotencyKey}
 	mmCreateOrder.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmCreateOrder.expectations {
 		if minimock.Equal(e.params, mmCreateOrder.defaultExpectation.params) {
@@ -199,8 +202,31 @@ func (mmCreateOrder *mOrdersClientMockCreateOrder) ExpectItemsParam3(items []mod
 	return mmCreateOrder
 }
 
+// ExpectIdempotencyKeyParam4 sets up expected param idempotencyKey for OrdersClient.CreateOrder
+func (mmCreateOrder *mOrdersClientMockCreateOrder) ExpectIdempotencyKeyParam4(idempotencyKey string) *mOrdersClientMockCreateOrder {
+	if mmCreateOrder.mock.funcCreateOrder != nil {
+		mmCreateOrder.mock.t.Fatalf("OrdersClientMock.CreateOrder mock is already set by Set")
+	}
+
+	if mmCreateOrder.defaultExpectation == nil {
+		mmCreateOrder.defaultExpectation = &OrdersClientMockCreateOrderExpectation{}
+	}
+
+	if mmCreateOrder.defaultExpectation.params != nil {
+		mmCreateOrder.mock.t.Fatalf("OrdersClientMock.CreateOrder mock is already set by Expect")
+	}
+
+	if mmCreateOrder.defaultExpectation.paramPtrs == nil {
+		mmCreateOrder.defaultExpectation.paramPtrs = &OrdersClientMockCreateOrderParamPtrs{}
+	}
+	mmCreateOrder.defaultExpectation.paramPtrs.idempotencyKey = &idempotencyKey
+	mmCreateOrder.defaultExpectation.expectationOrigins.originIdempotencyKey = minimock.CallerInfo(1)
+
+	return mmCreateOrder
+}
+
 // Inspect accepts an inspector function that has same arguments as the OrdersClient.CreateOrder
-func (mmCreateOrder *mOrdersClientMockCreateOrder) Inspect(f func(ctx context.Context, userId int64, items []model.CartItemModel)) *mOrdersClientMockCreateOrder {
+func (mmCreateOrder *mOrdersClientMockCreateOrder) Inspect(f func(ctx context.Context, userId int64, items []model.CartItemModel, idempotencyKey string)) *mOrdersClientMockCreateOrder {
 	if mmCreateOrder.mock.inspectFuncCreateOrder != nil {
 		mmCreateOrder.mock.t.Fatalf("Inspect function is already set for OrdersClientMock.CreateOrder")
 	}
@@ -225,7 +251,7 @@ func (mmCreateOrder *mOrdersClientMockCreateOrder) Return(i1 int64, err error) *
 }
 
 // Set uses given function f to mock the OrdersClient.CreateOrder method
-func (mmCreateOrder *mOrdersClientMockCreateOrder) Set(f func(ctx context.Context, userId int64, items []model.CartItemModel) (i1 int64, err error)) *OrdersClientMock {
+func (mmCreateOrder *mOrdersClientMockCreateOrder) Set(f func(ctx context.Context, userId int64, items []model.CartItemModel, idempotencyKey string) (i1 int64, err error)) *OrdersClientMock {
 	if mmCreateOrder.defaultExpectation != nil {
 		mmCreateOrder.mock.t.Fatalf("Default expectation is already set for the OrdersClient.CreateOrder method")
 	}
@@ -241,14 +267,14 @@ func (mmCreateOrder *mOrdersClientMockCreateOrder) Set(f func(ctx context.Contex
 
 // When sets expectation for the OrdersClient.CreateOrder which will trigger the result defined by the following
 // Then helper
-func (mmCreateOrder *mOrdersClientMockCreateOrder) When(ctx context.Context, userId int64, items []model.CartItemModel) *OrdersClientMockCreateOrderExpectation {
+func (mmCreateOrder *mOrdersClientMockCreateOrder) When(ctx context.Context, userId int64, items []model.CartItemModel, idempotencyKey string) *OrdersClientMockCreateOrderExpectation {
 	if mmCreateOrder.mock.funcCreateOrder != nil {
 		mmCreateOrder.mock.t.Fatalf("OrdersClientMock.CreateOrder mock is already set by Set")
 	}
 
 	expectation := &OrdersClientMockCreateOrderExpectation{
 		mock:               mmCreateOrder.mock,
-		params:             &OrdersClientMockCreateOrderParams{ctx, userId, items},
+		params:             &OrdersClientMockCreateOrderParams{ctx, userId, items, idempotencyKey},
 		expectationOrigins: OrdersClientMockCreateOrderExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmCreateOrder.expectations = append(mmCreateOrder.expectations, expectation)
@@ -283,17 +309,17 @@ func (mmCreateOrder *mOrdersClientMockCreateOrder) invocationsDone() bool {
 }
 
 // CreateOrder implements OrdersClient
-func (mmCreateOrder *OrdersClientMock) CreateOrder(ctx context.Context, userId int64, items []model.CartItemModel) (i1 int64, err error) {
+func (mmCreateOrder *OrdersClientMock) CreateOrder(ctx context.Context, userId int64, items []model.CartItemModel, idempotencyKey string) (i1 int64, err error) {
 	mm_atomic.AddUint64(&mmCreateOrder.beforeCreateOrderCounter, 1)
 	defer mm_atomic.AddUint64(&mmCreateOrder.afterCreateOrderCounter, 1)
 
 	mmCreateOrder.t.Helper()
 
 	if mmCreateOrder.inspectFuncCreateOrder != nil {
-		mmCreateOrder.inspectFuncCreateOrder(ctx, userId, items)
+		mmCreateOrder.inspectFuncCreateOrder(ctx, userId, items, idempotencyKey)
 	}
 
-	mm_params := OrdersClientMockCreateOrderParams{ctx, userId, items}
+	mm_params := OrdersClientMockCreateOrderParams{ctx, userId, items, idempotencyKey}
 
 	// Record call args
 	mmCreateOrder.CreateOrderMock.mutex.Lock()
@@ -312,7 +338,7 @@ func (mmCreateOrder *OrdersClientMock) CreateOrder(ctx context.Context, userId i
 		mm_want := mmCreateOrder.CreateOrderMock.defaultExpectation.params
 		mm_want_ptrs := mmCreateOrder.CreateOrderMock.defaultExpectation.paramPtrs
 
-		mm_got := OrdersClientMockCreateOrderParams{ctx, userId, items}
+		mm_got := OrdersClientMockCreateOrderParams{ctx, userId, items, idempotencyKey}
 
 		if mm_want_ptrs != nil {
 
@@ -331,6 +357,11 @@ func (mmCreateOrder *OrdersClientMock) CreateOrder(ctx context.Context, userId i
 					mmCreateOrder.CreateOrderMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
 			}
 
+			if mm_want_ptrs.idempotencyKey != nil && !minimock.Equal(*mm_want_ptrs.idempotencyKey, mm_got.idempotencyKey) {
+				mmCreateOrder.t.Errorf("OrdersClientMock.CreateOrder got unexpected parameter idempotencyKey, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCreateOrder.CreateOrderMock.defaultExpectation.expectationOrigins.originIdempotencyKey, *mm_want_ptrs.idempotencyKey, mm_got.idempotencyKey, minimock.Diff(*mm_want_ptrs.idempotencyKey, mm_got.idempotencyKey))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmCreateOrder.t.Errorf("OrdersClientMock.CreateOrder got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 				mmCreateOrder.CreateOrderMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
@@ -343,9 +374,9 @@ func (mmCreateOrder *OrdersClientMock) CreateOrder(ctx context.Context, userId i
 		return (*mm_results).i1, (*mm_results).err
 	}
 	if mmCreateOrder.funcCreateOrder != nil {
-		return mmCreateOrder.funcCreateOrder(ctx, userId, items)
+		return mmCreateOrder.funcCreateOrder(ctx, userId, items, idempotencyKey)
 	}
-	mmCreateOrder.t.Fatalf("Unexpected call to OrdersClientMock.CreateOrder. %v %v %v", ctx, userId, items)
+	mmCreateOrder.t.Fatalf("Unexpected call to OrdersClientMock.CreateOrder. %v %v %v %v", ctx, userId, items, idempotencyKey)
 	return
 }
 
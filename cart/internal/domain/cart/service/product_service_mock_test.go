@@ -26,6 +26,13 @@ type ProductServiceMock struct {
 	beforeGetProductCounter uint64
 	GetProductMock          mProductServiceMockGetProduct
 
+	funcGetProducts          func(ctx context.Context, skuIds []int64) (pa1 []model.ProductModel, err error)
+	funcGetProductsOrigin    string
+	inspectFuncGetProducts   func(ctx context.Context, skuIds []int64)
+	afterGetProductsCounter  uint64
+	beforeGetProductsCounter uint64
+	GetProductsMock          mProductServiceMockGetProducts
+
 	funcGetProductsAot          func(ctx context.Context, count int64, startSkuId int64) (pa1 []model.ProductModel, err error)
 	funcGetProductsAotOrigin    string
 	inspectFuncGetProductsAot   func(ctx context.Context, count int64, startSkuId int64)
@@ -52,6 +59,9 @@ func NewProductServiceMock(t minimock.Tester) *ProductServiceMock {
 	m.GetProductMock = mProductServiceMockGetProduct{mock: m}
 	m.GetProductMock.callArgs = []*ProductServiceMockGetProductParams{}
 
+	m.GetProductsMock = mProductServiceMockGetProducts{mock: m}
+	m.GetProductsMock.callArgs = []*ProductServiceMockGetProductsParams{}
+
 	m.GetProductsAotMock = mProductServiceMockGetProductsAot{mock: m}
 	m.GetProductsAotMock.callArgs = []*ProductServiceMockGetProductsAotParams{}
 
@@ -406,6 +416,349 @@ func (m *ProductServiceMock) MinimockGetProductInspect() {
 	}
 }
 
+type mProductServiceMockGetProducts struct {
+	optional           bool
+	mock               *ProductServiceMock
+	defaultExpectation *ProductServiceMockGetProductsExpectation
+	expectations       []*ProductServiceMockGetProductsExpectation
+
+	callArgs []*ProductServiceMockGetProductsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ProductServiceMockGetProductsExpectation specifies expectation struct of the ProductService.GetProducts
+type ProductServiceMockGetProductsExpectation struct {
+	mock               *ProductServiceMock
+	params             *ProductServiceMockGetProductsParams
+	paramPtrs          *ProductServiceMockGetProductsParamPtrs
+	expectationOrigins ProductServiceMockGetProductsExpectationOrigins
+	results            *ProductServiceMockGetProductsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ProductServiceMockGetProductsParams contains parameters of the ProductService.GetProducts
+type ProductServiceMockGetProductsParams struct {
+	ctx    context.Context
+	skuIds []int64
+}
+
+// ProductServiceMockGetProductsParamPtrs contains pointers to parameters of the ProductService.GetProducts
+type ProductServiceMockGetProductsParamPtrs struct {
+	ctx    *context.Context
+	skuIds *[]int64
+}
+
+// ProductServiceMockGetProductsResults contains results of the ProductService.GetProducts
+type ProductServiceMockGetProductsResults struct {
+	pa1 []model.ProductModel
+	err error
+}
+
+// ProductServiceMockGetProductsOrigins contains origins of expectations of the ProductService.GetProducts
+type ProductServiceMockGetProductsExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originSkuIds string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetProducts *mProductServiceMockGetProducts) Optional() *mProductServiceMockGetProducts {
+	mmGetProducts.optional = true
+	return mmGetProducts
+}
+
+// Expect sets up expected params for ProductService.GetProducts
+func (mmGetProducts *mProductServiceMockGetProducts) Expect(ctx context.Context, skuIds []int64) *mProductServiceMockGetProducts {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductServiceMock.GetProducts mock is already set by Set")
+	}
+
+	if mmGetProducts.defaultExpectation == nil {
+		mmGetProducts.defaultExpectation = &ProductServiceMockGetProductsExpectation{}
+	}
+
+	if mmGetProducts.defaultExpectation.paramPtrs != nil {
+		mmGetProducts.mock.t.Fatalf("ProductServiceMock.GetProducts mock is already set by ExpectParams functions")
+	}
+
+	mmGetProducts.defaultExpectation.params = &ProductServiceMockGetProductsParams{ctx, skuIds}
+	mmGetProducts.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetProducts.expectations {
+		if minimock.Equal(e.params, mmGetProducts.defaultExpectation.params) {
+			mmGetProducts.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetProducts.defaultExpectation.params)
+		}
+	}
+
+	return mmGetProducts
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ProductService.GetProducts
+func (mmGetProducts *mProductServiceMockGetProducts) ExpectCtxParam1(ctx context.Context) *mProductServiceMockGetProducts {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductServiceMock.GetProducts mock is already set by Set")
+	}
+
+	if mmGetProducts.defaultExpectation == nil {
+		mmGetProducts.defaultExpectation = &ProductServiceMockGetProductsExpectation{}
+	}
+
+	if mmGetProducts.defaultExpectation.params != nil {
+		mmGetProducts.mock.t.Fatalf("ProductServiceMock.GetProducts mock is already set by Expect")
+	}
+
+	if mmGetProducts.defaultExpectation.paramPtrs == nil {
+		mmGetProducts.defaultExpectation.paramPtrs = &ProductServiceMockGetProductsParamPtrs{}
+	}
+	mmGetProducts.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetProducts.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetProducts
+}
+
+// ExpectSkuIdsParam2 sets up expected param skuIds for ProductService.GetProducts
+func (mmGetProducts *mProductServiceMockGetProducts) ExpectSkuIdsParam2(skuIds []int64) *mProductServiceMockGetProducts {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductServiceMock.GetProducts mock is already set by Set")
+	}
+
+	if mmGetProducts.defaultExpectation == nil {
+		mmGetProducts.defaultExpectation = &ProductServiceMockGetProductsExpectation{}
+	}
+
+	if mmGetProducts.defaultExpectation.params != nil {
+		mmGetProducts.mock.t.Fatalf("ProductServiceMock.GetProducts mock is already set by Expect")
+	}
+
+	if mmGetProducts.defaultExpectation.paramPtrs == nil {
+		mmGetProducts.defaultExpectation.paramPtrs = &ProductServiceMockGetProductsParamPtrs{}
+	}
+	mmGetProducts.defaultExpectation.paramPtrs.skuIds = &skuIds
+	mmGetProducts.defaultExpectation.expectationOrigins.originSkuIds = minimock.CallerInfo(1)
+
+	return mmGetProducts
+}
+
+// Inspect accepts an inspector function that has same arguments as the ProductService.GetProducts
+func (mmGetProducts *mProductServiceMockGetProducts) Inspect(f func(ctx context.Context, skuIds []int64)) *mProductServiceMockGetProducts {
+	if mmGetProducts.mock.inspectFuncGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("Inspect function is already set for ProductServiceMock.GetProducts")
+	}
+
+	mmGetProducts.mock.inspectFuncGetProducts = f
+
+	return mmGetProducts
+}
+
+// Return sets up results that will be returned by ProductService.GetProducts
+func (mmGetProducts *mProductServiceMockGetProducts) Return(pa1 []model.ProductModel, err error) *ProductServiceMock {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductServiceMock.GetProducts mock is already set by Set")
+	}
+
+	if mmGetProducts.defaultExpectation == nil {
+		mmGetProducts.defaultExpectation = &ProductServiceMockGetProductsExpectation{mock: mmGetProducts.mock}
+	}
+	mmGetProducts.defaultExpectation.results = &ProductServiceMockGetProductsResults{pa1, err}
+	mmGetProducts.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetProducts.mock
+}
+
+// Set uses given function f to mock the ProductService.GetProducts method
+func (mmGetProducts *mProductServiceMockGetProducts) Set(f func(ctx context.Context, skuIds []int64) (pa1 []model.ProductModel, err error)) *ProductServiceMock {
+	if mmGetProducts.defaultExpectation != nil {
+		mmGetProducts.mock.t.Fatalf("Default expectation is already set for the ProductService.GetProducts method")
+	}
+
+	if len(mmGetProducts.expectations) > 0 {
+		mmGetProducts.mock.t.Fatalf("Some expectations are already set for the ProductService.GetProducts method")
+	}
+
+	mmGetProducts.mock.funcGetProducts = f
+	mmGetProducts.mock.funcGetProductsOrigin = minimock.CallerInfo(1)
+	return mmGetProducts.mock
+}
+
+// When sets expectation for the ProductService.GetProducts which will trigger the result defined by the following
+// Then helper
+func (mmGetProducts *mProductServiceMockGetProducts) When(ctx context.Context, skuIds []int64) *ProductServiceMockGetProductsExpectation {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductServiceMock.GetProducts mock is already set by Set")
+	}
+
+	expectation := &ProductServiceMockGetProductsExpectation{
+		mock:               mmGetProducts.mock,
+		params:             &ProductServiceMockGetProductsParams{ctx, skuIds},
+		expectationOrigins: ProductServiceMockGetProductsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetProducts.expectations = append(mmGetProducts.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ProductService.GetProducts return parameters for the expectation previously defined by the When method
+func (e *ProductServiceMockGetProductsExpectation) Then(pa1 []model.ProductModel, err error) *ProductServiceMock {
+	e.results = &ProductServiceMockGetProductsResults{pa1, err}
+	return e.mock
+}
+
+// Times sets number of times ProductService.GetProducts should be invoked
+func (mmGetProducts *mProductServiceMockGetProducts) Times(n uint64) *mProductServiceMockGetProducts {
+	if n == 0 {
+		mmGetProducts.mock.t.Fatalf("Times of ProductServiceMock.GetProducts mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetProducts.expectedInvocations, n)
+	mmGetProducts.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetProducts
+}
+
+func (mmGetProducts *mProductServiceMockGetProducts) invocationsDone() bool {
+	if len(mmGetProducts.expectations) == 0 && mmGetProducts.defaultExpectation == nil && mmGetProducts.mock.funcGetProducts == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetProducts.mock.afterGetProductsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetProducts.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetProducts implements ProductService
+func (mmGetProducts *ProductServiceMock) GetProducts(ctx context.Context, skuIds []int64) (pa1 []model.ProductModel, err error) {
+	mm_atomic.AddUint64(&mmGetProducts.beforeGetProductsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetProducts.afterGetProductsCounter, 1)
+
+	mmGetProducts.t.Helper()
+
+	if mmGetProducts.inspectFuncGetProducts != nil {
+		mmGetProducts.inspectFuncGetProducts(ctx, skuIds)
+	}
+
+	mm_params := ProductServiceMockGetProductsParams{ctx, skuIds}
+
+	// Record call args
+	mmGetProducts.GetProductsMock.mutex.Lock()
+	mmGetProducts.GetProductsMock.callArgs = append(mmGetProducts.GetProductsMock.callArgs, &mm_params)
+	mmGetProducts.GetProductsMock.mutex.Unlock()
+
+	for _, e := range mmGetProducts.GetProductsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.pa1, e.results.err
+		}
+	}
+
+	if mmGetProducts.GetProductsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetProducts.GetProductsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetProducts.GetProductsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetProducts.GetProductsMock.defaultExpectation.paramPtrs
+
+		mm_got := ProductServiceMockGetProductsParams{ctx, skuIds}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetProducts.t.Errorf("ProductServiceMock.GetProducts got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetProducts.GetProductsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.skuIds != nil && !minimock.Equal(*mm_want_ptrs.skuIds, mm_got.skuIds) {
+				mmGetProducts.t.Errorf("ProductServiceMock.GetProducts got unexpected parameter skuIds, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetProducts.GetProductsMock.defaultExpectation.expectationOrigins.originSkuIds, *mm_want_ptrs.skuIds, mm_got.skuIds, minimock.Diff(*mm_want_ptrs.skuIds, mm_got.skuIds))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetProducts.t.Errorf("ProductServiceMock.GetProducts got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetProducts.GetProductsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetProducts.GetProductsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetProducts.t.Fatal("No results are set for the ProductServiceMock.GetProducts")
+		}
+		return (*mm_results).pa1, (*mm_results).err
+	}
+	if mmGetProducts.funcGetProducts != nil {
+		return mmGetProducts.funcGetProducts(ctx, skuIds)
+	}
+	mmGetProducts.t.Fatalf("Unexpected call to ProductServiceMock.GetProducts. %v %v", ctx, skuIds)
+	return
+}
+
+// GetProductsAfterCounter returns a count of finished ProductServiceMock.GetProducts invocations
+func (mmGetProducts *ProductServiceMock) GetProductsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetProducts.afterGetProductsCounter)
+}
+
+// GetProductsBeforeCounter returns a count of ProductServiceMock.GetProducts invocations
+func (mmGetProducts *ProductServiceMock) GetProductsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetProducts.beforeGetProductsCounter)
+}
+
+// Calls returns a list of arguments used in each call to ProductServiceMock.GetProducts.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetProducts *mProductServiceMockGetProducts) Calls() []*ProductServiceMockGetProductsParams {
+	mmGetProducts.mutex.RLock()
+
+	argCopy := make([]*ProductServiceMockGetProductsParams, len(mmGetProducts.callArgs))
+	copy(argCopy, mmGetProducts.callArgs)
+
+	mmGetProducts.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetProductsDone returns true if the count of the GetProducts invocations corresponds
+// the number of defined expectations
+func (m *ProductServiceMock) MinimockGetProductsDone() bool {
+	if m.GetProductsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetProductsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetProductsMock.invocationsDone()
+}
+
+// MinimockGetProductsInspect logs each unmet expectation
+func (m *ProductServiceMock) MinimockGetProductsInspect() {
+	for _, e := range m.GetProductsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ProductServiceMock.GetProducts at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetProductsCounter := mm_atomic.LoadUint64(&m.afterGetProductsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetProductsMock.defaultExpectation != nil && afterGetProductsCounter < 1 {
+		if m.GetProductsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ProductServiceMock.GetProducts at\n%s", m.GetProductsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ProductServiceMock.GetProducts at\n%s with params: %#v", m.GetProductsMock.defaultExpectation.expectationOrigins.origin, *m.GetProductsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetProducts != nil && afterGetProductsCounter < 1 {
+		m.t.Errorf("Expected call to ProductServiceMock.GetProducts at\n%s", m.funcGetProductsOrigin)
+	}
+
+	if !m.GetProductsMock.invocationsDone() && afterGetProductsCounter > 0 {
+		m.t.Errorf("Expected %d calls to ProductServiceMock.GetProducts at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetProductsMock.expectedInvocations), m.GetProductsMock.expectedInvocationsOrigin, afterGetProductsCounter)
+	}
+}
+
 type mProductServiceMockGetProductsAot struct {
 	optional           bool
 	mock               *ProductServiceMock
@@ -1129,6 +1482,8 @@ func (m *ProductServiceMock) MinimockFinish() {
 		if !m.minimockDone() {
 			m.MinimockGetProductInspect()
 
+			m.MinimockGetProductsInspect()
+
 			m.MinimockGetProductsAotInspect()
 
 			m.MinimockIsProductExistsInspect()
@@ -1156,6 +1511,7 @@ func (m *ProductServiceMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockGetProductDone() &&
+		m.MinimockGetProductsDone() &&
 		m.MinimockGetProductsAotDone() &&
 		m.MinimockIsProductExistsDone()
 }
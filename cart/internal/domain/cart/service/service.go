@@ -3,10 +3,10 @@ package service
 import (
 	"context"
 	"fmt"
+	"math"
 	"route256/cart/internal/domain/model"
 	"route256/cart/internal/infra/logger"
-	"route256/cart/pkg/route_err_group"
-	"sync/atomic"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"go.opentelemetry.io/otel"
@@ -15,14 +15,26 @@ import (
 //go:generate minimock -i ProductService,CartRepository,StocksClient,OrdersClient -p service_test,service_test,service_test,service_test
 type CartRepository interface {
 	CreateItem(ctx context.Context, item *model.CartItemModel) (bool, error)
-	GetAllOrderBySku(ctx context.Context, userId int64) []model.CartItemModel
-	DeleteAll(ctx context.Context, userId int64)
-	DeleteBySku(ctx context.Context, userId int64, skuId int64)
+	GetAllOrderBySku(ctx context.Context, userId int64) ([]model.CartItemModel, error)
+	DeleteAll(ctx context.Context, userId int64) error
+	DeleteBySku(ctx context.Context, userId int64, skuId int64) error
+	SetItemCount(ctx context.Context, item *model.CartItemModel) error
+	ChangeItemCount(ctx context.Context, userId int64, skuId int64, delta int64, maxCount uint32) (uint32, error)
+	GetSnapshot(ctx context.Context, userId int64) (model.CartSnapshotModel, error)
+	Lock(ctx context.Context, userId int64, version int64, ttl time.Duration, checkout *model.CheckoutModel) error
+	Unlock(ctx context.Context, userId int64) error
+	DeleteSnapshot(ctx context.Context, snapshot model.CartSnapshotModel) error
+	GetCheckout(ctx context.Context, userId int64, idempotencyKey string) (model.CheckoutModel, bool, error)
+	SaveCheckout(ctx context.Context, checkout model.CheckoutModel) error
 }
 
+// checkoutLockTtl bounds how long the cart stays locked if the checkout dies halfway.
+const checkoutLockTtl = 30 * time.Second
+
 type ProductService interface {
 	IsProductExists(ctx context.Context, skuId int64) (bool, error)
 	GetProduct(ctx context.Context, skuId int64) (model.ProductModel, error)
+	GetProducts(ctx context.Context, skuIds []int64) ([]model.ProductModel, error)
 	GetProductsAot(ctx context.Context, count int64, startSkuId int64) ([]model.ProductModel, error)
 }
 
@@ -31,13 +43,14 @@ type StocksClient interface {
 }
 
 type OrdersClient interface {
-	CreateOrder(ctx context.Context, userId int64, items []model.CartItemModel) (int64, error)
+	CreateOrder(ctx context.Context, userId int64, items []model.CartItemModel, idempotencyKey string) (int64, error)
 }
 
 type CartService struct {
 	cartRepository CartRepository
 	productService ProductService
 	ordersClient   OrdersClient
+	stocksClient   StocksClient
 	validator      *validator.Validate
 }
 
@@ -45,11 +58,13 @@ func NewCartService(
 	cartRepository CartRepository,
 	productService ProductService,
 	orders OrdersClient,
+	stocks StocksClient,
 ) *CartService {
 	return &CartService{
 		cartRepository: cartRepository,
 		productService: productService,
 		ordersClient:   orders,
+		stocksClient:   stocks,
 		validator:      validator.New(validator.WithRequiredStructEnabled()),
 	}
 }
@@ -64,21 +79,154 @@ func (service *CartService) Create(ctx context.Context, cartItem *model.CartItem
 		return false, fmt.Errorf("failed to validate cart item %w", err)
 	}
 
-	exists, err := service.productService.IsProductExists(ctx, cartItem.SkuId)
+	if err := service.ensureProductExists(ctx, cartItem.SkuId); err != nil {
+		return false, err
+	}
+
+	inCart, err := service.countInCart(ctx, cartItem.UserId, cartItem.SkuId)
+	if err != nil {
+		return false, err
+	}
+
+	if err := service.ensureEnoughStock(ctx, cartItem.SkuId, uint64(inCart)+uint64(cartItem.Count)); err != nil {
+		return false, err
+	}
+
+	return service.cartRepository.CreateItem(ctx, cartItem)
+}
+
+// UpdateCount implements update_cart_item_handler.CartService.
+// Sets the absolute count of the sku in the cart, zero count removes the line.
+func (service *CartService) UpdateCount(ctx context.Context, userId int64, skuId int64, count uint32) error {
+	ctx, span := otel.Tracer("service").Start(ctx, "cart_service.UpdateCount")
+	defer span.End()
+
+	if count == 0 {
+		return service.DeleteBySkuId(ctx, userId, skuId)
+	}
+
+	var cartItem = &model.CartItemModel{UserId: userId, SkuId: skuId, Count: count}
+	if err := service.validator.Struct(cartItem); err != nil {
+		logger.Warn("Failed to validate cart item", "error", err)
+		return fmt.Errorf("failed to validate cart item %w", err)
+	}
+
+	if err := service.ensureProductExists(ctx, skuId); err != nil {
+		return err
+	}
+
+	inCart, err := service.countInCart(ctx, userId, skuId)
+	if err != nil {
+		return err
+	}
+
+	// lowering the count is always allowed, even if the stock dropped below it
+	if count > inCart {
+		if err := service.ensureEnoughStock(ctx, skuId, uint64(count)); err != nil {
+			return err
+		}
+	}
+
+	if err := service.cartRepository.SetItemCount(ctx, cartItem); err != nil {
+		return fmt.Errorf("updateCount: userId: %d, sku: %d, %w", userId, skuId, err)
+	}
+
+	return nil
+}
+
+// ChangeCount implements change_cart_item_handler.CartService.
+// Adds a signed delta to the count of the sku in the cart and returns the resulting count.
+// The delta is applied atomically by the repository, so concurrent changes do not overwrite each other.
+func (service *CartService) ChangeCount(ctx context.Context, userId int64, skuId int64, delta int64) (uint32, error) {
+	ctx, span := otel.Tracer("service").Start(ctx, "cart_service.ChangeCount")
+	defer span.End()
+
+	if userId <= 0 {
+		logger.Warn("ChangeCount failed, userId less than 0", "userId", userId)
+		return 0, fmt.Errorf("changeCount: userId: %d, %w", userId, model.ErrorUserIdLessThanZero)
+	}
+
+	if skuId <= 0 {
+		logger.Warn("ChangeCount failed, skuId less than 0", "skuId", skuId)
+		return 0, fmt.Errorf("changeCount: sku: %d, %w", skuId, model.ErrorSkuIdLessThanZero)
+	}
+
+	// only a growing count has to be backed by the stock
+	var maxCount uint32 = math.MaxUint32
+	if delta > 0 {
+		if err := service.ensureProductExists(ctx, skuId); err != nil {
+			return 0, err
+		}
+
+		available, err := service.stocksClient.StockInfo(ctx, skuId)
+		if err != nil {
+			logger.Warn("Failed to get stock info", "sku", skuId, "error", err)
+			return 0, fmt.Errorf("failed to get stock info %w", err)
+		}
+		maxCount = available
+	}
+
+	newCount, err := service.cartRepository.ChangeItemCount(ctx, userId, skuId, delta, maxCount)
+	if err != nil {
+		return 0, fmt.Errorf("changeCount: userId: %d, sku %d, delta %d, %w", userId, skuId, delta, err)
+	}
+
+	return newCount, nil
+}
+
+func (service *CartService) ensureProductExists(ctx context.Context, skuId int64) error {
+	exists, err := service.productService.IsProductExists(ctx, skuId)
 	if err != nil {
 		logger.Warn("Failed to check if product exists", "error", err)
-		return false, fmt.Errorf("failed to check if product exists %w", err)
+		return fmt.Errorf("failed to check if product exists %w", err)
 	}
 
 	if !exists {
-		return false, model.ErrProductDoesNotExist
+		return model.ErrProductDoesNotExist
 	}
 
-	return service.cartRepository.CreateItem(ctx, cartItem)
+	return nil
+}
+
+func (service *CartService) countInCart(ctx context.Context, userId int64, skuId int64) (uint32, error) {
+	items, err := service.cartRepository.GetAllOrderBySku(ctx, userId)
+	if err != nil {
+		logger.Warn("Failed to get cart items", "userId", userId, "error", err)
+		return 0, fmt.Errorf("failed to get cart items %w", err)
+	}
+
+	for _, item := range items {
+		if item.SkuId == skuId {
+			return item.Count, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// ensureEnoughStock checks that loms has at least the required count of the sku.
+func (service *CartService) ensureEnoughStock(ctx context.Context, skuId int64, required uint64) error {
+	available, err := service.stocksClient.StockInfo(ctx, skuId)
+	if err != nil {
+		logger.Warn("Failed to get stock info", "sku", skuId, "error", err)
+		return fmt.Errorf("failed to get stock info %w", err)
+	}
+
+	if required > uint64(available) {
+		logger.Debug("Not enough items in stock", "sku", skuId, "available", available, "required", required)
+		return fmt.Errorf("sku %d, available %d, requested %d: %w",
+			skuId, available, required, model.ErrNotEnoughItemsInStock)
+	}
+
+	return nil
 }
 
 // Checkout implements checkout_handler.CartService.
-func (service *CartService) Checkout(ctx context.Context, userId int64) (int64, error) {
+// Retries with the same idempotency key get the originally created order and leave the cart as is,
+// a retry with another expected version fails with ErrIdempotencyKeyReused.
+// The cart is locked while the order is created and only the snapshotted lines are removed afterwards.
+// Non zero expectedVersion must match the current cart version, otherwise ErrCartChanged is returned.
+func (service *CartService) Checkout(ctx context.Context, userId int64, idempotencyKey string, expectedVersion int64) (int64, error) {
 	ctx, span := otel.Tracer("service").Start(ctx, "cart_service.Checkout")
 	defer span.End()
 
@@ -87,22 +235,121 @@ func (service *CartService) Checkout(ctx context.Context, userId int64) (int64,
 		return 0, fmt.Errorf("checkout: userId: %d, %w", userId, model.ErrorUserIdLessThanZero)
 	}
 
-	items := service.cartRepository.GetAllOrderBySku(ctx, userId)
-	if len(items) == 0 {
+	if idempotencyKey != "" {
+		orderId, replayed, err := service.replayCheckout(ctx, userId, idempotencyKey, expectedVersion)
+		if err != nil || replayed {
+			return orderId, err
+		}
+	}
+
+	snapshot, err := service.cartRepository.GetSnapshot(ctx, userId)
+	if err != nil {
+		logger.Warn("Checkout failed, unable to get cart items", "userId", userId, "error", err)
+		return 0, fmt.Errorf("checkout: failed to get cart items for user %d, %w", userId, err)
+	}
+
+	if len(snapshot.Items) == 0 {
 		logger.Debug("Checkout failed, cart is empty", "userId", userId)
 		return 0, fmt.Errorf("checkout: %w", model.ErrTheCartIsEmpty)
 	}
 
-	orderId, err := service.ordersClient.CreateOrder(ctx, userId, items)
+	if expectedVersion != 0 && expectedVersion != snapshot.Version {
+		logger.Debug("Checkout failed, cart version mismatch", "userId", userId,
+			"expected", expectedVersion, "actual", snapshot.Version)
+		return 0, fmt.Errorf("checkout: expected version %d, actual %d, %w", expectedVersion, snapshot.Version, model.ErrCartChanged)
+	}
+
+	var pendingCheckout *model.CheckoutModel
+	if idempotencyKey != "" {
+		pendingCheckout = &model.CheckoutModel{
+			UserId:          userId,
+			IdempotencyKey:  idempotencyKey,
+			ExpectedVersion: expectedVersion,
+			Items:           snapshot.Items,
+		}
+	}
+
+	if err := service.cartRepository.Lock(ctx, userId, snapshot.Version, checkoutLockTtl, pendingCheckout); err != nil {
+		logger.Warn("Checkout failed, unable to lock the cart", "userId", userId, "error", err)
+		return 0, fmt.Errorf("checkout: failed to lock the cart for user %d, %w", userId, err)
+	}
+
+	orderId, err := service.ordersClient.CreateOrder(ctx, userId, snapshot.Items, idempotencyKey)
 	if err != nil {
 		logger.Warn("Checkout failed, create order failed", "userId", userId, "error", err)
+		// the client may be gone already, the cart has to be unlocked regardless
+		if err := service.cartRepository.Unlock(context.WithoutCancel(ctx), userId); err != nil {
+			logger.Error("Checkout failed, unable to unlock the cart", "userId", userId, "error", err)
+		}
+
 		return 0, fmt.Errorf("checkout: create order failed for user %d, %w", userId, err)
 	}
 
-	service.cartRepository.DeleteAll(ctx, userId)
+	service.completeCheckout(ctx, model.CheckoutModel{
+		UserId:          userId,
+		IdempotencyKey:  idempotencyKey,
+		ExpectedVersion: expectedVersion,
+		OrderId:         orderId,
+	}, snapshot)
+
 	return orderId, nil
 }
 
+// replayCheckout looks up the order created by the previous checkout with the idempotency key.
+// The key is resolved before the cart lock, so a retry of a checkout in progress is not rejected as a conflict.
+func (service *CartService) replayCheckout(ctx context.Context, userId int64, idempotencyKey string,
+	expectedVersion int64) (int64, bool, error) {
+	checkout, found, err := service.cartRepository.GetCheckout(ctx, userId, idempotencyKey)
+	if err != nil {
+		logger.Warn("Checkout failed, unable to get previous checkout", "userId", userId, "error", err)
+		return 0, false, fmt.Errorf("checkout: failed to get previous checkout for user %d, %w", userId, err)
+	}
+
+	if !found {
+		return 0, false, nil
+	}
+
+	if checkout.ExpectedVersion != expectedVersion {
+		logger.Debug("Checkout failed, idempotency key reused", "userId", userId,
+			"expected", expectedVersion, "original", checkout.ExpectedVersion)
+		return 0, false, fmt.Errorf("checkout: key %s, %w", idempotencyKey, model.ErrIdempotencyKeyReused)
+	}
+
+	if checkout.OrderId != 0 {
+		logger.Debug("Checkout replayed", "userId", userId, "orderId", checkout.OrderId)
+		return checkout.OrderId, true, nil
+	}
+
+	// the original checkout is still in progress, loms answers with its order for the same key
+	orderId, err := service.ordersClient.CreateOrder(ctx, userId, checkout.Items, idempotencyKey)
+	if err != nil {
+		logger.Warn("Checkout replay failed, create order failed", "userId", userId, "error", err)
+		return 0, false, fmt.Errorf("checkout: create order failed for user %d, %w", userId, err)
+	}
+
+	logger.Debug("Checkout replayed while in progress", "userId", userId, "orderId", orderId)
+	checkout.OrderId = orderId
+	service.completeCheckout(ctx, checkout, model.CartSnapshotModel{UserId: userId, Items: checkout.Items})
+
+	return orderId, true, nil
+}
+
+// completeCheckout saves the created order for the idempotency key and clears the checked out lines, unlocking the cart.
+// The order is already created at this point, so failing the checkout would only provoke a retry.
+func (service *CartService) completeCheckout(ctx context.Context, checkout model.CheckoutModel, snapshot model.CartSnapshotModel) {
+	if checkout.IdempotencyKey != "" {
+		if err := service.cartRepository.SaveCheckout(ctx, checkout); err != nil {
+			logger.Error("Checkout succeeded, but failed to save the checkout", "userId", checkout.UserId,
+				"orderId", checkout.OrderId, "error", err)
+		}
+	}
+
+	if err := service.cartRepository.DeleteSnapshot(ctx, snapshot); err != nil {
+		logger.Error("Checkout succeeded, but failed to clear the cart", "userId", checkout.UserId,
+			"orderId", checkout.OrderId, "error", err)
+	}
+}
+
 // DeleteAll implements delete_cart_handler.CartService.
 func (service *CartService) DeleteAll(ctx context.Context, userId int64) error {
 	ctx, span := otel.Tracer("service").Start(ctx, "cart_service.DeleteAll")
@@ -113,7 +360,10 @@ func (service *CartService) DeleteAll(ctx context.Context, userId int64) error {
 		return fmt.Errorf("deleteAll: userId %d, %w", userId, model.ErrorUserIdLessThanZero)
 	}
 
-	service.cartRepository.DeleteAll(ctx, userId)
+	if err := service.cartRepository.DeleteAll(ctx, userId); err != nil {
+		return fmt.Errorf("deleteAll: userId %d, %w", userId, err)
+	}
+
 	return nil
 }
 
@@ -132,7 +382,10 @@ func (service *CartService) DeleteBySkuId(ctx context.Context, userId int64, sku
 		return fmt.Errorf("deleteBySku: sku: %d, %w", userId, model.ErrorSkuIdLessThanZero)
 	}
 
-	service.cartRepository.DeleteBySku(ctx, userId, skuId)
+	if err := service.cartRepository.DeleteBySku(ctx, userId, skuId); err != nil {
+		return fmt.Errorf("deleteBySku: userId: %d, sku: %d, %w", userId, skuId, err)
+	}
+
 	return nil
 }
 
@@ -145,46 +398,51 @@ func (service *CartService) GetAllOrderBySku(ctx context.Context, userId int64)
 		return model.AllCartItemsModel{}, fmt.Errorf("getAllOrderBySku: userId %d, %w", userId, model.ErrorUserIdLessThanZero)
 	}
 
-	var items = service.cartRepository.GetAllOrderBySku(ctx, userId)
-	if len(items) == 0 {
+	snapshot, err := service.cartRepository.GetSnapshot(ctx, userId)
+	if err != nil {
+		logger.Warn("GetAllOrderBySku failed, unable to get cart items", "userId", userId, "error", err)
+		return model.AllCartItemsModel{}, fmt.Errorf("getAllOrderBySku: userId %d, %w", userId, err)
+	}
+
+	if len(snapshot.Items) == 0 {
 		logger.Debug("GetAllOrderBySku failed, cart is empty", "userId", userId)
 		return model.AllCartItemsModel{}, fmt.Errorf("getAllOrderBySku: %w", model.ErrTheCartIsEmpty)
 	}
 
-	return service.enrichCartProducts(ctx, items)
+	allItems, err := service.enrichCartProducts(ctx, snapshot.Items)
+	if err != nil {
+		return model.AllCartItemsModel{}, err
+	}
+
+	allItems.Version = snapshot.Version
+	return allItems, nil
 }
 
-// We need to speak with the products team to provide a better API, ugly n+1
 func (service *CartService) enrichCartProducts(ctx context.Context, items []model.CartItemModel) (model.AllCartItemsModel, error) {
-	var totalPrice atomic.Uint32
-	var promiseGroup = route_err_group.NewRouteErrorGroup[model.EnrichedCartItemModel](ctx,
-		route_err_group.Options{Rps: 10, BufferSize: len(items)})
-
-	for cartItemIndex := range items {
-		promiseGroup.Run(func(ctx context.Context) (model.EnrichedCartItemModel, error) {
-			var cartProductSku = items[cartItemIndex].SkuId
-			product, err := service.productService.GetProduct(ctx, cartProductSku)
-			if err != nil {
-				return model.EnrichedCartItemModel{}, fmt.Errorf("product not found: %w", err)
-			}
-
-			totalPrice.Add(product.Price * items[cartItemIndex].Count)
-			return model.EnrichedCartItemModel{
-				SkuId: items[cartItemIndex].SkuId,
-				Count: items[cartItemIndex].Count,
-				Name:  product.Name,
-				Price: product.Price,
-			}, nil
-		})
-	}
-
-	allItems, err := promiseGroup.Await()
+	var skuIds = make([]int64, len(items))
+	for i, item := range items {
+		skuIds[i] = item.SkuId
+	}
+
+	products, err := service.productService.GetProducts(ctx, skuIds)
 	if err != nil {
-		return model.AllCartItemsModel{}, fmt.Errorf("error found while awaiting a products fetch, %w", err)
+		return model.AllCartItemsModel{}, fmt.Errorf("error found while fetching cart products, %w", err)
+	}
+
+	var totalPrice uint32
+	var allItems = make([]model.EnrichedCartItemModel, len(items))
+	for i, product := range products {
+		totalPrice += product.Price * items[i].Count
+		allItems[i] = model.EnrichedCartItemModel{
+			SkuId: items[i].SkuId,
+			Count: items[i].Count,
+			Name:  product.Name,
+			Price: product.Price,
+		}
 	}
 
 	return model.AllCartItemsModel{
 		Items: allItems,
-		Total: totalPrice.Load(),
+		Total: totalPrice,
 	}, nil
 }
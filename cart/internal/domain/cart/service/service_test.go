@@ -3,10 +3,12 @@ package service_test
 import (
 	"context"
 	"errors"
+	"math"
 	"reflect"
 	"route256/cart/internal/domain/cart/service"
 	"route256/cart/internal/domain/model"
 	"testing"
+	"time"
 
 	"github.com/gojuno/minimock/v3"
 )
@@ -18,33 +20,87 @@ func TestCartService_Create(t *testing.T) {
 		cartRepository service.CartRepository
 		productService service.ProductService
 		ordersClient   service.OrdersClient
+		stocksClient   service.StocksClient
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		model   *model.CartItemModel
-		want    bool
-		wantErr bool
+		name      string
+		fields    fields
+		model     *model.CartItemModel
+		want      bool
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "should add new item and call repository",
 			fields: fields{
 				cartRepository: NewCartRepositoryMock(mc).
+					GetAllOrderBySkuMock.Return(nil, nil).
 					CreateItemMock.Return(true, nil),
 				productService: NewProductServiceMock(mc).
 					IsProductExistsMock.Return(true, nil),
 				ordersClient: NewOrdersClientMock(t),
+				stocksClient: NewStocksClientMock(mc).
+					StockInfoMock.Return(1, nil),
 			},
 			model:   &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1},
 			want:    true,
 			wantErr: false,
 		},
+		{
+			name: "should return error if stock is not enough for the cart and the new item",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					GetAllOrderBySkuMock.Return([]model.CartItemModel{
+					{UserId: 1, SkuId: 1, Count: 2}}, nil),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(true, nil),
+				ordersClient: NewOrdersClientMock(t),
+				stocksClient: NewStocksClientMock(mc).
+					StockInfoMock.Return(3, nil),
+			},
+			model:     &model.CartItemModel{UserId: 1, SkuId: 1, Count: 2},
+			want:      false,
+			wantErr:   true,
+			wantErrIs: model.ErrNotEnoughItemsInStock,
+		},
+		{
+			name: "should return error if sku has no stock in loms",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					GetAllOrderBySkuMock.Return(nil, nil),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(true, nil),
+				ordersClient: NewOrdersClientMock(t),
+				stocksClient: NewStocksClientMock(mc).
+					StockInfoMock.Return(0, nil),
+			},
+			model:     &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1},
+			want:      false,
+			wantErr:   true,
+			wantErrIs: model.ErrNotEnoughItemsInStock,
+		},
+		{
+			name: "should return error from stocks client",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					GetAllOrderBySkuMock.Return(nil, nil),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(true, nil),
+				ordersClient: NewOrdersClientMock(t),
+				stocksClient: NewStocksClientMock(mc).
+					StockInfoMock.Return(0, errors.New("some error")),
+			},
+			model:   &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1},
+			want:    false,
+			wantErr: true,
+		},
 		{
 			name: "should return error if model is not valid",
 			fields: fields{
 				cartRepository: NewCartRepositoryMock(mc),
 				productService: NewProductServiceMock(mc),
 				ordersClient:   NewOrdersClientMock(t),
+				stocksClient:   NewStocksClientMock(mc),
 			},
 			model:   &model.CartItemModel{UserId: 1, SkuId: -1, Count: 1},
 			want:    false,
@@ -57,6 +113,7 @@ func TestCartService_Create(t *testing.T) {
 				productService: NewProductServiceMock(mc).
 					IsProductExistsMock.Return(false, nil),
 				ordersClient: NewOrdersClientMock(t),
+				stocksClient: NewStocksClientMock(mc),
 			},
 			model:   &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1},
 			want:    false,
@@ -69,6 +126,7 @@ func TestCartService_Create(t *testing.T) {
 				productService: NewProductServiceMock(mc).
 					IsProductExistsMock.Return(false, errors.New("some error")),
 				ordersClient: NewOrdersClientMock(t),
+				stocksClient: NewStocksClientMock(mc),
 			},
 			model:   &model.CartItemModel{UserId: 1, SkuId: 1, Count: 1},
 			want:    false,
@@ -80,13 +138,17 @@ func TestCartService_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			service := service.NewCartService(tt.fields.cartRepository, tt.fields.productService,
-				tt.fields.ordersClient)
+				tt.fields.ordersClient, tt.fields.stocksClient)
 			got, err := service.Create(context.Background(), tt.model)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CartService.Create() = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("CartService.Create() = %v, wantErr %v", err, tt.wantErrIs)
+				return
+			}
 			if got != tt.want {
 				t.Errorf("CartService.Create() = %v, want %v", got, tt.want)
 			}
@@ -94,6 +156,191 @@ func TestCartService_Create(t *testing.T) {
 	}
 }
 
+func TestCartService_UpdateCount(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+	type fields struct {
+		cartRepository service.CartRepository
+		productService service.ProductService
+		stocksClient   service.StocksClient
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		count   uint32
+		wantErr error
+	}{
+		{
+			name: "should set absolute count",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					GetAllOrderBySkuMock.Return(nil, nil).
+					SetItemCountMock.Expect(minimock.AnyContext, &model.CartItemModel{UserId: 1, SkuId: 1, Count: 3}).
+					Return(nil),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(true, nil),
+				stocksClient: NewStocksClientMock(mc).
+					StockInfoMock.Return(3, nil),
+			},
+			count: 3,
+		},
+		{
+			name: "should remove line on zero count",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					DeleteBySkuMock.Expect(minimock.AnyContext, 1, 1).Return(nil),
+				productService: NewProductServiceMock(mc),
+				stocksClient:   NewStocksClientMock(mc),
+			},
+			count: 0,
+		},
+		{
+			name: "should fail if product does not exist",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(false, nil),
+				stocksClient: NewStocksClientMock(mc),
+			},
+			count:   3,
+			wantErr: model.ErrProductDoesNotExist,
+		},
+		{
+			name: "should fail if not enough items in stock",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					GetAllOrderBySkuMock.Return([]model.CartItemModel{{UserId: 1, SkuId: 1, Count: 1}}, nil),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(true, nil),
+				stocksClient: NewStocksClientMock(mc).
+					StockInfoMock.Return(2, nil),
+			},
+			count:   3,
+			wantErr: model.ErrNotEnoughItemsInStock,
+		},
+		{
+			name: "should lower count without checking stock",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					GetAllOrderBySkuMock.Return([]model.CartItemModel{{UserId: 1, SkuId: 1, Count: 5}}, nil).
+					SetItemCountMock.Expect(minimock.AnyContext, &model.CartItemModel{UserId: 1, SkuId: 1, Count: 3}).
+					Return(nil),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(true, nil),
+				stocksClient: NewStocksClientMock(mc),
+			},
+			count: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			service := service.NewCartService(tt.fields.cartRepository, tt.fields.productService,
+				NewOrdersClientMock(t), tt.fields.stocksClient)
+			err := service.UpdateCount(context.Background(), 1, 1, tt.count)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CartService.UpdateCount() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCartService_ChangeCount(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+	type fields struct {
+		cartRepository service.CartRepository
+		productService service.ProductService
+		stocksClient   service.StocksClient
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		delta   int64
+		want    uint32
+		wantErr error
+	}{
+		{
+			name: "should add positive delta bounded by the stock",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					ChangeItemCountMock.Expect(minimock.AnyContext, 1, 1, 3, 10).Return(5, nil),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(true, nil),
+				stocksClient: NewStocksClientMock(mc).
+					StockInfoMock.Return(10, nil),
+			},
+			delta: 3,
+			want:  5,
+		},
+		{
+			name: "should apply negative delta without checking stock",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					ChangeItemCountMock.Expect(minimock.AnyContext, 1, 1, -2, math.MaxUint32).Return(0, nil),
+				productService: NewProductServiceMock(mc),
+				stocksClient:   NewStocksClientMock(mc),
+			},
+			delta: -2,
+			want:  0,
+		},
+		{
+			name: "should fail if product does not exist",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(false, nil),
+				stocksClient: NewStocksClientMock(mc),
+			},
+			delta:   1,
+			wantErr: model.ErrProductDoesNotExist,
+		},
+		{
+			name: "should fail when delta brings count below zero",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					ChangeItemCountMock.Return(0, model.ErrNegativeItemCount),
+				productService: NewProductServiceMock(mc),
+				stocksClient:   NewStocksClientMock(mc),
+			},
+			delta:   -3,
+			wantErr: model.ErrNegativeItemCount,
+		},
+		{
+			name: "should fail when not enough items in stock",
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(mc).
+					ChangeItemCountMock.Return(0, model.ErrNotEnoughItemsInStock),
+				productService: NewProductServiceMock(mc).
+					IsProductExistsMock.Return(true, nil),
+				stocksClient: NewStocksClientMock(mc).
+					StockInfoMock.Return(2, nil),
+			},
+			delta:   3,
+			wantErr: model.ErrNotEnoughItemsInStock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			service := service.NewCartService(tt.fields.cartRepository, tt.fields.productService,
+				NewOrdersClientMock(t), tt.fields.stocksClient)
+			got, err := service.ChangeCount(context.Background(), 1, 1, tt.delta)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CartService.ChangeCount() = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("CartService.ChangeCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCartService_DeleteAll(t *testing.T) {
 	t.Parallel()
 	mc := minimock.NewController(t)
@@ -101,6 +348,7 @@ func TestCartService_DeleteAll(t *testing.T) {
 		cartRepository service.CartRepository
 		productService service.ProductService
 		ordersClient   service.OrdersClient
+		stocksClient   service.StocksClient
 	}
 	tests := []struct {
 		name    string
@@ -113,9 +361,10 @@ func TestCartService_DeleteAll(t *testing.T) {
 			userId: 1,
 			fields: fields{
 				cartRepository: NewCartRepositoryMock(mc).
-					DeleteAllMock.Return(),
+					DeleteAllMock.Return(nil),
 				productService: NewProductServiceMock(mc),
 				ordersClient:   NewOrdersClientMock(t),
+				stocksClient:   NewStocksClientMock(mc),
 			},
 			wantErr: false,
 		},
@@ -126,6 +375,7 @@ func TestCartService_DeleteAll(t *testing.T) {
 				cartRepository: NewCartRepositoryMock(mc),
 				productService: NewProductServiceMock(mc),
 				ordersClient:   NewOrdersClientMock(t),
+				stocksClient:   NewStocksClientMock(mc),
 			},
 			wantErr: true,
 		},
@@ -134,7 +384,7 @@ func TestCartService_DeleteAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			service := service.NewCartService(tt.fields.cartRepository, tt.fields.productService,
-				tt.fields.ordersClient)
+				tt.fields.ordersClient, tt.fields.stocksClient)
 
 			if err := service.DeleteAll(context.Background(), tt.userId); (err != nil) != tt.wantErr {
 				t.Errorf("CartService.DeleteAll() error = %v, wantErr %v", err, tt.wantErr)
@@ -166,7 +416,7 @@ func TestCartService_DeleteBySkuId(t *testing.T) {
 			args: args{userId: 1, skuId: 1},
 			fields: fields{
 				cartRepository: NewCartRepositoryMock(t).
-					DeleteBySkuMock.Return(),
+					DeleteBySkuMock.Return(nil),
 				productService: NewProductServiceMock(t),
 				ordersClient:   NewOrdersClientMock(t),
 				stocksClient:   NewStocksClientMock(t),
@@ -201,7 +451,7 @@ func TestCartService_DeleteBySkuId(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			service := service.NewCartService(tt.fields.cartRepository, tt.fields.productService,
-				tt.fields.ordersClient)
+				tt.fields.ordersClient, tt.fields.stocksClient)
 			if err := service.DeleteBySkuId(context.Background(), tt.args.userId, tt.args.skuId); (err != nil) != tt.wantErr {
 				t.Errorf("CartService.DeleteBySkuId() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -229,24 +479,16 @@ func TestCartService_GetAllOrderBySku(t *testing.T) {
 			userId: 1,
 			fields: fields{
 				cartRepository: NewCartRepositoryMock(t).
-					GetAllOrderBySkuMock.Return([]model.CartItemModel{
+					GetSnapshotMock.Return(model.CartSnapshotModel{UserId: 1, Version: 4, Items: []model.CartItemModel{
 					{UserId: 1, SkuId: 1, Count: 1},
 					{UserId: 1, SkuId: 2, Count: 1},
 					{UserId: 1, SkuId: 3, Count: 1},
-				}),
+				}}, nil),
 				productService: NewProductServiceMock(t).
-					GetProductMock.When(minimock.AnyContext, 1).Then(model.ProductModel{
-					Name:  "product1",
-					SkuId: 1,
-					Price: 100,
-				}, nil).GetProductMock.When(minimock.AnyContext, 2).Then(model.ProductModel{
-					Name:  "product2",
-					SkuId: 2,
-					Price: 200,
-				}, nil).GetProductMock.When(minimock.AnyContext, 3).Then(model.ProductModel{
-					Name:  "product3",
-					SkuId: 3,
-					Price: 300,
+					GetProductsMock.When(minimock.AnyContext, []int64{1, 2, 3}).Then([]model.ProductModel{
+					{Name: "product1", SkuId: 1, Price: 100},
+					{Name: "product2", SkuId: 2, Price: 200},
+					{Name: "product3", SkuId: 3, Price: 300},
 				}, nil),
 				orderClient:  NewOrdersClientMock(t),
 				stocksClient: NewStocksClientMock(t),
@@ -257,7 +499,8 @@ func TestCartService_GetAllOrderBySku(t *testing.T) {
 					{SkuId: 2, Count: 1, Name: "product2", Price: 200},
 					{SkuId: 3, Count: 1, Name: "product3", Price: 300},
 				},
-				Total: 600,
+				Total:   600,
+				Version: 4,
 			},
 			wantErr: false,
 		},
@@ -278,7 +521,7 @@ func TestCartService_GetAllOrderBySku(t *testing.T) {
 			userId: 1,
 			fields: fields{
 				cartRepository: NewCartRepositoryMock(t).
-					GetAllOrderBySkuMock.Return([]model.CartItemModel{}),
+					GetSnapshotMock.Return(model.CartSnapshotModel{UserId: 1, Version: 1}, nil),
 				productService: NewProductServiceMock(t),
 				orderClient:    NewOrdersClientMock(t),
 				stocksClient:   NewStocksClientMock(t),
@@ -291,11 +534,11 @@ func TestCartService_GetAllOrderBySku(t *testing.T) {
 			userId: 1,
 			fields: fields{
 				cartRepository: NewCartRepositoryMock(t).
-					GetAllOrderBySkuMock.Return([]model.CartItemModel{
+					GetSnapshotMock.Return(model.CartSnapshotModel{UserId: 1, Version: 1, Items: []model.CartItemModel{
 					{UserId: 1, SkuId: 1, Count: 1},
-				}),
+				}}, nil),
 				productService: NewProductServiceMock(t).
-					GetProductMock.Return(model.ProductModel{}, errors.New("get product error")),
+					GetProductsMock.Return(nil, errors.New("get products error")),
 				orderClient:  NewOrdersClientMock(t),
 				stocksClient: NewStocksClientMock(t),
 			},
@@ -308,7 +551,7 @@ func TestCartService_GetAllOrderBySku(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			service := service.NewCartService(tt.fields.cartRepository, tt.fields.productService,
-				tt.fields.orderClient)
+				tt.fields.orderClient, tt.fields.stocksClient)
 
 			got, err := service.GetAllOrderBySku(context.Background(), tt.userId)
 			if (err != nil) != tt.wantErr {
@@ -328,29 +571,122 @@ func TestCartService_Checkout(t *testing.T) {
 		cartRepository service.CartRepository
 		productService service.ProductService
 		orderClient    service.OrdersClient
+		stocksClient   service.StocksClient
 	}
+	var snapshot = model.CartSnapshotModel{UserId: 1, Version: 7, Items: []model.CartItemModel{
+		{UserId: 1, SkuId: 1, Count: 1},
+	}}
 	tests := []struct {
-		name    string
-		fields  fields
-		userId  int64
-		get     int64
-		wantErr bool
+		name            string
+		fields          fields
+		userId          int64
+		expectedVersion int64
+		get             int64
+		wantErr         error
 	}{
 		{
 			name:   "should successfully checkout by userId",
 			userId: 1,
 			fields: fields{
 				cartRepository: NewCartRepositoryMock(t).
-					GetAllOrderBySkuMock.Return([]model.CartItemModel{
-					{UserId: 1, SkuId: 1, Count: 1}}).
-					DeleteAllMock.Return(),
+					GetCheckoutMock.Return(model.CheckoutModel{}, false, nil).
+					GetSnapshotMock.Return(snapshot, nil).
+					LockMock.Return(nil).
+					SaveCheckoutMock.Expect(minimock.AnyContext, model.CheckoutModel{
+					UserId: 1, IdempotencyKey: "checkout-key", OrderId: 321,
+				}).Return(nil).
+					DeleteSnapshotMock.When(minimock.AnyContext, snapshot).Then(nil),
 				productService: NewProductServiceMock(t),
 				orderClient: NewOrdersClientMock(t).
-					CreateOrderMock.When(minimock.AnyContext, 1, []model.CartItemModel{
-					{UserId: 1, SkuId: 1, Count: 1}}).Then(321, nil),
+					CreateOrderMock.When(minimock.AnyContext, 1, snapshot.Items, "checkout-key").Then(321, nil),
+				stocksClient: NewStocksClientMock(t),
 			},
-			get:     321,
-			wantErr: false,
+			get: 321,
+		},
+		{
+			name:            "should successfully checkout if the expected version matches",
+			userId:          1,
+			expectedVersion: 7,
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(t).
+					GetCheckoutMock.Return(model.CheckoutModel{}, false, nil).
+					GetSnapshotMock.Return(snapshot, nil).
+					LockMock.Return(nil).
+					SaveCheckoutMock.Return(nil).
+					DeleteSnapshotMock.Return(nil),
+				productService: NewProductServiceMock(t),
+				orderClient: NewOrdersClientMock(t).
+					CreateOrderMock.Return(321, nil),
+				stocksClient: NewStocksClientMock(t),
+			},
+			get: 321,
+		},
+		{
+			name:   "should return order id even if failed to clear the cart",
+			userId: 1,
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(t).
+					GetCheckoutMock.Return(model.CheckoutModel{}, false, nil).
+					GetSnapshotMock.Return(snapshot, nil).
+					LockMock.Return(nil).
+					SaveCheckoutMock.Return(nil).
+					DeleteSnapshotMock.Return(errors.New("delete error")),
+				productService: NewProductServiceMock(t),
+				orderClient: NewOrdersClientMock(t).
+					CreateOrderMock.Return(321, nil),
+				stocksClient: NewStocksClientMock(t),
+			},
+			get: 321,
+		},
+		{
+			name:   "should replay checkout after success and leave the cart as is",
+			userId: 1,
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(t).
+					GetCheckoutMock.When(minimock.AnyContext, 1, "checkout-key").Then(model.CheckoutModel{
+					UserId: 1, IdempotencyKey: "checkout-key", OrderId: 321,
+				}, true, nil),
+				productService: NewProductServiceMock(t),
+				orderClient:    NewOrdersClientMock(t),
+				stocksClient:   NewStocksClientMock(t),
+			},
+			get: 321,
+		},
+		{
+			name:   "should replay checkout in progress with the pending items",
+			userId: 1,
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(t).
+					GetCheckoutMock.Return(model.CheckoutModel{
+					UserId: 1, IdempotencyKey: "checkout-key", Items: snapshot.Items,
+				}, true, nil).
+					SaveCheckoutMock.Expect(minimock.AnyContext, model.CheckoutModel{
+					UserId: 1, IdempotencyKey: "checkout-key", OrderId: 321, Items: snapshot.Items,
+				}).Return(nil).
+					DeleteSnapshotMock.Expect(minimock.AnyContext, model.CartSnapshotModel{
+					UserId: 1, Items: snapshot.Items,
+				}).Return(nil),
+				productService: NewProductServiceMock(t),
+				orderClient: NewOrdersClientMock(t).
+					CreateOrderMock.When(minimock.AnyContext, 1, snapshot.Items, "checkout-key").Then(321, nil),
+				stocksClient: NewStocksClientMock(t),
+			},
+			get: 321,
+		},
+		{
+			name:            "should reject replay with another expected version",
+			userId:          1,
+			expectedVersion: 7,
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(t).
+					GetCheckoutMock.Return(model.CheckoutModel{
+					UserId: 1, IdempotencyKey: "checkout-key", OrderId: 321,
+				}, true, nil),
+				productService: NewProductServiceMock(t),
+				orderClient:    NewOrdersClientMock(t),
+				stocksClient:   NewStocksClientMock(t),
+			},
+			wantErr: model.ErrIdempotencyKeyReused,
 		},
 		{
 			name:   "should return error if userId is invalid",
@@ -359,23 +695,68 @@ func TestCartService_Checkout(t *testing.T) {
 				cartRepository: NewCartRepositoryMock(t),
 				productService: NewProductServiceMock(t),
 				orderClient:    NewOrdersClientMock(t),
+				stocksClient:   NewStocksClientMock(t),
 			},
-			get:     0,
-			wantErr: true,
+			wantErr: model.ErrorUserIdLessThanZero,
 		},
 		{
-			name:   "should return error if failed to create order",
+			name:   "should return error if the cart is empty",
 			userId: 1,
 			fields: fields{
 				cartRepository: NewCartRepositoryMock(t).
-					GetAllOrderBySkuMock.Return([]model.CartItemModel{
-					{UserId: 1, SkuId: 1, Count: 1}}),
+					GetCheckoutMock.Return(model.CheckoutModel{}, false, nil).
+					GetSnapshotMock.Return(model.CartSnapshotModel{UserId: 1, Version: 2}, nil),
+				productService: NewProductServiceMock(t),
+				orderClient:    NewOrdersClientMock(t),
+				stocksClient:   NewStocksClientMock(t),
+			},
+			wantErr: model.ErrTheCartIsEmpty,
+		},
+		{
+			name:            "should return conflict if the expected version does not match",
+			userId:          1,
+			expectedVersion: 6,
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(t).
+					GetCheckoutMock.Return(model.CheckoutModel{}, false, nil).
+					GetSnapshotMock.Return(snapshot, nil),
+				productService: NewProductServiceMock(t),
+				orderClient:    NewOrdersClientMock(t),
+				stocksClient:   NewStocksClientMock(t),
+			},
+			wantErr: model.ErrCartChanged,
+		},
+		{
+			name:   "should return conflict if the cart changed before lock",
+			userId: 1,
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(t).
+					GetCheckoutMock.Return(model.CheckoutModel{}, false, nil).
+					GetSnapshotMock.Return(snapshot, nil).
+					LockMock.When(minimock.AnyContext, 1, 7, 30*time.Second, &model.CheckoutModel{
+					UserId: 1, IdempotencyKey: "checkout-key", Items: snapshot.Items,
+				}).Then(model.ErrCartChanged),
+				productService: NewProductServiceMock(t),
+				orderClient:    NewOrdersClientMock(t),
+				stocksClient:   NewStocksClientMock(t),
+			},
+			wantErr: model.ErrCartChanged,
+		},
+		{
+			name:   "should unlock the cart if failed to create order",
+			userId: 1,
+			fields: fields{
+				cartRepository: NewCartRepositoryMock(t).
+					GetCheckoutMock.Return(model.CheckoutModel{}, false, nil).
+					GetSnapshotMock.Return(snapshot, nil).
+					LockMock.Return(nil).
+					UnlockMock.When(minimock.AnyContext, 1).Then(nil),
 				productService: NewProductServiceMock(t),
 				orderClient: NewOrdersClientMock(t).
-					CreateOrderMock.Return(0, errors.New("create order error")),
+					CreateOrderMock.Return(0, model.ErrCreateOrderPreconditionFailed),
+				stocksClient: NewStocksClientMock(t),
 			},
-			get:     0,
-			wantErr: true,
+			wantErr: model.ErrCreateOrderPreconditionFailed,
 		},
 	}
 
@@ -383,10 +764,10 @@ func TestCartService_Checkout(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			service := service.NewCartService(tt.fields.cartRepository, tt.fields.productService,
-				tt.fields.orderClient)
+				tt.fields.orderClient, tt.fields.stocksClient)
 
-			orderId, err := service.Checkout(context.Background(), tt.userId)
-			if (err != nil) != tt.wantErr {
+			orderId, err := service.Checkout(context.Background(), tt.userId, "checkout-key", tt.expectedVersion)
+			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("CartService.Checkout() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
@@ -397,3 +778,32 @@ func TestCartService_Checkout(t *testing.T) {
 		})
 	}
 }
+
+func TestCartService_Checkout_UnlocksAfterClientLeft(t *testing.T) {
+	t.Parallel()
+	var snapshot = model.CartSnapshotModel{UserId: 1, Version: 7, Items: []model.CartItemModel{
+		{UserId: 1, SkuId: 1, Count: 1},
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cartRepository := NewCartRepositoryMock(t).
+		GetSnapshotMock.Return(snapshot, nil).
+		LockMock.Return(nil).
+		UnlockMock.Set(func(ctx context.Context, userId int64) error {
+		if ctx.Err() != nil {
+			t.Errorf("CartService.Checkout() unlocks with cancelled context, %v", ctx.Err())
+		}
+		return nil
+	})
+	orderClient := NewOrdersClientMock(t).
+		CreateOrderMock.Set(func(context.Context, int64, []model.CartItemModel, string) (int64, error) {
+		cancel()
+		return 0, context.Canceled
+	})
+
+	service := service.NewCartService(cartRepository, NewProductServiceMock(t), orderClient, NewStocksClientMock(t))
+	_, err := service.Checkout(ctx, 1, "", 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CartService.Checkout() error = %v, wantErr %v", err, context.Canceled)
+	}
+}
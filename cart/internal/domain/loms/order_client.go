@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"route256/cart/internal/domain/model"
+	"route256/cart/internal/infra/breaker"
 	"route256/cart/internal/infra/cart_config"
 	"route256/cart/internal/infra/logger"
 	"route256/cart/internal/infra/sre"
@@ -21,9 +22,11 @@ type OrderClient struct {
 	client orders_v1.OrdersServiceClient
 }
 
-func NewOrderClient(config *cart_config.Config) *OrderClient {
+func NewOrderClient(config *cart_config.Config, circuitBreaker *breaker.CircuitBreaker) *OrderClient {
 	var address = fmt.Sprintf("%s:%s", config.Loms.Host, config.Loms.Port)
-	grpcClient, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()), sre.WithTracingDial(config))
+	grpcClient, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()), sre.WithTracingDial(config),
+		breaker.WithBreakerDial(circuitBreaker))
 	if err != nil {
 		logger.Fatal("Failed to create orders grpc client", "err", err)
 	}
@@ -32,7 +35,8 @@ func NewOrderClient(config *cart_config.Config) *OrderClient {
 	return &OrderClient{client: realClient}
 }
 
-func (c *OrderClient) CreateOrder(ctx context.Context, userId int64, items []model.CartItemModel) (int64, error) {
+func (c *OrderClient) CreateOrder(ctx context.Context, userId int64, items []model.CartItemModel,
+	idempotencyKey string) (int64, error) {
 	ctx, span := otel.Tracer("client").Start(ctx, "order_client.CreateOrder")
 	defer span.End()
 
@@ -47,8 +51,9 @@ func (c *OrderClient) CreateOrder(ctx context.Context, userId int64, items []mod
 
 	startTime := time.Now()
 	response, err := c.client.CreateOrder(ctx, &orders_v1.CreateOrderRequest{
-		User:  userId,
-		Items: mappedItems,
+		User:           userId,
+		Items:          mappedItems,
+		IdempotencyKey: idempotencyKey,
 	})
 	sre.TrackExternalRequest("loms_create_order", err, startTime)
 	if st, ok := status.FromError(err); ok && st.Code() == codes.FailedPrecondition {
@@ -3,6 +3,8 @@ package loms
 import (
 	"context"
 	"fmt"
+	"route256/cart/internal/domain/model"
+	"route256/cart/internal/infra/breaker"
 	"route256/cart/internal/infra/cart_config"
 	"route256/cart/internal/infra/logger"
 	"route256/cart/internal/infra/sre"
@@ -11,17 +13,22 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
+//go:generate minimock -i route256/cart/internal/pb/stocks/v1.StocksServiceClient -o stocks_service_client_mock_test.go -n StocksServiceClientMock -p loms_test
+
 type StocksClient struct {
 	client stocks_v1.StocksServiceClient
 }
 
-func NewStocksClient(config *cart_config.Config) *StocksClient {
+func NewStocksClient(config *cart_config.Config, circuitBreaker *breaker.CircuitBreaker) *StocksClient {
 	var address = fmt.Sprintf("%s:%s", config.Loms.Host, config.Loms.Port)
 	grpcClient, err := grpc.NewClient(address,
-		grpc.WithTransportCredentials(insecure.NewCredentials()), sre.WithTracingDial(config))
+		grpc.WithTransportCredentials(insecure.NewCredentials()), sre.WithTracingDial(config),
+		breaker.WithBreakerDial(circuitBreaker))
 	if err != nil {
 		logger.Fatal("Failed to create stocks grpc client", "err", err)
 	}
@@ -39,9 +46,36 @@ func (c *StocksClient) StockInfo(ctx context.Context, skuId int64) (uint32, erro
 		Sku: skuId,
 	})
 	sre.TrackExternalRequest("loms_stocks_info", err, startTime)
+	if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+		// sku without a stock record simply has nothing available
+		return 0, nil
+	}
 	if err != nil {
 		return 0, fmt.Errorf("failed to get stock info for sku %d: %w", skuId, err)
 	}
 
 	return response.Count, nil
 }
+
+// StocksInfoBatch returns availability of the skus in one call, skus
+// without a stock record have nothing available and are not found.
+func (c *StocksClient) StocksInfoBatch(ctx context.Context, skuIds []int64) (map[int64]model.StockAvailability, error) {
+	ctx, span := otel.Tracer("client").Start(ctx, "stock_client.StocksInfoBatch")
+	defer span.End()
+
+	startTime := time.Now()
+	response, err := c.client.StocksInfoBatch(ctx, &stocks_v1.StocksInfoBatchRequest{
+		Skus: skuIds,
+	})
+	sre.TrackExternalRequest("loms_stocks_info_batch", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock info for %d skus: %w", len(skuIds), err)
+	}
+
+	var available = make(map[int64]model.StockAvailability, len(response.Stocks))
+	for _, stock := range response.Stocks {
+		available[stock.Sku] = model.StockAvailability{Count: stock.Count, Found: stock.Found}
+	}
+
+	return available, nil
+}
@@ -0,0 +1,9 @@
+package loms
+
+import (
+	stocks_v1 "route256/cart/internal/pb/stocks/v1"
+)
+
+func NewStocksClientForTest(client stocks_v1.StocksServiceClient) *StocksClient {
+	return &StocksClient{client: client}
+}
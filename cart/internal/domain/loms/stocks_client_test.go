@@ -0,0 +1,64 @@
+package loms_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"route256/cart/internal/domain/loms"
+	"route256/cart/internal/domain/model"
+	stocks_v1 "route256/cart/internal/pb/stocks/v1"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStocksClient_StocksInfoBatch(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+
+	tests := []struct {
+		name    string
+		client  *StocksServiceClientMock
+		skuIds  []int64
+		want    map[int64]model.StockAvailability
+		wantErr bool
+	}{
+		{
+			name: "should pass counts and found through",
+			client: NewStocksServiceClientMock(mc).StocksInfoBatchMock.
+				Expect(minimock.AnyContext, &stocks_v1.StocksInfoBatchRequest{Skus: []int64{1, 2}}).
+				Return(&stocks_v1.StocksInfoBatchResponse{Stocks: []*stocks_v1.StocksInfoBatchResponse_StockInfo{
+					{Sku: 1, Count: 10, Found: true},
+					{Sku: 2, Count: 0, Found: false},
+				}}, nil),
+			skuIds: []int64{1, 2},
+			want: map[int64]model.StockAvailability{
+				1: {Count: 10, Found: true},
+				2: {Count: 0, Found: false},
+			},
+		},
+		{
+			name: "should fail if loms fails",
+			client: NewStocksServiceClientMock(mc).StocksInfoBatchMock.
+				Return(nil, errors.New("loms error")),
+			skuIds:  []int64{1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := loms.NewStocksClientForTest(tt.client)
+
+			got, err := client.StocksInfoBatch(context.Background(), tt.skuIds)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
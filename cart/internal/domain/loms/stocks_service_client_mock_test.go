@@ -0,0 +1,837 @@
+// Code generated by http://github.com/gojuno/minimock (v3.4.5). DO NOT EDIT.
+
+package loms_test
+
+//go:generate minimock -i route256/cart/internal/pb/stocks/v1.StocksServiceClient -o stocks_service_client_mock_test.go -n StocksServiceClientMock -p loms_test
+
+import (
+	context "context"
+	mm_stocks_v1 "route256/cart/internal/pb/stocks/v1"
+	"sync"
+	mm_atomic "sync/atomic"
+	mm_time "time"
+
+	"github.com/gojuno/minimock/v3"
+	grpc "google.golang.org/grpc"
+)
+
+// StocksServiceClientMock implements mm_stocks_v1.StocksServiceClient
+type StocksServiceClientMock struct {
+	t          minimock.Tester
+	finishOnce sync.Once
+
+	funcStocksInfo          func(ctx context.Context, in *mm_stocks_v1.StocksInfoRequest, opts ...grpc.CallOption) (sp1 *mm_stocks_v1.StocksInfoResponse, err error)
+	funcStocksInfoOrigin    string
+	inspectFuncStocksInfo   func(ctx context.Context, in *mm_stocks_v1.StocksInfoRequest, opts ...grpc.CallOption)
+	afterStocksInfoCounter  uint64
+	beforeStocksInfoCounter uint64
+	StocksInfoMock          mStocksServiceClientMockStocksInfo
+
+	funcStocksInfoBatch          func(ctx context.Context, in *mm_stocks_v1.StocksInfoBatchRequest, opts ...grpc.CallOption) (sp1 *mm_stocks_v1.StocksInfoBatchResponse, err error)
+	funcStocksInfoBatchOrigin    string
+	inspectFuncStocksInfoBatch   func(ctx context.Context, in *mm_stocks_v1.StocksInfoBatchRequest, opts ...grpc.CallOption)
+	afterStocksInfoBatchCounter  uint64
+	beforeStocksInfoBatchCounter uint64
+	StocksInfoBatchMock          mStocksServiceClientMockStocksInfoBatch
+}
+
+// NewStocksServiceClientMock returns a mock for mm_stocks_v1.StocksServiceClient
+func NewStocksServiceClientMock(t minimock.Tester) *StocksServiceClientMock {
+	m := &StocksServiceClientMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.StocksInfoMock = mStocksServiceClientMockStocksInfo{mock: m}
+	m.StocksInfoMock.callArgs = []*StocksServiceClientMockStocksInfoParams{}
+
+	m.StocksInfoBatchMock = mStocksServiceClientMockStocksInfoBatch{mock: m}
+	m.StocksInfoBatchMock.callArgs = []*StocksServiceClientMockStocksInfoBatchParams{}
+
+	t.Cleanup(m.MinimockFinish)
+
+	return m
+}
+
+type mStocksServiceClientMockStocksInfo struct {
+	optional           bool
+	mock               *StocksServiceClientMock
+	defaultExpectation *StocksServiceClientMockStocksInfoExpectation
+	expectations       []*StocksServiceClientMockStocksInfoExpectation
+
+	callArgs []*StocksServiceClientMockStocksInfoParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// StocksServiceClientMockStocksInfoExpectation specifies expectation struct of the StocksServiceClient.StocksInfo
+type StocksServiceClientMockStocksInfoExpectation struct {
+	mock               *StocksServiceClientMock
+	params             *StocksServiceClientMockStocksInfoParams
+	paramPtrs          *StocksServiceClientMockStocksInfoParamPtrs
+	expectationOrigins StocksServiceClientMockStocksInfoExpectationOrigins
+	results            *StocksServiceClientMockStocksInfoResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// StocksServiceClientMockStocksInfoParams contains parameters of the StocksServiceClient.StocksInfo
+type StocksServiceClientMockStocksInfoParams struct {
+	ctx  context.Context
+	in   *mm_stocks_v1.StocksInfoRequest
+	opts []grpc.CallOption
+}
+
+// StocksServiceClientMockStocksInfoParamPtrs contains pointers to parameters of the StocksServiceClient.StocksInfo
+type StocksServiceClientMockStocksInfoParamPtrs struct {
+	ctx  *context.Context
+	in   **mm_stocks_v1.StocksInfoRequest
+	opts *[]grpc.CallOption
+}
+
+// StocksServiceClientMockStocksInfoResults contains results of the StocksServiceClient.StocksInfo
+type StocksServiceClientMockStocksInfoResults struct {
+	sp1 *mm_stocks_v1.StocksInfoResponse
+	err error
+}
+
+// StocksServiceClientMockStocksInfoOrigins contains origins of expectations of the StocksServiceClient.StocksInfo
+type StocksServiceClientMockStocksInfoExpectationOrigins struct {
+	origin     string
+	originCtx  string
+	originIn   string
+	originOpts string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) Optional() *mStocksServiceClientMockStocksInfo {
+	mmStocksInfo.optional = true
+	return mmStocksInfo
+}
+
+// Expect sets up expected params for StocksServiceClient.StocksInfo
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) Expect(ctx context.Context, in *mm_stocks_v1.StocksInfoRequest, opts ...grpc.CallOption) *mStocksServiceClientMockStocksInfo {
+	if mmStocksInfo.mock.funcStocksInfo != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by Set")
+	}
+
+	if mmStocksInfo.defaultExpectation == nil {
+		mmStocksInfo.defaultExpectation = &StocksServiceClientMockStocksInfoExpectation{}
+	}
+
+	if mmStocksInfo.defaultExpectation.paramPtrs != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by ExpectParams functions")
+	}
+
+	mmStocksInfo.defaultExpectation.params = &StocksServiceClientMockStocksInfoParams{ctx, in, opts}
+	mmStocksInfo.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmStocksInfo.expectations {
+		if minimock.Equal(e.params, mmStocksInfo.defaultExpectation.params) {
+			mmStocksInfo.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmStocksInfo.defaultExpectation.params)
+		}
+	}
+
+	return mmStocksInfo
+}
+
+// ExpectCtxParam1 sets up expected param ctx for StocksServiceClient.StocksInfo
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) ExpectCtxParam1(ctx context.Context) *mStocksServiceClientMockStocksInfo {
+	if mmStocksInfo.mock.funcStocksInfo != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by Set")
+	}
+
+	if mmStocksInfo.defaultExpectation == nil {
+		mmStocksInfo.defaultExpectation = &StocksServiceClientMockStocksInfoExpectation{}
+	}
+
+	if mmStocksInfo.defaultExpectation.params != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by Expect")
+	}
+
+	if mmStocksInfo.defaultExpectation.paramPtrs == nil {
+		mmStocksInfo.defaultExpectation.paramPtrs = &StocksServiceClientMockStocksInfoParamPtrs{}
+	}
+	mmStocksInfo.defaultExpectation.paramPtrs.ctx = &ctx
+	mmStocksInfo.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmStocksInfo
+}
+
+// ExpectInParam2 sets up expected param in for StocksServiceClient.StocksInfo
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) ExpectInParam2(in *mm_stocks_v1.StocksInfoRequest) *mStocksServiceClientMockStocksInfo {
+	if mmStocksInfo.mock.funcStocksInfo != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by Set")
+	}
+
+	if mmStocksInfo.defaultExpectation == nil {
+		mmStocksInfo.defaultExpectation = &StocksServiceClientMockStocksInfoExpectation{}
+	}
+
+	if mmStocksInfo.defaultExpectation.params != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by Expect")
+	}
+
+	if mmStocksInfo.defaultExpectation.paramPtrs == nil {
+		mmStocksInfo.defaultExpectation.paramPtrs = &StocksServiceClientMockStocksInfoParamPtrs{}
+	}
+	mmStocksInfo.defaultExpectation.paramPtrs.in = &in
+	mmStocksInfo.defaultExpectation.expectationOrigins.originIn = minimock.CallerInfo(1)
+
+	return mmStocksInfo
+}
+
+// ExpectOptsParam3 sets up expected param opts for StocksServiceClient.StocksInfo
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) ExpectOptsParam3(opts ...grpc.CallOption) *mStocksServiceClientMockStocksInfo {
+	if mmStocksInfo.mock.funcStocksInfo != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by Set")
+	}
+
+	if mmStocksInfo.defaultExpectation == nil {
+		mmStocksInfo.defaultExpectation = &StocksServiceClientMockStocksInfoExpectation{}
+	}
+
+	if mmStocksInfo.defaultExpectation.params != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by Expect")
+	}
+
+	if mmStocksInfo.defaultExpectation.paramPtrs == nil {
+		mmStocksInfo.defaultExpectation.paramPtrs = &StocksServiceClientMockStocksInfoParamPtrs{}
+	}
+	mmStocksInfo.defaultExpectation.paramPtrs.opts = &opts
+	mmStocksInfo.defaultExpectation.expectationOrigins.originOpts = minimock.CallerInfo(1)
+
+	return mmStocksInfo
+}
+
+// Inspect accepts an inspector function that has same arguments as the StocksServiceClient.StocksInfo
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) Inspect(f func(ctx context.Context, in *mm_stocks_v1.StocksInfoRequest, opts ...grpc.CallOption)) *mStocksServiceClientMockStocksInfo {
+	if mmStocksInfo.mock.inspectFuncStocksInfo != nil {
+		mmStocksInfo.mock.t.Fatalf("Inspect function is already set for StocksServiceClientMock.StocksInfo")
+	}
+
+	mmStocksInfo.mock.inspectFuncStocksInfo = f
+
+	return mmStocksInfo
+}
+
+// Return sets up results that will be returned by StocksServiceClient.StocksInfo
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) Return(sp1 *mm_stocks_v1.StocksInfoResponse, err error) *StocksServiceClientMock {
+	if mmStocksInfo.mock.funcStocksInfo != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by Set")
+	}
+
+	if mmStocksInfo.defaultExpectation == nil {
+		mmStocksInfo.defaultExpectation = &StocksServiceClientMockStocksInfoExpectation{mock: mmStocksInfo.mock}
+	}
+	mmStocksInfo.defaultExpectation.results = &StocksServiceClientMockStocksInfoResults{sp1, err}
+	mmStocksInfo.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmStocksInfo.mock
+}
+
+// Set uses given function f to mock the StocksServiceClient.StocksInfo method
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) Set(f func(ctx context.Context, in *mm_stocks_v1.StocksInfoRequest, opts ...grpc.CallOption) (sp1 *mm_stocks_v1.StocksInfoResponse, err error)) *StocksServiceClientMock {
+	if mmStocksInfo.defaultExpectation != nil {
+		mmStocksInfo.mock.t.Fatalf("Default expectation is already set for the StocksServiceClient.StocksInfo method")
+	}
+
+	if len(mmStocksInfo.expectations) > 0 {
+		mmStocksInfo.mock.t.Fatalf("Some expectations are already set for the StocksServiceClient.StocksInfo method")
+	}
+
+	mmStocksInfo.mock.funcStocksInfo = f
+	mmStocksInfo.mock.funcStocksInfoOrigin = minimock.CallerInfo(1)
+	return mmStocksInfo.mock
+}
+
+// When sets expectation for the StocksServiceClient.StocksInfo which will trigger the result defined by the following
+// Then helper
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) When(ctx context.Context, in *mm_stocks_v1.StocksInfoRequest, opts ...grpc.CallOption) *StocksServiceClientMockStocksInfoExpectation {
+	if mmStocksInfo.mock.funcStocksInfo != nil {
+		mmStocksInfo.mock.t.Fatalf("StocksServiceClientMock.StocksInfo mock is already set by Set")
+	}
+
+	expectation := &StocksServiceClientMockStocksInfoExpectation{
+		mock:               mmStocksInfo.mock,
+		params:             &StocksServiceClientMockStocksInfoParams{ctx, in, opts},
+		expectationOrigins: StocksServiceClientMockStocksInfoExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmStocksInfo.expectations = append(mmStocksInfo.expectations, expectation)
+	return expectation
+}
+
+// Then sets up StocksServiceClient.StocksInfo return parameters for the expectation previously defined by the When method
+func (e *StocksServiceClientMockStocksInfoExpectation) Then(sp1 *mm_stocks_v1.StocksInfoResponse, err error) *StocksServiceClientMock {
+	e.results = &StocksServiceClientMockStocksInfoResults{sp1, err}
+	return e.mock
+}
+
+// Times sets number of times StocksServiceClient.StocksInfo should be invoked
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) Times(n uint64) *mStocksServiceClientMockStocksInfo {
+	if n == 0 {
+		mmStocksInfo.mock.t.Fatalf("Times of StocksServiceClientMock.StocksInfo mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmStocksInfo.expectedInvocations, n)
+	mmStocksInfo.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmStocksInfo
+}
+
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) invocationsDone() bool {
+	if len(mmStocksInfo.expectations) == 0 && mmStocksInfo.defaultExpectation == nil && mmStocksInfo.mock.funcStocksInfo == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmStocksInfo.mock.afterStocksInfoCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmStocksInfo.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// StocksInfo implements mm_stocks_v1.StocksServiceClient
+func (mmStocksInfo *StocksServiceClientMock) StocksInfo(ctx context.Context, in *mm_stocks_v1.StocksInfoRequest, opts ...grpc.CallOption) (sp1 *mm_stocks_v1.StocksInfoResponse, err error) {
+	mm_atomic.AddUint64(&mmStocksInfo.beforeStocksInfoCounter, 1)
+	defer mm_atomic.AddUint64(&mmStocksInfo.afterStocksInfoCounter, 1)
+
+	mmStocksInfo.t.Helper()
+
+	if mmStocksInfo.inspectFuncStocksInfo != nil {
+		mmStocksInfo.inspectFuncStocksInfo(ctx, in, opts...)
+	}
+
+	mm_params := StocksServiceClientMockStocksInfoParams{ctx, in, opts}
+
+	// Record call args
+	mmStocksInfo.StocksInfoMock.mutex.Lock()
+	mmStocksInfo.StocksInfoMock.callArgs = append(mmStocksInfo.StocksInfoMock.callArgs, &mm_params)
+	mmStocksInfo.StocksInfoMock.mutex.Unlock()
+
+	for _, e := range mmStocksInfo.StocksInfoMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sp1, e.results.err
+		}
+	}
+
+	if mmStocksInfo.StocksInfoMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmStocksInfo.StocksInfoMock.defaultExpectation.Counter, 1)
+		mm_want := mmStocksInfo.StocksInfoMock.defaultExpectation.params
+		mm_want_ptrs := mmStocksInfo.StocksInfoMock.defaultExpectation.paramPtrs
+
+		mm_got := StocksServiceClientMockStocksInfoParams{ctx, in, opts}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmStocksInfo.t.Errorf("StocksServiceClientMock.StocksInfo got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmStocksInfo.StocksInfoMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.in != nil && !minimock.Equal(*mm_want_ptrs.in, mm_got.in) {
+				mmStocksInfo.t.Errorf("StocksServiceClientMock.StocksInfo got unexpected parameter in, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmStocksInfo.StocksInfoMock.defaultExpectation.expectationOrigins.originIn, *mm_want_ptrs.in, mm_got.in, minimock.Diff(*mm_want_ptrs.in, mm_got.in))
+			}
+
+			if mm_want_ptrs.opts != nil && !minimock.Equal(*mm_want_ptrs.opts, mm_got.opts) {
+				mmStocksInfo.t.Errorf("StocksServiceClientMock.StocksInfo got unexpected parameter opts, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmStocksInfo.StocksInfoMock.defaultExpectation.expectationOrigins.originOpts, *mm_want_ptrs.opts, mm_got.opts, minimock.Diff(*mm_want_ptrs.opts, mm_got.opts))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmStocksInfo.t.Errorf("StocksServiceClientMock.StocksInfo got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmStocksInfo.StocksInfoMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmStocksInfo.StocksInfoMock.defaultExpectation.results
+		if mm_results == nil {
+			mmStocksInfo.t.Fatal("No results are set for the StocksServiceClientMock.StocksInfo")
+		}
+		return (*mm_results).sp1, (*mm_results).err
+	}
+	if mmStocksInfo.funcStocksInfo != nil {
+		return mmStocksInfo.funcStocksInfo(ctx, in, opts...)
+	}
+	mmStocksInfo.t.Fatalf("Unexpected call to StocksServiceClientMock.StocksInfo. %v %v %v", ctx, in, opts)
+	return
+}
+
+// StocksInfoAfterCounter returns a count of finished StocksServiceClientMock.StocksInfo invocations
+func (mmStocksInfo *StocksServiceClientMock) StocksInfoAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStocksInfo.afterStocksInfoCounter)
+}
+
+// StocksInfoBeforeCounter returns a count of StocksServiceClientMock.StocksInfo invocations
+func (mmStocksInfo *StocksServiceClientMock) StocksInfoBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStocksInfo.beforeStocksInfoCounter)
+}
+
+// Calls returns a list of arguments used in each call to StocksServiceClientMock.StocksInfo.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmStocksInfo *mStocksServiceClientMockStocksInfo) Calls() []*StocksServiceClientMockStocksInfoParams {
+	mmStocksInfo.mutex.RLock()
+
+	argCopy := make([]*StocksServiceClientMockStocksInfoParams, len(mmStocksInfo.callArgs))
+	copy(argCopy, mmStocksInfo.callArgs)
+
+	mmStocksInfo.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockStocksInfoDone returns true if the count of the StocksInfo invocations corresponds
+// the number of defined expectations
+func (m *StocksServiceClientMock) MinimockStocksInfoDone() bool {
+	if m.StocksInfoMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.StocksInfoMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.StocksInfoMock.invocationsDone()
+}
+
+// MinimockStocksInfoInspect logs each unmet expectation
+func (m *StocksServiceClientMock) MinimockStocksInfoInspect() {
+	for _, e := range m.StocksInfoMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StocksServiceClientMock.StocksInfo at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterStocksInfoCounter := mm_atomic.LoadUint64(&m.afterStocksInfoCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.StocksInfoMock.defaultExpectation != nil && afterStocksInfoCounter < 1 {
+		if m.StocksInfoMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to StocksServiceClientMock.StocksInfo at\n%s", m.StocksInfoMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to StocksServiceClientMock.StocksInfo at\n%s with params: %#v", m.StocksInfoMock.defaultExpectation.expectationOrigins.origin, *m.StocksInfoMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcStocksInfo != nil && afterStocksInfoCounter < 1 {
+		m.t.Errorf("Expected call to StocksServiceClientMock.StocksInfo at\n%s", m.funcStocksInfoOrigin)
+	}
+
+	if !m.StocksInfoMock.invocationsDone() && afterStocksInfoCounter > 0 {
+		m.t.Errorf("Expected %d calls to StocksServiceClientMock.StocksInfo at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.StocksInfoMock.expectedInvocations), m.StocksInfoMock.expectedInvocationsOrigin, afterStocksInfoCounter)
+	}
+}
+
+type mStocksServiceClientMockStocksInfoBatch struct {
+	optional           bool
+	mock               *StocksServiceClientMock
+	defaultExpectation *StocksServiceClientMockStocksInfoBatchExpectation
+	expectations       []*StocksServiceClientMockStocksInfoBatchExpectation
+
+	callArgs []*StocksServiceClientMockStocksInfoBatchParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// StocksServiceClientMockStocksInfoBatchExpectation specifies expectation struct of the StocksServiceClient.StocksInfoBatch
+type StocksServiceClientMockStocksInfoBatchExpectation struct {
+	mock               *StocksServiceClientMock
+	params             *StocksServiceClientMockStocksInfoBatchParams
+	paramPtrs          *StocksServiceClientMockStocksInfoBatchParamPtrs
+	expectationOrigins StocksServiceClientMockStocksInfoBatchExpectationOrigins
+	results            *StocksServiceClientMockStocksInfoBatchResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// StocksServiceClientMockStocksInfoBatchParams contains parameters of the StocksServiceClient.StocksInfoBatch
+type StocksServiceClientMockStocksInfoBatchParams struct {
+	ctx  context.Context
+	in   *mm_stocks_v1.StocksInfoBatchRequest
+	opts []grpc.CallOption
+}
+
+// StocksServiceClientMockStocksInfoBatchParamPtrs contains pointers to parameters of the StocksServiceClient.StocksInfoBatch
+type StocksServiceClientMockStocksInfoBatchParamPtrs struct {
+	ctx  *context.Context
+	in   **mm_stocks_v1.StocksInfoBatchRequest
+	opts *[]grpc.CallOption
+}
+
+// StocksServiceClientMockStocksInfoBatchResults contains results of the StocksServiceClient.StocksInfoBatch
+type StocksServiceClientMockStocksInfoBatchResults struct {
+	sp1 *mm_stocks_v1.StocksInfoBatchResponse
+	err error
+}
+
+// StocksServiceClientMockStocksInfoBatchOrigins contains origins of expectations of the StocksServiceClient.StocksInfoBatch
+type StocksServiceClientMockStocksInfoBatchExpectationOrigins struct {
+	origin     string
+	originCtx  string
+	originIn   string
+	originOpts string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) Optional() *mStocksServiceClientMockStocksInfoBatch {
+	mmStocksInfoBatch.optional = true
+	return mmStocksInfoBatch
+}
+
+// Expect sets up expected params for StocksServiceClient.StocksInfoBatch
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) Expect(ctx context.Context, in *mm_stocks_v1.StocksInfoBatchRequest, opts ...grpc.CallOption) *mStocksServiceClientMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &StocksServiceClientMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by ExpectParams functions")
+	}
+
+	mmStocksInfoBatch.defaultExpectation.params = &StocksServiceClientMockStocksInfoBatchParams{ctx, in, opts}
+	mmStocksInfoBatch.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmStocksInfoBatch.expectations {
+		if minimock.Equal(e.params, mmStocksInfoBatch.defaultExpectation.params) {
+			mmStocksInfoBatch.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmStocksInfoBatch.defaultExpectation.params)
+		}
+	}
+
+	return mmStocksInfoBatch
+}
+
+// ExpectCtxParam1 sets up expected param ctx for StocksServiceClient.StocksInfoBatch
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) ExpectCtxParam1(ctx context.Context) *mStocksServiceClientMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &StocksServiceClientMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.params != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by Expect")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs == nil {
+		mmStocksInfoBatch.defaultExpectation.paramPtrs = &StocksServiceClientMockStocksInfoBatchParamPtrs{}
+	}
+	mmStocksInfoBatch.defaultExpectation.paramPtrs.ctx = &ctx
+	mmStocksInfoBatch.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmStocksInfoBatch
+}
+
+// ExpectInParam2 sets up expected param in for StocksServiceClient.StocksInfoBatch
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) ExpectInParam2(in *mm_stocks_v1.StocksInfoBatchRequest) *mStocksServiceClientMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &StocksServiceClientMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.params != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by Expect")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs == nil {
+		mmStocksInfoBatch.defaultExpectation.paramPtrs = &StocksServiceClientMockStocksInfoBatchParamPtrs{}
+	}
+	mmStocksInfoBatch.defaultExpectation.paramPtrs.in = &in
+	mmStocksInfoBatch.defaultExpectation.expectationOrigins.originIn = minimock.CallerInfo(1)
+
+	return mmStocksInfoBatch
+}
+
+// ExpectOptsParam3 sets up expected param opts for StocksServiceClient.StocksInfoBatch
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) ExpectOptsParam3(opts ...grpc.CallOption) *mStocksServiceClientMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &StocksServiceClientMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.params != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by Expect")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs == nil {
+		mmStocksInfoBatch.defaultExpectation.paramPtrs = &StocksServiceClientMockStocksInfoBatchParamPtrs{}
+	}
+	mmStocksInfoBatch.defaultExpectation.paramPtrs.opts = &opts
+	mmStocksInfoBatch.defaultExpectation.expectationOrigins.originOpts = minimock.CallerInfo(1)
+
+	return mmStocksInfoBatch
+}
+
+// Inspect accepts an inspector function that has same arguments as the StocksServiceClient.StocksInfoBatch
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) Inspect(f func(ctx context.Context, in *mm_stocks_v1.StocksInfoBatchRequest, opts ...grpc.CallOption)) *mStocksServiceClientMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.inspectFuncStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("Inspect function is already set for StocksServiceClientMock.StocksInfoBatch")
+	}
+
+	mmStocksInfoBatch.mock.inspectFuncStocksInfoBatch = f
+
+	return mmStocksInfoBatch
+}
+
+// Return sets up results that will be returned by StocksServiceClient.StocksInfoBatch
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) Return(sp1 *mm_stocks_v1.StocksInfoBatchResponse, err error) *StocksServiceClientMock {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &StocksServiceClientMockStocksInfoBatchExpectation{mock: mmStocksInfoBatch.mock}
+	}
+	mmStocksInfoBatch.defaultExpectation.results = &StocksServiceClientMockStocksInfoBatchResults{sp1, err}
+	mmStocksInfoBatch.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmStocksInfoBatch.mock
+}
+
+// Set uses given function f to mock the StocksServiceClient.StocksInfoBatch method
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) Set(f func(ctx context.Context, in *mm_stocks_v1.StocksInfoBatchRequest, opts ...grpc.CallOption) (sp1 *mm_stocks_v1.StocksInfoBatchResponse, err error)) *StocksServiceClientMock {
+	if mmStocksInfoBatch.defaultExpectation != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("Default expectation is already set for the StocksServiceClient.StocksInfoBatch method")
+	}
+
+	if len(mmStocksInfoBatch.expectations) > 0 {
+		mmStocksInfoBatch.mock.t.Fatalf("Some expectations are already set for the StocksServiceClient.StocksInfoBatch method")
+	}
+
+	mmStocksInfoBatch.mock.funcStocksInfoBatch = f
+	mmStocksInfoBatch.mock.funcStocksInfoBatchOrigin = minimock.CallerInfo(1)
+	return mmStocksInfoBatch.mock
+}
+
+// When sets expectation for the StocksServiceClient.StocksInfoBatch which will trigger the result defined by the following
+// Then helper
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) When(ctx context.Context, in *mm_stocks_v1.StocksInfoBatchRequest, opts ...grpc.CallOption) *StocksServiceClientMockStocksInfoBatchExpectation {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("StocksServiceClientMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	expectation := &StocksServiceClientMockStocksInfoBatchExpectation{
+		mock:               mmStocksInfoBatch.mock,
+		params:             &StocksServiceClientMockStocksInfoBatchParams{ctx, in, opts},
+		expectationOrigins: StocksServiceClientMockStocksInfoBatchExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmStocksInfoBatch.expectations = append(mmStocksInfoBatch.expectations, expectation)
+	return expectation
+}
+
+// Then sets up StocksServiceClient.StocksInfoBatch return parameters for the expectation previously defined by the When method
+func (e *StocksServiceClientMockStocksInfoBatchExpectation) Then(sp1 *mm_stocks_v1.StocksInfoBatchResponse, err error) *StocksServiceClientMock {
+	e.results = &StocksServiceClientMockStocksInfoBatchResults{sp1, err}
+	return e.mock
+}
+
+// Times sets number of times StocksServiceClient.StocksInfoBatch should be invoked
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) Times(n uint64) *mStocksServiceClientMockStocksInfoBatch {
+	if n == 0 {
+		mmStocksInfoBatch.mock.t.Fatalf("Times of StocksServiceClientMock.StocksInfoBatch mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmStocksInfoBatch.expectedInvocations, n)
+	mmStocksInfoBatch.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmStocksInfoBatch
+}
+
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) invocationsDone() bool {
+	if len(mmStocksInfoBatch.expectations) == 0 && mmStocksInfoBatch.defaultExpectation == nil && mmStocksInfoBatch.mock.funcStocksInfoBatch == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmStocksInfoBatch.mock.afterStocksInfoBatchCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmStocksInfoBatch.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// StocksInfoBatch implements mm_stocks_v1.StocksServiceClient
+func (mmStocksInfoBatch *StocksServiceClientMock) StocksInfoBatch(ctx context.Context, in *mm_stocks_v1.StocksInfoBatchRequest, opts ...grpc.CallOption) (sp1 *mm_stocks_v1.StocksInfoBatchResponse, err error) {
+	mm_atomic.AddUint64(&mmStocksInfoBatch.beforeStocksInfoBatchCounter, 1)
+	defer mm_atomic.AddUint64(&mmStocksInfoBatch.afterStocksInfoBatchCounter, 1)
+
+	mmStocksInfoBatch.t.Helper()
+
+	if mmStocksInfoBatch.inspectFuncStocksInfoBatch != nil {
+		mmStocksInfoBatch.inspectFuncStocksInfoBatch(ctx, in, opts...)
+	}
+
+	mm_params := StocksServiceClientMockStocksInfoBatchParams{ctx, in, opts}
+
+	// Record call args
+	mmStocksInfoBatch.StocksInfoBatchMock.mutex.Lock()
+	mmStocksInfoBatch.StocksInfoBatchMock.callArgs = append(mmStocksInfoBatch.StocksInfoBatchMock.callArgs, &mm_params)
+	mmStocksInfoBatch.StocksInfoBatchMock.mutex.Unlock()
+
+	for _, e := range mmStocksInfoBatch.StocksInfoBatchMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sp1, e.results.err
+		}
+	}
+
+	if mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.Counter, 1)
+		mm_want := mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.params
+		mm_want_ptrs := mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.paramPtrs
+
+		mm_got := StocksServiceClientMockStocksInfoBatchParams{ctx, in, opts}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmStocksInfoBatch.t.Errorf("StocksServiceClientMock.StocksInfoBatch got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.in != nil && !minimock.Equal(*mm_want_ptrs.in, mm_got.in) {
+				mmStocksInfoBatch.t.Errorf("StocksServiceClientMock.StocksInfoBatch got unexpected parameter in, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.expectationOrigins.originIn, *mm_want_ptrs.in, mm_got.in, minimock.Diff(*mm_want_ptrs.in, mm_got.in))
+			}
+
+			if mm_want_ptrs.opts != nil && !minimock.Equal(*mm_want_ptrs.opts, mm_got.opts) {
+				mmStocksInfoBatch.t.Errorf("StocksServiceClientMock.StocksInfoBatch got unexpected parameter opts, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.expectationOrigins.originOpts, *mm_want_ptrs.opts, mm_got.opts, minimock.Diff(*mm_want_ptrs.opts, mm_got.opts))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmStocksInfoBatch.t.Errorf("StocksServiceClientMock.StocksInfoBatch got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.results
+		if mm_results == nil {
+			mmStocksInfoBatch.t.Fatal("No results are set for the StocksServiceClientMock.StocksInfoBatch")
+		}
+		return (*mm_results).sp1, (*mm_results).err
+	}
+	if mmStocksInfoBatch.funcStocksInfoBatch != nil {
+		return mmStocksInfoBatch.funcStocksInfoBatch(ctx, in, opts...)
+	}
+	mmStocksInfoBatch.t.Fatalf("Unexpected call to StocksServiceClientMock.StocksInfoBatch. %v %v %v", ctx, in, opts)
+	return
+}
+
+// StocksInfoBatchAfterCounter returns a count of finished StocksServiceClientMock.StocksInfoBatch invocations
+func (mmStocksInfoBatch *StocksServiceClientMock) StocksInfoBatchAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStocksInfoBatch.afterStocksInfoBatchCounter)
+}
+
+// StocksInfoBatchBeforeCounter returns a count of StocksServiceClientMock.StocksInfoBatch invocations
+func (mmStocksInfoBatch *StocksServiceClientMock) StocksInfoBatchBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStocksInfoBatch.beforeStocksInfoBatchCounter)
+}
+
+// Calls returns a list of arguments used in each call to StocksServiceClientMock.StocksInfoBatch.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmStocksInfoBatch *mStocksServiceClientMockStocksInfoBatch) Calls() []*StocksServiceClientMockStocksInfoBatchParams {
+	mmStocksInfoBatch.mutex.RLock()
+
+	argCopy := make([]*StocksServiceClientMockStocksInfoBatchParams, len(mmStocksInfoBatch.callArgs))
+	copy(argCopy, mmStocksInfoBatch.callArgs)
+
+	mmStocksInfoBatch.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockStocksInfoBatchDone returns true if the count of the StocksInfoBatch invocations corresponds
+// the number of defined expectations
+func (m *StocksServiceClientMock) MinimockStocksInfoBatchDone() bool {
+	if m.StocksInfoBatchMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.StocksInfoBatchMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.StocksInfoBatchMock.invocationsDone()
+}
+
+// MinimockStocksInfoBatchInspect logs each unmet expectation
+func (m *StocksServiceClientMock) MinimockStocksInfoBatchInspect() {
+	for _, e := range m.StocksInfoBatchMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StocksServiceClientMock.StocksInfoBatch at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterStocksInfoBatchCounter := mm_atomic.LoadUint64(&m.afterStocksInfoBatchCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.StocksInfoBatchMock.defaultExpectation != nil && afterStocksInfoBatchCounter < 1 {
+		if m.StocksInfoBatchMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to StocksServiceClientMock.StocksInfoBatch at\n%s", m.StocksInfoBatchMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to StocksServiceClientMock.StocksInfoBatch at\n%s with params: %#v", m.StocksInfoBatchMock.defaultExpectation.expectationOrigins.origin, *m.StocksInfoBatchMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcStocksInfoBatch != nil && afterStocksInfoBatchCounter < 1 {
+		m.t.Errorf("Expected call to StocksServiceClientMock.StocksInfoBatch at\n%s", m.funcStocksInfoBatchOrigin)
+	}
+
+	if !m.StocksInfoBatchMock.invocationsDone() && afterStocksInfoBatchCounter > 0 {
+		m.t.Errorf("Expected %d calls to StocksServiceClientMock.StocksInfoBatch at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.StocksInfoBatchMock.expectedInvocations), m.StocksInfoBatchMock.expectedInvocationsOrigin, afterStocksInfoBatchCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *StocksServiceClientMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockStocksInfoInspect()
+
+			m.MinimockStocksInfoBatchInspect()
+		}
+	})
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *StocksServiceClientMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *StocksServiceClientMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockStocksInfoDone() &&
+		m.MinimockStocksInfoBatchDone()
+}
@@ -1,7 +1,31 @@
 package model
 
+import (
+	"fmt"
+	"math"
+)
+
 type CartItemModel struct {
 	UserId int64  `validate:"required,min=1"`
 	SkuId  int64  `validate:"required,min=1"`
 	Count  uint32 `validate:"required,min=1"`
 }
+
+// ApplyCountDelta returns the count of the cart line after the delta is added.
+// Only a growing count is bounded by maxCount, so a line can always be lowered.
+func ApplyCountDelta(inCart uint32, delta int64, maxCount uint32) (uint32, error) {
+	var newCount = int64(inCart) + delta
+	if newCount < 0 {
+		return 0, fmt.Errorf("in cart %d, delta %d, %w", inCart, delta, ErrNegativeItemCount)
+	}
+
+	if newCount > math.MaxUint32 {
+		return 0, fmt.Errorf("in cart %d, delta %d, %w", inCart, delta, ErrTotalCountExceeded)
+	}
+
+	if delta > 0 && newCount > int64(maxCount) {
+		return 0, fmt.Errorf("available %d, requested %d: %w", maxCount, newCount, ErrNotEnoughItemsInStock)
+	}
+
+	return uint32(newCount), nil
+}
@@ -8,6 +8,7 @@ type EnrichedCartItemModel struct {
 }
 
 type AllCartItemsModel struct {
-	Items []EnrichedCartItemModel
-	Total uint32
+	Items   []EnrichedCartItemModel
+	Total   uint32
+	Version int64
 }
@@ -0,0 +1,9 @@
+package model
+
+// CartSnapshotModel is the state of the cart at the given version. Every modification of the cart
+// increments its version, so the version can be used as an ETag.
+type CartSnapshotModel struct {
+	UserId  int64
+	Version int64
+	Items   []CartItemModel
+}
@@ -0,0 +1,12 @@
+package model
+
+// CheckoutModel remembers the order created by the checkout with the idempotency key,
+// so that a retried checkout gets the same order instead of checking out the cart again.
+// The checkout is pending with zero OrderId while the order is being created from Items.
+type CheckoutModel struct {
+	UserId          int64
+	IdempotencyKey  string
+	ExpectedVersion int64
+	OrderId         int64
+	Items           []CartItemModel
+}
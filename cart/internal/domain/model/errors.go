@@ -2,6 +2,7 @@ package model
 
 import (
 	"errors"
+	"fmt"
 )
 
 var ErrTheCartIsEmpty = errors.New("the cart is empty")
@@ -9,5 +10,22 @@ var ErrProductDoesNotExist = errors.New("product does not exist")
 var ErrNotEnoughItemsInStock = errors.New("not enough items in stock")
 var ErrorUserIdLessThanZero = errors.New("user id must be greater than 0")
 var ErrorSkuIdLessThanZero = errors.New("sku id must be greater than 0")
+var ErrNegativeItemCount = errors.New("the count of items can not be negative")
 var ErrTotalCountExceeded = errors.New("the count of items exceeds the maximum allowed")
+var ErrCartLocked = errors.New("the cart is locked by checkout")
+var ErrCartChanged = errors.New("the cart has been changed")
+var ErrIdempotencyKeyReused = errors.New("the idempotency key is already used by another checkout")
 var ErrCreateOrderPreconditionFailed = errors.New("failed to create order, precondition failed")
+
+// ErrProductNotFound names the sku unknown to the products service, it matches ErrProductDoesNotExist.
+type ErrProductNotFound struct {
+	SkuId int64
+}
+
+func (e *ErrProductNotFound) Error() string {
+	return fmt.Sprintf("product %d: %v", e.SkuId, ErrProductDoesNotExist)
+}
+
+func (e *ErrProductNotFound) Unwrap() error {
+	return ErrProductDoesNotExist
+}
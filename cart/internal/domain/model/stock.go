@@ -0,0 +1,8 @@
+package model
+
+// StockAvailability is the available count of the sku, Found is false when
+// loms has no stock record of the sku.
+type StockAvailability struct {
+	Count uint32
+	Found bool
+}
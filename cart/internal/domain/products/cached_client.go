@@ -0,0 +1,219 @@
+package products
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"route256/cart/internal/domain/model"
+	"route256/cart/internal/infra/cart_config"
+	"route256/cart/internal/infra/sre"
+	"route256/cart/pkg/lru_cache"
+
+	"go.opentelemetry.io/otel"
+	"golang.org/x/sync/singleflight"
+)
+
+//go:generate minimock -i ProductsProvider -o products_provider_mock_test.go -n ProductsProviderMock -p products_test
+
+type ProductsProvider interface {
+	GetProduct(ctx context.Context, skuId int64) (model.ProductModel, error)
+	GetProducts(ctx context.Context, skuIds []int64) ([]model.ProductModel, error)
+	GetProductsAot(ctx context.Context, count int64, startSkuId int64) ([]model.ProductModel, error)
+}
+
+const productsCacheName = "products"
+const missingProductsCacheName = "missing_products"
+
+// flightTimeout bounds a coalesced upstream request, it is detached from the caller that started it
+// so a cancelled first caller does not fail everyone waiting on the same flight.
+const flightTimeout = 10 * time.Second
+
+// CachedProductsClient is a read-through cache over the products service. Concurrent misses of the same
+// sku are coalesced into a single upstream request, skus answered with 404 are remembered for negativeTtl.
+type CachedProductsClient struct {
+	provider ProductsProvider
+	products *lru_cache.LruCache[int64, model.ProductModel]
+	missing  *lru_cache.LruCache[int64, struct{}]
+	flight   singleflight.Group
+}
+
+func NewCachedProductsClient(provider ProductsProvider, cartConfig *cart_config.Config) *CachedProductsClient {
+	var cacheConfig = cartConfig.Products.Cache
+
+	return &CachedProductsClient{
+		provider: provider,
+		products: lru_cache.NewLruCache[int64, model.ProductModel](lru_cache.Options[int64]{
+			Size:    cacheConfig.Size,
+			Ttl:     cacheConfig.Ttl,
+			OnEvict: trackEviction(productsCacheName),
+		}),
+		missing: lru_cache.NewLruCache[int64, struct{}](lru_cache.Options[int64]{
+			Size:    cacheConfig.Size,
+			Ttl:     cacheConfig.NegativeTtl,
+			OnEvict: trackEviction(missingProductsCacheName),
+		}),
+	}
+}
+
+// IsProductExists implements service.ProductService.
+func (client *CachedProductsClient) IsProductExists(ctx context.Context, skuId int64) (bool, error) {
+	ctx, span := otel.Tracer("client").Start(ctx, "cached_products_client.IsProductExists")
+	defer span.End()
+
+	_, err := client.GetProduct(ctx, skuId)
+	if errors.Is(err, model.ErrProductDoesNotExist) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetProduct implements service.ProductService.
+func (client *CachedProductsClient) GetProduct(ctx context.Context, skuId int64) (model.ProductModel, error) {
+	ctx, span := otel.Tracer("client").Start(ctx, "cached_products_client.GetProduct")
+	defer span.End()
+
+	if product, ok := client.lookup(skuId); ok {
+		return product, nil
+	}
+
+	if client.isMissing(skuId) {
+		return model.ProductModel{}, &model.ErrProductNotFound{SkuId: skuId}
+	}
+
+	product, err := client.do(ctx, strconv.FormatInt(skuId, 10), func(ctx context.Context) (any, error) {
+		product, err := client.provider.GetProduct(ctx, skuId)
+		if errors.Is(err, model.ErrProductDoesNotExist) {
+			client.missing.Set(skuId, struct{}{})
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		client.products.Set(skuId, product)
+		return product, nil
+	})
+	if err != nil {
+		return model.ProductModel{}, err
+	}
+
+	return product.(model.ProductModel), nil
+}
+
+// GetProducts implements service.ProductService.
+// Only the skus missing in cache are requested from upstream, all in a single batch.
+func (client *CachedProductsClient) GetProducts(ctx context.Context, skuIds []int64) ([]model.ProductModel, error) {
+	ctx, span := otel.Tracer("client").Start(ctx, "cached_products_client.GetProducts")
+	defer span.End()
+
+	var products = make([]model.ProductModel, len(skuIds))
+	var misses []int64
+	var missesIndexes []int
+	for i, skuId := range skuIds {
+		product, ok := client.lookup(skuId)
+		if !ok && client.isMissing(skuId) {
+			return nil, &model.ErrProductNotFound{SkuId: skuId}
+		}
+
+		if !ok {
+			misses = append(misses, skuId)
+			missesIndexes = append(missesIndexes, i)
+			continue
+		}
+
+		products[i] = product
+	}
+
+	if len(misses) == 0 {
+		return products, nil
+	}
+
+	fetched, err := client.do(ctx, batchKey(misses), func(ctx context.Context) (any, error) {
+		fetched, err := client.provider.GetProducts(ctx, misses)
+		var notFoundErr *model.ErrProductNotFound
+		if errors.As(err, &notFoundErr) {
+			client.missing.Set(notFoundErr.SkuId, struct{}{})
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, product := range fetched {
+			client.products.Set(product.SkuId, product)
+		}
+
+		return fetched, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// upstream returns products in the order of the requested skus
+	for i, product := range fetched.([]model.ProductModel) {
+		products[missesIndexes[i]] = product
+	}
+
+	return products, nil
+}
+
+// GetProductsAot implements service.ProductService.
+func (client *CachedProductsClient) GetProductsAot(ctx context.Context, count int64, startSkuId int64) ([]model.ProductModel, error) {
+	return client.provider.GetProductsAot(ctx, count, startSkuId)
+}
+
+// do coalesces concurrent calls with the same key, every caller waits for the result on its own ctx.
+func (client *CachedProductsClient) do(ctx context.Context, key string, fn func(ctx context.Context) (any, error)) (any, error) {
+	result := client.flight.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flightTimeout)
+		defer cancel()
+
+		return fn(ctx)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		return res.Val, res.Err
+	}
+}
+
+func (client *CachedProductsClient) lookup(skuId int64) (model.ProductModel, bool) {
+	product, ok := client.products.Get(skuId)
+	sre.TrackCacheRequest(productsCacheName, ok)
+
+	return product, ok
+}
+
+func (client *CachedProductsClient) isMissing(skuId int64) bool {
+	_, ok := client.missing.Get(skuId)
+	sre.TrackCacheRequest(missingProductsCacheName, ok)
+
+	return ok
+}
+
+func trackEviction(cache string) func(int64, lru_cache.EvictReason) {
+	return func(_ int64, reason lru_cache.EvictReason) {
+		sre.TrackCacheEviction(cache, string(reason))
+	}
+}
+
+func batchKey(skuIds []int64) string {
+	var builder strings.Builder
+	builder.WriteString("batch")
+	for _, skuId := range skuIds {
+		builder.WriteByte(':')
+		builder.WriteString(strconv.FormatInt(skuId, 10))
+	}
+
+	return builder.String()
+}
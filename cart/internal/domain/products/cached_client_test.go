@@ -0,0 +1,197 @@
+package products_test
+
+import (
+	"context"
+	"errors"
+	"route256/cart/internal/domain/model"
+	"route256/cart/internal/domain/products"
+	"route256/cart/internal/infra/cart_config"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func newCacheConfig() *cart_config.Config {
+	config := &cart_config.Config{}
+	config.Products.Cache.Size = 10
+	config.Products.Cache.Ttl = time.Minute
+	config.Products.Cache.NegativeTtl = time.Minute
+
+	return config
+}
+
+func TestCachedProductsClient_GetProduct_ShouldCacheProduct(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+	provider := NewProductsProviderMock(mc).
+		GetProductMock.Times(1).Return(model.ProductModel{SkuId: 1, Name: "name1", Price: 100}, nil)
+	client := products.NewCachedProductsClient(provider, newCacheConfig())
+
+	for range 3 {
+		product, err := client.GetProduct(context.Background(), 1)
+		require.NoError(t, err)
+		require.Equal(t, model.ProductModel{SkuId: 1, Name: "name1", Price: 100}, product)
+	}
+}
+
+func TestCachedProductsClient_GetProduct_ShouldNotCacheErrors(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+	provider := NewProductsProviderMock(mc).
+		GetProductMock.Times(2).Return(model.ProductModel{}, errors.New("upstream error"))
+	client := products.NewCachedProductsClient(provider, newCacheConfig())
+
+	for range 2 {
+		_, err := client.GetProduct(context.Background(), 1)
+		require.Error(t, err)
+	}
+}
+
+func TestCachedProductsClient_IsProductExists_ShouldCacheMissingProduct(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+	provider := NewProductsProviderMock(mc).
+		GetProductMock.Times(1).Return(model.ProductModel{}, model.ErrProductDoesNotExist)
+	client := products.NewCachedProductsClient(provider, newCacheConfig())
+
+	for range 3 {
+		exists, err := client.IsProductExists(context.Background(), 1)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+}
+
+func TestCachedProductsClient_GetProduct_ShouldCoalesceConcurrentMisses(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int64
+	var release = make(chan struct{})
+	mc := minimock.NewController(t)
+	provider := NewProductsProviderMock(mc).
+		GetProductMock.Set(func(ctx context.Context, skuId int64) (model.ProductModel, error) {
+		calls.Add(1)
+		<-release
+		return model.ProductModel{SkuId: skuId}, nil
+	})
+	client := products.NewCachedProductsClient(provider, newCacheConfig())
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			product, err := client.GetProduct(context.Background(), 1)
+			require.NoError(t, err)
+			require.Equal(t, int64(1), product.SkuId)
+		}()
+	}
+
+	require.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.Equal(t, int64(1), calls.Load())
+}
+
+func TestCachedProductsClient_GetProducts_ShouldRequestOnlyMisses(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+	provider := NewProductsProviderMock(mc).
+		GetProductMock.Return(model.ProductModel{SkuId: 2, Name: "name2", Price: 200}, nil).
+		GetProductsMock.Expect(minimock.AnyContext, []int64{1, 3}).Return([]model.ProductModel{
+		{SkuId: 1, Name: "name1", Price: 100},
+		{SkuId: 3, Name: "name3", Price: 300},
+	}, nil)
+	client := products.NewCachedProductsClient(provider, newCacheConfig())
+
+	_, err := client.GetProduct(context.Background(), 2)
+	require.NoError(t, err)
+
+	got, err := client.GetProducts(context.Background(), []int64{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, []model.ProductModel{
+		{SkuId: 1, Name: "name1", Price: 100},
+		{SkuId: 2, Name: "name2", Price: 200},
+		{SkuId: 3, Name: "name3", Price: 300},
+	}, got)
+
+	got, err = client.GetProducts(context.Background(), []int64{3, 1})
+	require.NoError(t, err)
+	require.Equal(t, []model.ProductModel{
+		{SkuId: 3, Name: "name3", Price: 300},
+		{SkuId: 1, Name: "name1", Price: 100},
+	}, got)
+}
+
+func TestCachedProductsClient_GetProduct_ShouldNotFailWaitersWhenFirstCallerLeaves(t *testing.T) {
+	t.Parallel()
+	var release = make(chan struct{})
+	var started = make(chan struct{})
+	mc := minimock.NewController(t)
+	provider := NewProductsProviderMock(mc).
+		GetProductMock.Times(1).Set(func(ctx context.Context, skuId int64) (model.ProductModel, error) {
+		close(started)
+		<-release
+		if err := ctx.Err(); err != nil {
+			return model.ProductModel{}, err
+		}
+		return model.ProductModel{SkuId: skuId}, nil
+	})
+	client := products.NewCachedProductsClient(provider, newCacheConfig())
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetProduct(firstCtx, 1)
+		firstErr <- err
+	}()
+	<-started
+
+	secondResult := make(chan error, 1)
+	go func() {
+		product, err := client.GetProduct(context.Background(), 1)
+		if err == nil && product.SkuId != 1 {
+			err = errors.New("unexpected product")
+		}
+		secondResult <- err
+	}()
+
+	cancelFirst()
+	require.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+	require.NoError(t, <-secondResult)
+}
+
+func TestCachedProductsClient_GetProducts_ShouldUseMissingCache(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+	provider := NewProductsProviderMock(mc).
+		GetProductMock.Times(1).Return(model.ProductModel{}, model.ErrProductDoesNotExist)
+	client := products.NewCachedProductsClient(provider, newCacheConfig())
+
+	exists, err := client.IsProductExists(context.Background(), 1)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	_, err = client.GetProducts(context.Background(), []int64{2, 1})
+	require.ErrorIs(t, err, model.ErrProductDoesNotExist)
+}
+
+func TestCachedProductsClient_GetProducts_ShouldCacheMissingProduct(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+	provider := NewProductsProviderMock(mc).
+		GetProductsMock.Times(1).Return(nil, &model.ErrProductNotFound{SkuId: 1})
+	client := products.NewCachedProductsClient(provider, newCacheConfig())
+
+	for range 2 {
+		_, err := client.GetProducts(context.Background(), []int64{2, 1})
+		require.ErrorIs(t, err, model.ErrProductDoesNotExist)
+	}
+	require.Equal(t, uint64(1), provider.GetProductsAfterCounter())
+}
@@ -1,43 +1,80 @@
 package products
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"route256/cart/internal/domain/model"
+	"route256/cart/internal/infra/breaker"
 	"route256/cart/internal/infra/cart_config"
+	"route256/cart/internal/infra/logger"
 	"route256/cart/internal/infra/sre"
 	"route256/cart/internal/infra/tripper"
+	"route256/cart/pkg/route_err_group"
 
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel"
 )
 
 type ProductsClient struct {
-	http    http.Client
-	address string
-	apiKey  string
+	http      http.Client
+	address   string
+	apiKey    string
+	batchSize int
+	// shared by all lookups so the products service sees a process-wide rate
+	limiter *route_err_group.RateLimiter
+
+	// unix nanos until which the batch endpoint is not probed after upstream answered that it does not know it
+	batchUnsupportedUntil atomic.Int64
+	batchReprobe          time.Duration
 }
 
 const TwitterStatusCodeRateLimit = 420
+const DefaultBatchSize = 20
+const DefaultBatchReprobeInterval = time.Minute
+
+var ErrBatchNotSupported = errors.New("products batch lookup is not supported by upstream")
+
+type getProductsBatchRequest struct {
+	Skus []int64 `json:"skus"`
+}
 
 func NewProductsClient(cartConfig *cart_config.Config) *ProductsClient {
 	transport := http.DefaultTransport
 	transport = tripper.NewRetryRoundTripper(transport, tripper.RetryConfig{
-		RetryOn:   []int{http.StatusTooManyRequests, TwitterStatusCodeRateLimit},
-		Times:     3,
-		WaitForMs: 500,
+		RetryOn: []int{http.StatusTooManyRequests, TwitterStatusCodeRateLimit},
+		RetryOnIdempotent: []int{
+			http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout,
+		},
+		Times:      cartConfig.Products.Retry.Times,
+		BaseDelay:  cartConfig.Products.Retry.BaseDelay,
+		MaxDelay:   cartConfig.Products.Retry.MaxDelay,
+		MaxElapsed: cartConfig.Products.Retry.MaxElapsed,
 	})
+	transport = breaker.NewBreakerRoundTripper(transport,
+		breaker.NewCircuitBreaker("products", breaker.ConfigFrom(cartConfig)))
 	address := fmt.Sprintf("http://%s:%s", cartConfig.Products.Host, cartConfig.Products.Port)
 	transport = otelhttp.NewTransport(transport)
 
+	batchSize := cartConfig.Products.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
 	return &ProductsClient{
-		http:    http.Client{Transport: transport},
-		address: address,
-		apiKey:  cartConfig.Products.Token,
+		http:         http.Client{Transport: transport},
+		address:      address,
+		apiKey:       cartConfig.Products.Token,
+		batchSize:    batchSize,
+		batchReprobe: DefaultBatchReprobeInterval,
+		limiter:      route_err_group.NewRateLimiter(float64(cartConfig.Products.Limit), cartConfig.Products.Burst),
 	}
 }
 
@@ -76,6 +113,10 @@ func (client *ProductsClient) GetProduct(ctx context.Context, skuId int64) (mode
 	defer response.Body.Close()
 	sre.TrackExternalRequest("products_get_product", err, startTime)
 
+	if response.StatusCode == http.StatusNotFound {
+		return model.ProductModel{}, &model.ErrProductNotFound{SkuId: skuId}
+	}
+
 	if response.StatusCode != http.StatusOK {
 		return model.ProductModel{}, fmt.Errorf("failed to get product %d", response.StatusCode)
 	}
@@ -88,6 +129,117 @@ func (client *ProductsClient) GetProduct(ctx context.Context, skuId int64) (mode
 	return product, nil
 }
 
+// GetProducts implements service.ProductService.
+// Products are returned in the order of the requested skus. Skus are looked up in chunks of batchSize
+// through the batch endpoint, falling back to the per-sku endpoint if upstream does not support batches.
+// The batch endpoint is probed again once batchReprobe passed, so an upstream upgrade or a stray 404 is picked up.
+func (client *ProductsClient) GetProducts(ctx context.Context, skus []int64) ([]model.ProductModel, error) {
+	ctx, span := otel.Tracer("client").Start(ctx, "products_client.GetProducts")
+	defer span.End()
+
+	if len(skus) == 0 {
+		return nil, nil
+	}
+
+	if time.Now().UnixNano() >= client.batchUnsupportedUntil.Load() {
+		products, err := client.getProductsBatched(ctx, skus)
+		if !errors.Is(err, ErrBatchNotSupported) {
+			return products, err
+		}
+
+		logger.Warn("Products batch lookup is not supported, falling back to per-sku lookup")
+		client.batchUnsupportedUntil.Store(time.Now().Add(client.batchReprobe).UnixNano())
+	}
+
+	return client.getProductsOneByOne(ctx, skus)
+}
+
+func (client *ProductsClient) getProductsBatched(ctx context.Context, skus []int64) ([]model.ProductModel, error) {
+	var chunksCount = (len(skus) + client.batchSize - 1) / client.batchSize
+	var group = route_err_group.NewRouteErrorGroup[[]model.ProductModel](ctx,
+		route_err_group.Options{BufferSize: chunksCount})
+
+	for start := 0; start < len(skus); start += client.batchSize {
+		var chunk = skus[start:min(start+client.batchSize, len(skus))]
+		group.Run(func(ctx context.Context) ([]model.ProductModel, error) {
+			return client.getProductsChunk(ctx, chunk)
+		})
+	}
+
+	chunks, err := group.Await()
+	if err != nil {
+		return nil, err
+	}
+
+	var bySku = make(map[int64]model.ProductModel, len(skus))
+	for _, chunk := range chunks {
+		for _, product := range chunk {
+			bySku[product.SkuId] = product
+		}
+	}
+
+	var products = make([]model.ProductModel, 0, len(skus))
+	for _, sku := range skus {
+		product, ok := bySku[sku]
+		if !ok {
+			return nil, &model.ErrProductNotFound{SkuId: sku}
+		}
+
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+func (client *ProductsClient) getProductsChunk(ctx context.Context, skus []int64) ([]model.ProductModel, error) {
+	body, err := json.Marshal(getProductsBatchRequest{Skus: skus})
+	if err != nil {
+		return nil, err
+	}
+
+	var url = fmt.Sprintf("%s/product/batch", client.address)
+	startTime := time.Now()
+	response, err := client.doRequestWithBody(ctx, http.MethodPost, url, bytes.NewReader(body))
+	sre.TrackExternalRequest("products_get_products_batch", err, startTime)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return nil, ErrBatchNotSupported
+	default:
+		return nil, fmt.Errorf("failed to get products batch %d", response.StatusCode)
+	}
+
+	var products []model.ProductModel
+	if err := json.NewDecoder(response.Body).Decode(&products); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (client *ProductsClient) getProductsOneByOne(ctx context.Context, skus []int64) ([]model.ProductModel, error) {
+	var group = route_err_group.NewRouteErrorGroup[model.ProductModel](ctx,
+		route_err_group.Options{BufferSize: len(skus)})
+
+	for _, sku := range skus {
+		group.Run(func(ctx context.Context) (model.ProductModel, error) {
+			product, err := client.GetProduct(ctx, sku)
+			if err != nil {
+				return model.ProductModel{}, fmt.Errorf("product %d not found: %w", sku, err)
+			}
+
+			return product, nil
+		})
+	}
+
+	return group.Await()
+}
+
 // IsProductExists implements service.ProductService.
 func (client *ProductsClient) IsProductExists(ctx context.Context, skuId int64) (bool, error) {
 	ctx, span := otel.Tracer("client").Start(ctx, "products_client.IsProductExists")
@@ -106,12 +258,26 @@ func (client *ProductsClient) IsProductExists(ctx context.Context, skuId int64)
 }
 
 func (client *ProductsClient) doRequest(ctx context.Context, url string) (*http.Response, error) {
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	return client.doRequestWithBody(ctx, http.MethodGet, url, http.NoBody)
+}
+
+// doRequestWithBody is the single path to upstream, so every lookup is throttled by the same limiter.
+func (client *ProductsClient) doRequestWithBody(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+	if client.limiter != nil {
+		if err := client.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
 
 	request.Header.Set("X-API-KEY", client.apiKey)
+	if body != http.NoBody {
+		request.Header.Set("Content-Type", "application/json")
+	}
 	response, err := client.http.Do(request)
 	if err != nil {
 		return nil, err
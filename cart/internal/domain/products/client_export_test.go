@@ -2,12 +2,37 @@ package products
 
 import (
 	"net/http"
+	"route256/cart/pkg/route_err_group"
+	"time"
 )
 
 func NewProductsClientForTest(transport http.RoundTripper, address, apiKey string) *ProductsClient {
 	return &ProductsClient{
-		http:    http.Client{Transport: transport},
-		address: address,
-		apiKey:  apiKey,
+		http:         http.Client{Transport: transport},
+		address:      address,
+		apiKey:       apiKey,
+		batchSize:    DefaultBatchSize,
+		batchReprobe: DefaultBatchReprobeInterval,
 	}
 }
+
+func NewProductsClientWithBatchForTest(transport http.RoundTripper, batchSize int) *ProductsClient {
+	client := NewProductsClientForTest(transport, "test", "test")
+	client.batchSize = batchSize
+
+	return client
+}
+
+func NewProductsClientWithReprobeForTest(transport http.RoundTripper, reprobe time.Duration) *ProductsClient {
+	client := NewProductsClientForTest(transport, "test", "test")
+	client.batchReprobe = reprobe
+
+	return client
+}
+
+func NewProductsClientWithLimiterForTest(transport http.RoundTripper, limit float64, burst int) *ProductsClient {
+	client := NewProductsClientForTest(transport, "test", "test")
+	client.limiter = route_err_group.NewRateLimiter(limit, burst)
+
+	return client
+}
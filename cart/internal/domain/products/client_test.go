@@ -9,7 +9,10 @@ import (
 	"reflect"
 	"route256/cart/internal/domain/model"
 	"route256/cart/internal/domain/products"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gojuno/minimock/v3"
 )
@@ -188,3 +191,165 @@ func TestProductsClient_IsProductExists(t *testing.T) {
 		})
 	}
 }
+
+func TestProductsClient_GetProducts(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+
+	respond := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader([]byte(body)))}
+	}
+
+	tests := []struct {
+		name      string
+		skus      []int64
+		batchSize int
+		transport http.RoundTripper
+		want      []model.ProductModel
+		wantErr   bool
+	}{
+		{
+			name:      "should return products in the requested order using chunked batches",
+			skus:      []int64{102, 101, 103},
+			batchSize: 2,
+			transport: NewRoundTripperMock(mc).RoundTripMock.Set(func(request *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(request.Body)
+				if string(body) == `{"skus":[102,101]}` {
+					return respond(http.StatusOK,
+						`[{"sku":101,"name":"name1","price":100},{"sku":102,"name":"name2","price":200}]`), nil
+				}
+				return respond(http.StatusOK, `[{"sku":103,"name":"name3","price":300}]`), nil
+			}),
+			want: []model.ProductModel{
+				{SkuId: 102, Name: "name2", Price: 200},
+				{SkuId: 101, Name: "name1", Price: 100},
+				{SkuId: 103, Name: "name3", Price: 300},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "should fall back to per sku lookup if batch is not supported",
+			skus:      []int64{101, 102},
+			batchSize: 20,
+			transport: NewRoundTripperMock(mc).RoundTripMock.Set(func(request *http.Request) (*http.Response, error) {
+				switch {
+				case strings.HasSuffix(request.URL.Path, "/product/101"):
+					return respond(http.StatusOK, `{"sku":101,"name":"name1","price":100}`), nil
+				case strings.HasSuffix(request.URL.Path, "/product/102"):
+					return respond(http.StatusOK, `{"sku":102,"name":"name2","price":200}`), nil
+				default:
+					return respond(http.StatusNotFound, ``), nil
+				}
+			}),
+			want: []model.ProductModel{
+				{SkuId: 101, Name: "name1", Price: 100},
+				{SkuId: 102, Name: "name2", Price: 200},
+			},
+			wantErr: false,
+		},
+		{
+			name:      "should fail if batch response misses a product",
+			skus:      []int64{101, 102},
+			batchSize: 20,
+			transport: NewRoundTripperMock(mc).
+				RoundTripMock.Return(respond(http.StatusOK, `[{"sku":101,"name":"name1","price":100}]`), nil),
+			wantErr: true,
+		},
+		{
+			name:      "should fail on the batch transport error",
+			skus:      []int64{101},
+			batchSize: 20,
+			transport: NewRoundTripperMock(mc).
+				RoundTripMock.Return(nil, errors.New("transport error")),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := products.NewProductsClientWithBatchForTest(tt.transport, tt.batchSize)
+			got, err := client.GetProducts(context.Background(), tt.skus)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProductsClient.GetProducts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProductsClient.GetProducts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductsClient_GetProducts_ReprobesBatch(t *testing.T) {
+	t.Parallel()
+
+	respond := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader([]byte(body)))}
+	}
+
+	tests := []struct {
+		name       string
+		reprobe    time.Duration
+		wantProbes int32
+	}{
+		{
+			name:       "should not probe batch again until the reprobe interval passed",
+			reprobe:    time.Hour,
+			wantProbes: 1,
+		},
+		{
+			name:       "should probe batch again once the reprobe interval passed",
+			reprobe:    0,
+			wantProbes: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mc := minimock.NewController(t)
+			var probes atomic.Int32
+			transport := NewRoundTripperMock(mc).RoundTripMock.Set(func(request *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(request.URL.Path, "/product/batch") {
+					probes.Add(1)
+					return respond(http.StatusNotFound, ``), nil
+				}
+				return respond(http.StatusOK, `{"sku":101,"name":"name1","price":100}`), nil
+			})
+
+			client := products.NewProductsClientWithReprobeForTest(transport, tt.reprobe)
+			for range 2 {
+				got, err := client.GetProducts(context.Background(), []int64{101})
+				if err != nil {
+					t.Fatalf("ProductsClient.GetProducts() error = %v", err)
+				}
+				if len(got) != 1 || got[0].SkuId != 101 {
+					t.Fatalf("ProductsClient.GetProducts() = %v", got)
+				}
+			}
+
+			if probes.Load() != tt.wantProbes {
+				t.Errorf("batch probes = %d, want %d", probes.Load(), tt.wantProbes)
+			}
+		})
+	}
+}
+
+func TestProductsClient_SharesLimiterBetweenLookups(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+	transport := NewRoundTripperMock(mc).RoundTripMock.Times(1).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"sku":101,"name":"name1","price":100}`)),
+	}, nil)
+	client := products.NewProductsClientWithLimiterForTest(transport, 0.001, 1)
+
+	if _, err := client.GetProduct(context.Background(), 101); err != nil {
+		t.Fatalf("ProductsClient.GetProduct() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := client.IsProductExists(ctx, 101); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ProductsClient.IsProductExists() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,1161 @@
+// Code generated by http://github.com/gojuno/minimock (v3.4.5). DO NOT EDIT.
+
+package products_test
+
+//go:generate minimock -i route256/cart/internal/domain/products.ProductsProvider -o products_provider_mock_test.go -n ProductsProviderMock -p products_test
+
+import (
+	"context"
+	"route256/cart/internal/domain/model"
+	"sync"
+	mm_atomic "sync/atomic"
+	mm_time "time"
+
+	"github.com/gojuno/minimock/v3"
+)
+
+// ProductsProviderMock implements ProductsProvider
+type ProductsProviderMock struct {
+	t          minimock.Tester
+	finishOnce sync.Once
+
+	funcGetProduct          func(ctx context.Context, skuId int64) (p1 model.ProductModel, err error)
+	funcGetProductOrigin    string
+	inspectFuncGetProduct   func(ctx context.Context, skuId int64)
+	afterGetProductCounter  uint64
+	beforeGetProductCounter uint64
+	GetProductMock          mProductsProviderMockGetProduct
+
+	funcGetProducts          func(ctx context.Context, skuIds []int64) (pa1 []model.ProductModel, err error)
+	funcGetProductsOrigin    string
+	inspectFuncGetProducts   func(ctx context.Context, skuIds []int64)
+	afterGetProductsCounter  uint64
+	beforeGetProductsCounter uint64
+	GetProductsMock          mProductsProviderMockGetProducts
+
+	funcGetProductsAot          func(ctx context.Context, count int64, startSkuId int64) (pa1 []model.ProductModel, err error)
+	funcGetProductsAotOrigin    string
+	inspectFuncGetProductsAot   func(ctx context.Context, count int64, startSkuId int64)
+	afterGetProductsAotCounter  uint64
+	beforeGetProductsAotCounter uint64
+	GetProductsAotMock          mProductsProviderMockGetProductsAot
+}
+
+// NewProductsProviderMock returns a mock for ProductsProvider
+func NewProductsProviderMock(t minimock.Tester) *ProductsProviderMock {
+	m := &ProductsProviderMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.GetProductMock = mProductsProviderMockGetProduct{mock: m}
+	m.GetProductMock.callArgs = []*ProductsProviderMockGetProductParams{}
+
+	m.GetProductsMock = mProductsProviderMockGetProducts{mock: m}
+	m.GetProductsMock.callArgs = []*ProductsProviderMockGetProductsParams{}
+
+	m.GetProductsAotMock = mProductsProviderMockGetProductsAot{mock: m}
+	m.GetProductsAotMock.callArgs = []*ProductsProviderMockGetProductsAotParams{}
+
+	t.Cleanup(m.MinimockFinish)
+
+	return m
+}
+
+type mProductsProviderMockGetProduct struct {
+	optional           bool
+	mock               *ProductsProviderMock
+	defaultExpectation *ProductsProviderMockGetProductExpectation
+	expectations       []*ProductsProviderMockGetProductExpectation
+
+	callArgs []*ProductsProviderMockGetProductParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ProductsProviderMockGetProductExpectation specifies expectation struct of the ProductsProvider.GetProduct
+type ProductsProviderMockGetProductExpectation struct {
+	mock               *ProductsProviderMock
+	params             *ProductsProviderMockGetProductParams
+	paramPtrs          *ProductsProviderMockGetProductParamPtrs
+	expectationOrigins ProductsProviderMockGetProductExpectationOrigins
+	results            *ProductsProviderMockGetProductResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ProductsProviderMockGetProductParams contains parameters of the ProductsProvider.GetProduct
+type ProductsProviderMockGetProductParams struct {
+	ctx   context.Context
+	skuId int64
+}
+
+// ProductsProviderMockGetProductParamPtrs contains pointers to parameters of the ProductsProvider.GetProduct
+type ProductsProviderMockGetProductParamPtrs struct {
+	ctx   *context.Context
+	skuId *int64
+}
+
+// ProductsProviderMockGetProductResults contains results of the ProductsProvider.GetProduct
+type ProductsProviderMockGetProductResults struct {
+	p1  model.ProductModel
+	err error
+}
+
+// ProductsProviderMockGetProductOrigins contains origins of expectations of the ProductsProvider.GetProduct
+type ProductsProviderMockGetProductExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originSkuId string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetProduct *mProductsProviderMockGetProduct) Optional() *mProductsProviderMockGetProduct {
+	mmGetProduct.optional = true
+	return mmGetProduct
+}
+
+// Expect sets up expected params for ProductsProvider.GetProduct
+func (mmGetProduct *mProductsProviderMockGetProduct) Expect(ctx context.Context, skuId int64) *mProductsProviderMockGetProduct {
+	if mmGetProduct.mock.funcGetProduct != nil {
+		mmGetProduct.mock.t.Fatalf("ProductsProviderMock.GetProduct mock is already set by Set")
+	}
+
+	if mmGetProduct.defaultExpectation == nil {
+		mmGetProduct.defaultExpectation = &ProductsProviderMockGetProductExpectation{}
+	}
+
+	if mmGetProduct.defaultExpectation.paramPtrs != nil {
+		mmGetProduct.mock.t.Fatalf("ProductsProviderMock.GetProduct mock is already set by ExpectParams functions")
+	}
+
+	mmGetProduct.defaultExpectation.params = &ProductsProviderMockGetProductParams{ctx, skuId}
+	mmGetProduct.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetProduct.expectations {
+		if minimock.Equal(e.params, mmGetProduct.defaultExpectation.params) {
+			mmGetProduct.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetProduct.defaultExpectation.params)
+		}
+	}
+
+	return mmGetProduct
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ProductsProvider.GetProduct
+func (mmGetProduct *mProductsProviderMockGetProduct) ExpectCtxParam1(ctx context.Context) *mProductsProviderMockGetProduct {
+	if mmGetProduct.mock.funcGetProduct != nil {
+		mmGetProduct.mock.t.Fatalf("ProductsProviderMock.GetProduct mock is already set by Set")
+	}
+
+	if mmGetProduct.defaultExpectation == nil {
+		mmGetProduct.defaultExpectation = &ProductsProviderMockGetProductExpectation{}
+	}
+
+	if mmGetProduct.defaultExpectation.params != nil {
+		mmGetProduct.mock.t.Fatalf("ProductsProviderMock.GetProduct mock is already set by Expect")
+	}
+
+	if mmGetProduct.defaultExpectation.paramPtrs == nil {
+		mmGetProduct.defaultExpectation.paramPtrs = &ProductsProviderMockGetProductParamPtrs{}
+	}
+	mmGetProduct.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetProduct.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetProduct
+}
+
+// ExpectSkuIdParam2 sets up expected param skuId for ProductsProvider.GetProduct
+func (mmGetProduct *mProductsProviderMockGetProduct) ExpectSkuIdParam2(skuId int64) *mProductsProviderMockGetProduct {
+	if mmGetProduct.mock.funcGetProduct != nil {
+		mmGetProduct.mock.t.Fatalf("ProductsProviderMock.GetProduct mock is already set by Set")
+	}
+
+	if mmGetProduct.defaultExpectation == nil {
+		mmGetProduct.defaultExpectation = &ProductsProviderMockGetProductExpectation{}
+	}
+
+	if mmGetProduct.defaultExpectation.params != nil {
+		mmGetProduct.mock.t.Fatalf("ProductsProviderMock.GetProduct mock is already set by Expect")
+	}
+
+	if mmGetProduct.defaultExpectation.paramPtrs == nil {
+		mmGetProduct.defaultExpectation.paramPtrs = &ProductsProviderMockGetProductParamPtrs{}
+	}
+	mmGetProduct.defaultExpectation.paramPtrs.skuId = &skuId
+	mmGetProduct.defaultExpectation.expectationOrigins.originSkuId = minimock.CallerInfo(1)
+
+	return mmGetProduct
+}
+
+// Inspect accepts an inspector function that has same arguments as the ProductsProvider.GetProduct
+func (mmGetProduct *mProductsProviderMockGetProduct) Inspect(f func(ctx context.Context, skuId int64)) *mProductsProviderMockGetProduct {
+	if mmGetProduct.mock.inspectFuncGetProduct != nil {
+		mmGetProduct.mock.t.Fatalf("Inspect function is already set for ProductsProviderMock.GetProduct")
+	}
+
+	mmGetProduct.mock.inspectFuncGetProduct = f
+
+	return mmGetProduct
+}
+
+// Return sets up results that will be returned by ProductsProvider.GetProduct
+func (mmGetProduct *mProductsProviderMockGetProduct) Return(p1 model.ProductModel, err error) *ProductsProviderMock {
+	if mmGetProduct.mock.funcGetProduct != nil {
+		mmGetProduct.mock.t.Fatalf("ProductsProviderMock.GetProduct mock is already set by Set")
+	}
+
+	if mmGetProduct.defaultExpectation == nil {
+		mmGetProduct.defaultExpectation = &ProductsProviderMockGetProductExpectation{mock: mmGetProduct.mock}
+	}
+	mmGetProduct.defaultExpectation.results = &ProductsProviderMockGetProductResults{p1, err}
+	mmGetProduct.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetProduct.mock
+}
+
+// Set uses given function f to mock the ProductsProvider.GetProduct method
+func (mmGetProduct *mProductsProviderMockGetProduct) Set(f func(ctx context.Context, skuId int64) (p1 model.ProductModel, err error)) *ProductsProviderMock {
+	if mmGetProduct.defaultExpectation != nil {
+		mmGetProduct.mock.t.Fatalf("Default expectation is already set for the ProductsProvider.GetProduct method")
+	}
+
+	if len(mmGetProduct.expectations) > 0 {
+		mmGetProduct.mock.t.Fatalf("Some expectations are already set for the ProductsProvider.GetProduct method")
+	}
+
+	mmGetProduct.mock.funcGetProduct = f
+	mmGetProduct.mock.funcGetProductOrigin = minimock.CallerInfo(1)
+	return mmGetProduct.mock
+}
+
+// When sets expectation for the ProductsProvider.GetProduct which will trigger the result defined by the following
+// Then helper
+func (mmGetProduct *mProductsProviderMockGetProduct) When(ctx context.Context, skuId int64) *ProductsProviderMockGetProductExpectation {
+	if mmGetProduct.mock.funcGetProduct != nil {
+		mmGetProduct.mock.t.Fatalf("ProductsProviderMock.GetProduct mock is already set by Set")
+	}
+
+	expectation := &ProductsProviderMockGetProductExpectation{
+		mock:               mmGetProduct.mock,
+		params:             &ProductsProviderMockGetProductParams{ctx, skuId},
+		expectationOrigins: ProductsProviderMockGetProductExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetProduct.expectations = append(mmGetProduct.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ProductsProvider.GetProduct return parameters for the expectation previously defined by the When method
+func (e *ProductsProviderMockGetProductExpectation) Then(p1 model.ProductModel, err error) *ProductsProviderMock {
+	e.results = &ProductsProviderMockGetProductResults{p1, err}
+	return e.mock
+}
+
+// Times sets number of times ProductsProvider.GetProduct should be invoked
+func (mmGetProduct *mProductsProviderMockGetProduct) Times(n uint64) *mProductsProviderMockGetProduct {
+	if n == 0 {
+		mmGetProduct.mock.t.Fatalf("Times of ProductsProviderMock.GetProduct mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetProduct.expectedInvocations, n)
+	mmGetProduct.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetProduct
+}
+
+func (mmGetProduct *mProductsProviderMockGetProduct) invocationsDone() bool {
+	if len(mmGetProduct.expectations) == 0 && mmGetProduct.defaultExpectation == nil && mmGetProduct.mock.funcGetProduct == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetProduct.mock.afterGetProductCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetProduct.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetProduct implements ProductsProvider
+func (mmGetProduct *ProductsProviderMock) GetProduct(ctx context.Context, skuId int64) (p1 model.ProductModel, err error) {
+	mm_atomic.AddUint64(&mmGetProduct.beforeGetProductCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetProduct.afterGetProductCounter, 1)
+
+	mmGetProduct.t.Helper()
+
+	if mmGetProduct.inspectFuncGetProduct != nil {
+		mmGetProduct.inspectFuncGetProduct(ctx, skuId)
+	}
+
+	mm_params := ProductsProviderMockGetProductParams{ctx, skuId}
+
+	// Record call args
+	mmGetProduct.GetProductMock.mutex.Lock()
+	mmGetProduct.GetProductMock.callArgs = append(mmGetProduct.GetProductMock.callArgs, &mm_params)
+	mmGetProduct.GetProductMock.mutex.Unlock()
+
+	for _, e := range mmGetProduct.GetProductMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.p1, e.results.err
+		}
+	}
+
+	if mmGetProduct.GetProductMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetProduct.GetProductMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetProduct.GetProductMock.defaultExpectation.params
+		mm_want_ptrs := mmGetProduct.GetProductMock.defaultExpectation.paramPtrs
+
+		mm_got := ProductsProviderMockGetProductParams{ctx, skuId}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetProduct.t.Errorf("ProductsProviderMock.GetProduct got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetProduct.GetProductMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.skuId != nil && !minimock.Equal(*mm_want_ptrs.skuId, mm_got.skuId) {
+				mmGetProduct.t.Errorf("ProductsProviderMock.GetProduct got unexpected parameter skuId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetProduct.GetProductMock.defaultExpectation.expectationOrigins.originSkuId, *mm_want_ptrs.skuId, mm_got.skuId, minimock.Diff(*mm_want_ptrs.skuId, mm_got.skuId))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetProduct.t.Errorf("ProductsProviderMock.GetProduct got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetProduct.GetProductMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetProduct.GetProductMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetProduct.t.Fatal("No results are set for the ProductsProviderMock.GetProduct")
+		}
+		return (*mm_results).p1, (*mm_results).err
+	}
+	if mmGetProduct.funcGetProduct != nil {
+		return mmGetProduct.funcGetProduct(ctx, skuId)
+	}
+	mmGetProduct.t.Fatalf("Unexpected call to ProductsProviderMock.GetProduct. %v %v", ctx, skuId)
+	return
+}
+
+// GetProductAfterCounter returns a count of finished ProductsProviderMock.GetProduct invocations
+func (mmGetProduct *ProductsProviderMock) GetProductAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetProduct.afterGetProductCounter)
+}
+
+// GetProductBeforeCounter returns a count of ProductsProviderMock.GetProduct invocations
+func (mmGetProduct *ProductsProviderMock) GetProductBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetProduct.beforeGetProductCounter)
+}
+
+// Calls returns a list of arguments used in each call to ProductsProviderMock.GetProduct.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetProduct *mProductsProviderMockGetProduct) Calls() []*ProductsProviderMockGetProductParams {
+	mmGetProduct.mutex.RLock()
+
+	argCopy := make([]*ProductsProviderMockGetProductParams, len(mmGetProduct.callArgs))
+	copy(argCopy, mmGetProduct.callArgs)
+
+	mmGetProduct.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetProductDone returns true if the count of the GetProduct invocations corresponds
+// the number of defined expectations
+func (m *ProductsProviderMock) MinimockGetProductDone() bool {
+	if m.GetProductMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetProductMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetProductMock.invocationsDone()
+}
+
+// MinimockGetProductInspect logs each unmet expectation
+func (m *ProductsProviderMock) MinimockGetProductInspect() {
+	for _, e := range m.GetProductMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ProductsProviderMock.GetProduct at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetProductCounter := mm_atomic.LoadUint64(&m.afterGetProductCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetProductMock.defaultExpectation != nil && afterGetProductCounter < 1 {
+		if m.GetProductMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ProductsProviderMock.GetProduct at\n%s", m.GetProductMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ProductsProviderMock.GetProduct at\n%s with params: %#v", m.GetProductMock.defaultExpectation.expectationOrigins.origin, *m.GetProductMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetProduct != nil && afterGetProductCounter < 1 {
+		m.t.Errorf("Expected call to ProductsProviderMock.GetProduct at\n%s", m.funcGetProductOrigin)
+	}
+
+	if !m.GetProductMock.invocationsDone() && afterGetProductCounter > 0 {
+		m.t.Errorf("Expected %d calls to ProductsProviderMock.GetProduct at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetProductMock.expectedInvocations), m.GetProductMock.expectedInvocationsOrigin, afterGetProductCounter)
+	}
+}
+
+type mProductsProviderMockGetProducts struct {
+	optional           bool
+	mock               *ProductsProviderMock
+	defaultExpectation *ProductsProviderMockGetProductsExpectation
+	expectations       []*ProductsProviderMockGetProductsExpectation
+
+	callArgs []*ProductsProviderMockGetProductsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ProductsProviderMockGetProductsExpectation specifies expectation struct of the ProductsProvider.GetProducts
+type ProductsProviderMockGetProductsExpectation struct {
+	mock               *ProductsProviderMock
+	params             *ProductsProviderMockGetProductsParams
+	paramPtrs          *ProductsProviderMockGetProductsParamPtrs
+	expectationOrigins ProductsProviderMockGetProductsExpectationOrigins
+	results            *ProductsProviderMockGetProductsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ProductsProviderMockGetProductsParams contains parameters of the ProductsProvider.GetProducts
+type ProductsProviderMockGetProductsParams struct {
+	ctx    context.Context
+	skuIds []int64
+}
+
+// ProductsProviderMockGetProductsParamPtrs contains pointers to parameters of the ProductsProvider.GetProducts
+type ProductsProviderMockGetProductsParamPtrs struct {
+	ctx    *context.Context
+	skuIds *[]int64
+}
+
+// ProductsProviderMockGetProductsResults contains results of the ProductsProvider.GetProducts
+type ProductsProviderMockGetProductsResults struct {
+	pa1 []model.ProductModel
+	err error
+}
+
+// ProductsProviderMockGetProductsOrigins contains origins of expectations of the ProductsProvider.GetProducts
+type ProductsProviderMockGetProductsExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originSkuIds string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetProducts *mProductsProviderMockGetProducts) Optional() *mProductsProviderMockGetProducts {
+	mmGetProducts.optional = true
+	return mmGetProducts
+}
+
+// Expect sets up expected params for ProductsProvider.GetProducts
+func (mmGetProducts *mProductsProviderMockGetProducts) Expect(ctx context.Context, skuIds []int64) *mProductsProviderMockGetProducts {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductsProviderMock.GetProducts mock is already set by Set")
+	}
+
+	if mmGetProducts.defaultExpectation == nil {
+		mmGetProducts.defaultExpectation = &ProductsProviderMockGetProductsExpectation{}
+	}
+
+	if mmGetProducts.defaultExpectation.paramPtrs != nil {
+		mmGetProducts.mock.t.Fatalf("ProductsProviderMock.GetProducts mock is already set by ExpectParams functions")
+	}
+
+	mmGetProducts.defaultExpectation.params = &ProductsProviderMockGetProductsParams{ctx, skuIds}
+	mmGetProducts.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetProducts.expectations {
+		if minimock.Equal(e.params, mmGetProducts.defaultExpectation.params) {
+			mmGetProducts.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetProducts.defaultExpectation.params)
+		}
+	}
+
+	return mmGetProducts
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ProductsProvider.GetProducts
+func (mmGetProducts *mProductsProviderMockGetProducts) ExpectCtxParam1(ctx context.Context) *mProductsProviderMockGetProducts {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductsProviderMock.GetProducts mock is already set by Set")
+	}
+
+	if mmGetProducts.defaultExpectation == nil {
+		mmGetProducts.defaultExpectation = &ProductsProviderMockGetProductsExpectation{}
+	}
+
+	if mmGetProducts.defaultExpectation.params != nil {
+		mmGetProducts.mock.t.Fatalf("ProductsProviderMock.GetProducts mock is already set by Expect")
+	}
+
+	if mmGetProducts.defaultExpectation.paramPtrs == nil {
+		mmGetProducts.defaultExpectation.paramPtrs = &ProductsProviderMockGetProductsParamPtrs{}
+	}
+	mmGetProducts.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetProducts.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetProducts
+}
+
+// ExpectSkuIdsParam2 sets up expected param skuIds for ProductsProvider.GetProducts
+func (mmGetProducts *mProductsProviderMockGetProducts) ExpectSkuIdsParam2(skuIds []int64) *mProductsProviderMockGetProducts {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductsProviderMock.GetProducts mock is already set by Set")
+	}
+
+	if mmGetProducts.defaultExpectation == nil {
+		mmGetProducts.defaultExpectation = &ProductsProviderMockGetProductsExpectation{}
+	}
+
+	if mmGetProducts.defaultExpectation.params != nil {
+		mmGetProducts.mock.t.Fatalf("ProductsProviderMock.GetProducts mock is already set by Expect")
+	}
+
+	if mmGetProducts.defaultExpectation.paramPtrs == nil {
+		mmGetProducts.defaultExpectation.paramPtrs = &ProductsProviderMockGetProductsParamPtrs{}
+	}
+	mmGetProducts.defaultExpectation.paramPtrs.skuIds = &skuIds
+	mmGetProducts.defaultExpectation.expectationOrigins.originSkuIds = minimock.CallerInfo(1)
+
+	return mmGetProducts
+}
+
+// Inspect accepts an inspector function that has same arguments as the ProductsProvider.GetProducts
+func (mmGetProducts *mProductsProviderMockGetProducts) Inspect(f func(ctx context.Context, skuIds []int64)) *mProductsProviderMockGetProducts {
+	if mmGetProducts.mock.inspectFuncGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("Inspect function is already set for ProductsProviderMock.GetProducts")
+	}
+
+	mmGetProducts.mock.inspectFuncGetProducts = f
+
+	return mmGetProducts
+}
+
+// Return sets up results that will be returned by ProductsProvider.GetProducts
+func (mmGetProducts *mProductsProviderMockGetProducts) Return(pa1 []model.ProductModel, err error) *ProductsProviderMock {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductsProviderMock.GetProducts mock is already set by Set")
+	}
+
+	if mmGetProducts.defaultExpectation == nil {
+		mmGetProducts.defaultExpectation = &ProductsProviderMockGetProductsExpectation{mock: mmGetProducts.mock}
+	}
+	mmGetProducts.defaultExpectation.results = &ProductsProviderMockGetProductsResults{pa1, err}
+	mmGetProducts.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetProducts.mock
+}
+
+// Set uses given function f to mock the ProductsProvider.GetProducts method
+func (mmGetProducts *mProductsProviderMockGetProducts) Set(f func(ctx context.Context, skuIds []int64) (pa1 []model.ProductModel, err error)) *ProductsProviderMock {
+	if mmGetProducts.defaultExpectation != nil {
+		mmGetProducts.mock.t.Fatalf("Default expectation is already set for the ProductsProvider.GetProducts method")
+	}
+
+	if len(mmGetProducts.expectations) > 0 {
+		mmGetProducts.mock.t.Fatalf("Some expectations are already set for the ProductsProvider.GetProducts method")
+	}
+
+	mmGetProducts.mock.funcGetProducts = f
+	mmGetProducts.mock.funcGetProductsOrigin = minimock.CallerInfo(1)
+	return mmGetProducts.mock
+}
+
+// When sets expectation for the ProductsProvider.GetProducts which will trigger the result defined by the following
+// Then helper
+func (mmGetProducts *mProductsProviderMockGetProducts) When(ctx context.Context, skuIds []int64) *ProductsProviderMockGetProductsExpectation {
+	if mmGetProducts.mock.funcGetProducts != nil {
+		mmGetProducts.mock.t.Fatalf("ProductsProviderMock.GetProducts mock is already set by Set")
+	}
+
+	expectation := &ProductsProviderMockGetProductsExpectation{
+		mock:               mmGetProducts.mock,
+		params:             &ProductsProviderMockGetProductsParams{ctx, skuIds},
+		expectationOrigins: ProductsProviderMockGetProductsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetProducts.expectations = append(mmGetProducts.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ProductsProvider.GetProducts return parameters for the expectation previously defined by the When method
+func (e *ProductsProviderMockGetProductsExpectation) Then(pa1 []model.ProductModel, err error) *ProductsProviderMock {
+	e.results = &ProductsProviderMockGetProductsResults{pa1, err}
+	return e.mock
+}
+
+// Times sets number of times ProductsProvider.GetProducts should be invoked
+func (mmGetProducts *mProductsProviderMockGetProducts) Times(n uint64) *mProductsProviderMockGetProducts {
+	if n == 0 {
+		mmGetProducts.mock.t.Fatalf("Times of ProductsProviderMock.GetProducts mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetProducts.expectedInvocations, n)
+	mmGetProducts.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetProducts
+}
+
+func (mmGetProducts *mProductsProviderMockGetProducts) invocationsDone() bool {
+	if len(mmGetProducts.expectations) == 0 && mmGetProducts.defaultExpectation == nil && mmGetProducts.mock.funcGetProducts == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetProducts.mock.afterGetProductsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetProducts.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetProducts implements ProductsProvider
+func (mmGetProducts *ProductsProviderMock) GetProducts(ctx context.Context, skuIds []int64) (pa1 []model.ProductModel, err error) {
+	mm_atomic.AddUint64(&mmGetProducts.beforeGetProductsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetProducts.afterGetProductsCounter, 1)
+
+	mmGetProducts.t.Helper()
+
+	if mmGetProducts.inspectFuncGetProducts != nil {
+		mmGetProducts.inspectFuncGetProducts(ctx, skuIds)
+	}
+
+	mm_params := ProductsProviderMockGetProductsParams{ctx, skuIds}
+
+	// Record call args
+	mmGetProducts.GetProductsMock.mutex.Lock()
+	mmGetProducts.GetProductsMock.callArgs = append(mmGetProducts.GetProductsMock.callArgs, &mm_params)
+	mmGetProducts.GetProductsMock.mutex.Unlock()
+
+	for _, e := range mmGetProducts.GetProductsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.pa1, e.results.err
+		}
+	}
+
+	if mmGetProducts.GetProductsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetProducts.GetProductsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetProducts.GetProductsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetProducts.GetProductsMock.defaultExpectation.paramPtrs
+
+		mm_got := ProductsProviderMockGetProductsParams{ctx, skuIds}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetProducts.t.Errorf("ProductsProviderMock.GetProducts got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetProducts.GetProductsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.skuIds != nil && !minimock.Equal(*mm_want_ptrs.skuIds, mm_got.skuIds) {
+				mmGetProducts.t.Errorf("ProductsProviderMock.GetProducts got unexpected parameter skuIds, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetProducts.GetProductsMock.defaultExpectation.expectationOrigins.originSkuIds, *mm_want_ptrs.skuIds, mm_got.skuIds, minimock.Diff(*mm_want_ptrs.skuIds, mm_got.skuIds))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetProducts.t.Errorf("ProductsProviderMock.GetProducts got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetProducts.GetProductsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetProducts.GetProductsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetProducts.t.Fatal("No results are set for the ProductsProviderMock.GetProducts")
+		}
+		return (*mm_results).pa1, (*mm_results).err
+	}
+	if mmGetProducts.funcGetProducts != nil {
+		return mmGetProducts.funcGetProducts(ctx, skuIds)
+	}
+	mmGetProducts.t.Fatalf("Unexpected call to ProductsProviderMock.GetProducts. %v %v", ctx, skuIds)
+	return
+}
+
+// GetProductsAfterCounter returns a count of finished ProductsProviderMock.GetProducts invocations
+func (mmGetProducts *ProductsProviderMock) GetProductsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetProducts.afterGetProductsCounter)
+}
+
+// GetProductsBeforeCounter returns a count of ProductsProviderMock.GetProducts invocations
+func (mmGetProducts *ProductsProviderMock) GetProductsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetProducts.beforeGetProductsCounter)
+}
+
+// Calls returns a list of arguments used in each call to ProductsProviderMock.GetProducts.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetProducts *mProductsProviderMockGetProducts) Calls() []*ProductsProviderMockGetProductsParams {
+	mmGetProducts.mutex.RLock()
+
+	argCopy := make([]*ProductsProviderMockGetProductsParams, len(mmGetProducts.callArgs))
+	copy(argCopy, mmGetProducts.callArgs)
+
+	mmGetProducts.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetProductsDone returns true if the count of the GetProducts invocations corresponds
+// the number of defined expectations
+func (m *ProductsProviderMock) MinimockGetProductsDone() bool {
+	if m.GetProductsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetProductsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetProductsMock.invocationsDone()
+}
+
+// MinimockGetProductsInspect logs each unmet expectation
+func (m *ProductsProviderMock) MinimockGetProductsInspect() {
+	for _, e := range m.GetProductsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ProductsProviderMock.GetProducts at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetProductsCounter := mm_atomic.LoadUint64(&m.afterGetProductsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetProductsMock.defaultExpectation != nil && afterGetProductsCounter < 1 {
+		if m.GetProductsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ProductsProviderMock.GetProducts at\n%s", m.GetProductsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ProductsProviderMock.GetProducts at\n%s with params: %#v", m.GetProductsMock.defaultExpectation.expectationOrigins.origin, *m.GetProductsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetProducts != nil && afterGetProductsCounter < 1 {
+		m.t.Errorf("Expected call to ProductsProviderMock.GetProducts at\n%s", m.funcGetProductsOrigin)
+	}
+
+	if !m.GetProductsMock.invocationsDone() && afterGetProductsCounter > 0 {
+		m.t.Errorf("Expected %d calls to ProductsProviderMock.GetProducts at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetProductsMock.expectedInvocations), m.GetProductsMock.expectedInvocationsOrigin, afterGetProductsCounter)
+	}
+}
+
+type mProductsProviderMockGetProductsAot struct {
+	optional           bool
+	mock               *ProductsProviderMock
+	defaultExpectation *ProductsProviderMockGetProductsAotExpectation
+	expectations       []*ProductsProviderMockGetProductsAotExpectation
+
+	callArgs []*ProductsProviderMockGetProductsAotParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// ProductsProviderMockGetProductsAotExpectation specifies expectation struct of the ProductsProvider.GetProductsAot
+type ProductsProviderMockGetProductsAotExpectation struct {
+	mock               *ProductsProviderMock
+	params             *ProductsProviderMockGetProductsAotParams
+	paramPtrs          *ProductsProviderMockGetProductsAotParamPtrs
+	expectationOrigins ProductsProviderMockGetProductsAotExpectationOrigins
+	results            *ProductsProviderMockGetProductsAotResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// ProductsProviderMockGetProductsAotParams contains parameters of the ProductsProvider.GetProductsAot
+type ProductsProviderMockGetProductsAotParams struct {
+	ctx        context.Context
+	count      int64
+	startSkuId int64
+}
+
+// ProductsProviderMockGetProductsAotParamPtrs contains pointers to parameters of the ProductsProvider.GetProductsAot
+type ProductsProviderMockGetProductsAotParamPtrs struct {
+	ctx        *context.Context
+	count      *int64
+	startSkuId *int64
+}
+
+// ProductsProviderMockGetProductsAotResults contains results of the ProductsProvider.GetProductsAot
+type ProductsProviderMockGetProductsAotResults struct {
+	pa1 []model.ProductModel
+	err error
+}
+
+// ProductsProviderMockGetProductsAotOrigins contains origins of expectations of the ProductsProvider.GetProductsAot
+type ProductsProviderMockGetProductsAotExpectationOrigins struct {
+	origin           string
+	originCtx        string
+	originCount      string
+	originStartSkuId string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) Optional() *mProductsProviderMockGetProductsAot {
+	mmGetProductsAot.optional = true
+	return mmGetProductsAot
+}
+
+// Expect sets up expected params for ProductsProvider.GetProductsAot
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) Expect(ctx context.Context, count int64, startSkuId int64) *mProductsProviderMockGetProductsAot {
+	if mmGetProductsAot.mock.funcGetProductsAot != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by Set")
+	}
+
+	if mmGetProductsAot.defaultExpectation == nil {
+		mmGetProductsAot.defaultExpectation = &ProductsProviderMockGetProductsAotExpectation{}
+	}
+
+	if mmGetProductsAot.defaultExpectation.paramPtrs != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by ExpectParams functions")
+	}
+
+	mmGetProductsAot.defaultExpectation.params = &ProductsProviderMockGetProductsAotParams{ctx, count, startSkuId}
+	mmGetProductsAot.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetProductsAot.expectations {
+		if minimock.Equal(e.params, mmGetProductsAot.defaultExpectation.params) {
+			mmGetProductsAot.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetProductsAot.defaultExpectation.params)
+		}
+	}
+
+	return mmGetProductsAot
+}
+
+// ExpectCtxParam1 sets up expected param ctx for ProductsProvider.GetProductsAot
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) ExpectCtxParam1(ctx context.Context) *mProductsProviderMockGetProductsAot {
+	if mmGetProductsAot.mock.funcGetProductsAot != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by Set")
+	}
+
+	if mmGetProductsAot.defaultExpectation == nil {
+		mmGetProductsAot.defaultExpectation = &ProductsProviderMockGetProductsAotExpectation{}
+	}
+
+	if mmGetProductsAot.defaultExpectation.params != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by Expect")
+	}
+
+	if mmGetProductsAot.defaultExpectation.paramPtrs == nil {
+		mmGetProductsAot.defaultExpectation.paramPtrs = &ProductsProviderMockGetProductsAotParamPtrs{}
+	}
+	mmGetProductsAot.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetProductsAot.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetProductsAot
+}
+
+// ExpectCountParam2 sets up expected param count for ProductsProvider.GetProductsAot
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) ExpectCountParam2(count int64) *mProductsProviderMockGetProductsAot {
+	if mmGetProductsAot.mock.funcGetProductsAot != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by Set")
+	}
+
+	if mmGetProductsAot.defaultExpectation == nil {
+		mmGetProductsAot.defaultExpectation = &ProductsProviderMockGetProductsAotExpectation{}
+	}
+
+	if mmGetProductsAot.defaultExpectation.params != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by Expect")
+	}
+
+	if mmGetProductsAot.defaultExpectation.paramPtrs == nil {
+		mmGetProductsAot.defaultExpectation.paramPtrs = &ProductsProviderMockGetProductsAotParamPtrs{}
+	}
+	mmGetProductsAot.defaultExpectation.paramPtrs.count = &count
+	mmGetProductsAot.defaultExpectation.expectationOrigins.originCount = minimock.CallerInfo(1)
+
+	return mmGetProductsAot
+}
+
+// ExpectStartSkuIdParam3 sets up expected param startSkuId for ProductsProvider.GetProductsAot
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) ExpectStartSkuIdParam3(startSkuId int64) *mProductsProviderMockGetProductsAot {
+	if mmGetProductsAot.mock.funcGetProductsAot != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by Set")
+	}
+
+	if mmGetProductsAot.defaultExpectation == nil {
+		mmGetProductsAot.defaultExpectation = &ProductsProviderMockGetProductsAotExpectation{}
+	}
+
+	if mmGetProductsAot.defaultExpectation.params != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by Expect")
+	}
+
+	if mmGetProductsAot.defaultExpectation.paramPtrs == nil {
+		mmGetProductsAot.defaultExpectation.paramPtrs = &ProductsProviderMockGetProductsAotParamPtrs{}
+	}
+	mmGetProductsAot.defaultExpectation.paramPtrs.startSkuId = &startSkuId
+	mmGetProductsAot.defaultExpectation.expectationOrigins.originStartSkuId = minimock.CallerInfo(1)
+
+	return mmGetProductsAot
+}
+
+// Inspect accepts an inspector function that has same arguments as the ProductsProvider.GetProductsAot
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) Inspect(f func(ctx context.Context, count int64, startSkuId int64)) *mProductsProviderMockGetProductsAot {
+	if mmGetProductsAot.mock.inspectFuncGetProductsAot != nil {
+		mmGetProductsAot.mock.t.Fatalf("Inspect function is already set for ProductsProviderMock.GetProductsAot")
+	}
+
+	mmGetProductsAot.mock.inspectFuncGetProductsAot = f
+
+	return mmGetProductsAot
+}
+
+// Return sets up results that will be returned by ProductsProvider.GetProductsAot
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) Return(pa1 []model.ProductModel, err error) *ProductsProviderMock {
+	if mmGetProductsAot.mock.funcGetProductsAot != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by Set")
+	}
+
+	if mmGetProductsAot.defaultExpectation == nil {
+		mmGetProductsAot.defaultExpectation = &ProductsProviderMockGetProductsAotExpectation{mock: mmGetProductsAot.mock}
+	}
+	mmGetProductsAot.defaultExpectation.results = &ProductsProviderMockGetProductsAotResults{pa1, err}
+	mmGetProductsAot.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetProductsAot.mock
+}
+
+// Set uses given function f to mock the ProductsProvider.GetProductsAot method
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) Set(f func(ctx context.Context, count int64, startSkuId int64) (pa1 []model.ProductModel, err error)) *ProductsProviderMock {
+	if mmGetProductsAot.defaultExpectation != nil {
+		mmGetProductsAot.mock.t.Fatalf("Default expectation is already set for the ProductsProvider.GetProductsAot method")
+	}
+
+	if len(mmGetProductsAot.expectations) > 0 {
+		mmGetProductsAot.mock.t.Fatalf("Some expectations are already set for the ProductsProvider.GetProductsAot method")
+	}
+
+	mmGetProductsAot.mock.funcGetProductsAot = f
+	mmGetProductsAot.mock.funcGetProductsAotOrigin = minimock.CallerInfo(1)
+	return mmGetProductsAot.mock
+}
+
+// When sets expectation for the ProductsProvider.GetProductsAot which will trigger the result defined by the following
+// Then helper
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) When(ctx context.Context, count int64, startSkuId int64) *ProductsProviderMockGetProductsAotExpectation {
+	if mmGetProductsAot.mock.funcGetProductsAot != nil {
+		mmGetProductsAot.mock.t.Fatalf("ProductsProviderMock.GetProductsAot mock is already set by Set")
+	}
+
+	expectation := &ProductsProviderMockGetProductsAotExpectation{
+		mock:               mmGetProductsAot.mock,
+		params:             &ProductsProviderMockGetProductsAotParams{ctx, count, startSkuId},
+		expectationOrigins: ProductsProviderMockGetProductsAotExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetProductsAot.expectations = append(mmGetProductsAot.expectations, expectation)
+	return expectation
+}
+
+// Then sets up ProductsProvider.GetProductsAot return parameters for the expectation previously defined by the When method
+func (e *ProductsProviderMockGetProductsAotExpectation) Then(pa1 []model.ProductModel, err error) *ProductsProviderMock {
+	e.results = &ProductsProviderMockGetProductsAotResults{pa1, err}
+	return e.mock
+}
+
+// Times sets number of times ProductsProvider.GetProductsAot should be invoked
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) Times(n uint64) *mProductsProviderMockGetProductsAot {
+	if n == 0 {
+		mmGetProductsAot.mock.t.Fatalf("Times of ProductsProviderMock.GetProductsAot mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetProductsAot.expectedInvocations, n)
+	mmGetProductsAot.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetProductsAot
+}
+
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) invocationsDone() bool {
+	if len(mmGetProductsAot.expectations) == 0 && mmGetProductsAot.defaultExpectation == nil && mmGetProductsAot.mock.funcGetProductsAot == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetProductsAot.mock.afterGetProductsAotCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetProductsAot.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetProductsAot implements ProductsProvider
+func (mmGetProductsAot *ProductsProviderMock) GetProductsAot(ctx context.Context, count int64, startSkuId int64) (pa1 []model.ProductModel, err error) {
+	mm_atomic.AddUint64(&mmGetProductsAot.beforeGetProductsAotCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetProductsAot.afterGetProductsAotCounter, 1)
+
+	mmGetProductsAot.t.Helper()
+
+	if mmGetProductsAot.inspectFuncGetProductsAot != nil {
+		mmGetProductsAot.inspectFuncGetProductsAot(ctx, count, startSkuId)
+	}
+
+	mm_params := ProductsProviderMockGetProductsAotParams{ctx, count, startSkuId}
+
+	// Record call args
+	mmGetProductsAot.GetProductsAotMock.mutex.Lock()
+	mmGetProductsAot.GetProductsAotMock.callArgs = append(mmGetProductsAot.GetProductsAotMock.callArgs, &mm_params)
+	mmGetProductsAot.GetProductsAotMock.mutex.Unlock()
+
+	for _, e := range mmGetProductsAot.GetProductsAotMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.pa1, e.results.err
+		}
+	}
+
+	if mmGetProductsAot.GetProductsAotMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetProductsAot.GetProductsAotMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetProductsAot.GetProductsAotMock.defaultExpectation.params
+		mm_want_ptrs := mmGetProductsAot.GetProductsAotMock.defaultExpectation.paramPtrs
+
+		mm_got := ProductsProviderMockGetProductsAotParams{ctx, count, startSkuId}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetProductsAot.t.Errorf("ProductsProviderMock.GetProductsAot got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetProductsAot.GetProductsAotMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.count != nil && !minimock.Equal(*mm_want_ptrs.count, mm_got.count) {
+				mmGetProductsAot.t.Errorf("ProductsProviderMock.GetProductsAot got unexpected parameter count, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetProductsAot.GetProductsAotMock.defaultExpectation.expectationOrigins.originCount, *mm_want_ptrs.count, mm_got.count, minimock.Diff(*mm_want_ptrs.count, mm_got.count))
+			}
+
+			if mm_want_ptrs.startSkuId != nil && !minimock.Equal(*mm_want_ptrs.startSkuId, mm_got.startSkuId) {
+				mmGetProductsAot.t.Errorf("ProductsProviderMock.GetProductsAot got unexpected parameter startSkuId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetProductsAot.GetProductsAotMock.defaultExpectation.expectationOrigins.originStartSkuId, *mm_want_ptrs.startSkuId, mm_got.startSkuId, minimock.Diff(*mm_want_ptrs.startSkuId, mm_got.startSkuId))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetProductsAot.t.Errorf("ProductsProviderMock.GetProductsAot got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetProductsAot.GetProductsAotMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetProductsAot.GetProductsAotMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetProductsAot.t.Fatal("No results are set for the ProductsProviderMock.GetProductsAot")
+		}
+		return (*mm_results).pa1, (*mm_results).err
+	}
+	if mmGetProductsAot.funcGetProductsAot != nil {
+		return mmGetProductsAot.funcGetProductsAot(ctx, count, startSkuId)
+	}
+	mmGetProductsAot.t.Fatalf("Unexpected call to ProductsProviderMock.GetProductsAot. %v %v %v", ctx, count, startSkuId)
+	return
+}
+
+// GetProductsAotAfterCounter returns a count of finished ProductsProviderMock.GetProductsAot invocations
+func (mmGetProductsAot *ProductsProviderMock) GetProductsAotAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetProductsAot.afterGetProductsAotCounter)
+}
+
+// GetProductsAotBeforeCounter returns a count of ProductsProviderMock.GetProductsAot invocations
+func (mmGetProductsAot *ProductsProviderMock) GetProductsAotBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetProductsAot.beforeGetProductsAotCounter)
+}
+
+// Calls returns a list of arguments used in each call to ProductsProviderMock.GetProductsAot.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetProductsAot *mProductsProviderMockGetProductsAot) Calls() []*ProductsProviderMockGetProductsAotParams {
+	mmGetProductsAot.mutex.RLock()
+
+	argCopy := make([]*ProductsProviderMockGetProductsAotParams, len(mmGetProductsAot.callArgs))
+	copy(argCopy, mmGetProductsAot.callArgs)
+
+	mmGetProductsAot.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetProductsAotDone returns true if the count of the GetProductsAot invocations corresponds
+// the number of defined expectations
+func (m *ProductsProviderMock) MinimockGetProductsAotDone() bool {
+	if m.GetProductsAotMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetProductsAotMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetProductsAotMock.invocationsDone()
+}
+
+// MinimockGetProductsAotInspect logs each unmet expectation
+func (m *ProductsProviderMock) MinimockGetProductsAotInspect() {
+	for _, e := range m.GetProductsAotMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to ProductsProviderMock.GetProductsAot at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetProductsAotCounter := mm_atomic.LoadUint64(&m.afterGetProductsAotCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetProductsAotMock.defaultExpectation != nil && afterGetProductsAotCounter < 1 {
+		if m.GetProductsAotMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to ProductsProviderMock.GetProductsAot at\n%s", m.GetProductsAotMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to ProductsProviderMock.GetProductsAot at\n%s with params: %#v", m.GetProductsAotMock.defaultExpectation.expectationOrigins.origin, *m.GetProductsAotMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetProductsAot != nil && afterGetProductsAotCounter < 1 {
+		m.t.Errorf("Expected call to ProductsProviderMock.GetProductsAot at\n%s", m.funcGetProductsAotOrigin)
+	}
+
+	if !m.GetProductsAotMock.invocationsDone() && afterGetProductsAotCounter > 0 {
+		m.t.Errorf("Expected %d calls to ProductsProviderMock.GetProductsAot at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetProductsAotMock.expectedInvocations), m.GetProductsAotMock.expectedInvocationsOrigin, afterGetProductsAotCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *ProductsProviderMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockGetProductInspect()
+
+			m.MinimockGetProductsInspect()
+
+			m.MinimockGetProductsAotInspect()
+		}
+	})
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *ProductsProviderMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *ProductsProviderMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockGetProductDone() &&
+		m.MinimockGetProductsDone() &&
+		m.MinimockGetProductsAotDone()
+}
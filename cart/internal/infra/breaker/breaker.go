@@ -0,0 +1,197 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"route256/cart/internal/infra/cart_config"
+	"route256/cart/internal/infra/logger"
+	"route256/cart/internal/infra/sre"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateOpen
+	StateHalfOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type Config struct {
+	// FailureRatio of failed requests within the window that opens the circuit
+	FailureRatio float64
+	// MinRequests within the window before the failure ratio is taken into account
+	MinRequests int
+	Window      time.Duration
+	// CoolDown is the time the circuit stays open before probing upstream again
+	CoolDown time.Duration
+	// HalfOpenRequests is the number of probes that must succeed to close the circuit
+	HalfOpenRequests int
+}
+
+func ConfigFrom(config *cart_config.Config) Config {
+	return Config{
+		FailureRatio:     config.CircuitBreaker.FailureRatio,
+		MinRequests:      config.CircuitBreaker.MinRequests,
+		Window:           config.CircuitBreaker.Window,
+		CoolDown:         config.CircuitBreaker.CoolDown,
+		HalfOpenRequests: config.CircuitBreaker.HalfOpenRequests,
+	}
+}
+
+type CircuitBreaker struct {
+	mtx    sync.Mutex
+	name   string
+	config Config
+	now    func() time.Time
+
+	state State
+	// generation changes with every state transition, outcomes of requests allowed in another one are stale
+	generation  uint64
+	windowStart time.Time
+	requests    int
+	failures    int
+	openedAt    time.Time
+
+	probesInFlight int
+	probesPassed   int
+}
+
+func NewCircuitBreaker(name string, config Config) *CircuitBreaker {
+	config.MinRequests = max(config.MinRequests, 1)
+	config.HalfOpenRequests = max(config.HalfOpenRequests, 1)
+
+	breaker := &CircuitBreaker{
+		name:   name,
+		config: config,
+		now:    time.Now,
+	}
+	breaker.windowStart = breaker.now()
+	sre.TrackCircuitBreakerState(name, int(StateClosed))
+
+	return breaker
+}
+
+// Allow checks whether a request may go upstream. Every allowed request must be followed by Report
+// with the returned generation.
+func (b *CircuitBreaker) Allow() (uint64, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	now := b.now()
+	switch b.state {
+	case StateOpen:
+		if now.Sub(b.openedAt) < b.config.CoolDown {
+			return 0, ErrCircuitOpen
+		}
+
+		b.setState(StateHalfOpen)
+		return b.generation, b.allowProbe()
+	case StateHalfOpen:
+		return b.generation, b.allowProbe()
+	default:
+		b.rollWindow(now)
+		return b.generation, nil
+	}
+}
+
+// Report records the outcome of a request allowed by Allow.
+// Outcomes of requests allowed before the last state change are ignored.
+func (b *CircuitBreaker) Report(generation uint64, success bool) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case StateHalfOpen:
+		b.probesInFlight = max(b.probesInFlight-1, 0)
+		if !success {
+			b.open()
+			return
+		}
+
+		b.probesPassed++
+		if b.probesPassed >= b.config.HalfOpenRequests {
+			b.close()
+		}
+	case StateClosed:
+		b.rollWindow(b.now())
+		b.requests++
+		if !success {
+			b.failures++
+		}
+
+		if b.requests >= b.config.MinRequests &&
+			float64(b.failures)/float64(b.requests) >= b.config.FailureRatio {
+			b.open()
+		}
+	}
+}
+
+func (b *CircuitBreaker) State() State {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	return b.state
+}
+
+func (b *CircuitBreaker) allowProbe() error {
+	if b.probesInFlight+b.probesPassed >= b.config.HalfOpenRequests {
+		return ErrCircuitOpen
+	}
+
+	b.probesInFlight++
+	return nil
+}
+
+func (b *CircuitBreaker) rollWindow(now time.Time) {
+	if now.Sub(b.windowStart) < b.config.Window {
+		return
+	}
+
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) open() {
+	b.openedAt = b.now()
+	b.setState(StateOpen)
+}
+
+func (b *CircuitBreaker) close() {
+	b.windowStart = b.now()
+	b.requests = 0
+	b.failures = 0
+	b.setState(StateClosed)
+}
+
+func (b *CircuitBreaker) setState(state State) {
+	b.probesInFlight = 0
+	b.probesPassed = 0
+	if b.state == state {
+		return
+	}
+
+	logger.Warn("Circuit breaker state changed", "name", b.name, "from", b.state.String(), "to", state.String())
+	b.state = state
+	b.generation++
+	sre.TrackCircuitBreakerState(b.name, int(state))
+}
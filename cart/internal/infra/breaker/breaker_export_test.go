@@ -0,0 +1,11 @@
+package breaker
+
+import "time"
+
+func (b *CircuitBreaker) SetNowForTest(now func() time.Time) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.now = now
+	b.windowStart = now()
+}
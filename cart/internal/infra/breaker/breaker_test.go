@@ -0,0 +1,177 @@
+package breaker_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"route256/cart/internal/infra/breaker"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newBreaker(t *testing.T) (*breaker.CircuitBreaker, *clock) {
+	t.Helper()
+	var testClock = &clock{now: time.Now()}
+	var circuitBreaker = breaker.NewCircuitBreaker(t.Name(), breaker.Config{
+		FailureRatio:     0.5,
+		MinRequests:      4,
+		Window:           time.Minute,
+		CoolDown:         time.Second,
+		HalfOpenRequests: 2,
+	})
+	circuitBreaker.SetNowForTest(testClock.Now)
+
+	return circuitBreaker, testClock
+}
+
+func report(t *testing.T, circuitBreaker *breaker.CircuitBreaker, outcomes ...bool) {
+	t.Helper()
+	for _, success := range outcomes {
+		generation, err := circuitBreaker.Allow()
+		require.NoError(t, err)
+		circuitBreaker.Report(generation, success)
+	}
+}
+
+func TestCircuitBreaker_ShouldOpenOnFailureRatio(t *testing.T) {
+	t.Parallel()
+	circuitBreaker, _ := newBreaker(t)
+
+	report(t, circuitBreaker, false, false, true)
+	require.Equal(t, breaker.StateClosed, circuitBreaker.State(), "Min requests are not reached yet")
+
+	report(t, circuitBreaker, true)
+	require.Equal(t, breaker.StateOpen, circuitBreaker.State())
+	_, err := circuitBreaker.Allow()
+	require.ErrorIs(t, err, breaker.ErrCircuitOpen)
+}
+
+func TestCircuitBreaker_ShouldResetCountersOnNewWindow(t *testing.T) {
+	t.Parallel()
+	circuitBreaker, testClock := newBreaker(t)
+
+	report(t, circuitBreaker, false, false, true)
+	testClock.now = testClock.now.Add(time.Minute)
+	report(t, circuitBreaker, false, true, true, true)
+
+	require.Equal(t, breaker.StateClosed, circuitBreaker.State())
+}
+
+func TestCircuitBreaker_ShouldCloseAfterSuccessfulProbes(t *testing.T) {
+	t.Parallel()
+	circuitBreaker, testClock := newBreaker(t)
+	report(t, circuitBreaker, false, false, false, false)
+
+	testClock.now = testClock.now.Add(time.Second)
+	firstProbe, err := circuitBreaker.Allow()
+	require.NoError(t, err)
+	secondProbe, err := circuitBreaker.Allow()
+	require.NoError(t, err)
+	_, err = circuitBreaker.Allow()
+	require.ErrorIs(t, err, breaker.ErrCircuitOpen, "Only configured number of probes is allowed")
+	require.Equal(t, breaker.StateHalfOpen, circuitBreaker.State())
+
+	circuitBreaker.Report(firstProbe, true)
+	circuitBreaker.Report(secondProbe, true)
+	require.Equal(t, breaker.StateClosed, circuitBreaker.State())
+}
+
+func TestCircuitBreaker_ShouldReopenOnFailedProbe(t *testing.T) {
+	t.Parallel()
+	circuitBreaker, testClock := newBreaker(t)
+	report(t, circuitBreaker, false, false, false, false)
+
+	testClock.now = testClock.now.Add(time.Second)
+	report(t, circuitBreaker, false)
+
+	require.Equal(t, breaker.StateOpen, circuitBreaker.State())
+	_, err := circuitBreaker.Allow()
+	require.ErrorIs(t, err, breaker.ErrCircuitOpen)
+}
+
+func TestCircuitBreaker_ShouldIgnoreStaleReports(t *testing.T) {
+	t.Parallel()
+	circuitBreaker, testClock := newBreaker(t)
+	staleGeneration, err := circuitBreaker.Allow()
+	require.NoError(t, err)
+	_, err = circuitBreaker.Allow()
+	require.NoError(t, err)
+	report(t, circuitBreaker, false, false, false, false)
+
+	testClock.now = testClock.now.Add(time.Second)
+	probeGeneration, err := circuitBreaker.Allow()
+	require.NoError(t, err)
+	circuitBreaker.Report(staleGeneration, true)
+	circuitBreaker.Report(staleGeneration, true)
+	require.Equal(t, breaker.StateHalfOpen, circuitBreaker.State(), "Requests allowed while closed are not probes")
+
+	circuitBreaker.Report(probeGeneration, false)
+	require.Equal(t, breaker.StateOpen, circuitBreaker.State())
+	circuitBreaker.Report(staleGeneration, false)
+	require.Equal(t, breaker.StateOpen, circuitBreaker.State())
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestBreakerRoundTripper_ShouldFailFastWhenOpen(t *testing.T) {
+	t.Parallel()
+	circuitBreaker, _ := newBreaker(t)
+	var calls = 0
+	var transport = breaker.NewBreakerRoundTripper(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+	}), circuitBreaker)
+
+	for range 4 {
+		req, _ := http.NewRequest(http.MethodGet, "http://test/product/1", http.NoBody)
+		_, err := transport.RoundTrip(req)
+		require.NoError(t, err)
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://test/product/1", http.NoBody)
+	_, err := transport.RoundTrip(req)
+	require.ErrorIs(t, err, breaker.ErrCircuitOpen)
+	require.Equal(t, 4, calls)
+}
+
+func TestUnaryClientInterceptor_ShouldIgnoreBusinessErrors(t *testing.T) {
+	t.Parallel()
+	circuitBreaker, _ := newBreaker(t)
+	var interceptor = breaker.UnaryClientInterceptor(circuitBreaker)
+	var invoke = func(err error) error {
+		return interceptor(context.Background(), "/test", nil, nil, nil,
+			func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+				return err
+			})
+	}
+
+	for range 4 {
+		require.Error(t, invoke(status.Error(codes.NotFound, "not found")))
+	}
+	require.Equal(t, breaker.StateClosed, circuitBreaker.State())
+
+	for range 4 {
+		require.Error(t, invoke(status.Error(codes.Unavailable, "unavailable")))
+	}
+	err := invoke(nil)
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	_, err = circuitBreaker.Allow()
+	require.True(t, errors.Is(err, breaker.ErrCircuitOpen))
+}
@@ -0,0 +1,43 @@
+package breaker
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WithBreakerDial fails grpc calls fast while the circuit is open.
+func WithBreakerDial(breaker *CircuitBreaker) grpc.DialOption {
+	return grpc.WithChainUnaryInterceptor(UnaryClientInterceptor(breaker))
+}
+
+func UnaryClientInterceptor(breaker *CircuitBreaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		generation, err := breaker.Allow()
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "%s: %v", method, err)
+		}
+
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		breaker.Report(generation, !isUpstreamFailure(ctx, err))
+
+		return err
+	}
+}
+
+// isUpstreamFailure tells whether the error is caused by upstream health rather than by the request itself.
+func isUpstreamFailure(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,36 @@
+package breaker
+
+import (
+	"fmt"
+	"net/http"
+)
+
+type BreakerRoundTripper struct {
+	tripper http.RoundTripper
+	breaker *CircuitBreaker
+}
+
+func NewBreakerRoundTripper(tripper http.RoundTripper, breaker *CircuitBreaker) *BreakerRoundTripper {
+	return &BreakerRoundTripper{
+		tripper: tripper,
+		breaker: breaker,
+	}
+}
+
+// RoundTrip fails fast while the circuit is open. Transport errors and 5xx responses count as failures.
+func (t *BreakerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	generation, err := t.breaker.Allow()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", req.Method, req.URL.Host, err)
+	}
+
+	resp, err := t.tripper.RoundTrip(req)
+	// cancelled by the caller, says nothing about upstream health
+	if err != nil && req.Context().Err() != nil {
+		t.breaker.Report(generation, true)
+		return resp, err
+	}
+
+	t.breaker.Report(generation, err == nil && resp.StatusCode < http.StatusInternalServerError)
+	return resp, err
+}
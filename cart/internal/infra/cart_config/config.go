@@ -2,6 +2,7 @@ package cart_config
 
 import (
 	"os"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
@@ -9,14 +10,39 @@ import (
 
 type Config struct {
 	Server struct {
-		Host string `yaml:"host" validate:"required"`
-		Port string `yaml:"port" validate:"required,number,gt=0,lte=65535"`
+		Host       string `yaml:"host" validate:"required"`
+		Port       string `yaml:"port" validate:"required,number,gt=0,lte=65535"`
+		IsInMemory bool   `yaml:"in_memory"`
 	} `yaml:"service"`
 
+	Db struct {
+		Host     string `yaml:"host" validate:"required"`
+		Port     string `yaml:"port" validate:"required,number,gt=0,lte=65535"`
+		User     string `yaml:"user" validate:"required"`
+		Password string `yaml:"password" validate:"required"`
+		DbName   string `yaml:"db_name" validate:"required"`
+	} `yaml:"db"`
+
 	Products struct {
-		Host  string `yaml:"host" validate:"required"`
-		Port  string `yaml:"port" validate:"required,number,gt=0,lte=65535"`
-		Token string `yaml:"token" validate:"required"`
+		Host      string `yaml:"host" validate:"required"`
+		Port      string `yaml:"port" validate:"required,number,gt=0,lte=65535"`
+		Token     string `yaml:"token" validate:"required"`
+		Limit     int    `yaml:"limit" validate:"gt=0"`
+		Burst     int    `yaml:"burst" validate:"gt=0"`
+		BatchSize int    `yaml:"batch_size" validate:"gte=0"`
+
+		Cache struct {
+			Size        int           `yaml:"size" validate:"gte=0"`
+			Ttl         time.Duration `yaml:"ttl" validate:"required_with=Size,gte=0"`
+			NegativeTtl time.Duration `yaml:"negative_ttl" validate:"gte=0"`
+		} `yaml:"cache"`
+
+		Retry struct {
+			Times      int           `yaml:"times" validate:"gte=0"`
+			BaseDelay  time.Duration `yaml:"base_delay" validate:"gte=0"`
+			MaxDelay   time.Duration `yaml:"max_delay" validate:"gte=0"`
+			MaxElapsed time.Duration `yaml:"max_elapsed" validate:"gte=0"`
+		} `yaml:"retry"`
 	} `yaml:"product_service"`
 
 	Loms struct {
@@ -24,6 +50,14 @@ type Config struct {
 		Port string `yaml:"port" validate:"required,number,gt=0,lte=65535"`
 	} `yaml:"loms_service"`
 
+	CircuitBreaker struct {
+		FailureRatio     float64       `yaml:"failure_ratio" validate:"gt=0,lte=1"`
+		MinRequests      int           `yaml:"min_requests" validate:"gte=0"`
+		Window           time.Duration `yaml:"window" validate:"gt=0"`
+		CoolDown         time.Duration `yaml:"cool_down" validate:"gt=0"`
+		HalfOpenRequests int           `yaml:"half_open_requests" validate:"gte=0"`
+	} `yaml:"circuit_breaker"`
+
 	Jaeger struct {
 		Host string `yaml:"host" validate:"required"`
 		Port string `yaml:"port" validate:"required,number,gt=0,lte=65535"`
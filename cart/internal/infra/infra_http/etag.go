@@ -0,0 +1,36 @@
+package infra_http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const ETagHeader = "ETag"
+const IfMatchHeader = "If-Match"
+
+// FormatETag formats the version as a strong entity tag.
+func FormatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// GetIfMatchVersion parses the version from the If-Match header, zero means that the header is absent or "*".
+func GetIfMatchVersion(r *http.Request) (int64, error) {
+	var rawValue = strings.TrimSpace(r.Header.Get(IfMatchHeader))
+	if rawValue == "" || rawValue == "*" {
+		return 0, nil
+	}
+
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(rawValue, "W/"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s header: %s", IfMatchHeader, rawValue)
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, fmt.Errorf("invalid %s header: %s", IfMatchHeader, rawValue)
+	}
+
+	return version, nil
+}
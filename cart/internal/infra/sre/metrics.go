@@ -39,6 +39,49 @@ var (
 		},
 		[]string{"action", "status"},
 	)
+	TotalExternalRequestAttempts = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cart_external_total_request_attempts",
+			Help: "Total number of external request attempts including retries",
+		},
+		[]string{"host", "attempt", "result"},
+	)
+	TotalDatabaseRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cart_database_total_requests",
+			Help: "Total number of database requests",
+		},
+		[]string{"action", "category", "status"},
+	)
+	DatabaseRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "cart_database_request_duration_seconds",
+			Help:    "Duration of database requests in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"action", "category", "status"},
+	)
+	TotalCacheRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cart_cache_total_requests",
+			Help: "Total number of cache lookups",
+		},
+		[]string{"cache", "result"},
+	)
+	TotalCacheEvictions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cart_cache_total_evictions",
+			Help: "Total number of entries evicted from cache",
+		},
+		[]string{"cache", "reason"},
+	)
+	CircuitBreakerState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cart_circuit_breaker_state",
+			Help: "State of the circuit breaker: 0 closed, 1 open, 2 half-open",
+		},
+		[]string{"name"},
+	)
 	InMemoryCartItems = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "cart_in_memory_items_count",
@@ -48,6 +91,51 @@ var (
 	)
 )
 
+func TrackDbRequest(action, category string, err error, start time.Time) {
+	duration := time.Since(start)
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+
+	TotalDatabaseRequests.With(prometheus.Labels{
+		"action":   action,
+		"category": category,
+		"status":   status,
+	}).Inc()
+
+	DatabaseRequestDuration.With(prometheus.Labels{
+		"action":   action,
+		"category": category,
+		"status":   status,
+	}).Observe(duration.Seconds())
+}
+
+func TrackCacheRequest(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+
+	TotalCacheRequests.With(prometheus.Labels{
+		"cache":  cache,
+		"result": result,
+	}).Inc()
+}
+
+func TrackCacheEviction(cache, reason string) {
+	TotalCacheEvictions.With(prometheus.Labels{
+		"cache":  cache,
+		"reason": reason,
+	}).Inc()
+}
+
+func TrackCircuitBreakerState(name string, state int) {
+	CircuitBreakerState.With(prometheus.Labels{
+		"name": name,
+	}).Set(float64(state))
+}
+
 func TrackExternalRequest(action string, err error, startTime time.Time) {
 	duration := time.Since(startTime)
 
@@ -66,6 +154,14 @@ func TrackExternalRequest(action string, err error, startTime time.Time) {
 	}).Observe(duration.Seconds())
 }
 
+func TrackRequestAttempt(host string, attempt int, result string) {
+	TotalExternalRequestAttempts.With(prometheus.Labels{
+		"host":    host,
+		"attempt": strconv.Itoa(attempt),
+		"result":  result,
+	}).Inc()
+}
+
 func TrackHttpRequest(method, path string, statusCode int, startTime time.Time) {
 	duration := time.Since(startTime)
 
@@ -3,64 +3,208 @@ package tripper
 import (
 	"bytes"
 	"io"
+	"math/rand/v2"
 	"net/http"
+	"slices"
+	"strconv"
 	"time"
+
+	"route256/cart/internal/infra/sre"
 )
 
+const transportErrorReason = "transport_error"
+
 type RetryRoundTripper struct {
-	tripper http.RoundTripper
-	retryOn []int
-	times   int
-	waitFor time.Duration
+	tripper           http.RoundTripper
+	retryOn           []int
+	retryOnIdempotent []int
+	times             int
+	baseDelay         time.Duration
+	maxDelay          time.Duration
+	maxElapsed        time.Duration
 }
 
 type RetryConfig struct {
-	RetryOn   []int
-	Times     int
-	WaitForMs int
+	// RetryOn status codes are retried for any method, upstream did not process such requests
+	RetryOn []int
+	// RetryOnIdempotent status codes and transport errors are retried only for idempotent requests
+	RetryOnIdempotent []int
+	Times             int
+	BaseDelay         time.Duration
+	MaxDelay          time.Duration
+	// MaxElapsed limits the total time spent in retries, zero means no limit
+	MaxElapsed time.Duration
 }
 
 func NewRetryRoundTripper(tripper http.RoundTripper, config RetryConfig) *RetryRoundTripper {
+	maxDelay := config.MaxDelay
+	if maxDelay < config.BaseDelay {
+		maxDelay = config.BaseDelay
+	}
+
 	return &RetryRoundTripper{
-		tripper: tripper,
-		retryOn: config.RetryOn,
-		times:   config.Times,
-		waitFor: time.Duration(config.WaitForMs) * time.Microsecond,
+		tripper:           tripper,
+		retryOn:           config.RetryOn,
+		retryOnIdempotent: config.RetryOnIdempotent,
+		times:             config.Times,
+		baseDelay:         config.BaseDelay,
+		maxDelay:          maxDelay,
+		maxElapsed:        config.MaxElapsed,
 	}
 }
 
 func (r *RetryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	var bodyBytes []byte
-	if req.Body != nil {
-		bodyBytes, err := io.ReadAll(req.Body)
+	getBody, err := bodyFactory(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var start = time.Now()
+	for attempt := 0; ; attempt++ {
+		attemptReq, err := newAttemptRequest(req, getBody)
 		if err != nil {
 			return nil, err
 		}
 
-		req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		resp, err := r.tripper.RoundTrip(attemptReq)
+		reason, retry := r.shouldRetry(req, resp, err)
+		sre.TrackRequestAttempt(req.URL.Host, attempt+1, reason)
+		if !retry || attempt >= r.times {
+			return resp, err
+		}
+
+		delay, ok := r.nextDelay(attempt, resp, time.Since(start))
+		if !ok {
+			return resp, err
+		}
+
+		if resp != nil {
+			drainResponseBody(resp)
+		}
+
+		if err := sleep(req, delay); err != nil {
+			return nil, err
+		}
 	}
+}
 
-	resp, err := r.tripper.RoundTrip(req)
+func (r *RetryRoundTripper) shouldRetry(req *http.Request, resp *http.Response, err error) (string, bool) {
 	if err != nil {
-		return nil, err
+		// the request is cancelled by the caller, retrying it makes no sense
+		if req.Context().Err() != nil {
+			return transportErrorReason, false
+		}
+
+		return transportErrorReason, isIdempotent(req)
 	}
 
-	for i := 0; i < r.times && isErrorRetry(resp, r.retryOn); i++ {
-		time.Sleep(r.waitFor)
+	var reason = strconv.Itoa(resp.StatusCode)
+	if slices.Contains(r.retryOn, resp.StatusCode) {
+		return reason, true
+	}
 
-		drainResponseBody(resp)
+	return reason, isIdempotent(req) && slices.Contains(r.retryOnIdempotent, resp.StatusCode)
+}
 
-		if req.Body != nil {
-			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+// nextDelay returns the delay before the next attempt using exponential backoff with full jitter,
+// Retry-After of the response takes precedence. Returns false if the delay exceeds the elapsed budget.
+func (r *RetryRoundTripper) nextDelay(attempt int, resp *http.Response, elapsed time.Duration) (time.Duration, bool) {
+	delay, ok := retryAfter(resp)
+	if !ok {
+		backoff := r.maxDelay
+		if attempt < 32 {
+			backoff = min(r.maxDelay, r.baseDelay<<attempt)
 		}
 
-		resp, err = r.tripper.RoundTrip(req)
-		if err != nil {
-			return nil, err
+		if backoff > 0 {
+			delay = rand.N(backoff + 1)
 		}
 	}
 
-	return resp, nil
+	if r.maxElapsed > 0 && elapsed+delay > r.maxElapsed {
+		return 0, false
+	}
+
+	return delay, true
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	// same convention as net/http uses to retry non-idempotent requests
+	_, hasIdempotencyKey := req.Header["Idempotency-Key"]
+	return hasIdempotencyKey
+}
+
+func sleep(req *http.Request, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
+
+func bodyFactory(req *http.Request) (func() (io.ReadCloser, error), error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		return req.GetBody, nil
+	}
+
+	bodyBytes, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(bodyBytes)), nil
+	}, nil
+}
+
+func newAttemptRequest(req *http.Request, getBody func() (io.ReadCloser, error)) (*http.Request, error) {
+	attemptReq := req.Clone(req.Context())
+	if getBody == nil {
+		return attemptReq, nil
+	}
+
+	body, err := getBody()
+	if err != nil {
+		return nil, err
+	}
+
+	attemptReq.Body = body
+	return attemptReq, nil
 }
 
 func drainResponseBody(resp *http.Response) {
@@ -69,12 +213,3 @@ func drainResponseBody(resp *http.Response) {
 		resp.Body.Close()
 	}
 }
-
-func isErrorRetry(response *http.Response, retryOn []int) bool {
-	for _, code := range retryOn {
-		if response.StatusCode == code {
-			return true
-		}
-	}
-	return false
-}
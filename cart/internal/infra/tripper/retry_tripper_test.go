@@ -0,0 +1,146 @@
+package tripper_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"route256/cart/internal/infra/tripper"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func respond(status int, headers ...string) *http.Response {
+	response := &http.Response{StatusCode: status, Header: http.Header{}, Body: http.NoBody}
+	for i := 0; i+1 < len(headers); i += 2 {
+		response.Header.Set(headers[i], headers[i+1])
+	}
+
+	return response
+}
+
+func newTripper(transport roundTripFunc) *tripper.RetryRoundTripper {
+	return tripper.NewRetryRoundTripper(transport, tripper.RetryConfig{
+		RetryOn:           []int{http.StatusTooManyRequests},
+		RetryOnIdempotent: []int{http.StatusServiceUnavailable},
+		Times:             3,
+		BaseDelay:         time.Millisecond,
+		MaxDelay:          5 * time.Millisecond,
+	})
+}
+
+func TestRetryRoundTripper_ShouldRetryAndResendBody(t *testing.T) {
+	t.Parallel()
+	var attempts atomic.Int64
+	var retry = newTripper(func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		require.Equal(t, "payload", string(body))
+		if attempts.Add(1) < 3 {
+			return respond(http.StatusTooManyRequests), nil
+		}
+
+		return respond(http.StatusOK), nil
+	})
+
+	req, _ := http.NewRequest(http.MethodPost, "http://test/product/batch", strings.NewReader("payload"))
+	resp, err := retry.RoundTrip(req)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int64(3), attempts.Load())
+}
+
+func TestRetryRoundTripper_ShouldStopAfterTimes(t *testing.T) {
+	t.Parallel()
+	var attempts atomic.Int64
+	var retry = newTripper(func(req *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return respond(http.StatusServiceUnavailable), nil
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://test/product/1", http.NoBody)
+	resp, err := retry.RoundTrip(req)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	require.Equal(t, int64(4), attempts.Load())
+}
+
+func TestRetryRoundTripper_ShouldRetryTransportErrorsOnlyForIdempotentMethods(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		method       string
+		wantAttempts int64
+	}{
+		{name: "get is retried", method: http.MethodGet, wantAttempts: 4},
+		{name: "post is not retried", method: http.MethodPost, wantAttempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var attempts atomic.Int64
+			var retry = newTripper(func(req *http.Request) (*http.Response, error) {
+				attempts.Add(1)
+				return nil, errors.New("connection reset")
+			})
+
+			req, _ := http.NewRequest(tt.method, "http://test/product", http.NoBody)
+			_, err := retry.RoundTrip(req)
+
+			require.Error(t, err)
+			require.Equal(t, tt.wantAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestRetryRoundTripper_ShouldHonorRetryAfterWithinMaxElapsed(t *testing.T) {
+	t.Parallel()
+	var attempts atomic.Int64
+	var retry = tripper.NewRetryRoundTripper(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return respond(http.StatusTooManyRequests, "Retry-After", "10"), nil
+	}), tripper.RetryConfig{
+		RetryOn:    []int{http.StatusTooManyRequests},
+		Times:      3,
+		BaseDelay:  time.Millisecond,
+		MaxElapsed: time.Second,
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "http://test/product/1", http.NoBody)
+	resp, err := retry.RoundTrip(req)
+
+	require.NoError(t, err)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.Equal(t, int64(1), attempts.Load())
+}
+
+func TestRetryRoundTripper_ShouldStopSleepingOnContextCancel(t *testing.T) {
+	t.Parallel()
+	var retry = tripper.NewRetryRoundTripper(roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return respond(http.StatusTooManyRequests, "Retry-After", "10"), nil
+	}), tripper.RetryConfig{
+		RetryOn: []int{http.StatusTooManyRequests},
+		Times:   3,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://test/product/1", http.NoBody)
+
+	start := time.Now()
+	_, err := retry.RoundTrip(req)
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,125 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: outbox/v1/outbox.proto
+
+package outbox_v1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OutboxAdminService_RequeueDeadLetters_FullMethodName = "/outbox.v1.OutboxAdminService/RequeueDeadLetters"
+)
+
+// OutboxAdminServiceClient is the client API for OutboxAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OutboxAdminService manages events that ran out of delivery attempts
+type OutboxAdminServiceClient interface {
+	// RequeueDeadLetters schedules dead-lettered events for delivery with a fresh attempt budget
+	RequeueDeadLetters(ctx context.Context, in *RequeueDeadLettersRequest, opts ...grpc.CallOption) (*RequeueDeadLettersResponse, error)
+}
+
+type outboxAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOutboxAdminServiceClient(cc grpc.ClientConnInterface) OutboxAdminServiceClient {
+	return &outboxAdminServiceClient{cc}
+}
+
+func (c *outboxAdminServiceClient) RequeueDeadLetters(ctx context.Context, in *RequeueDeadLettersRequest, opts ...grpc.CallOption) (*RequeueDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequeueDeadLettersResponse)
+	err := c.cc.Invoke(ctx, OutboxAdminService_RequeueDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OutboxAdminServiceServer is the server API for OutboxAdminService service.
+// All implementations should embed UnimplementedOutboxAdminServiceServer
+// for forward compatibility.
+//
+// OutboxAdminService manages events that ran out of delivery attempts
+type OutboxAdminServiceServer interface {
+	// RequeueDeadLetters schedules dead-lettered events for delivery with a fresh attempt budget
+	RequeueDeadLetters(context.Context, *RequeueDeadLettersRequest) (*RequeueDeadLettersResponse, error)
+}
+
+// UnimplementedOutboxAdminServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOutboxAdminServiceServer struct{}
+
+func (UnimplementedOutboxAdminServiceServer) RequeueDeadLetters(context.Context, *RequeueDeadLettersRequest) (*RequeueDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequeueDeadLetters not implemented")
+}
+func (UnimplementedOutboxAdminServiceServer) testEmbeddedByValue() {}
+
+// UnsafeOutboxAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OutboxAdminServiceServer will
+// result in compilation errors.
+type UnsafeOutboxAdminServiceServer interface {
+	mustEmbedUnimplementedOutboxAdminServiceServer()
+}
+
+func RegisterOutboxAdminServiceServer(s grpc.ServiceRegistrar, srv OutboxAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOutboxAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OutboxAdminService_ServiceDesc, srv)
+}
+
+func _OutboxAdminService_RequeueDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequeueDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutboxAdminServiceServer).RequeueDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OutboxAdminService_RequeueDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutboxAdminServiceServer).RequeueDeadLetters(ctx, req.(*RequeueDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OutboxAdminService_ServiceDesc is the grpc.ServiceDesc for OutboxAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OutboxAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "outbox.v1.OutboxAdminService",
+	HandlerType: (*OutboxAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RequeueDeadLetters",
+			Handler:    _OutboxAdminService_RequeueDeadLetters_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "outbox/v1/outbox.proto",
+}
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	StocksService_StocksInfo_FullMethodName = "/stocks.v1.StocksService/StocksInfo"
+	StocksService_StocksInfo_FullMethodName      = "/stocks.v1.StocksService/StocksInfo"
+	StocksService_StocksInfoBatch_FullMethodName = "/stocks.v1.StocksService/StocksInfoBatch"
 )
 
 // StocksServiceClient is the client API for StocksService service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type StocksServiceClient interface {
 	StocksInfo(ctx context.Context, in *StocksInfoRequest, opts ...grpc.CallOption) (*StocksInfoResponse, error)
+	StocksInfoBatch(ctx context.Context, in *StocksInfoBatchRequest, opts ...grpc.CallOption) (*StocksInfoBatchResponse, error)
 }
 
 type stocksServiceClient struct {
@@ -47,11 +49,22 @@ func (c *stocksServiceClient) StocksInfo(ctx context.Context, in *StocksInfoRequ
 	return out, nil
 }
 
+func (c *stocksServiceClient) StocksInfoBatch(ctx context.Context, in *StocksInfoBatchRequest, opts ...grpc.CallOption) (*StocksInfoBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StocksInfoBatchResponse)
+	err := c.cc.Invoke(ctx, StocksService_StocksInfoBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StocksServiceServer is the server API for StocksService service.
 // All implementations should embed UnimplementedStocksServiceServer
 // for forward compatibility.
 type StocksServiceServer interface {
 	StocksInfo(context.Context, *StocksInfoRequest) (*StocksInfoResponse, error)
+	StocksInfoBatch(context.Context, *StocksInfoBatchRequest) (*StocksInfoBatchResponse, error)
 }
 
 // UnimplementedStocksServiceServer should be embedded to have
@@ -64,6 +77,9 @@ type UnimplementedStocksServiceServer struct{}
 func (UnimplementedStocksServiceServer) StocksInfo(context.Context, *StocksInfoRequest) (*StocksInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StocksInfo not implemented")
 }
+func (UnimplementedStocksServiceServer) StocksInfoBatch(context.Context, *StocksInfoBatchRequest) (*StocksInfoBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StocksInfoBatch not implemented")
+}
 func (UnimplementedStocksServiceServer) testEmbeddedByValue() {}
 
 // UnsafeStocksServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -102,6 +118,24 @@ func _StocksService_StocksInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StocksService_StocksInfoBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StocksInfoBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StocksServiceServer).StocksInfoBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StocksService_StocksInfoBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StocksServiceServer).StocksInfoBatch(ctx, req.(*StocksInfoBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StocksService_ServiceDesc is the grpc.ServiceDesc for StocksService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -113,6 +147,192 @@ var StocksService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StocksInfo",
 			Handler:    _StocksService_StocksInfo_Handler,
 		},
+		{
+			MethodName: "StocksInfoBatch",
+			Handler:    _StocksService_StocksInfoBatch_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "stocks/v1/stocks.proto",
+}
+
+const (
+	StocksAdminService_AddStock_FullMethodName    = "/stocks.v1.StocksAdminService/AddStock"
+	StocksAdminService_AdjustStock_FullMethodName = "/stocks.v1.StocksAdminService/AdjustStock"
+	StocksAdminService_SetStock_FullMethodName    = "/stocks.v1.StocksAdminService/SetStock"
+)
+
+// StocksAdminServiceClient is the client API for StocksAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StocksAdminService changes stock levels, every change is written to the stock ledger
+type StocksAdminServiceClient interface {
+	AddStock(ctx context.Context, in *AddStockRequest, opts ...grpc.CallOption) (*StockChangeResponse, error)
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockChangeResponse, error)
+	// SetStock is idempotent and creates the stock of an unknown sku
+	SetStock(ctx context.Context, in *SetStockRequest, opts ...grpc.CallOption) (*StockChangeResponse, error)
+}
+
+type stocksAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStocksAdminServiceClient(cc grpc.ClientConnInterface) StocksAdminServiceClient {
+	return &stocksAdminServiceClient{cc}
+}
+
+func (c *stocksAdminServiceClient) AddStock(ctx context.Context, in *AddStockRequest, opts ...grpc.CallOption) (*StockChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockChangeResponse)
+	err := c.cc.Invoke(ctx, StocksAdminService_AddStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stocksAdminServiceClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*StockChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockChangeResponse)
+	err := c.cc.Invoke(ctx, StocksAdminService_AdjustStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stocksAdminServiceClient) SetStock(ctx context.Context, in *SetStockRequest, opts ...grpc.CallOption) (*StockChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StockChangeResponse)
+	err := c.cc.Invoke(ctx, StocksAdminService_SetStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StocksAdminServiceServer is the server API for StocksAdminService service.
+// All implementations should embed UnimplementedStocksAdminServiceServer
+// for forward compatibility.
+//
+// StocksAdminService changes stock levels, every change is written to the stock ledger
+type StocksAdminServiceServer interface {
+	AddStock(context.Context, *AddStockRequest) (*StockChangeResponse, error)
+	AdjustStock(context.Context, *AdjustStockRequest) (*StockChangeResponse, error)
+	// SetStock is idempotent and creates the stock of an unknown sku
+	SetStock(context.Context, *SetStockRequest) (*StockChangeResponse, error)
+}
+
+// UnimplementedStocksAdminServiceServer should be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStocksAdminServiceServer struct{}
+
+func (UnimplementedStocksAdminServiceServer) AddStock(context.Context, *AddStockRequest) (*StockChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddStock not implemented")
+}
+func (UnimplementedStocksAdminServiceServer) AdjustStock(context.Context, *AdjustStockRequest) (*StockChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedStocksAdminServiceServer) SetStock(context.Context, *SetStockRequest) (*StockChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStock not implemented")
+}
+func (UnimplementedStocksAdminServiceServer) testEmbeddedByValue() {}
+
+// UnsafeStocksAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StocksAdminServiceServer will
+// result in compilation errors.
+type UnsafeStocksAdminServiceServer interface {
+	mustEmbedUnimplementedStocksAdminServiceServer()
+}
+
+func RegisterStocksAdminServiceServer(s grpc.ServiceRegistrar, srv StocksAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStocksAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StocksAdminService_ServiceDesc, srv)
+}
+
+func _StocksAdminService_AddStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StocksAdminServiceServer).AddStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StocksAdminService_AddStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StocksAdminServiceServer).AddStock(ctx, req.(*AddStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StocksAdminService_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StocksAdminServiceServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StocksAdminService_AdjustStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StocksAdminServiceServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StocksAdminService_SetStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StocksAdminServiceServer).SetStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StocksAdminService_SetStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StocksAdminServiceServer).SetStock(ctx, req.(*SetStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StocksAdminService_ServiceDesc is the grpc.ServiceDesc for StocksAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StocksAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "stocks.v1.StocksAdminService",
+	HandlerType: (*StocksAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AddStock",
+			Handler:    _StocksAdminService_AddStock_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _StocksAdminService_AdjustStock_Handler,
+		},
+		{
+			MethodName: "SetStock",
+			Handler:    _StocksAdminService_SetStock_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stocks/v1/stocks.proto",
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+create table cart_items (
+    user_id bigint not null,
+    sku bigint not null,
+    count bigint not null,
+    created_at timestamp default now() not null,
+    updated_at timestamp default now() not null,
+    primary key (user_id, sku),
+    constraint cart_items_count_uint32 check (
+        count > 0
+        and count <= 4294967295
+    )
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table cart_items;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+create table carts (
+    user_id bigint primary key,
+    version bigint default 1 not null,
+    locked_until timestamptz,
+    created_at timestamp default now() not null,
+    updated_at timestamp default now() not null
+);
+
+insert into carts (user_id)
+select distinct user_id
+from cart_items;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table carts;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+create table cart_checkouts (
+    user_id bigint not null,
+    idempotency_key text not null,
+    expected_version bigint not null,
+    order_id bigint not null,
+    created_at timestamp default now() not null,
+    primary key (user_id, idempotency_key)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table cart_checkouts;
+-- +goose StatementEnd
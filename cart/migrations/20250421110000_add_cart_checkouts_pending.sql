@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- checkouts are recorded as pending together with the cart lock, order_id is set once the order is created
+alter table cart_checkouts
+    alter column order_id drop not null,
+    add column skus bigint[] not null default '{}',
+    add column counts bigint[] not null default '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+delete from cart_checkouts
+where order_id is null;
+
+alter table cart_checkouts
+    drop column counts,
+    drop column skus,
+    alter column order_id set not null;
+-- +goose StatementEnd
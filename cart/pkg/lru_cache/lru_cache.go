@@ -0,0 +1,116 @@
+package lru_cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type EvictReason string
+
+const (
+	EvictReasonCapacity EvictReason = "capacity"
+	EvictReasonExpired  EvictReason = "expired"
+)
+
+type Options[K comparable] struct {
+	Size int
+	Ttl  time.Duration
+	// OnEvict is called under the cache lock, so it must not call back into the cache
+	OnEvict func(key K, reason EvictReason)
+	// Now allows to substitute the clock, time.Now is used if not set
+	Now func() time.Time
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// LruCache is a fixed size least recently used cache where every entry lives no longer than ttl.
+type LruCache[K comparable, V any] struct {
+	mtx     sync.Mutex
+	size    int
+	ttl     time.Duration
+	onEvict func(key K, reason EvictReason)
+	now     func() time.Time
+
+	order   *list.List
+	entries map[K]*list.Element
+}
+
+func NewLruCache[K comparable, V any](options Options[K]) *LruCache[K, V] {
+	now := options.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	onEvict := options.OnEvict
+	if onEvict == nil {
+		onEvict = func(K, EvictReason) {}
+	}
+
+	return &LruCache[K, V]{
+		size:    max(options.Size, 1),
+		ttl:     options.Ttl,
+		onEvict: onEvict,
+		now:     now,
+		order:   list.New(),
+		entries: make(map[K]*list.Element, options.Size),
+	}
+}
+
+func (cache *LruCache[K, V]) Get(key K) (V, bool) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	element, ok := cache.entries[key]
+	if !ok {
+		var empty V
+		return empty, false
+	}
+
+	item := element.Value.(*entry[K, V])
+	if !cache.now().Before(item.expiresAt) {
+		cache.remove(element, EvictReasonExpired)
+
+		var empty V
+		return empty, false
+	}
+
+	cache.order.MoveToFront(element)
+	return item.value, true
+}
+
+func (cache *LruCache[K, V]) Set(key K, value V) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	expiresAt := cache.now().Add(cache.ttl)
+	if element, ok := cache.entries[key]; ok {
+		item := element.Value.(*entry[K, V])
+		item.value = value
+		item.expiresAt = expiresAt
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.entries[key] = cache.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if cache.order.Len() > cache.size {
+		cache.remove(cache.order.Back(), EvictReasonCapacity)
+	}
+}
+
+func (cache *LruCache[K, V]) Len() int {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	return cache.order.Len()
+}
+
+func (cache *LruCache[K, V]) remove(element *list.Element, reason EvictReason) {
+	item := cache.order.Remove(element).(*entry[K, V])
+	delete(cache.entries, item.key)
+	cache.onEvict(item.key, reason)
+}
@@ -0,0 +1,73 @@
+package lru_cache_test
+
+import (
+	"route256/cart/pkg/lru_cache"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type evicted struct {
+	key    int
+	reason lru_cache.EvictReason
+}
+
+func TestLruCache_Get_ShouldReturnStoredValue(t *testing.T) {
+	t.Parallel()
+	cache := lru_cache.NewLruCache[int, string](lru_cache.Options[int]{Size: 2, Ttl: time.Minute})
+
+	cache.Set(1, "one")
+	value, ok := cache.Get(1)
+	require.True(t, ok)
+	require.Equal(t, "one", value)
+
+	_, ok = cache.Get(2)
+	require.False(t, ok)
+}
+
+func TestLruCache_Set_ShouldEvictLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	var evictions []evicted
+	cache := lru_cache.NewLruCache[int, string](lru_cache.Options[int]{
+		Size: 2,
+		Ttl:  time.Minute,
+		OnEvict: func(key int, reason lru_cache.EvictReason) {
+			evictions = append(evictions, evicted{key, reason})
+		},
+	})
+
+	cache.Set(1, "one")
+	cache.Set(2, "two")
+	_, _ = cache.Get(1)
+	cache.Set(3, "three")
+
+	_, ok := cache.Get(2)
+	require.False(t, ok)
+	_, ok = cache.Get(1)
+	require.True(t, ok)
+	require.Equal(t, 2, cache.Len())
+	require.Equal(t, []evicted{{2, lru_cache.EvictReasonCapacity}}, evictions)
+}
+
+func TestLruCache_Get_ShouldExpireEntries(t *testing.T) {
+	t.Parallel()
+	var now = time.Now()
+	var evictions []evicted
+	cache := lru_cache.NewLruCache[int, string](lru_cache.Options[int]{
+		Size: 2,
+		Ttl:  time.Minute,
+		Now:  func() time.Time { return now },
+		OnEvict: func(key int, reason lru_cache.EvictReason) {
+			evictions = append(evictions, evicted{key, reason})
+		},
+	})
+
+	cache.Set(1, "one")
+	now = now.Add(time.Minute)
+
+	_, ok := cache.Get(1)
+	require.False(t, ok)
+	require.Equal(t, 0, cache.Len())
+	require.Equal(t, []evicted{{1, lru_cache.EvictReasonExpired}}, evictions)
+}
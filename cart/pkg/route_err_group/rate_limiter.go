@@ -0,0 +1,77 @@
+package route_err_group
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket refilled with limit tokens per second and holding at most burst tokens.
+// A single limiter can be shared between many groups to make the limit process-wide.
+type RateLimiter struct {
+	mtx    sync.Mutex
+	limit  float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func NewRateLimiter(limit float64, burst int) *RateLimiter {
+	if limit <= 0 {
+		limit = 1
+	}
+
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &RateLimiter{
+		limit:  limit,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or the context is done.
+func (limiter *RateLimiter) Wait(ctx context.Context) error {
+	delay := limiter.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		limiter.cancel()
+		return ctx.Err()
+	}
+}
+
+// reserve takes a token, possibly going into debt, and returns how long to wait until the debt is paid.
+func (limiter *RateLimiter) reserve() time.Duration {
+	limiter.mtx.Lock()
+	defer limiter.mtx.Unlock()
+
+	now := time.Now()
+	limiter.tokens = min(limiter.burst, limiter.tokens+now.Sub(limiter.last).Seconds()*limiter.limit)
+	limiter.last = now
+	limiter.tokens -= 1
+
+	if limiter.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-limiter.tokens / limiter.limit * float64(time.Second))
+}
+
+func (limiter *RateLimiter) cancel() {
+	limiter.mtx.Lock()
+	defer limiter.mtx.Unlock()
+
+	limiter.tokens = min(limiter.burst, limiter.tokens+1)
+}
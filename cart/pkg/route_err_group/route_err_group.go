@@ -10,13 +10,14 @@ import (
 var ErrBufferExceeded = fmt.Errorf("err group buffer size exceeded")
 
 type Options struct {
-	Rps        int
+	// Limiter throttles the start of every operation, operations are not throttled if it is nil
+	Limiter    *RateLimiter
 	BufferSize int
 }
 
 type RouteErrGroup[T any] struct {
 	wg       sync.WaitGroup
-	sem      chan struct{}
+	limiter  *RateLimiter
 	counter  atomic.Int64
 	groupMtx sync.Mutex
 
@@ -30,10 +31,6 @@ type RouteErrGroup[T any] struct {
 }
 
 func NewRouteErrorGroup[T any](ctx context.Context, options Options) *RouteErrGroup[T] {
-	if options.Rps <= 0 {
-		options.Rps = 1
-	}
-
 	if options.BufferSize < 0 {
 		options.BufferSize = 0
 	}
@@ -41,7 +38,7 @@ func NewRouteErrorGroup[T any](ctx context.Context, options Options) *RouteErrGr
 	ctx, cancel := context.WithCancelCause(ctx)
 	return &RouteErrGroup[T]{
 		wg:         sync.WaitGroup{},
-		sem:        make(chan struct{}, options.Rps),
+		limiter:    options.Limiter,
 		results:    make([]T, options.BufferSize),
 		ctx:        ctx,
 		cancelFunc: cancel,
@@ -57,12 +54,16 @@ func (eg *RouteErrGroup[T]) Run(function func(ctx context.Context) (T, error)) {
 	go func() {
 		defer eg.wg.Done()
 
-		select {
-		case eg.sem <- struct{}{}:
-		case <-eg.ctx.Done():
+		if eg.limiter != nil {
+			// the operation did not run, so the group must not look successful
+			if err := eg.limiter.Wait(eg.ctx); err != nil {
+				eg.handleError(err)
+				return
+			}
+		} else if err := eg.ctx.Err(); err != nil {
+			eg.handleError(err)
 			return
 		}
-		defer func() { <-eg.sem }()
 
 		result, err := function(eg.ctx)
 		if err != nil {
@@ -3,10 +3,10 @@ package route_err_group_test
 import (
 	"context"
 	"errors"
-	"fmt"
 	"route256/cart/pkg/route_err_group"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
@@ -23,10 +23,11 @@ func TestRouteErrGroup_Success_ShouldCorrectlyHandleRps(t *testing.T) {
 
 	var ctx = context.Background()
 	var errGroup = route_err_group.NewRouteErrorGroup[someStruct](ctx, route_err_group.Options{
-		Rps:        5,
+		Limiter:    route_err_group.NewRateLimiter(50, 5),
 		BufferSize: 15,
 	})
 	var counter atomic.Int64
+	var start = time.Now()
 
 	for range 15 {
 		errGroup.Run(func(ctx context.Context) (someStruct, error) {
@@ -40,6 +41,69 @@ func TestRouteErrGroup_Success_ShouldCorrectlyHandleRps(t *testing.T) {
 
 	require.Equal(t, len(res), 15)
 	require.Contains(t, res, someStruct{count: 11})
+	// first 5 operations are taken from the burst, the rest are spaced by 1/50 of a second
+	require.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+}
+
+func TestRouteErrGroup_Success_ShouldShareLimiterBetweenGroups(t *testing.T) {
+	t.Parallel()
+	var ctx = context.Background()
+	var limiter = route_err_group.NewRateLimiter(50, 1)
+	var start = time.Now()
+
+	var firstGroup = route_err_group.NewRouteErrorGroup[int64](ctx, route_err_group.Options{Limiter: limiter})
+	var secondGroup = route_err_group.NewRouteErrorGroup[int64](ctx, route_err_group.Options{Limiter: limiter})
+	for range 5 {
+		firstGroup.Run(func(ctx context.Context) (int64, error) { return 1, nil })
+		secondGroup.Run(func(ctx context.Context) (int64, error) { return 2, nil })
+	}
+
+	_, err := firstGroup.Await()
+	require.NoError(t, err, "Error is unexpected")
+	_, err = secondGroup.Await()
+	require.NoError(t, err, "Error is unexpected")
+
+	require.GreaterOrEqual(t, time.Since(start), 170*time.Millisecond)
+}
+
+func TestRouteErrGroup_Error_ShouldStopWaitingForLimiterOnCancel(t *testing.T) {
+	t.Parallel()
+	var ctx, cancel = context.WithCancel(context.Background())
+	var errGroup = route_err_group.NewRouteErrorGroup[int64](ctx, route_err_group.Options{
+		Limiter: route_err_group.NewRateLimiter(0.1, 1),
+	})
+	var counter atomic.Int64
+
+	for range 3 {
+		errGroup.Run(func(ctx context.Context) (int64, error) {
+			counter.Add(1)
+			return 10, nil
+		})
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	_, err := errGroup.Await()
+
+	require.ErrorIs(t, err, context.Canceled, "Skipped operations must fail the group")
+	require.Equal(t, int64(1), counter.Load())
+}
+
+func TestRouteErrGroup_Error_ShouldFailWhenCancelledBeforeStart(t *testing.T) {
+	t.Parallel()
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	var errGroup = route_err_group.NewRouteErrorGroup[int64](ctx, route_err_group.Options{})
+	var counter atomic.Int64
+
+	errGroup.Run(func(ctx context.Context) (int64, error) {
+		counter.Add(1)
+		return 10, nil
+	})
+	_, err := errGroup.Await()
+
+	require.ErrorIs(t, err, context.Canceled, "Skipped operations must fail the group")
+	require.Equal(t, int64(0), counter.Load())
 }
 
 func TestRouteErrGroup_Success_ShouldHandleBufferOverflow(t *testing.T) {
@@ -49,7 +113,7 @@ func TestRouteErrGroup_Success_ShouldHandleBufferOverflow(t *testing.T) {
 
 	var ctx = context.Background()
 	var errGroup = route_err_group.NewRouteErrorGroup[someStruct](ctx, route_err_group.Options{
-		Rps:        5,
+		Limiter:    route_err_group.NewRateLimiter(1000, 5),
 		BufferSize: 15,
 	})
 	var counter atomic.Int64
@@ -69,19 +133,11 @@ func TestRouteErrGroup_Success_ShouldCorrectlyHandleDynamicBuffer(t *testing.T)
 	t.Parallel()
 	var ctx = context.Background()
 	var errGroup = route_err_group.NewRouteErrorGroup[int64](ctx, route_err_group.Options{
-		Rps: 5,
+		Limiter: route_err_group.NewRateLimiter(1000, 5),
 	})
-	var concurrencyCounter atomic.Int64
 
 	for range 50 {
 		errGroup.Run(func(ctx context.Context) (int64, error) {
-			var newValue = concurrencyCounter.Add(1)
-			if newValue > 5 {
-				return 0, fmt.Errorf("concurrency limit exceeded: %d", newValue)
-			}
-
-			defer concurrencyCounter.Add(-1)
-
 			return 10, nil
 		})
 	}
@@ -95,11 +151,11 @@ func TestRouteErrGroup_Success_ShouldWorkWithEmptyOptions(t *testing.T) {
 	t.Parallel()
 	var ctx = context.Background()
 	var errGroup = route_err_group.NewRouteErrorGroup[int64](ctx, route_err_group.Options{})
-	var unsafeCounter = 0
+	var counter atomic.Int64
 
 	for range 50 {
 		errGroup.Run(func(ctx context.Context) (int64, error) {
-			unsafeCounter += 1
+			counter.Add(1)
 			return 10, nil
 		})
 	}
@@ -107,13 +163,15 @@ func TestRouteErrGroup_Success_ShouldWorkWithEmptyOptions(t *testing.T) {
 	result, err := errGroup.Await()
 	require.NoError(t, err, "Error is unexpected")
 	require.Equal(t, len(result), 50)
-	require.Equal(t, unsafeCounter, 50)
+	require.Equal(t, counter.Load(), int64(50))
 }
 
 func TestRouteErrGroup_Error_AndCancelOtherOperations(t *testing.T) {
 	t.Parallel()
 	var ctx = context.Background()
-	var errGroup = route_err_group.NewRouteErrorGroup[int64](ctx, route_err_group.Options{Rps: 1})
+	var errGroup = route_err_group.NewRouteErrorGroup[int64](ctx, route_err_group.Options{
+		Limiter: route_err_group.NewRateLimiter(100, 1),
+	})
 	var counter atomic.Int64
 	const numberOfOpsBeforeCancel = 5
 
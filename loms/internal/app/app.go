@@ -10,6 +10,7 @@ import (
 	"route256/loms/internal/infra/sre"
 	"route256/loms/internal/mw"
 	orders_v1 "route256/loms/pkg/api/orders/v1"
+	outbox_v1 "route256/loms/pkg/api/outbox/v1"
 	stocks_v1 "route256/loms/pkg/api/stocks/v1"
 	"sync"
 
@@ -78,7 +79,7 @@ func (app *App) ListenAndServe() error {
 
 func (app *App) Shutdown(context context.Context) error {
 	var wg sync.WaitGroup
-	var grpcErr, httpErr, notifierErr error
+	var grpcErr, httpErr, notifierErr, retentionErr, expirationErr, recoveryErr, reconciliationErr error
 
 	wg.Add(1)
 	go func() {
@@ -98,10 +99,36 @@ func (app *App) Shutdown(context context.Context) error {
 		notifierErr = app.deps.notifier.Close()
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		retentionErr = app.deps.retentionWorker.Close()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		expirationErr = app.deps.expirationWorker.Close()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		recoveryErr = app.deps.recoveryWorker.Close()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		reconciliationErr = app.deps.reconciliationWorker.Close()
+	}()
+
 	wg.Wait()
 
-	if grpcErr != nil || httpErr != nil || notifierErr != nil {
-		return fmt.Errorf("failed to shutdown servers: grpc: %w, http: %w, notifier %w", grpcErr, httpErr, notifierErr)
+	if grpcErr != nil || httpErr != nil || notifierErr != nil || retentionErr != nil || expirationErr != nil ||
+		recoveryErr != nil || reconciliationErr != nil {
+		return fmt.Errorf("failed to shutdown servers: grpc: %w, http: %w, notifier %w, retention %w, expiration %w, recovery %w, reconciliation %w",
+			grpcErr, httpErr, notifierErr, retentionErr, expirationErr, recoveryErr, reconciliationErr)
 	}
 
 	return nil
@@ -166,6 +193,16 @@ func bootstrapHttpGateway(app *App) {
 		logger.Fatal("Failed to register stocks gateway", "error", err)
 	}
 
+	err = stocks_v1.RegisterStocksAdminServiceHandlerFromEndpoint(context.Background(), mux, address, options)
+	if err != nil {
+		logger.Fatal("Failed to register stocks admin gateway", "error", err)
+	}
+
+	err = outbox_v1.RegisterOutboxAdminServiceHandlerFromEndpoint(context.Background(), mux, address, options)
+	if err != nil {
+		logger.Fatal("Failed to register outbox admin gateway", "error", err)
+	}
+
 	bootstrapHandler(mux, app)
 }
 
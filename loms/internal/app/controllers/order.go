@@ -8,11 +8,13 @@ import (
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type OrderService interface {
 	CreateOrder(ctx context.Context, order *model.CreateOrderModel) (int64, error)
 	OrderInfo(ctx context.Context, orderId int64) (*model.OrderModel, error)
+	ListOrders(ctx context.Context, filter *model.ListOrdersFilter) (*model.OrdersPage, error)
 	PayOrder(ctx context.Context, orderId int64) error
 	CancelOrder(ctx context.Context, orderId int64) error
 }
@@ -39,8 +41,9 @@ func (c *OrderController) CreateOrder(ctx context.Context,
 	}
 
 	order := &model.CreateOrderModel{
-		UserId: createRequest.User,
-		Items:  orderItems,
+		UserId:         createRequest.User,
+		Items:          orderItems,
+		IdempotencyKey: createRequest.IdempotencyKey,
 	}
 
 	orderId, err := c.service.CreateOrder(ctx, order)
@@ -70,19 +73,70 @@ func (c *OrderController) OrderInfo(ctx context.Context,
 		return nil, status.Errorf(codes.Unknown, "orderInfo: %v", err)
 	}
 
-	var orderItems = make([]*orders_v1.OrderItem, 0, len(orderInfo.Items))
-	for _, item := range orderInfo.Items {
+	return &orders_v1.OrderInfoResponse{
+		User:      orderInfo.UserId,
+		Status:    toProtoStatus(orderInfo.Status),
+		Items:     toOrderItems(orderInfo.Items),
+		CreatedAt: timestamppb.New(orderInfo.CreatedAt),
+		UpdatedAt: timestamppb.New(orderInfo.UpdatedAt),
+		History:   toProtoHistory(orderInfo.History),
+	}, nil
+}
+
+func (c *OrderController) ListOrders(ctx context.Context,
+	listRequest *orders_v1.ListOrdersRequest) (*orders_v1.ListOrdersResponse, error) {
+	filter := &model.ListOrdersFilter{
+		UserId:   listRequest.User,
+		Statuses: fromProtoStatuses(listRequest.Statuses),
+		AfterId:  listRequest.Cursor,
+		Limit:    int32(listRequest.Limit),
+	}
+
+	if listRequest.CreatedFrom != nil {
+		filter.CreatedFrom = listRequest.CreatedFrom.AsTime()
+	}
+
+	if listRequest.CreatedTo != nil {
+		filter.CreatedTo = listRequest.CreatedTo.AsTime()
+	}
+
+	page, err := c.service.ListOrders(ctx, filter)
+	if err != nil {
+		var unknownStatusErr *model.ErrUnknownOrderStatus
+		if errors.As(err, &unknownStatusErr) {
+			return nil, status.Errorf(codes.InvalidArgument, "listOrders: %v", err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "listOrders: %v", err)
+	}
+
+	var orders = make([]*orders_v1.ListOrdersResponse_Order, 0, len(page.Orders))
+	for _, order := range page.Orders {
+		orders = append(orders, &orders_v1.ListOrdersResponse_Order{
+			OrderId:   order.Id,
+			User:      order.UserId,
+			Status:    toProtoStatus(order.Status),
+			Items:     toOrderItems(order.Items),
+			CreatedAt: timestamppb.New(order.CreatedAt),
+		})
+	}
+
+	return &orders_v1.ListOrdersResponse{
+		Orders:     orders,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func toOrderItems(items []model.OrderItem) []*orders_v1.OrderItem {
+	var orderItems = make([]*orders_v1.OrderItem, 0, len(items))
+	for _, item := range items {
 		orderItems = append(orderItems, &orders_v1.OrderItem{
 			Sku:   item.Sku,
 			Count: item.Count,
 		})
 	}
 
-	return &orders_v1.OrderInfoResponse{
-		User:   orderInfo.UserId,
-		Status: orderInfo.Status,
-		Items:  orderItems,
-	}, nil
+	return orderItems
 }
 
 func (c *OrderController) PayOrder(ctx context.Context,
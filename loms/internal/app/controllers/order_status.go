@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"route256/loms/internal/domain/model"
+	orders_v1 "route256/loms/pkg/api/orders/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var orderStatusToProto = map[string]orders_v1.OrderStatus{
+	model.OrderStatusNew:             orders_v1.OrderStatus_ORDER_STATUS_NEW,
+	model.OrderStatusAwaitingPayment: orders_v1.OrderStatus_ORDER_STATUS_AWAITING_PAYMENT,
+	model.OrderStatusFailed:          orders_v1.OrderStatus_ORDER_STATUS_FAILED,
+	model.OrderStatusPayed:           orders_v1.OrderStatus_ORDER_STATUS_PAYED,
+	model.OrderStatusCancelled:       orders_v1.OrderStatus_ORDER_STATUS_CANCELLED,
+	model.OrderStatusReserving:       orders_v1.OrderStatus_ORDER_STATUS_RESERVING,
+	model.OrderStatusPaying:          orders_v1.OrderStatus_ORDER_STATUS_PAYING,
+	model.OrderStatusCancelling:      orders_v1.OrderStatus_ORDER_STATUS_CANCELLING,
+}
+
+var orderStatusFromProto = func() map[orders_v1.OrderStatus]string {
+	var statuses = make(map[orders_v1.OrderStatus]string, len(orderStatusToProto))
+	for status, protoStatus := range orderStatusToProto {
+		statuses[protoStatus] = status
+	}
+
+	return statuses
+}()
+
+// toProtoStatus maps the empty or unknown status to ORDER_STATUS_UNSPECIFIED.
+func toProtoStatus(status string) orders_v1.OrderStatus {
+	return orderStatusToProto[status]
+}
+
+// fromProtoStatuses maps the unspecified status to empty string, that is rejected by the service.
+func fromProtoStatuses(protoStatuses []orders_v1.OrderStatus) []string {
+	var statuses = make([]string, len(protoStatuses))
+	for i, protoStatus := range protoStatuses {
+		statuses[i] = orderStatusFromProto[protoStatus]
+	}
+
+	return statuses
+}
+
+func toProtoHistory(history []model.OrderStatusChange) []*orders_v1.OrderInfoResponse_StatusChange {
+	var changes = make([]*orders_v1.OrderInfoResponse_StatusChange, 0, len(history))
+	for _, change := range history {
+		changes = append(changes, &orders_v1.OrderInfoResponse_StatusChange{
+			FromStatus: toProtoStatus(change.FromStatus),
+			ToStatus:   toProtoStatus(change.ToStatus),
+			ChangedAt:  timestamppb.New(change.ChangedAt),
+		})
+	}
+
+	return changes
+}
@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"context"
+	outbox_v1 "route256/loms/pkg/api/outbox/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type OutboxAdmin interface {
+	RequeueDead(ctx context.Context, ids []int64) (int64, error)
+}
+
+type OutboxAdminController struct {
+	outbox_v1.UnimplementedOutboxAdminServiceServer
+	outbox OutboxAdmin
+}
+
+func NewOutboxAdminController(outbox OutboxAdmin) *OutboxAdminController {
+	return &OutboxAdminController{
+		outbox: outbox,
+	}
+}
+
+func (c *OutboxAdminController) RequeueDeadLetters(ctx context.Context,
+	request *outbox_v1.RequeueDeadLettersRequest) (*outbox_v1.RequeueDeadLettersResponse, error) {
+	requeued, err := c.outbox.RequeueDead(ctx, request.Ids)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "requeueDeadLetters: %v", err)
+	}
+
+	return &outbox_v1.RequeueDeadLettersResponse{
+		Requeued: requeued,
+	}, nil
+}
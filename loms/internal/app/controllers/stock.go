@@ -12,6 +12,7 @@ import (
 
 type StockService interface {
 	StocksInfo(ctx context.Context, skuId int64) (uint32, error)
+	StocksInfoBatch(ctx context.Context, skuIds []int64) ([]model.StockAvailability, error)
 }
 
 type StockController struct {
@@ -40,3 +41,108 @@ func (c *StockController) StocksInfo(ctx context.Context, stockInfo *stocks_v1.S
 		Count: count,
 	}, nil
 }
+
+func (c *StockController) StocksInfoBatch(ctx context.Context,
+	batchRequest *stocks_v1.StocksInfoBatchRequest) (*stocks_v1.StocksInfoBatchResponse, error) {
+	stocks, err := c.service.StocksInfoBatch(ctx, batchRequest.Skus)
+	if err != nil {
+		var invalidBatchErr *model.ErrInvalidStocksBatch
+		if errors.As(err, &invalidBatchErr) {
+			return nil, status.Errorf(codes.InvalidArgument, "stocksInfoBatch: %v", err)
+		}
+
+		var notFoundErr *model.ErrStockNotFound
+		if errors.As(err, &notFoundErr) {
+			return nil, status.Errorf(codes.NotFound, "stocksInfoBatch: %v", err)
+		}
+
+		return nil, status.Errorf(codes.Internal, "stocksInfoBatch: %v", err)
+	}
+
+	var stocksInfo = make([]*stocks_v1.StocksInfoBatchResponse_StockInfo, 0, len(stocks))
+	for _, stock := range stocks {
+		stocksInfo = append(stocksInfo, &stocks_v1.StocksInfoBatchResponse_StockInfo{
+			Sku:   stock.Sku,
+			Count: stock.Count,
+			Found: stock.Found,
+		})
+	}
+
+	return &stocks_v1.StocksInfoBatchResponse{
+		Stocks: stocksInfo,
+	}, nil
+}
+
+type StockAdminService interface {
+	AddStock(ctx context.Context, sku int64, count uint32, reason string) (*model.StockModel, error)
+	AdjustStock(ctx context.Context, sku int64, delta int64, reason string) (*model.StockModel, error)
+	SetStock(ctx context.Context, sku int64, totalCount uint32, reason string) (*model.StockModel, error)
+}
+
+type StockAdminController struct {
+	stocks_v1.UnimplementedStocksAdminServiceServer
+	service StockAdminService
+}
+
+func NewStocksAdminController(service StockAdminService) *StockAdminController {
+	return &StockAdminController{
+		service: service,
+	}
+}
+
+func (c *StockAdminController) AddStock(ctx context.Context,
+	addRequest *stocks_v1.AddStockRequest) (*stocks_v1.StockChangeResponse, error) {
+	stock, err := c.service.AddStock(ctx, addRequest.Sku, addRequest.Count, addRequest.Reason)
+	if err != nil {
+		return nil, toStockChangeError("addStock", err)
+	}
+
+	return toStockChangeResponse(stock), nil
+}
+
+func (c *StockAdminController) AdjustStock(ctx context.Context,
+	adjustRequest *stocks_v1.AdjustStockRequest) (*stocks_v1.StockChangeResponse, error) {
+	stock, err := c.service.AdjustStock(ctx, adjustRequest.Sku, adjustRequest.Delta, adjustRequest.Reason)
+	if err != nil {
+		return nil, toStockChangeError("adjustStock", err)
+	}
+
+	return toStockChangeResponse(stock), nil
+}
+
+func (c *StockAdminController) SetStock(ctx context.Context,
+	setRequest *stocks_v1.SetStockRequest) (*stocks_v1.StockChangeResponse, error) {
+	stock, err := c.service.SetStock(ctx, setRequest.Sku, setRequest.TotalCount, setRequest.Reason)
+	if err != nil {
+		return nil, toStockChangeError("setStock", err)
+	}
+
+	return toStockChangeResponse(stock), nil
+}
+
+func toStockChangeResponse(stock *model.StockModel) *stocks_v1.StockChangeResponse {
+	return &stocks_v1.StockChangeResponse{
+		Sku:        stock.Sku,
+		TotalCount: stock.TotalCount,
+		Reserved:   stock.Reserved,
+	}
+}
+
+func toStockChangeError(method string, err error) error {
+	var notFoundErr *model.ErrStockNotFound
+	if errors.As(err, &notFoundErr) {
+		return status.Errorf(codes.NotFound, "%s: %v", method, err)
+	}
+
+	var invalidChangeErr *model.ErrInvalidStockChange
+	if errors.As(err, &invalidChangeErr) {
+		return status.Errorf(codes.InvalidArgument, "%s: %v", method, err)
+	}
+
+	var outOfBoundsErr *model.ErrStockOutOfBounds
+	if errors.As(err, &outOfBoundsErr) {
+		return status.Errorf(codes.FailedPrecondition, "%s: %v", method, err)
+	}
+
+	return status.Errorf(codes.Internal, "%s: %v", method, err)
+}
@@ -5,15 +5,20 @@ import (
 	"fmt"
 	"route256/loms/internal/app/controllers"
 	"route256/loms/internal/domain/notifier"
+	"route256/loms/internal/domain/order/order_expiration"
+	"route256/loms/internal/domain/order/order_recovery"
 	"route256/loms/internal/domain/order/order_repository"
 	"route256/loms/internal/domain/order/order_repository_pg"
 	"route256/loms/internal/domain/order/order_service"
+	"route256/loms/internal/domain/stock/stock_reconciliation"
 	"route256/loms/internal/domain/stock/stock_repository"
 	"route256/loms/internal/domain/stock/stock_repository_pg"
 	"route256/loms/internal/domain/stock/stock_service"
 	"route256/loms/internal/infra/logger"
 	"route256/loms/internal/infra/loms_config"
+	"route256/loms/internal/infra/transactor"
 	orders_v1 "route256/loms/pkg/api/orders/v1"
+	outbox_v1 "route256/loms/pkg/api/outbox/v1"
 	stocks_v1 "route256/loms/pkg/api/stocks/v1"
 	"time"
 
@@ -39,14 +44,36 @@ type NotifierProducer interface {
 	Close() error
 }
 
+type ExpirationWorker interface {
+	Close() error
+}
+
+type RecoveryWorker interface {
+	Close() error
+}
+
+type ReconciliationWorker interface {
+	Close() error
+}
+
+type RetentionWorker interface {
+	Close() error
+}
+
 type Deps struct {
-	notifier NotifierProducer
+	notifier             NotifierProducer
+	retentionWorker      RetentionWorker
+	expirationWorker     ExpirationWorker
+	recoveryWorker       RecoveryWorker
+	reconciliationWorker ReconciliationWorker
 }
 
 func InitializeDeps(ctx context.Context, grpcServer *grpc.Server, config *loms_config.Config) *Deps {
 	var orderRepository OrderRepository
 	var stockRepository StockRepository
 	var notifyProducer NotifierProducer
+	var retentionWorker RetentionWorker
+	var unitOfWork order_service.Transactor
 
 	ctx, span := otel.GetTracerProvider().Tracer("initialize").Start(ctx, "initialize.deps")
 	defer span.End()
@@ -54,6 +81,7 @@ func InitializeDeps(ctx context.Context, grpcServer *grpc.Server, config *loms_c
 	if config.Server.IsInMemory {
 		orderRepository = order_repository.NewOrderRepository()
 		stockRepository = stock_repository.NewStockRepository()
+		unitOfWork = transactor.NoopTransactor{}
 	} else {
 		master, replica, err := connectToDatabases(ctx, config)
 		if err != nil {
@@ -62,19 +90,27 @@ func InitializeDeps(ctx context.Context, grpcServer *grpc.Server, config *loms_c
 
 		orderRepository = order_repository_pg.NewOrderRepository(master, replica, config)
 		stockRepository = stock_repository_pg.NewOrderRepository(master, replica)
-		outboxRepository := notifier.NewOutboxRepository(master)
+		unitOfWork = transactor.NewPgTransactor(master)
+		outboxRepository := notifier.NewOutboxRepository(master, config)
+		outbox_v1.RegisterOutboxAdminServiceServer(grpcServer, controllers.NewOutboxAdminController(outboxRepository))
 
 		notifyProducer = notifier.NewNotifierProducer(ctx, config, outboxRepository)
+		retentionWorker = notifier.NewRetentionWorker(config, outboxRepository)
 	}
 
-	var orderService = order_service.NewOrderService(orderRepository, stockRepository)
+	var orderService = order_service.NewOrderService(orderRepository, stockRepository, unitOfWork)
 	var stocksService = stock_service.NewStockService(stockRepository)
 
 	orders_v1.RegisterOrdersServiceServer(grpcServer, controllers.NewOrderController(orderService))
 	stocks_v1.RegisterStocksServiceServer(grpcServer, controllers.NewStocksController(stocksService))
+	stocks_v1.RegisterStocksAdminServiceServer(grpcServer, controllers.NewStocksAdminController(stocksService))
 
 	return &Deps{
-		notifier: notifyProducer,
+		notifier:             notifyProducer,
+		retentionWorker:      retentionWorker,
+		expirationWorker:     order_expiration.NewExpirationWorker(config, orderService),
+		recoveryWorker:       order_recovery.NewRecoveryWorker(config, orderService),
+		reconciliationWorker: stock_reconciliation.NewReconciliationWorker(config, stocksService),
 	}
 }
 
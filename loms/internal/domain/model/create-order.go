@@ -8,4 +8,6 @@ type OrderItem struct {
 type CreateOrderModel struct {
 	UserId int64       `validate:"required,gte=1"`
 	Items  []OrderItem `validate:"required,min=1,dive"`
+	// IdempotencyKey is optional, orders of the same user with the same key are created once
+	IdempotencyKey string `validate:"max=128"`
 }
@@ -60,11 +60,20 @@ func (e *ErrOrderItemOutOfBounds) Error() string {
 type ErrInvalidOrderStatus struct {
 	OrderId int64
 	Status  string
+	Target  string
 }
 
 func (e *ErrInvalidOrderStatus) Error() string {
-	return fmt.Sprintf("status not valid for the operation, order: %d, status: %s",
-		e.OrderId, e.Status)
+	return fmt.Sprintf("status not valid for the operation, order: %d, status: %s, target: %s",
+		e.OrderId, e.Status, e.Target)
+}
+
+type ErrUnknownOrderStatus struct {
+	Status string
+}
+
+func (e *ErrUnknownOrderStatus) Error() string {
+	return fmt.Sprintf("unknown order status: %s", e.Status)
 }
 
 type ErrOrderStatusMismatch struct {
@@ -77,3 +86,30 @@ func (e *ErrOrderStatusMismatch) Error() string {
 	return fmt.Sprintf("status mismatch for order: %d, current: %s, expected: %s",
 		e.OrderId, e.CurrentStatus, e.ExpectedState)
 }
+
+type ErrDuplicateIdempotencyKey struct {
+	OrderId int64
+	Status  string
+}
+
+func (e *ErrDuplicateIdempotencyKey) Error() string {
+	return fmt.Sprintf("order with the same idempotency key already exists: %d, status: %s",
+		e.OrderId, e.Status)
+}
+
+type ErrInvalidStockChange struct {
+	Sku    int64
+	Reason string
+}
+
+func (e *ErrInvalidStockChange) Error() string {
+	return fmt.Sprintf("invalid stock change for sku %d: %s", e.Sku, e.Reason)
+}
+
+type ErrInvalidStocksBatch struct {
+	Reason string
+}
+
+func (e *ErrInvalidStocksBatch) Error() string {
+	return fmt.Sprintf("invalid stocks batch: %s", e.Reason)
+}
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+const DefaultOrdersPageSize = 20
+
+// ListOrdersFilter selects orders page by page ordered by id. Zero values of the fields do not filter.
+type ListOrdersFilter struct {
+	UserId   int64    `validate:"gte=0"`
+	Statuses []string `validate:"max=16"`
+	// CreatedFrom is inclusive, CreatedTo is exclusive
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// AfterId is the keyset cursor, only orders with greater id are returned
+	AfterId int64 `validate:"gte=0"`
+	Limit   int32 `validate:"gte=0,lte=100"`
+}
+
+type OrdersPage struct {
+	Orders []OrderModel
+	// NextCursor is the AfterId of the next page, zero if there are no more orders
+	NextCursor int64
+}
@@ -0,0 +1,115 @@
+package model
+
+// OrderState describes a single order status of the state machine.
+type OrderState struct {
+	Status string
+	// Internal states are the intermediate steps of the operations and never become visible to other services.
+	Internal bool
+	// EmitsEvent states are reported to the outbox once the order enters them.
+	EmitsEvent bool
+}
+
+// OrderStateMachine declares the order statuses and the transitions allowed between them.
+type OrderStateMachine struct {
+	initial     string
+	statuses    []string
+	states      map[string]OrderState
+	transitions map[string]map[string]struct{}
+}
+
+// NewOrderStateMachine creates a state machine starting at initial status. Transitions map a status
+// to all statuses that can be reached from it. Every status used in transitions must be declared in states.
+func NewOrderStateMachine(initial string, states []OrderState, transitions map[string][]string) *OrderStateMachine {
+	var machine = &OrderStateMachine{
+		initial:     initial,
+		states:      make(map[string]OrderState, len(states)),
+		transitions: make(map[string]map[string]struct{}, len(transitions)),
+	}
+
+	for _, state := range states {
+		machine.statuses = append(machine.statuses, state.Status)
+		machine.states[state.Status] = state
+	}
+
+	for from, targets := range transitions {
+		machine.transitions[from] = make(map[string]struct{}, len(targets))
+		for _, to := range targets {
+			machine.transitions[from][to] = struct{}{}
+		}
+	}
+
+	return machine
+}
+
+// OrderStatuses is the state machine of the order lifecycle.
+//
+//	new -> reserving -> awaiting payment | failed
+//	awaiting payment -> paying -> payed | awaiting payment
+//	awaiting payment -> cancelling -> cancelled | awaiting payment
+var OrderStatuses = NewOrderStateMachine(OrderStatusNew,
+	[]OrderState{
+		{Status: OrderStatusNew, EmitsEvent: true},
+		{Status: OrderStatusAwaitingPayment, EmitsEvent: true},
+		{Status: OrderStatusFailed, EmitsEvent: true},
+		{Status: OrderStatusPayed, EmitsEvent: true},
+		{Status: OrderStatusCancelled, EmitsEvent: true},
+		{Status: OrderStatusReserving, Internal: true},
+		{Status: OrderStatusPaying, Internal: true},
+		{Status: OrderStatusCancelling, Internal: true},
+	},
+	map[string][]string{
+		OrderStatusNew:             {OrderStatusReserving},
+		OrderStatusReserving:       {OrderStatusAwaitingPayment, OrderStatusFailed},
+		OrderStatusAwaitingPayment: {OrderStatusPaying, OrderStatusCancelling},
+		OrderStatusPaying:          {OrderStatusPayed, OrderStatusAwaitingPayment},
+		OrderStatusCancelling:      {OrderStatusCancelled, OrderStatusAwaitingPayment},
+	},
+)
+
+// Initial returns the status every order is created with.
+func (m *OrderStateMachine) Initial() string {
+	return m.initial
+}
+
+// IsKnown reports whether the status is declared in the state machine.
+func (m *OrderStateMachine) IsKnown(status string) bool {
+	_, ok := m.states[status]
+	return ok
+}
+
+// IsInternal reports whether the status is an intermediate step of an operation.
+func (m *OrderStateMachine) IsInternal(status string) bool {
+	return m.states[status].Internal
+}
+
+// InternalStatuses returns the internal statuses in the order of declaration.
+func (m *OrderStateMachine) InternalStatuses() []string {
+	var statuses = make([]string, 0, len(m.statuses))
+	for _, status := range m.statuses {
+		if m.IsInternal(status) {
+			statuses = append(statuses, status)
+		}
+	}
+
+	return statuses
+}
+
+// EmitsEvent reports whether entering the status must be reported to the outbox.
+func (m *OrderStateMachine) EmitsEvent(status string) bool {
+	return m.states[status].EmitsEvent
+}
+
+// CanTransition reports whether the order in from status can be moved to the to status.
+func (m *OrderStateMachine) CanTransition(from string, to string) bool {
+	_, ok := m.transitions[from][to]
+	return ok
+}
+
+// ValidateTransition returns ErrInvalidOrderStatus if the order can not be moved from status to status.
+func (m *OrderStateMachine) ValidateTransition(orderId int64, from string, to string) error {
+	if !m.CanTransition(from, to) {
+		return &ErrInvalidOrderStatus{OrderId: orderId, Status: from, Target: to}
+	}
+
+	return nil
+}
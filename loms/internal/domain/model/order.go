@@ -1,21 +1,33 @@
 package model
 
+import "time"
+
 const (
 	OrderStatusNew             = "new"
 	OrderStatusAwaitingPayment = "awaiting payment"
 	OrderStatusFailed          = "failed"
 	OrderStatusPayed           = "payed"
 	OrderStatusCancelled       = "cancelled"
-	// internal statuses. !IMPORTANT if status ends with 'ing'
-	// it is a transition status and is not reported to outbox!
+	// internal statuses, see OrderStatuses for the transitions between them
 	OrderStatusReserving  = "reserving"
 	OrderStatusPaying     = "paying"
 	OrderStatusCancelling = "cancelling"
 )
 
 type OrderModel struct {
-	Id     int64
-	Status string
-	UserId int64
-	Items  []OrderItem
+	Id        int64
+	Status    string
+	UserId    int64
+	Items     []OrderItem
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// History is filled only for a single order lookup
+	History []OrderStatusChange
+}
+
+// OrderStatusChange is a single transition of the order status, FromStatus is empty for the order creation.
+type OrderStatusChange struct {
+	FromStatus string
+	ToStatus   string
+	ChangedAt  time.Time
 }
@@ -1,7 +1,10 @@
 package model
 
 const (
-	statusPending = "pending"
-	statusSent    = "sent"
-	statusFailed  = "failed"
+	OutboxStatusPending = "pending"
+	// OutboxStatusInFlight messages are leased to a loms instance until their lease expires
+	OutboxStatusInFlight = "in_flight"
+	OutboxStatusSent     = "sent"
+	// OutboxStatusDead messages ran out of delivery attempts and wait to be requeued by an admin
+	OutboxStatusDead = "dead"
 )
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+const (
+	StockOperationAdd    = "add"
+	StockOperationAdjust = "adjust"
+	StockOperationSet    = "set"
+)
+
+// StockChange describes an administrative change of the stock total count.
+// Delta is applied for add and adjust operations, TotalCount replaces the
+// current value for the set operation.
+type StockChange struct {
+	Sku        int64
+	Operation  string
+	Delta      int64
+	TotalCount uint32
+	Reason     string
+}
+
+// StockLedgerEntry is an audit record of the applied stock change.
+type StockLedgerEntry struct {
+	Sku         int64
+	Operation   string
+	Delta       int64
+	TotalBefore uint32
+	TotalAfter  uint32
+	Reason      string
+	CreatedAt   time.Time
+}
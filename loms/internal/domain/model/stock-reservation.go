@@ -0,0 +1,25 @@
+package model
+
+const (
+	// ReservationStateReserved is the only open state, open reservations sum up to the stock reserved counter
+	ReservationStateReserved  = "reserved"
+	ReservationStateRemoved   = "removed"
+	ReservationStateCancelled = "cancelled"
+)
+
+// StockReservation is the count of the sku held by the order. Reservations without an order (zero OrderId)
+// are the opening balance held before the reservations were recorded.
+type StockReservation struct {
+	OrderId int64
+	Sku     int64
+	Count   uint32
+	State   string
+}
+
+// ReservedDrift is a mismatch between the stock reserved counter and the sum of open reservations.
+type ReservedDrift struct {
+	Sku      int64
+	Reserved uint32
+	Expected uint32
+	Repaired bool
+}
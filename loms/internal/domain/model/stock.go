@@ -1,7 +1,45 @@
 package model
 
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
 type StockModel struct {
 	Sku        int64  `json:"sku"`
 	TotalCount uint32 `json:"total_count"`
 	Reserved   uint32 `json:"reserved"`
 }
+
+// StockAvailability is the available count of the sku, Found is false when
+// the sku has no stock record.
+type StockAvailability struct {
+	Sku   int64
+	Count uint32
+	Found bool
+}
+
+// MergeStockItems sums the counts of duplicate skus and orders the items by
+// sku, so every transaction touches stock rows in the same order.
+func MergeStockItems(items []OrderItem) ([]OrderItem, error) {
+	var totals = make(map[int64]uint64, len(items))
+	for _, item := range items {
+		totals[item.Sku] += uint64(item.Count)
+	}
+
+	var merged = make([]OrderItem, 0, len(totals))
+	for sku, total := range totals {
+		if total > math.MaxUint32 {
+			return nil, &ErrOrderItemOutOfBounds{Sku: sku, Total: total}
+		}
+
+		merged = append(merged, OrderItem{Sku: sku, Count: uint32(total)})
+	}
+
+	slices.SortFunc(merged, func(a, b OrderItem) int {
+		return cmp.Compare(a.Sku, b.Sku)
+	})
+
+	return merged, nil
+}
@@ -1,11 +1,112 @@
--- name: UpdateRowStatus :batchexec
+-- name: ClaimPending :many
+with expired as (
+    -- an expired lease spends an attempt, so messages without attempts left are dead-lettered
+    update outbox
+    set status = 'dead',
+        attempts = attempts + 1,
+        last_error = 'lease expired',
+        lease_until = null,
+        updated_at = now()
+    where status = 'in_flight'
+        and lease_until < now()
+        and attempts + 1 >= @max_attempts::int
+), claimable as (
+    -- a message waits while an earlier message of its key is not delivered and can not be claimed
+    -- along with it, so the messages of a key are delivered in the order of ids
+    select id from outbox m
+    where ((m.status = 'pending' and m.next_attempt_at <= now())
+            or (m.status = 'in_flight' and m.lease_until < now() and m.attempts + 1 < @max_attempts::int))
+        and not exists (
+            select 1 from outbox e
+            where e.key = m.key
+                and e.id < m.id
+                and (e.status = 'dead'
+                    or (e.status = 'pending' and e.next_attempt_at > now())
+                    or (e.status = 'in_flight' and (e.lease_until >= now() or e.attempts + 1 >= @max_attempts::int)))
+        )
+    order by m.id asc
+    limit @batch_size
+    for update skip locked
+)
+update outbox o
+set status = 'in_flight',
+    attempts = case when o.status = 'in_flight' then o.attempts + 1 else o.attempts end,
+    lease_until = now() + make_interval(secs => @lease_seconds::float8),
+    owner = @owner::text,
+    updated_at = now()
+from claimable c
+where o.id = c.id
+returning o.*;
+
+-- name: MarkSent :batchexec
+update outbox
+set status = 'sent',
+    lease_until = null,
+    updated_at = now()
+where id = @id and status = 'in_flight' and owner = @owner::text;
+
+-- name: MarkFailed :batchexec
+update outbox
+set attempts = attempts + 1,
+    status = case when attempts + 1 >= @max_attempts::int then 'dead' else 'pending' end,
+    next_attempt_at = now() + make_interval(secs => @backoff_seconds::float8),
+    last_error = @last_error::text,
+    lease_until = null,
+    updated_at = now()
+where id = @id and status = 'in_flight' and owner = @owner::text;
+
+-- name: ReleaseClaimed :batchexec
 update outbox
-set status = @status, updated_at = now()
-where id = @id;
+set status = 'pending',
+    last_error = @last_error::text,
+    lease_until = null,
+    updated_at = now()
+where id = @id and status = 'in_flight' and owner = @owner::text;
+
+-- name: RequeueDead :execrows
+update outbox
+set status = 'pending',
+    attempts = 0,
+    next_attempt_at = now(),
+    updated_at = now()
+where status = 'dead'
+    and (cardinality(@ids::bigint[]) = 0 or id = any(@ids::bigint[]));
+
+-- name: DeleteSent :execrows
+with purged as (
+    select id from outbox
+    where status = 'sent' and updated_at < now() - make_interval(days => @retention_days::int)
+    order by updated_at asc
+    limit @batch_size
+    for update skip locked
+)
+delete from outbox o
+using purged p
+where o.id = p.id;
+
+-- name: ArchiveSent :execrows
+with purged as (
+    select id from outbox
+    where status = 'sent' and updated_at < now() - make_interval(days => @retention_days::int)
+    order by updated_at asc
+    limit @batch_size
+    for update skip locked
+), archived as (
+    delete from outbox o
+    using purged p
+    where o.id = p.id
+    returning o.*
+)
+insert into outbox_archive (
+    id, aggregate_id, aggregate_type, event_type, key, payload, topic, attempts, created_at, sent_at
+)
+select id, aggregate_id, aggregate_type, event_type, key, payload, topic, attempts, created_at, updated_at
+from archived;
 
--- name: GetPending :many
-select * from outbox
-where status = 'pending'
-order by updated_at asc
-limit @batch_size
-for update skip locked;
+-- name: GetBacklog :many
+select status,
+    count(*)::bigint as size,
+    coalesce(extract(epoch from now() - min(created_at)), 0)::float8 as oldest_age_seconds
+from outbox
+where status <> 'sent'
+group by status;
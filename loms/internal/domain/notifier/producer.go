@@ -2,6 +2,7 @@ package notifier
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"route256/loms/internal/infra/logger"
 	"route256/loms/internal/infra/loms_config"
@@ -13,6 +14,11 @@ import (
 	"go.opentelemetry.io/otel"
 )
 
+const (
+	outboxBatchSize        = 10
+	listenerReconnectDelay = time.Second
+)
+
 type NotifierProducer struct {
 	cfg      *loms_config.Config
 	producer sarama.SyncProducer
@@ -67,55 +73,173 @@ func (p *NotifierProducer) Close() error {
 	return nil
 }
 
+// runOutboxPoller relays pending messages as soon as the outbox notifies about them.
 func (p *NotifierProducer) runOutboxPoller() {
-	ctx := context.Background()
+	ctx, cancel := context.WithCancel(context.Background())
+	wakeups := make(chan struct{}, 1)
+	listening := make(chan bool)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.runOutboxListener(ctx, wakeups, listening)
+	}()
 
 	p.wg.Add(1)
 	go func() {
 		defer p.wg.Done()
-		ticker := time.NewTicker(time.Duration(p.cfg.Kafka.PollMs) * time.Millisecond)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-p.stopChan:
-				return
-			case <-ticker.C:
-				if err := p.processPendingMessages(ctx); err != nil {
-					logger.Warn("Failed to process pending outbox messages", "error", err)
-				}
+		p.pollOutbox(ctx, wakeups, listening, p.processPendingMessages)
+	}()
+
+	go func() {
+		<-p.stopChan
+		cancel()
+	}()
+}
+
+// pollOutbox calls process on every wakeup and tick until the producer is stopped.
+// The ticker is a safety net for retries and expired leases while the outbox is listened,
+// it falls back to the kafka poll interval once the listener connection drops.
+// Failed relays back the poller off as a whole, wakeups and ticks are skipped until resume fires.
+func (p *NotifierProducer) pollOutbox(
+	ctx context.Context,
+	wakeups <-chan struct{},
+	listening <-chan bool,
+	process func(context.Context) error,
+) {
+	pollInterval := time.Duration(p.cfg.Kafka.PollMs) * time.Millisecond
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var failures int32
+	var resume <-chan time.Time
+
+	for {
+		select {
+		case <-p.stopChan:
+			return
+		case isListening := <-listening:
+			if isListening {
+				ticker.Reset(p.cfg.Outbox.ListenPoll)
+				continue
 			}
+			ticker.Reset(pollInterval)
+		case <-resume:
+			resume = nil
+		case <-wakeups:
+		case <-ticker.C:
 		}
-	}()
+
+		if resume != nil {
+			continue
+		}
+
+		if err := process(ctx); err != nil {
+			delay := p.outbox.backoff(failures)
+			failures++
+			resume = time.After(delay)
+			logger.Warn("Failed to process pending outbox messages, backing off", "delay", delay, "error", err)
+			continue
+		}
+
+		failures = 0
+	}
 }
 
+// runOutboxListener keeps listening the outbox channel and reconnects when the connection drops
+func (p *NotifierProducer) runOutboxListener(ctx context.Context, wakeups chan<- struct{}, listening chan<- bool) {
+	wakeup := func() {
+		select {
+		case wakeups <- struct{}{}:
+		default:
+		}
+	}
+
+	setListening := func(isListening bool) {
+		select {
+		case listening <- isListening:
+		case <-ctx.Done():
+		}
+	}
+
+	for {
+		err := p.outbox.ListenPending(ctx, func() {
+			logger.Info("Listening outbox notifications", "channel", OutboxChannel)
+			setListening(true)
+			// messages committed while the listener was down are not notified again
+			wakeup()
+		}, wakeup)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Warn("Outbox listener dropped, falling back to polling", "error", err)
+		setListening(false)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenerReconnectDelay):
+		}
+	}
+}
+
+// processPendingMessages relays full batches until the outbox runs out of pending messages
 func (p *NotifierProducer) processPendingMessages(ctx context.Context) error {
-	return p.outbox.ProcessPendingMessagesFn(ctx, 10, func(row []OutboxEntity) error {
-		if len(row) == 0 {
-			return nil
+	for {
+		processed, err := p.outbox.ProcessPendingMessagesFn(ctx, outboxBatchSize, p.sendMessages)
+		if err != nil || processed < outboxBatchSize || ctx.Err() != nil {
+			return err
 		}
+	}
+}
 
-		messages := make([]*sarama.ProducerMessage, 0, len(row))
-		for _, entity := range row {
-			message := &sarama.ProducerMessage{
-				Topic: entity.Topic,
-				Key:   sarama.StringEncoder(entity.Key),
-				Value: sarama.ByteEncoder(entity.Payload),
-			}
+// sendMessages reports the messages kafka failed to accept one by one, so that
+// the delivered part of the batch is not sent again
+func (p *NotifierProducer) sendMessages(row []OutboxEntity) error {
+	if len(row) == 0 {
+		return nil
+	}
 
-			messages = append(messages, message)
+	messages := make([]*sarama.ProducerMessage, 0, len(row))
+	for _, entity := range row {
+		message := &sarama.ProducerMessage{
+			Topic:    entity.Topic,
+			Key:      sarama.StringEncoder(entity.Key),
+			Value:    sarama.ByteEncoder(entity.Payload),
+			Metadata: entity.ID,
 		}
 
-		startTime := time.Now()
-		err := p.producer.SendMessages(messages)
-		sre.TrackExternalRequest("kafka_send_messages", err, startTime)
-		if err != nil {
+		messages = append(messages, message)
+	}
+
+	startTime := time.Now()
+	err := p.producer.SendMessages(messages)
+	sre.TrackExternalRequest("kafka_send_messages", err, startTime)
+	if err != nil {
+		var producerErrs sarama.ProducerErrors
+		if !errors.As(err, &producerErrs) {
+			logger.Warn("Failed to send messages to kafka producer", "message_count", len(messages), "error", err)
 			return fmt.Errorf("failed to send messages to producer: %w", err)
 		}
 
-		logger.Info("Sent messages to kafka producer", "topic", row[0].Topic, "message_count", len(messages))
-		return nil
-	})
+		deliveryErrs := make(DeliveryErrors, len(producerErrs))
+		for _, producerErr := range producerErrs {
+			id, ok := producerErr.Msg.Metadata.(int64)
+			if !ok {
+				return fmt.Errorf("failed to send messages to producer: %w", err)
+			}
+			deliveryErrs[id] = producerErr
+		}
+
+		logger.Warn("Failed to send part of messages to kafka producer",
+			"message_count", len(messages), "failed_count", len(deliveryErrs), "error", err)
+		return deliveryErrs
+	}
+
+	logger.Info("Sent messages to kafka producer", "topic", row[0].Topic, "message_count", len(messages))
+	return nil
 }
 
 func connectToProducer(
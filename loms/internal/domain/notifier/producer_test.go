@@ -0,0 +1,200 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"route256/loms/internal/infra/loms_config"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/require"
+)
+
+type syncProducerStub struct {
+	sarama.SyncProducer
+	sendMessages func([]*sarama.ProducerMessage) error
+}
+
+func (s *syncProducerStub) SendMessages(messages []*sarama.ProducerMessage) error {
+	return s.sendMessages(messages)
+}
+
+func TestNotifierProducer_SendMessages(t *testing.T) {
+	t.Parallel()
+
+	entities := []OutboxEntity{
+		{ID: 1, Key: "1", Payload: []byte(`{}`), Topic: "topic"},
+		{ID: 2, Key: "2", Payload: []byte(`{}`), Topic: "topic"},
+		{ID: 3, Key: "3", Payload: []byte(`{}`), Topic: "topic"},
+	}
+
+	t.Run("reports failed messages one by one", func(t *testing.T) {
+		t.Parallel()
+
+		brokerErr := errors.New("broker is not available")
+		producer := &NotifierProducer{producer: &syncProducerStub{
+			sendMessages: func(messages []*sarama.ProducerMessage) error {
+				return sarama.ProducerErrors{{Msg: messages[1], Err: brokerErr}}
+			},
+		}}
+
+		err := producer.sendMessages(entities)
+
+		var deliveryErrs DeliveryErrors
+		require.ErrorAs(t, err, &deliveryErrs)
+		require.Len(t, deliveryErrs, 1)
+		require.ErrorIs(t, deliveryErrs[2], brokerErr)
+	})
+
+	t.Run("fails whole batch on unknown error", func(t *testing.T) {
+		t.Parallel()
+
+		producer := &NotifierProducer{producer: &syncProducerStub{
+			sendMessages: func([]*sarama.ProducerMessage) error {
+				return sarama.ErrClosedClient
+			},
+		}}
+
+		err := producer.sendMessages(entities)
+
+		var deliveryErrs DeliveryErrors
+		require.ErrorIs(t, err, sarama.ErrClosedClient)
+		require.False(t, errors.As(err, &deliveryErrs))
+	})
+}
+
+func TestNotifierProducer_PollOutbox(t *testing.T) {
+	t.Parallel()
+
+	const wait = time.Second
+
+	type poller struct {
+		wakeups   chan struct{}
+		listening chan bool
+		processed chan struct{}
+		stop      func()
+	}
+
+	// startPoller runs the poll loop with a fake listener feeding wakeups and listening changes
+	startPoller := func(t *testing.T, listenPoll time.Duration, process func() error) poller {
+		cfg := &loms_config.Config{}
+		cfg.Kafka.PollMs = time.Hour.Milliseconds()
+		cfg.Outbox.ListenPoll = listenPoll
+
+		producer := &NotifierProducer{
+			cfg:      cfg,
+			stopChan: make(chan struct{}),
+			outbox:   &OutboxRepository{backoffBase: 100 * time.Millisecond, backoffMax: time.Second},
+		}
+		p := poller{
+			wakeups:   make(chan struct{}, 1),
+			listening: make(chan bool),
+			processed: make(chan struct{}, 100),
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			producer.pollOutbox(context.Background(), p.wakeups, p.listening, func(context.Context) error {
+				p.processed <- struct{}{}
+				return process()
+			})
+		}()
+
+		p.stop = func() {
+			close(producer.stopChan)
+			select {
+			case <-done:
+			case <-time.After(wait):
+				t.Fatal("poller did not stop")
+			}
+		}
+		t.Cleanup(func() {
+			select {
+			case <-producer.stopChan:
+			default:
+				p.stop()
+			}
+		})
+
+		return p
+	}
+
+	requireProcessed := func(t *testing.T, p poller) {
+		select {
+		case <-p.processed:
+		case <-time.After(wait):
+			t.Fatal("outbox was not processed")
+		}
+	}
+
+	requireIdle := func(t *testing.T, p poller, during time.Duration) {
+		select {
+		case <-p.processed:
+			t.Fatal("outbox was processed unexpectedly")
+		case <-time.After(during):
+		}
+	}
+
+	t.Run("processes on every wakeup", func(t *testing.T) {
+		t.Parallel()
+		p := startPoller(t, time.Hour, func() error { return nil })
+
+		for range 3 {
+			p.wakeups <- struct{}{}
+			requireProcessed(t, p)
+		}
+		requireIdle(t, p, 20*time.Millisecond)
+	})
+
+	t.Run("ticks with the listen poll interval while listening", func(t *testing.T) {
+		t.Parallel()
+		p := startPoller(t, 10*time.Millisecond, func() error { return nil })
+
+		p.listening <- true
+		requireProcessed(t, p)
+		requireProcessed(t, p)
+	})
+
+	t.Run("processes at once when the listener drops", func(t *testing.T) {
+		t.Parallel()
+		p := startPoller(t, time.Hour, func() error { return nil })
+
+		p.listening <- true
+		requireIdle(t, p, 20*time.Millisecond)
+
+		p.listening <- false
+		requireProcessed(t, p)
+	})
+
+	t.Run("backs off after a failed relay", func(t *testing.T) {
+		t.Parallel()
+		var failed bool
+		p := startPoller(t, time.Hour, func() error {
+			if !failed {
+				failed = true
+				return errors.New("kafka is down")
+			}
+			return nil
+		})
+
+		p.wakeups <- struct{}{}
+		requireProcessed(t, p)
+
+		// wakeups during the back off are skipped, the relay is resumed once it passes
+		p.wakeups <- struct{}{}
+		requireIdle(t, p, 50*time.Millisecond)
+		requireProcessed(t, p)
+
+		p.wakeups <- struct{}{}
+		requireProcessed(t, p)
+	})
+
+	t.Run("stops on close", func(t *testing.T) {
+		t.Parallel()
+		p := startPoller(t, time.Hour, func() error { return nil })
+
+		p.stop()
+	})
+}
@@ -16,37 +16,48 @@ var (
 	ErrBatchAlreadyClosed = errors.New("batch already closed")
 )
 
-const updateRowStatus = `-- name: UpdateRowStatus :batchexec
+const markFailed = `-- name: MarkFailed :batchexec
 update outbox
-set status = $1, updated_at = now()
-where id = $2
+set attempts = attempts + 1,
+    status = case when attempts + 1 >= $1::int then 'dead' else 'pending' end,
+    next_attempt_at = now() + make_interval(secs => $2::float8),
+    last_error = $3::text,
+    lease_until = null,
+    updated_at = now()
+where id = $4 and status = 'in_flight' and owner = $5::text
 `
 
-type UpdateRowStatusBatchResults struct {
+type MarkFailedBatchResults struct {
 	br     pgx.BatchResults
 	tot    int
 	closed bool
 }
 
-type UpdateRowStatusParams struct {
-	Status string
-	ID     int64
+type MarkFailedParams struct {
+	MaxAttempts    int32
+	BackoffSeconds float64
+	LastError      string
+	ID             int64
+	Owner          string
 }
 
-func (q *Queries) UpdateRowStatus(ctx context.Context, arg []UpdateRowStatusParams) *UpdateRowStatusBatchResults {
+func (q *Queries) MarkFailed(ctx context.Context, arg []MarkFailedParams) *MarkFailedBatchResults {
 	batch := &pgx.Batch{}
 	for _, a := range arg {
 		vals := []interface{}{
-			a.Status,
+			a.MaxAttempts,
+			a.BackoffSeconds,
+			a.LastError,
 			a.ID,
+			a.Owner,
 		}
-		batch.Queue(updateRowStatus, vals...)
+		batch.Queue(markFailed, vals...)
 	}
 	br := q.db.SendBatch(ctx, batch)
-	return &UpdateRowStatusBatchResults{br, len(arg), false}
+	return &MarkFailedBatchResults{br, len(arg), false}
 }
 
-func (b *UpdateRowStatusBatchResults) Exec(f func(int, error)) {
+func (b *MarkFailedBatchResults) Exec(f func(int, error)) {
 	defer b.br.Close()
 	for t := 0; t < b.tot; t++ {
 		if b.closed {
@@ -62,7 +73,116 @@ func (b *UpdateRowStatusBatchResults) Exec(f func(int, error)) {
 	}
 }
 
-func (b *UpdateRowStatusBatchResults) Close() error {
+func (b *MarkFailedBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const markSent = `-- name: MarkSent :batchexec
+update outbox
+set status = 'sent',
+    lease_until = null,
+    updated_at = now()
+where id = $1 and status = 'in_flight' and owner = $2::text
+`
+
+type MarkSentBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type MarkSentParams struct {
+	ID    int64
+	Owner string
+}
+
+func (q *Queries) MarkSent(ctx context.Context, arg []MarkSentParams) *MarkSentBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.ID,
+			a.Owner,
+		}
+		batch.Queue(markSent, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &MarkSentBatchResults{br, len(arg), false}
+}
+
+func (b *MarkSentBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *MarkSentBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const releaseClaimed = `-- name: ReleaseClaimed :batchexec
+update outbox
+set status = 'pending',
+    last_error = $1::text,
+    lease_until = null,
+    updated_at = now()
+where id = $2 and status = 'in_flight' and owner = $3::text
+`
+
+type ReleaseClaimedBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type ReleaseClaimedParams struct {
+	LastError string
+	ID        int64
+	Owner     string
+}
+
+func (q *Queries) ReleaseClaimed(ctx context.Context, arg []ReleaseClaimedParams) *ReleaseClaimedBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.LastError,
+			a.ID,
+			a.Owner,
+		}
+		batch.Queue(releaseClaimed, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &ReleaseClaimedBatchResults{br, len(arg), false}
+}
+
+func (b *ReleaseClaimedBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, ErrBatchAlreadyClosed)
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *ReleaseClaimedBatchResults) Close() error {
 	b.closed = true
 	return b.br.Close()
 }
@@ -9,16 +9,94 @@ import (
 	"context"
 )
 
-const getPending = `-- name: GetPending :many
-select id, aggregate_id, aggregate_type, event_type, key, payload, topic, status, created_at, updated_at from outbox
-where status = 'pending'
-order by updated_at asc
-limit $1
-for update skip locked
+const archiveSent = `-- name: ArchiveSent :execrows
+with purged as (
+    select id from outbox
+    where status = 'sent' and updated_at < now() - make_interval(days => $1::int)
+    order by updated_at asc
+    limit $2
+    for update skip locked
+), archived as (
+    delete from outbox o
+    using purged p
+    where o.id = p.id
+    returning o.id, o.aggregate_id, o.aggregate_type, o.event_type, o.key, o.payload, o.topic, o.status, o.created_at, o.updated_at, o.attempts, o.next_attempt_at, o.last_error, o.lease_until, o.owner
+)
+insert into outbox_archive (
+    id, aggregate_id, aggregate_type, event_type, key, payload, topic, attempts, created_at, sent_at
+)
+select id, aggregate_id, aggregate_type, event_type, key, payload, topic, attempts, created_at, updated_at
+from archived
+`
+
+type ArchiveSentParams struct {
+	RetentionDays int32
+	BatchSize     int32
+}
+
+func (q *Queries) ArchiveSent(ctx context.Context, arg ArchiveSentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, archiveSent, arg.RetentionDays, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const claimPending = `-- name: ClaimPending :many
+with expired as (
+    -- an expired lease spends an attempt, so messages without attempts left are dead-lettered
+    update outbox
+    set status = 'dead',
+        attempts = attempts + 1,
+        last_error = 'lease expired',
+        lease_until = null,
+        updated_at = now()
+    where status = 'in_flight'
+        and lease_until < now()
+        and attempts + 1 >= $3::int
+), claimable as (
+    -- a message waits while an earlier message of its key is not delivered and can not be claimed
+    -- along with it, so the messages of a key are delivered in the order of ids
+    select id from outbox m
+    where ((m.status = 'pending' and m.next_attempt_at <= now())
+            or (m.status = 'in_flight' and m.lease_until < now() and m.attempts + 1 < $3::int))
+        and not exists (
+            select 1 from outbox e
+            where e.key = m.key
+                and e.id < m.id
+                and (e.status = 'dead'
+                    or (e.status = 'pending' and e.next_attempt_at > now())
+                    or (e.status = 'in_flight' and (e.lease_until >= now() or e.attempts + 1 >= $3::int)))
+        )
+    order by m.id asc
+    limit $4
+    for update skip locked
+)
+update outbox o
+set status = 'in_flight',
+    attempts = case when o.status = 'in_flight' then o.attempts + 1 else o.attempts end,
+    lease_until = now() + make_interval(secs => $1::float8),
+    owner = $2::text,
+    updated_at = now()
+from claimable c
+where o.id = c.id
+returning o.id, o.aggregate_id, o.aggregate_type, o.event_type, o.key, o.payload, o.topic, o.status, o.created_at, o.updated_at, o.attempts, o.next_attempt_at, o.last_error, o.lease_until, o.owner
 `
 
-func (q *Queries) GetPending(ctx context.Context, batchSize int32) ([]Outbox, error) {
-	rows, err := q.db.Query(ctx, getPending, batchSize)
+type ClaimPendingParams struct {
+	LeaseSeconds float64
+	Owner        string
+	MaxAttempts  int32
+	BatchSize    int32
+}
+
+func (q *Queries) ClaimPending(ctx context.Context, arg ClaimPendingParams) ([]Outbox, error) {
+	rows, err := q.db.Query(ctx, claimPending,
+		arg.LeaseSeconds,
+		arg.Owner,
+		arg.MaxAttempts,
+		arg.BatchSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +115,11 @@ func (q *Queries) GetPending(ctx context.Context, batchSize int32) ([]Outbox, er
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastError,
+			&i.LeaseUntil,
+			&i.Owner,
 		); err != nil {
 			return nil, err
 		}
@@ -47,3 +130,82 @@ func (q *Queries) GetPending(ctx context.Context, batchSize int32) ([]Outbox, er
 	}
 	return items, nil
 }
+
+const deleteSent = `-- name: DeleteSent :execrows
+with purged as (
+    select id from outbox
+    where status = 'sent' and updated_at < now() - make_interval(days => $1::int)
+    order by updated_at asc
+    limit $2
+    for update skip locked
+)
+delete from outbox o
+using purged p
+where o.id = p.id
+`
+
+type DeleteSentParams struct {
+	RetentionDays int32
+	BatchSize     int32
+}
+
+func (q *Queries) DeleteSent(ctx context.Context, arg DeleteSentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteSent, arg.RetentionDays, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getBacklog = `-- name: GetBacklog :many
+select status,
+    count(*)::bigint as size,
+    coalesce(extract(epoch from now() - min(created_at)), 0)::float8 as oldest_age_seconds
+from outbox
+where status <> 'sent'
+group by status
+`
+
+type GetBacklogRow struct {
+	Status           string
+	Size             int64
+	OldestAgeSeconds float64
+}
+
+func (q *Queries) GetBacklog(ctx context.Context) ([]GetBacklogRow, error) {
+	rows, err := q.db.Query(ctx, getBacklog)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetBacklogRow
+	for rows.Next() {
+		var i GetBacklogRow
+		if err := rows.Scan(&i.Status, &i.Size, &i.OldestAgeSeconds); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const requeueDead = `-- name: RequeueDead :execrows
+update outbox
+set status = 'pending',
+    attempts = 0,
+    next_attempt_at = now(),
+    updated_at = now()
+where status = 'dead'
+    and (cardinality($1::bigint[]) = 0 or id = any($1::bigint[]))
+`
+
+func (q *Queries) RequeueDead(ctx context.Context, ids []int64) (int64, error) {
+	result, err := q.db.Exec(ctx, requeueDead, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -1,91 +1,378 @@
 package notifier
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"route256/loms/internal/domain/notifier/query"
+	"route256/loms/internal/infra/loms_config"
+	"route256/loms/internal/infra/sre"
+	"slices"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// OutboxChannel is notified by the outbox triggers whenever a message is committed as pending:
+// inserted, requeued by an admin or scheduled for a retry
+const OutboxChannel = "outbox_pending"
+
 type OutboxRepository struct {
 	master *pgxpool.Pool
+	// owner identifies the instance holding the lease of claimed messages
+	owner    string
+	leaseTtl time.Duration
+
+	maxAttempts int32
+	backoffBase time.Duration
+	backoffMax  time.Duration
+}
+
+// DeliveryErrors reports the entities of a batch that failed to be delivered by their ids,
+// the rest of the batch counts as delivered
+type DeliveryErrors map[int64]error
+
+func (e DeliveryErrors) Error() string {
+	return fmt.Sprintf("failed to deliver %d outbox messages", len(e))
+}
+
+type OutboxBacklog struct {
+	Status    string
+	Size      int64
+	OldestAge time.Duration
+}
+
+type failedOutboxRow struct {
+	row query.Outbox
+	err error
 }
 
 type OutboxEntity struct {
+	ID      int64
 	Key     string
 	Payload []byte
 	Topic   string
 }
 
-func NewOutboxRepository(master *pgxpool.Pool) *OutboxRepository {
+func NewOutboxRepository(master *pgxpool.Pool, cfg *loms_config.Config) *OutboxRepository {
 	return &OutboxRepository{
-		master: master,
+		master:      master,
+		owner:       instanceOwner(),
+		leaseTtl:    cfg.Outbox.LeaseTtl,
+		maxAttempts: cfg.Outbox.MaxAttempts,
+		backoffBase: cfg.Outbox.BackoffBase,
+		backoffMax:  cfg.Outbox.BackoffMax,
 	}
 }
 
+// ProcessPendingMessagesFn leases a batch of pending messages to this instance and confirms
+// the delivery outcome reported by the predicate. No transaction is held while the predicate runs,
+// messages of an instance that fails to confirm in time are claimed again once the lease expires,
+// spending an attempt.
+// Only the messages reported by DeliveryErrors spend an attempt, any other predicate error returns
+// the whole batch to pending as is and is returned to the caller.
+// Returns the number of claimed messages.
 func (r *OutboxRepository) ProcessPendingMessagesFn(
 	ctx context.Context,
 	batch int,
 	predicate func([]OutboxEntity) error,
-) error {
-	err := pgx.BeginTxFunc(ctx, r.master, pgx.TxOptions{}, func(tx pgx.Tx) error {
-		repository := query.New(tx)
+) (int, error) {
+	startTime := time.Now()
+	claimedRows, err := query.New(r.master).ClaimPending(ctx, query.ClaimPendingParams{
+		MaxAttempts:  r.maxAttempts,
+		LeaseSeconds: r.leaseTtl.Seconds(),
+		Owner:        r.owner,
+		BatchSize:    int32(batch),
+	})
+	sre.TrackDbRequest("outbox_claim_pending", "update", err, startTime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim pending messages: %w", err)
+	}
 
-		pendingRows, err := repository.GetPending(ctx, int32(batch))
-		if err != nil {
-			return fmt.Errorf("failed to get pending messages: %w", err)
+	if len(claimedRows) == 0 {
+		return 0, nil
+	}
+
+	slices.SortFunc(claimedRows, func(a, b query.Outbox) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	entities := make([]OutboxEntity, 0, len(claimedRows))
+	for _, claimedRow := range claimedRows {
+		entities = append(entities, OutboxEntity{
+			ID:      claimedRow.ID,
+			Key:     claimedRow.Key,
+			Payload: claimedRow.Payload,
+			Topic:   claimedRow.Topic,
+		})
+	}
+
+	// the outcome is confirmed even if the caller is cancelled meanwhile, the messages are
+	// already handed to kafka and would otherwise be sent again once the lease expires
+	ctx = context.WithoutCancel(ctx)
+
+	deliveryErr := predicate(entities)
+	var deliveryErrs DeliveryErrors
+	if deliveryErr != nil && !errors.As(deliveryErr, &deliveryErrs) {
+		// nothing was delivered for a reason unrelated to the messages, so no attempt is spent
+		// and the caller is expected to back off
+		if err := r.releaseClaimed(ctx, claimedRows, deliveryErr); err != nil {
+			return len(claimedRows), errors.Join(deliveryErr, err)
 		}
 
-		entities := make([]OutboxEntity, 0, len(pendingRows))
-		for _, pendingRow := range pendingRows {
-			entities = append(entities, OutboxEntity{
-				Key:     pendingRow.Key,
-				Payload: pendingRow.Payload,
-				Topic:   pendingRow.Topic,
-			})
+		return len(claimedRows), fmt.Errorf("failed to deliver outbox messages: %w", deliveryErr)
+	}
+
+	sentRows, failedRows := splitDelivered(claimedRows, deliveryErrs)
+
+	err = pgx.BeginTxFunc(ctx, r.master, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		repository := query.New(tx)
+
+		if len(sentRows) > 0 {
+			err := r.handleOutboxBatchSent(ctx, repository, sentRows)
+			if err != nil {
+				return err
+			}
 		}
 
-		err = predicate(entities)
+		if len(failedRows) > 0 {
+			return r.handleOutboxBatchFailure(ctx, repository, failedRows)
+		}
 
-		return handleOutboxBatchStatusUpdate(ctx, repository, pendingRows, err)
+		return nil
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed process outbox messages in transaction: %w", err)
+		return len(claimedRows), fmt.Errorf("failed to confirm outbox messages in transaction: %w", err)
 	}
 
-	return nil
+	return len(claimedRows), nil
 }
 
-func handleOutboxBatchStatusUpdate(
+// ListenPending blocks on a dedicated connection and calls onNotification every time
+// a pending message is committed to the outbox. onListening is called once the connection
+// listens, an error is returned when the connection drops or the context is cancelled.
+func (r *OutboxRepository) ListenPending(ctx context.Context, onListening func(), onNotification func()) error {
+	pooledConn, err := r.master.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire outbox listener connection: %w", err)
+	}
+
+	// the connection is taken out of the pool for good, so that listening does not starve queries
+	conn := pooledConn.Hijack()
+	defer conn.Close(context.Background())
+
+	_, err = conn.Exec(ctx, "listen "+pgx.Identifier{OutboxChannel}.Sanitize())
+	if err != nil {
+		return fmt.Errorf("failed to listen outbox channel: %w", err)
+	}
+
+	onListening()
+
+	for {
+		_, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to wait for outbox notification: %w", err)
+		}
+
+		onNotification()
+	}
+}
+
+// RequeueDead moves dead-lettered messages back to pending with a fresh attempt budget.
+// All dead messages are requeued when no ids are given.
+func (r *OutboxRepository) RequeueDead(ctx context.Context, ids []int64) (int64, error) {
+	// a null array would match no message
+	if ids == nil {
+		ids = []int64{}
+	}
+
+	startTime := time.Now()
+	requeued, err := query.New(r.master).RequeueDead(ctx, ids)
+	sre.TrackDbRequest("outbox_requeue_dead", "update", err, startTime)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue dead outbox messages: %w", err)
+	}
+
+	return requeued, nil
+}
+
+// PurgeSent removes up to batchSize messages sent more than retentionDays ago,
+// purged messages are moved to the outbox archive when archive is set
+func (r *OutboxRepository) PurgeSent(ctx context.Context, retentionDays, batchSize int32, archive bool) (int64, error) {
+	repository := query.New(r.master)
+
+	startTime := time.Now()
+	var purged int64
+	var err error
+	if archive {
+		purged, err = repository.ArchiveSent(ctx, query.ArchiveSentParams{
+			RetentionDays: retentionDays,
+			BatchSize:     batchSize,
+		})
+		sre.TrackDbRequest("outbox_archive_sent", "insert", err, startTime)
+	} else {
+		purged, err = repository.DeleteSent(ctx, query.DeleteSentParams{
+			RetentionDays: retentionDays,
+			BatchSize:     batchSize,
+		})
+		sre.TrackDbRequest("outbox_delete_sent", "delete", err, startTime)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge sent outbox messages: %w", err)
+	}
+
+	return purged, nil
+}
+
+// GetBacklog reports the messages waiting for delivery or an admin by their status
+func (r *OutboxRepository) GetBacklog(ctx context.Context) ([]OutboxBacklog, error) {
+	startTime := time.Now()
+	rows, err := query.New(r.master).GetBacklog(ctx)
+	sre.TrackDbRequest("outbox_get_backlog", "select", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox backlog: %w", err)
+	}
+
+	backlog := make([]OutboxBacklog, 0, len(rows))
+	for _, row := range rows {
+		backlog = append(backlog, OutboxBacklog{
+			Status:    row.Status,
+			Size:      row.Size,
+			OldestAge: time.Duration(row.OldestAgeSeconds * float64(time.Second)),
+		})
+	}
+
+	return backlog, nil
+}
+
+func (r *OutboxRepository) handleOutboxBatchSent(
 	ctx context.Context,
 	repository *query.Queries,
-	pendingRows []query.Outbox,
-	predicateErr error,
+	sentRows []query.Outbox,
 ) error {
-	status := "sent"
-	if predicateErr != nil {
-		status = "failed"
+	sentBatch := make([]query.MarkSentParams, 0, len(sentRows))
+	for _, sentRow := range sentRows {
+		sentBatch = append(sentBatch, query.MarkSentParams{
+			ID:    sentRow.ID,
+			Owner: r.owner,
+		})
 	}
 
-	statusBatch := make([]query.UpdateRowStatusParams, 0, len(pendingRows))
-	for _, pendingRow := range pendingRows {
-		statusBatch = append(statusBatch, query.UpdateRowStatusParams{
-			Status: status,
-			ID:     pendingRow.ID,
+	batchQuery := repository.MarkSent(ctx, sentBatch)
+
+	var batchErr error = nil
+	batchQuery.Exec(func(i int, err error) {
+		if batchErr == nil && err != nil {
+			batchErr = fmt.Errorf("failed to mark outbox message as sent: %w", err)
+		}
+	})
+
+	return batchErr
+}
+
+// handleOutboxBatchFailure schedules the next attempt of every failed row,
+// rows without attempts left are dead-lettered
+func (r *OutboxRepository) handleOutboxBatchFailure(
+	ctx context.Context,
+	repository *query.Queries,
+	failedRows []failedOutboxRow,
+) error {
+	failedBatch := make([]query.MarkFailedParams, 0, len(failedRows))
+	dead := 0
+	for _, failedRow := range failedRows {
+		if failedRow.row.Attempts+1 >= r.maxAttempts {
+			dead++
+		}
+
+		failedBatch = append(failedBatch, query.MarkFailedParams{
+			MaxAttempts:    r.maxAttempts,
+			BackoffSeconds: r.backoff(failedRow.row.Attempts).Seconds(),
+			LastError:      failedRow.err.Error(),
+			ID:             failedRow.row.ID,
+			Owner:          r.owner,
 		})
 	}
 
-	batchQuery := repository.UpdateRowStatus(ctx, statusBatch)
+	batchQuery := repository.MarkFailed(ctx, failedBatch)
 
 	var batchErr error = nil
 	batchQuery.Exec(func(i int, err error) {
 		if batchErr == nil && err != nil {
-			batchErr = fmt.Errorf("failed to update pending message status: %w", err)
+			batchErr = fmt.Errorf("failed to mark outbox message as failed: %w", err)
 		}
 	})
 
+	if batchErr == nil {
+		sre.TrackOutboxFailures(len(failedRows)-dead, dead)
+	}
+
 	return batchErr
 }
+
+// releaseClaimed returns the claimed rows to pending without spending an attempt
+func (r *OutboxRepository) releaseClaimed(ctx context.Context, rows []query.Outbox, cause error) error {
+	releaseBatch := make([]query.ReleaseClaimedParams, 0, len(rows))
+	for _, row := range rows {
+		releaseBatch = append(releaseBatch, query.ReleaseClaimedParams{
+			LastError: cause.Error(),
+			ID:        row.ID,
+			Owner:     r.owner,
+		})
+	}
+
+	startTime := time.Now()
+	batchQuery := query.New(r.master).ReleaseClaimed(ctx, releaseBatch)
+
+	var batchErr error = nil
+	batchQuery.Exec(func(i int, err error) {
+		if batchErr == nil && err != nil {
+			batchErr = fmt.Errorf("failed to release claimed outbox message: %w", err)
+		}
+	})
+	sre.TrackDbRequest("outbox_release_claimed", "update", batchErr, startTime)
+
+	return batchErr
+}
+
+func instanceOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "loms"
+	}
+
+	return fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), time.Now().UnixNano())
+}
+
+// splitDelivered separates the delivered rows from the ones reported by the delivery errors
+func splitDelivered(rows []query.Outbox, deliveryErrs DeliveryErrors) ([]query.Outbox, []failedOutboxRow) {
+	sentRows := make([]query.Outbox, 0, len(rows))
+	failedRows := make([]failedOutboxRow, 0, len(deliveryErrs))
+	for _, row := range rows {
+		if rowErr, failed := deliveryErrs[row.ID]; failed {
+			failedRows = append(failedRows, failedOutboxRow{row: row, err: rowErr})
+			continue
+		}
+
+		sentRows = append(sentRows, row)
+	}
+
+	return sentRows, failedRows
+}
+
+// backoff doubles the delay with every failed attempt starting from the base delay
+func (r *OutboxRepository) backoff(attempts int32) time.Duration {
+	delay := r.backoffBase
+	for range attempts {
+		if delay >= r.backoffMax/2 {
+			return r.backoffMax
+		}
+		delay *= 2
+	}
+
+	return min(delay, r.backoffMax)
+}
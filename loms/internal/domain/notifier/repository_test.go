@@ -0,0 +1,62 @@
+package notifier
+
+import (
+	"errors"
+	"route256/loms/internal/domain/notifier/query"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxRepository_Backoff(t *testing.T) {
+	t.Parallel()
+
+	repository := &OutboxRepository{
+		maxAttempts: 10,
+		backoffBase: time.Second,
+		backoffMax:  time.Minute,
+	}
+
+	require.Equal(t, time.Second, repository.backoff(0))
+	require.Equal(t, 2*time.Second, repository.backoff(1))
+	require.Equal(t, 32*time.Second, repository.backoff(5))
+	require.Equal(t, time.Minute, repository.backoff(6))
+	require.Equal(t, time.Minute, repository.backoff(1000))
+}
+
+func TestSplitDelivered(t *testing.T) {
+	t.Parallel()
+
+	rows := []query.Outbox{{ID: 1, Key: "1"}, {ID: 2, Key: "2"}, {ID: 3, Key: "3"}}
+
+	t.Run("all delivered", func(t *testing.T) {
+		t.Parallel()
+
+		sentRows, failedRows := splitDelivered(rows, nil)
+
+		require.Equal(t, rows, sentRows)
+		require.Empty(t, failedRows)
+	})
+
+	t.Run("partially delivered", func(t *testing.T) {
+		t.Parallel()
+
+		deliveryErr := errors.New("delivery failed")
+		sentRows, failedRows := splitDelivered(rows, DeliveryErrors{2: deliveryErr})
+
+		require.Equal(t, []query.Outbox{rows[0], rows[2]}, sentRows)
+		require.Equal(t, []failedOutboxRow{{row: rows[1], err: deliveryErr}}, failedRows)
+	})
+
+	t.Run("fails only the reported rows of a key", func(t *testing.T) {
+		t.Parallel()
+
+		keyedRows := []query.Outbox{{ID: 1, Key: "a"}, {ID: 2, Key: "a"}, {ID: 3, Key: "a"}}
+		deliveryErr := errors.New("delivery failed")
+		sentRows, failedRows := splitDelivered(keyedRows, DeliveryErrors{2: deliveryErr})
+
+		require.Equal(t, []query.Outbox{keyedRows[0], keyedRows[2]}, sentRows, "acked rows are not sent again")
+		require.Equal(t, []failedOutboxRow{{row: keyedRows[1], err: deliveryErr}}, failedRows)
+	})
+}
@@ -0,0 +1,119 @@
+package notifier
+
+import (
+	"context"
+	"route256/loms/internal/domain/model"
+	"route256/loms/internal/infra/logger"
+	"route256/loms/internal/infra/loms_config"
+	"route256/loms/internal/infra/sre"
+	"sync"
+	"time"
+)
+
+type OutboxRetention interface {
+	PurgeSent(ctx context.Context, retentionDays, batchSize int32, archive bool) (int64, error)
+	GetBacklog(ctx context.Context) ([]OutboxBacklog, error)
+}
+
+// RetentionWorker periodically purges sent outbox messages and reports the outbox backlog.
+type RetentionWorker struct {
+	cfg      loms_config.OutboxRetentionConfig
+	outbox   OutboxRetention
+	stopChan chan struct{}
+	wg       *sync.WaitGroup
+}
+
+func NewRetentionWorker(cfg *loms_config.Config, outbox OutboxRetention) *RetentionWorker {
+	worker := &RetentionWorker{
+		cfg:      cfg.OutboxRetention,
+		outbox:   outbox,
+		stopChan: make(chan struct{}),
+		wg:       &sync.WaitGroup{},
+	}
+
+	worker.run()
+
+	logger.Info("Outbox retention worker started",
+		"retention_days", cfg.OutboxRetention.RetentionDays, "archive", cfg.OutboxRetention.Archive)
+	return worker
+}
+
+func (w *RetentionWorker) Close() error {
+	logger.Info("Closing outbox retention worker...")
+
+	close(w.stopChan)
+	w.wg.Wait()
+
+	return nil
+}
+
+func (w *RetentionWorker) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.stopChan:
+				return
+			case <-ticker.C:
+				w.purge(ctx)
+				w.reportBacklog(ctx)
+			}
+		}
+	}()
+
+	go func() {
+		<-w.stopChan
+		cancel()
+	}()
+}
+
+// purge removes expired messages batch by batch until a batch comes back incomplete
+func (w *RetentionWorker) purge(ctx context.Context) {
+	mode := "delete"
+	if w.cfg.Archive {
+		mode = "archive"
+	}
+
+	var total int64
+	for ctx.Err() == nil {
+		purged, err := w.outbox.PurgeSent(ctx, w.cfg.RetentionDays, w.cfg.BatchSize, w.cfg.Archive)
+		if err != nil {
+			logger.Warn("Failed to purge sent outbox messages", "error", err)
+			break
+		}
+
+		sre.TrackOutboxPurged(mode, purged)
+		total += purged
+		if purged < int64(w.cfg.BatchSize) {
+			break
+		}
+	}
+
+	if total > 0 {
+		logger.Info("Purged sent outbox messages", "mode", mode, "count", total)
+	}
+}
+
+func (w *RetentionWorker) reportBacklog(ctx context.Context) {
+	backlog, err := w.outbox.GetBacklog(ctx)
+	if err != nil {
+		logger.Warn("Failed to get outbox backlog", "error", err)
+		return
+	}
+
+	// statuses missing from the backlog have no messages left
+	for _, status := range []string{model.OutboxStatusPending, model.OutboxStatusInFlight, model.OutboxStatusDead} {
+		sre.TrackOutboxBacklog(status, 0, 0)
+	}
+
+	for _, statusBacklog := range backlog {
+		sre.TrackOutboxBacklog(statusBacklog.Status, statusBacklog.Size, statusBacklog.OldestAge)
+	}
+}
@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"route256/loms/internal/infra/loms_config"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type outboxRetentionStub struct {
+	purged []int64
+	err    error
+	calls  int
+}
+
+func (s *outboxRetentionStub) PurgeSent(context.Context, int32, int32, bool) (int64, error) {
+	s.calls++
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	purged := s.purged[0]
+	s.purged = s.purged[1:]
+	return purged, nil
+}
+
+func (s *outboxRetentionStub) GetBacklog(context.Context) ([]OutboxBacklog, error) {
+	return nil, nil
+}
+
+func TestRetentionWorker_Purge(t *testing.T) {
+	t.Parallel()
+
+	cfg := loms_config.OutboxRetentionConfig{RetentionDays: 7, BatchSize: 10}
+
+	t.Run("purges until batch is incomplete", func(t *testing.T) {
+		t.Parallel()
+
+		outbox := &outboxRetentionStub{purged: []int64{10, 10, 3}}
+		worker := &RetentionWorker{cfg: cfg, outbox: outbox}
+
+		worker.purge(context.Background())
+
+		require.Equal(t, 3, outbox.calls)
+	})
+
+	t.Run("stops on error", func(t *testing.T) {
+		t.Parallel()
+
+		outbox := &outboxRetentionStub{err: errors.New("db is down")}
+		worker := &RetentionWorker{cfg: cfg, outbox: outbox}
+
+		worker.purge(context.Background())
+
+		require.Equal(t, 1, outbox.calls)
+	})
+}
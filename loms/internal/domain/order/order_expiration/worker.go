@@ -0,0 +1,90 @@
+package order_expiration
+
+import (
+	"context"
+	"route256/loms/internal/infra/logger"
+	"route256/loms/internal/infra/loms_config"
+	"sync"
+	"time"
+)
+
+type OrderService interface {
+	CancelExpiredOrders(ctx context.Context, ttl time.Duration, limit int32) (int, error)
+}
+
+// ExpirationWorker periodically cancels orders that were not paid in time, releasing their reserved stock.
+type ExpirationWorker struct {
+	cfg      loms_config.OrderExpirationConfig
+	service  OrderService
+	stopChan chan struct{}
+	wg       *sync.WaitGroup
+}
+
+func NewExpirationWorker(cfg *loms_config.Config, service OrderService) *ExpirationWorker {
+	worker := &ExpirationWorker{
+		cfg:      cfg.OrderExpiration,
+		service:  service,
+		stopChan: make(chan struct{}),
+		wg:       &sync.WaitGroup{},
+	}
+
+	worker.run()
+
+	logger.Info("Order expiration worker started", "payment_ttl", cfg.OrderExpiration.PaymentTtl)
+	return worker
+}
+
+func (w *ExpirationWorker) Close() error {
+	logger.Info("Closing order expiration worker...")
+
+	close(w.stopChan)
+	w.wg.Wait()
+
+	return nil
+}
+
+func (w *ExpirationWorker) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.stopChan:
+				return
+			case <-ticker.C:
+				w.cancelExpired(ctx)
+			}
+		}
+	}()
+
+	// stop the in-flight batch as well, the remaining orders are picked up after restart
+	go func() {
+		<-w.stopChan
+		cancel()
+	}()
+}
+
+// cancelExpired drains all expired orders batch by batch, so a backlog does not wait for the next ticks.
+func (w *ExpirationWorker) cancelExpired(ctx context.Context) {
+	for ctx.Err() == nil {
+		cancelled, err := w.service.CancelExpiredOrders(ctx, w.cfg.PaymentTtl, w.cfg.BatchSize)
+		if err != nil {
+			logger.Warn("Failed to cancel expired orders", "error", err)
+			return
+		}
+
+		if cancelled > 0 {
+			logger.Info("Cancelled expired orders", "count", cancelled)
+		}
+
+		if cancelled < int(w.cfg.BatchSize) {
+			return
+		}
+	}
+}
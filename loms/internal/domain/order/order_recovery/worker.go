@@ -0,0 +1,89 @@
+package order_recovery
+
+import (
+	"context"
+	"route256/loms/internal/infra/logger"
+	"route256/loms/internal/infra/loms_config"
+	"sync"
+	"time"
+)
+
+type OrderService interface {
+	RecoverStuckOrders(ctx context.Context, stuckFor time.Duration, limit int32) (int, error)
+}
+
+// RecoveryWorker periodically resolves orders left in internal statuses, e.g. after a crash of the service.
+type RecoveryWorker struct {
+	cfg      loms_config.OrderRecoveryConfig
+	service  OrderService
+	stopChan chan struct{}
+	wg       *sync.WaitGroup
+}
+
+func NewRecoveryWorker(cfg *loms_config.Config, service OrderService) *RecoveryWorker {
+	worker := &RecoveryWorker{
+		cfg:      cfg.OrderRecovery,
+		service:  service,
+		stopChan: make(chan struct{}),
+		wg:       &sync.WaitGroup{},
+	}
+
+	worker.run()
+
+	logger.Info("Order recovery worker started", "stuck_after", cfg.OrderRecovery.StuckAfter)
+	return worker
+}
+
+func (w *RecoveryWorker) Close() error {
+	logger.Info("Closing order recovery worker...")
+
+	close(w.stopChan)
+	w.wg.Wait()
+
+	return nil
+}
+
+func (w *RecoveryWorker) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.stopChan:
+				return
+			case <-ticker.C:
+				w.recoverStuck(ctx)
+			}
+		}
+	}()
+
+	go func() {
+		<-w.stopChan
+		cancel()
+	}()
+}
+
+// recoverStuck drains stuck orders batch by batch, orders failing to recover are retried on the next tick.
+func (w *RecoveryWorker) recoverStuck(ctx context.Context) {
+	for ctx.Err() == nil {
+		recovered, err := w.service.RecoverStuckOrders(ctx, w.cfg.StuckAfter, w.cfg.BatchSize)
+		if err != nil {
+			logger.Warn("Failed to recover stuck orders", "error", err)
+			return
+		}
+
+		if recovered > 0 {
+			logger.Info("Recovered stuck orders", "count", recovered)
+		}
+
+		if recovered < int(w.cfg.BatchSize) {
+			return
+		}
+	}
+}
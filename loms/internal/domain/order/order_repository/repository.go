@@ -3,20 +3,35 @@ package order_repository
 import (
 	"context"
 	"route256/loms/internal/domain/model"
+	"slices"
 	"sort"
 	"sync"
+	"time"
 )
 
 type OrderRepository struct {
 	mtx       sync.RWMutex
 	orders    map[int64]*model.OrderModel
 	idCounter int64
+	// order ids by user and idempotency key
+	idempotencyKeys map[idempotencyKey]int64
+	// status changes by order id
+	history map[int64][]model.OrderStatusChange
+	now     func() time.Time
+}
+
+type idempotencyKey struct {
+	userId int64
+	key    string
 }
 
 func NewOrderRepository() *OrderRepository {
 	return &OrderRepository{
-		mtx:    sync.RWMutex{},
-		orders: make(map[int64]*model.OrderModel),
+		mtx:             sync.RWMutex{},
+		orders:          make(map[int64]*model.OrderModel),
+		idempotencyKeys: make(map[idempotencyKey]int64),
+		history:         make(map[int64][]model.OrderStatusChange),
+		now:             time.Now,
 	}
 }
 
@@ -25,14 +40,24 @@ func (o *OrderRepository) Create(_ context.Context, createOrder *model.CreateOrd
 	o.mtx.Lock()
 	defer o.mtx.Unlock()
 
+	var key = idempotencyKey{userId: createOrder.UserId, key: createOrder.IdempotencyKey}
+	if orderId, ok := o.idempotencyKeys[key]; ok {
+		return 0, &model.ErrDuplicateIdempotencyKey{OrderId: orderId, Status: o.orders[orderId].Status}
+	}
+
 	o.idCounter = o.idCounter + 1
 	order := &model.OrderModel{
-		Id:     o.idCounter,
-		UserId: createOrder.UserId,
-		Items:  createOrder.Items,
-		Status: model.OrderStatusNew,
+		Id:        o.idCounter,
+		UserId:    createOrder.UserId,
+		Items:     createOrder.Items,
+		Status:    model.OrderStatuses.Initial(),
+		CreatedAt: o.now(),
 	}
 	o.orders[o.idCounter] = order
+	o.setStatus(order, order.Status, "")
+	if createOrder.IdempotencyKey != "" {
+		o.idempotencyKeys[key] = o.idCounter
+	}
 
 	return o.idCounter, nil
 }
@@ -52,11 +77,70 @@ func (o *OrderRepository) GetById(_ context.Context, orderId int64) (*model.Orde
 	})
 
 	orderCopy := *item
+	orderCopy.History = slices.Clone(o.history[orderId])
 	return &orderCopy, nil
 }
 
+// List implements order_service.OrderRepository.
+func (o *OrderRepository) List(_ context.Context, filter *model.ListOrdersFilter) (*model.OrdersPage, error) {
+	o.mtx.RLock()
+	defer o.mtx.RUnlock()
+
+	var orders = make([]model.OrderModel, 0)
+	for _, order := range o.orders {
+		if matchesFilter(order, filter) {
+			orderCopy := *order
+			orderCopy.Items = append([]model.OrderItem{}, order.Items...)
+			sort.Slice(orderCopy.Items, func(i, j int) bool {
+				return orderCopy.Items[i].Sku < orderCopy.Items[j].Sku
+			})
+			orders = append(orders, orderCopy)
+		}
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Id < orders[j].Id
+	})
+
+	var page = &model.OrdersPage{Orders: orders}
+	if len(orders) > int(filter.Limit) {
+		page.Orders = orders[:filter.Limit]
+		page.NextCursor = page.Orders[len(page.Orders)-1].Id
+	}
+
+	return page, nil
+}
+
+func matchesFilter(order *model.OrderModel, filter *model.ListOrdersFilter) bool {
+	if order.Id <= filter.AfterId {
+		return false
+	}
+
+	if filter.UserId != 0 && order.UserId != filter.UserId {
+		return false
+	}
+
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, order.Status) {
+		return false
+	}
+
+	if !filter.CreatedFrom.IsZero() && order.CreatedAt.Before(filter.CreatedFrom) {
+		return false
+	}
+
+	if !filter.CreatedTo.IsZero() && !order.CreatedAt.Before(filter.CreatedTo) {
+		return false
+	}
+
+	return true
+}
+
 // UpdateStatus implements order_service.OrderRepository.
 func (o *OrderRepository) UpdateStatus(_ context.Context, orderId int64, status string, expertStatus string) error {
+	if err := model.OrderStatuses.ValidateTransition(orderId, expertStatus, status); err != nil {
+		return err
+	}
+
 	o.mtx.Lock()
 	defer o.mtx.Unlock()
 
@@ -73,6 +157,69 @@ func (o *OrderRepository) UpdateStatus(_ context.Context, orderId int64, status
 		}
 	}
 
-	order.Status = status
+	o.setStatus(order, status, expertStatus)
 	return nil
 }
+
+func (o *OrderRepository) setStatus(order *model.OrderModel, status string, fromStatus string) {
+	var now = o.now()
+	order.Status = status
+	order.UpdatedAt = now
+	o.history[order.Id] = append(o.history[order.Id], model.OrderStatusChange{
+		FromStatus: fromStatus,
+		ToStatus:   status,
+		ChangedAt:  now,
+	})
+}
+
+// ClaimExpired implements order_service.OrderRepository.
+func (o *OrderRepository) ClaimExpired(_ context.Context, from string, to string, ttl time.Duration, limit int32) ([]int64, error) {
+	if err := model.OrderStatuses.ValidateTransition(0, from, to); err != nil {
+		return nil, err
+	}
+
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	var orderIds = o.updatedBefore([]string{from}, o.now().Add(-ttl), nil, limit)
+	for _, orderId := range orderIds {
+		o.setStatus(o.orders[orderId], to, from)
+	}
+
+	return orderIds, nil
+}
+
+// GetStuck implements order_service.OrderRepository.
+func (o *OrderRepository) GetStuck(_ context.Context, statuses []string, stuckFor time.Duration,
+	skip []int64, limit int32) ([]int64, error) {
+	o.mtx.RLock()
+	defer o.mtx.RUnlock()
+
+	return o.updatedBefore(statuses, o.now().Add(-stuckFor), skip, limit), nil
+}
+
+// updatedBefore returns up to limit ids of the orders in the statuses except the skipped ones,
+// the least recently updated first.
+func (o *OrderRepository) updatedBefore(statuses []string, before time.Time, skip []int64, limit int32) []int64 {
+	var orderIds = make([]int64, 0)
+	for orderId, order := range o.orders {
+		if slices.Contains(statuses, order.Status) && order.UpdatedAt.Before(before) && !slices.Contains(skip, orderId) {
+			orderIds = append(orderIds, orderId)
+		}
+	}
+
+	sort.Slice(orderIds, func(i, j int) bool {
+		left, right := o.orders[orderIds[i]].UpdatedAt, o.orders[orderIds[j]].UpdatedAt
+		if left.Equal(right) {
+			return orderIds[i] < orderIds[j]
+		}
+
+		return left.Before(right)
+	})
+
+	if len(orderIds) > int(limit) {
+		orderIds = orderIds[:limit]
+	}
+
+	return orderIds
+}
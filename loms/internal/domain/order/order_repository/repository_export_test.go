@@ -0,0 +1,11 @@
+package order_repository
+
+import "time"
+
+// NewOrderRepositoryForTest creates the repository with the clock frozen at now.
+func NewOrderRepositoryForTest(now time.Time) *OrderRepository {
+	repository := NewOrderRepository()
+	repository.now = func() time.Time { return now }
+
+	return repository
+}
@@ -5,10 +5,13 @@ import (
 	"route256/loms/internal/domain/model"
 	"route256/loms/internal/domain/order/order_repository"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
+var createdAt = time.Date(2025, time.April, 19, 12, 0, 0, 0, time.UTC)
+
 func TestOrderRepository_Create(t *testing.T) {
 	t.Parallel()
 
@@ -47,6 +50,15 @@ func TestOrderRepository_Create(t *testing.T) {
 			},
 			wantId: []int64{1, 2}, wantErr: []bool{false, false},
 		},
+		{
+			name: "should reject second order with the same idempotency key",
+			args: []*model.CreateOrderModel{
+				{UserId: 5, Items: []model.OrderItem{{Sku: 1, Count: 2}}, IdempotencyKey: "key"},
+				{UserId: 5, Items: []model.OrderItem{{Sku: 1, Count: 2}}, IdempotencyKey: "key"},
+				{UserId: 6, Items: []model.OrderItem{{Sku: 1, Count: 2}}, IdempotencyKey: "key"},
+			},
+			wantId: []int64{1, 0, 2}, wantErr: []bool{false, true, false},
+		},
 	}
 
 	for _, tt := range tests {
@@ -93,10 +105,15 @@ func TestOrderRepository_GetById(t *testing.T) {
 				},
 			},
 			want: &model.OrderModel{
-				Id:     2,
-				UserId: 5,
-				Status: model.OrderStatusNew,
-				Items:  []model.OrderItem{{Sku: 1, Count: 3}},
+				Id:        2,
+				UserId:    5,
+				Status:    model.OrderStatusNew,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+				History: []model.OrderStatusChange{
+					{FromStatus: "", ToStatus: model.OrderStatusNew, ChangedAt: createdAt},
+				},
+				Items: []model.OrderItem{{Sku: 1, Count: 3}},
 			},
 			wantErr: false,
 		},
@@ -113,7 +130,7 @@ func TestOrderRepository_GetById(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			repo := order_repository.NewOrderRepository()
+			repo := order_repository.NewOrderRepositoryForTest(createdAt)
 
 			for _, order := range tt.prepareOrders {
 				repo.Create(context.Background(), order)
@@ -158,36 +175,92 @@ func TestOrderRepository_UpdateStatus(t *testing.T) {
 				},
 			},
 			updates: []updates{
+				{
+					OrderId:      1,
+					Status:       model.OrderStatusReserving,
+					ExpectStatus: model.OrderStatusNew,
+				},
 				{
 					OrderId:      1,
 					Status:       model.OrderStatusAwaitingPayment,
+					ExpectStatus: model.OrderStatusReserving,
+				},
+				{
+					OrderId:      2,
+					Status:       model.OrderStatusReserving,
 					ExpectStatus: model.OrderStatusNew,
 				},
 				{
 					OrderId:      2,
 					Status:       model.OrderStatusFailed,
-					ExpectStatus: model.OrderStatusNew,
+					ExpectStatus: model.OrderStatusReserving,
 				},
 			},
 			want: []*model.OrderModel{
 				{
-					Id:     1,
+					Id:        1,
+					UserId:    5,
+					Status:    model.OrderStatusAwaitingPayment,
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+					History: []model.OrderStatusChange{
+						{FromStatus: "", ToStatus: model.OrderStatusNew, ChangedAt: createdAt},
+						{FromStatus: model.OrderStatusNew, ToStatus: model.OrderStatusReserving, ChangedAt: createdAt},
+						{FromStatus: model.OrderStatusReserving, ToStatus: model.OrderStatusAwaitingPayment, ChangedAt: createdAt},
+					},
+					Items: []model.OrderItem{{Sku: 1, Count: 2}},
+				},
+				{
+					Id:        2,
+					UserId:    5,
+					Status:    model.OrderStatusFailed,
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+					History: []model.OrderStatusChange{
+						{FromStatus: "", ToStatus: model.OrderStatusNew, ChangedAt: createdAt},
+						{FromStatus: model.OrderStatusNew, ToStatus: model.OrderStatusReserving, ChangedAt: createdAt},
+						{FromStatus: model.OrderStatusReserving, ToStatus: model.OrderStatusFailed, ChangedAt: createdAt},
+					},
+					Items: []model.OrderItem{{Sku: 1, Count: 3}},
+				},
+			},
+			wantErr: []bool{false, false, false, false},
+		},
+		{
+			name: "should reject illegal status transition",
+			prepareOrders: []*model.CreateOrderModel{
+				{
 					UserId: 5,
-					Status: model.OrderStatusAwaitingPayment,
 					Items:  []model.OrderItem{{Sku: 1, Count: 2}},
 				},
+			},
+			updates: []updates{
 				{
-					Id:     2,
-					UserId: 5,
-					Status: model.OrderStatusFailed,
-					Items:  []model.OrderItem{{Sku: 1, Count: 3}},
+					OrderId:      1,
+					Status:       model.OrderStatusPayed,
+					ExpectStatus: model.OrderStatusNew,
 				},
 			},
-			wantErr: []bool{false, false},
+			want: []*model.OrderModel{
+				{
+					Id:        1,
+					UserId:    5,
+					Status:    model.OrderStatusNew,
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+					History: []model.OrderStatusChange{
+						{FromStatus: "", ToStatus: model.OrderStatusNew, ChangedAt: createdAt},
+					},
+					Items: []model.OrderItem{{Sku: 1, Count: 2}},
+				},
+			},
+			wantErr: []bool{true},
 		},
 		{
-			name:          "should return error if no order found when updating status",
-			updates:       []updates{{OrderId: 999, Status: model.OrderStatusFailed}},
+			name: "should return error if no order found when updating status",
+			updates: []updates{
+				{OrderId: 999, Status: model.OrderStatusFailed, ExpectStatus: model.OrderStatusReserving},
+			},
 			prepareOrders: []*model.CreateOrderModel{},
 			want:          []*model.OrderModel{},
 			wantErr:       []bool{true},
@@ -198,7 +271,7 @@ func TestOrderRepository_UpdateStatus(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			repo := order_repository.NewOrderRepository()
+			repo := order_repository.NewOrderRepositoryForTest(createdAt)
 
 			for _, order := range tt.prepareOrders {
 				repo.Create(context.Background(), order)
@@ -220,3 +293,112 @@ func TestOrderRepository_UpdateStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderRepository_ClaimExpired(t *testing.T) {
+	t.Parallel()
+	var ctx = context.Background()
+	repo := order_repository.NewOrderRepository()
+
+	for range 3 {
+		orderId, err := repo.Create(ctx, &model.CreateOrderModel{UserId: 5, Items: []model.OrderItem{{Sku: 1, Count: 1}}})
+		require.NoError(t, err)
+		require.NoError(t, repo.UpdateStatus(ctx, orderId, model.OrderStatusReserving, model.OrderStatusNew))
+		require.NoError(t, repo.UpdateStatus(ctx, orderId, model.OrderStatusAwaitingPayment, model.OrderStatusReserving))
+	}
+
+	orderIds, err := repo.ClaimExpired(ctx, model.OrderStatusAwaitingPayment, model.OrderStatusCancelling, time.Hour, 10)
+	require.NoError(t, err)
+	require.Empty(t, orderIds, "orders are not expired yet")
+
+	orderIds, err = repo.ClaimExpired(ctx, model.OrderStatusAwaitingPayment, model.OrderStatusCancelling, -time.Second, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2}, orderIds)
+
+	order, err := repo.GetById(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusCancelling, order.Status)
+
+	orderIds, err = repo.ClaimExpired(ctx, model.OrderStatusAwaitingPayment, model.OrderStatusCancelling, -time.Second, 2)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, orderIds, "claimed orders are not claimed twice")
+
+	_, err = repo.ClaimExpired(ctx, model.OrderStatusAwaitingPayment, model.OrderStatusPayed, -time.Second, 2)
+	var invalidStatus *model.ErrInvalidOrderStatus
+	require.ErrorAs(t, err, &invalidStatus)
+}
+
+func TestOrderRepository_GetStuck(t *testing.T) {
+	t.Parallel()
+	var ctx = context.Background()
+	repo := order_repository.NewOrderRepository()
+
+	for range 3 {
+		orderId, err := repo.Create(ctx, &model.CreateOrderModel{UserId: 5, Items: []model.OrderItem{{Sku: 1, Count: 1}}})
+		require.NoError(t, err)
+		require.NoError(t, repo.UpdateStatus(ctx, orderId, model.OrderStatusReserving, model.OrderStatusNew))
+	}
+	require.NoError(t, repo.UpdateStatus(ctx, 2, model.OrderStatusAwaitingPayment, model.OrderStatusReserving))
+	require.NoError(t, repo.UpdateStatus(ctx, 2, model.OrderStatusPaying, model.OrderStatusAwaitingPayment))
+
+	orderIds, err := repo.GetStuck(ctx, []string{model.OrderStatusReserving}, time.Hour, nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, orderIds, "orders are not stuck yet")
+
+	orderIds, err = repo.GetStuck(ctx, []string{model.OrderStatusReserving, model.OrderStatusPaying}, -time.Second, nil, 10)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int64{1, 2, 3}, orderIds)
+
+	orderIds, err = repo.GetStuck(ctx, []string{model.OrderStatusReserving, model.OrderStatusPaying}, -time.Second, []int64{1, 3}, 10)
+	require.NoError(t, err)
+	require.Equal(t, []int64{2}, orderIds, "skipped orders are not returned")
+
+	orderIds, err = repo.GetStuck(ctx, []string{model.OrderStatusReserving}, -time.Second, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, orderIds, 1)
+
+	order, err := repo.GetById(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusReserving, order.Status, "stuck orders are not changed")
+}
+
+func TestOrderRepository_List(t *testing.T) {
+	t.Parallel()
+	var ctx = context.Background()
+	repo := order_repository.NewOrderRepositoryForTest(createdAt)
+
+	for _, userId := range []int64{1, 2, 1, 1} {
+		_, err := repo.Create(ctx, &model.CreateOrderModel{UserId: userId, Items: []model.OrderItem{{Sku: 2, Count: 1}, {Sku: 1, Count: 1}}})
+		require.NoError(t, err)
+	}
+	require.NoError(t, repo.UpdateStatus(ctx, 3, model.OrderStatusReserving, model.OrderStatusNew))
+
+	page, err := repo.List(ctx, &model.ListOrdersFilter{UserId: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, &model.OrdersPage{
+		Orders: []model.OrderModel{{
+			Id: 1, UserId: 1, Status: model.OrderStatusNew, CreatedAt: createdAt, UpdatedAt: createdAt,
+			Items: []model.OrderItem{{Sku: 1, Count: 1}, {Sku: 2, Count: 1}},
+		}},
+		NextCursor: 1,
+	}, page)
+
+	page, err = repo.List(ctx, &model.ListOrdersFilter{UserId: 1, AfterId: page.NextCursor, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 2)
+	require.Equal(t, int64(3), page.Orders[0].Id)
+	require.Equal(t, int64(4), page.Orders[1].Id)
+	require.Zero(t, page.NextCursor)
+
+	page, err = repo.List(ctx, &model.ListOrdersFilter{Statuses: []string{model.OrderStatusReserving}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 1)
+	require.Equal(t, int64(3), page.Orders[0].Id)
+
+	page, err = repo.List(ctx, &model.ListOrdersFilter{CreatedFrom: createdAt.Add(time.Second), Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, page.Orders)
+
+	page, err = repo.List(ctx, &model.ListOrdersFilter{CreatedFrom: createdAt, CreatedTo: createdAt.Add(time.Second), Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 4)
+}
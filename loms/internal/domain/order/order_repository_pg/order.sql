@@ -1,7 +1,7 @@
 -- name: CreateOrder :one
 with create_order as (
-    insert into orders (user_id, status)
-    values ($1, $2)
+    insert into orders (user_id, status, idempotency_key)
+    values ($1, $2, sqlc.narg(idempotency_key))
     returning id, status, user_id
 ), insert_outbox as (
     insert into outbox (
@@ -27,9 +27,22 @@ with create_order as (
         @topic,
         'pending'
     from create_order as co
+), insert_history as (
+    insert into order_status_history (order_id, from_status, to_status)
+    select co.id,
+        '',
+        co.status
+    from create_order as co
 )
 select id from create_order;
 
+-- name: GetByIdempotencyKey :one
+select id,
+    status
+from orders
+where user_id = $1
+    and idempotency_key = $2;
+
 -- name: CreateOrderItems :copyfrom
 insert into order_items (order_id, sku, quantity)
 values ($1, $2, $3);
@@ -38,6 +51,8 @@ values ($1, $2, $3);
 select o.id,
     o.user_id,
     o.status,
+    o.created_at,
+    o.updated_at,
     oi.sku,
     oi.quantity
 from orders as o
@@ -77,7 +92,92 @@ with update_status as (
         @topic,
         'pending'
     from update_status as u
-    where u.status not like '%ing'
+    where @emit_event::boolean
+), insert_history as (
+    insert into order_status_history (order_id, from_status, to_status)
+    select u.id,
+        @old_status,
+        u.status
+    from update_status as u
 )
 select id
-from update_status;
\ No newline at end of file
+from update_status;
+
+-- name: ClaimExpired :many
+with expired as (
+    select eo.id
+    from orders as eo
+    where eo.status = @expired_status::text
+        and eo.updated_at < now() - make_interval(secs => @ttl_seconds::float8)
+    order by eo.updated_at asc
+    limit @batch_size
+    for update skip locked
+), claimed as (
+    update orders as o
+    set status = @status::text,
+        updated_at = now()
+    from expired as e
+    where o.id = e.id
+    returning o.id, o.status
+), insert_history as (
+    insert into order_status_history (order_id, from_status, to_status)
+    select c.id,
+        @expired_status::text,
+        c.status
+    from claimed as c
+)
+select id
+from claimed;
+
+-- name: ListOrders :many
+select o.id,
+    o.user_id,
+    o.status,
+    o.created_at
+from orders as o
+where o.id > @after_id
+    and (
+        sqlc.narg(user_id)::bigint is null
+        or o.user_id = sqlc.narg(user_id)
+    )
+    and (
+        cardinality(@statuses::text []) = 0
+        or o.status = any(@statuses::text [])
+    )
+    and (
+        sqlc.narg(created_from)::timestamp is null
+        or o.created_at >= sqlc.narg(created_from)
+    )
+    and (
+        sqlc.narg(created_to)::timestamp is null
+        or o.created_at < sqlc.narg(created_to)
+    )
+order by o.id asc
+limit @page_size;
+
+-- name: GetItemsByOrderIds :many
+select order_id,
+    sku,
+    quantity
+from order_items
+where order_id = any(@order_ids::bigint [])
+order by order_id asc,
+    sku asc;
+
+-- name: GetStatusHistory :many
+select from_status,
+    to_status,
+    created_at
+from order_status_history
+where order_id = $1
+order by id asc;
+
+-- name: GetStuckOrderIds :many
+select id
+from orders
+where status = any(@statuses::text[])
+    and updated_at < now() - make_interval(secs => @stuck_seconds::float8)
+    and not (id = any(@skip_ids::bigint[]))
+order by updated_at asc
+limit @batch_size
+for update skip locked;
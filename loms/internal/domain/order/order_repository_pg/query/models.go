@@ -9,11 +9,12 @@ import (
 )
 
 type Order struct {
-	ID        int64
-	UserID    int64
-	Status    string
-	CreatedAt pgtype.Timestamp
-	UpdatedAt pgtype.Timestamp
+	ID             int64
+	UserID         int64
+	Status         string
+	CreatedAt      pgtype.Timestamp
+	UpdatedAt      pgtype.Timestamp
+	IdempotencyKey pgtype.Text
 }
 
 type OrderItem struct {
@@ -25,6 +26,14 @@ type OrderItem struct {
 	UpdatedAt pgtype.Timestamp
 }
 
+type OrderStatusHistory struct {
+	ID         int64
+	OrderID    int64
+	FromStatus string
+	ToStatus   string
+	CreatedAt  pgtype.Timestamp
+}
+
 type Outbox struct {
 	ID            int64
 	AggregateID   string
@@ -36,6 +45,25 @@ type Outbox struct {
 	Status        string
 	CreatedAt     pgtype.Timestamptz
 	UpdatedAt     pgtype.Timestamptz
+	Attempts      int32
+	NextAttemptAt pgtype.Timestamptz
+	LastError     pgtype.Text
+	LeaseUntil    pgtype.Timestamptz
+	Owner         pgtype.Text
+}
+
+type OutboxArchive struct {
+	ID            int64
+	AggregateID   string
+	AggregateType string
+	EventType     string
+	Key           string
+	Payload       []byte
+	Topic         string
+	Attempts      int32
+	CreatedAt     pgtype.Timestamptz
+	SentAt        pgtype.Timestamptz
+	ArchivedAt    pgtype.Timestamptz
 }
 
 type Stock struct {
@@ -46,3 +74,24 @@ type Stock struct {
 	CreatedAt  pgtype.Timestamp
 	UpdatedAt  pgtype.Timestamp
 }
+
+type StockLedger struct {
+	ID          int64
+	Sku         int64
+	Operation   string
+	Delta       int64
+	TotalBefore int64
+	TotalAfter  int64
+	Reason      string
+	CreatedAt   pgtype.Timestamp
+}
+
+type StockReservation struct {
+	ID        int64
+	OrderID   pgtype.Int8
+	Sku       int64
+	Count     int64
+	State     string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+}
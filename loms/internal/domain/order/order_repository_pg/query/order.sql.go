@@ -7,12 +7,73 @@ package query
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimExpired = `-- name: ClaimExpired :many
+with expired as (
+    select eo.id
+    from orders as eo
+    where eo.status = $1::text
+        and eo.updated_at < now() - make_interval(secs => $2::float8)
+    order by eo.updated_at asc
+    limit $3
+    for update skip locked
+), claimed as (
+    update orders as o
+    set status = $4::text,
+        updated_at = now()
+    from expired as e
+    where o.id = e.id
+    returning o.id, o.status
+), insert_history as (
+    insert into order_status_history (order_id, from_status, to_status)
+    select c.id,
+        $1::text,
+        c.status
+    from claimed as c
 )
+select id
+from claimed
+`
+
+type ClaimExpiredParams struct {
+	ExpiredStatus string
+	TtlSeconds    float64
+	BatchSize     int32
+	Status        string
+}
+
+func (q *Queries) ClaimExpired(ctx context.Context, arg ClaimExpiredParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, claimExpired,
+		arg.ExpiredStatus,
+		arg.TtlSeconds,
+		arg.BatchSize,
+		arg.Status,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
 
 const createOrder = `-- name: CreateOrder :one
 with create_order as (
-    insert into orders (user_id, status)
-    values ($1, $2)
+    insert into orders (user_id, status, idempotency_key)
+    values ($1, $2, $3)
     returning id, status, user_id
 ), insert_outbox as (
     insert into outbox (
@@ -35,21 +96,33 @@ with create_order as (
             'order_id', co.id,
             'user_id', co.user_id
         ),
-        $3,
+        $4,
         'pending'
     from create_order as co
+), insert_history as (
+    insert into order_status_history (order_id, from_status, to_status)
+    select co.id,
+        '',
+        co.status
+    from create_order as co
 )
 select id from create_order
 `
 
 type CreateOrderParams struct {
-	UserID int64
-	Status string
-	Topic  string
+	UserID         int64
+	Status         string
+	IdempotencyKey pgtype.Text
+	Topic          string
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (int64, error) {
-	row := q.db.QueryRow(ctx, createOrder, arg.UserID, arg.Status, arg.Topic)
+	row := q.db.QueryRow(ctx, createOrder,
+		arg.UserID,
+		arg.Status,
+		arg.IdempotencyKey,
+		arg.Topic,
+	)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
@@ -65,6 +138,8 @@ const getByID = `-- name: GetByID :many
 select o.id,
     o.user_id,
     o.status,
+    o.created_at,
+    o.updated_at,
     oi.sku,
     oi.quantity
 from orders as o
@@ -74,11 +149,13 @@ order by oi.sku asc
 `
 
 type GetByIDRow struct {
-	ID       int64
-	UserID   int64
-	Status   string
-	Sku      int64
-	Quantity int64
+	ID        int64
+	UserID    int64
+	Status    string
+	CreatedAt pgtype.Timestamp
+	UpdatedAt pgtype.Timestamp
+	Sku       int64
+	Quantity  int64
 }
 
 func (q *Queries) GetByID(ctx context.Context, id int64) ([]GetByIDRow, error) {
@@ -94,6 +171,8 @@ func (q *Queries) GetByID(ctx context.Context, id int64) ([]GetByIDRow, error) {
 			&i.ID,
 			&i.UserID,
 			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
 			&i.Sku,
 			&i.Quantity,
 		); err != nil {
@@ -107,6 +186,220 @@ func (q *Queries) GetByID(ctx context.Context, id int64) ([]GetByIDRow, error) {
 	return items, nil
 }
 
+const getByIdempotencyKey = `-- name: GetByIdempotencyKey :one
+select id,
+    status
+from orders
+where user_id = $1
+    and idempotency_key = $2
+`
+
+type GetByIdempotencyKeyParams struct {
+	UserID         int64
+	IdempotencyKey pgtype.Text
+}
+
+type GetByIdempotencyKeyRow struct {
+	ID     int64
+	Status string
+}
+
+func (q *Queries) GetByIdempotencyKey(ctx context.Context, arg GetByIdempotencyKeyParams) (GetByIdempotencyKeyRow, error) {
+	row := q.db.QueryRow(ctx, getByIdempotencyKey, arg.UserID, arg.IdempotencyKey)
+	var i GetByIdempotencyKeyRow
+	err := row.Scan(&i.ID, &i.Status)
+	return i, err
+}
+
+const getItemsByOrderIds = `-- name: GetItemsByOrderIds :many
+select order_id,
+    sku,
+    quantity
+from order_items
+where order_id = any($1::bigint [])
+order by order_id asc,
+    sku asc
+`
+
+type GetItemsByOrderIdsRow struct {
+	OrderID  int64
+	Sku      int64
+	Quantity int64
+}
+
+func (q *Queries) GetItemsByOrderIds(ctx context.Context, orderIds []int64) ([]GetItemsByOrderIdsRow, error) {
+	rows, err := q.db.Query(ctx, getItemsByOrderIds, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemsByOrderIdsRow
+	for rows.Next() {
+		var i GetItemsByOrderIdsRow
+		if err := rows.Scan(&i.OrderID, &i.Sku, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStatusHistory = `-- name: GetStatusHistory :many
+select from_status,
+    to_status,
+    created_at
+from order_status_history
+where order_id = $1
+order by id asc
+`
+
+type GetStatusHistoryRow struct {
+	FromStatus string
+	ToStatus   string
+	CreatedAt  pgtype.Timestamp
+}
+
+func (q *Queries) GetStatusHistory(ctx context.Context, orderID int64) ([]GetStatusHistoryRow, error) {
+	rows, err := q.db.Query(ctx, getStatusHistory, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStatusHistoryRow
+	for rows.Next() {
+		var i GetStatusHistoryRow
+		if err := rows.Scan(&i.FromStatus, &i.ToStatus, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStuckOrderIds = `-- name: GetStuckOrderIds :many
+select id
+from orders
+where status = any($1::text[])
+    and updated_at < now() - make_interval(secs => $2::float8)
+    and not (id = any($3::bigint[]))
+order by updated_at asc
+limit $4
+for update skip locked
+`
+
+type GetStuckOrderIdsParams struct {
+	Statuses     []string
+	StuckSeconds float64
+	SkipIds      []int64
+	BatchSize    int32
+}
+
+func (q *Queries) GetStuckOrderIds(ctx context.Context, arg GetStuckOrderIdsParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getStuckOrderIds,
+		arg.Statuses,
+		arg.StuckSeconds,
+		arg.SkipIds,
+		arg.BatchSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOrders = `-- name: ListOrders :many
+select o.id,
+    o.user_id,
+    o.status,
+    o.created_at
+from orders as o
+where o.id > $1
+    and (
+        $2::bigint is null
+        or o.user_id = $2
+    )
+    and (
+        cardinality($3::text []) = 0
+        or o.status = any($3::text [])
+    )
+    and (
+        $4::timestamp is null
+        or o.created_at >= $4
+    )
+    and (
+        $5::timestamp is null
+        or o.created_at < $5
+    )
+order by o.id asc
+limit $6
+`
+
+type ListOrdersParams struct {
+	AfterID     int64
+	UserID      pgtype.Int8
+	Statuses    []string
+	CreatedFrom pgtype.Timestamp
+	CreatedTo   pgtype.Timestamp
+	PageSize    int32
+}
+
+type ListOrdersRow struct {
+	ID        int64
+	UserID    int64
+	Status    string
+	CreatedAt pgtype.Timestamp
+}
+
+func (q *Queries) ListOrders(ctx context.Context, arg ListOrdersParams) ([]ListOrdersRow, error) {
+	rows, err := q.db.Query(ctx, listOrders,
+		arg.AfterID,
+		arg.UserID,
+		arg.Statuses,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.PageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrdersRow
+	for rows.Next() {
+		var i ListOrdersRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateStatus = `-- name: UpdateStatus :one
 with update_status as (
     update orders as o
@@ -139,7 +432,13 @@ with update_status as (
         $4,
         'pending'
     from update_status as u
-    where u.status not like '%ing'
+    where $5::boolean
+), insert_history as (
+    insert into order_status_history (order_id, from_status, to_status)
+    select u.id,
+        $3,
+        u.status
+    from update_status as u
 )
 select id
 from update_status
@@ -150,6 +449,7 @@ type UpdateStatusParams struct {
 	ID        int64
 	OldStatus string
 	Topic     string
+	EmitEvent bool
 }
 
 func (q *Queries) UpdateStatus(ctx context.Context, arg UpdateStatusParams) (int64, error) {
@@ -158,6 +458,7 @@ func (q *Queries) UpdateStatus(ctx context.Context, arg UpdateStatusParams) (int
 		arg.ID,
 		arg.OldStatus,
 		arg.Topic,
+		arg.EmitEvent,
 	)
 	var id int64
 	err := row.Scan(&id)
@@ -8,13 +8,18 @@ import (
 	"route256/loms/internal/domain/order/order_repository_pg/query"
 	"route256/loms/internal/infra/loms_config"
 	"route256/loms/internal/infra/sre"
+	"route256/loms/internal/infra/transactor"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"go.opentelemetry.io/otel"
 )
 
+const ConstraintIdempotencyKeyUnique = "orders_user_id_idempotency_key_unique"
+
 type OrderRepository struct {
 	master  *pgxpool.Pool
 	replica *pgxpool.Pool
@@ -36,14 +41,15 @@ func (r *OrderRepository) Create(ctx context.Context, createOrder *model.CreateO
 	defer span.End()
 
 	var orderIdResult int64 = 0
-	err := pgx.BeginTxFunc(ctx, r.master, pgx.TxOptions{}, func(tx pgx.Tx) error {
+	err := transactor.BeginFunc(ctx, r.master, func(tx pgx.Tx) error {
 		var repository = query.New(tx)
 
 		startTime := time.Now()
 		orderId, err := repository.CreateOrder(ctx, query.CreateOrderParams{
-			UserID: createOrder.UserId,
-			Topic:  r.statusTopic,
-			Status: model.OrderStatusNew,
+			UserID:         createOrder.UserId,
+			Topic:          r.statusTopic,
+			Status:         model.OrderStatuses.Initial(),
+			IdempotencyKey: idempotencyKey(createOrder.IdempotencyKey),
 		})
 		sre.TrackDbRequest("order_create", "insert", err, startTime)
 		if err != nil {
@@ -68,9 +74,34 @@ func (r *OrderRepository) Create(ctx context.Context, createOrder *model.CreateO
 		return nil
 	})
 
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == ConstraintIdempotencyKeyUnique {
+		return 0, r.duplicateOrderError(ctx, createOrder)
+	}
+
 	return orderIdResult, err
 }
 
+func (r *OrderRepository) duplicateOrderError(ctx context.Context, createOrder *model.CreateOrderModel) error {
+	var repository = query.New(r.master)
+
+	startTime := time.Now()
+	order, err := repository.GetByIdempotencyKey(ctx, query.GetByIdempotencyKeyParams{
+		UserID:         createOrder.UserId,
+		IdempotencyKey: idempotencyKey(createOrder.IdempotencyKey),
+	})
+	sre.TrackDbRequest("order_get_by_idempotency_key", "select", err, startTime)
+	if err != nil {
+		return fmt.Errorf("failed to get db order by idempotency key: %w", err)
+	}
+
+	return &model.ErrDuplicateIdempotencyKey{OrderId: order.ID, Status: order.Status}
+}
+
+func idempotencyKey(key string) pgtype.Text {
+	return pgtype.Text{String: key, Valid: key != ""}
+}
+
 // GetById implements app.OrderRepository.
 func (r *OrderRepository) GetById(ctx context.Context, orderId int64) (*model.OrderModel, error) {
 	ctx, span := otel.GetTracerProvider().Tracer("repo").Start(ctx, "order_repository.GetById")
@@ -98,20 +129,114 @@ func (r *OrderRepository) GetById(ctx context.Context, orderId int64) (*model.Or
 		}
 	}
 
+	startTime = time.Now()
+	historyRows, err := repository.GetStatusHistory(ctx, orderId)
+	sre.TrackDbRequest("order_get_status_history", "select", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get db order status history: %w", err)
+	}
+
+	var history = make([]model.OrderStatusChange, len(historyRows))
+	for i, row := range historyRows {
+		history[i] = model.OrderStatusChange{
+			FromStatus: row.FromStatus,
+			ToStatus:   row.ToStatus,
+			ChangedAt:  row.CreatedAt.Time,
+		}
+	}
+
 	return &model.OrderModel{
-		Id:     order[0].ID,
-		UserId: order[0].UserID,
-		Status: order[0].Status,
-		Items:  items,
+		Id:        order[0].ID,
+		UserId:    order[0].UserID,
+		Status:    order[0].Status,
+		Items:     items,
+		CreatedAt: order[0].CreatedAt.Time,
+		UpdatedAt: order[0].UpdatedAt.Time,
+		History:   history,
 	}, nil
 }
 
+// List implements order_service.OrderRepository.
+// Orders are read from the replica, so the recently changed orders may be reported with a lag.
+func (r *OrderRepository) List(ctx context.Context, filter *model.ListOrdersFilter) (*model.OrdersPage, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("repo").Start(ctx, "order_repository.List")
+	defer span.End()
+
+	var repository = query.New(r.replica)
+
+	startTime := time.Now()
+	// one extra row tells whether the next page exists
+	rows, err := repository.ListOrders(ctx, query.ListOrdersParams{
+		AfterID:     filter.AfterId,
+		UserID:      pgtype.Int8{Int64: filter.UserId, Valid: filter.UserId != 0},
+		Statuses:    append([]string{}, filter.Statuses...),
+		CreatedFrom: timestamp(filter.CreatedFrom),
+		CreatedTo:   timestamp(filter.CreatedTo),
+		PageSize:    filter.Limit + 1,
+	})
+	sre.TrackDbRequest("order_list", "select", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list db orders: %w", err)
+	}
+
+	var page = &model.OrdersPage{}
+	if len(rows) > int(filter.Limit) {
+		rows = rows[:filter.Limit]
+		page.NextCursor = rows[len(rows)-1].ID
+	}
+
+	if len(rows) == 0 {
+		return page, nil
+	}
+
+	var orderIds = make([]int64, len(rows))
+	for i, row := range rows {
+		orderIds[i] = row.ID
+	}
+
+	startTime = time.Now()
+	itemRows, err := repository.GetItemsByOrderIds(ctx, orderIds)
+	sre.TrackDbRequest("order_get_items_by_order_ids", "select", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get db order items: %w", err)
+	}
+
+	var itemsByOrder = make(map[int64][]model.OrderItem, len(rows))
+	for _, item := range itemRows {
+		itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], model.OrderItem{
+			Sku:   item.Sku,
+			Count: uint32(item.Quantity),
+		})
+	}
+
+	page.Orders = make([]model.OrderModel, len(rows))
+	for i, row := range rows {
+		page.Orders[i] = model.OrderModel{
+			Id:        row.ID,
+			UserId:    row.UserID,
+			Status:    row.Status,
+			Items:     itemsByOrder[row.ID],
+			CreatedAt: row.CreatedAt.Time,
+		}
+	}
+
+	return page, nil
+}
+
+func timestamp(value time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: value, Valid: !value.IsZero()}
+}
+
 // UpdateStatus implements app.OrderRepository.
 func (r *OrderRepository) UpdateStatus(ctx context.Context, orderId int64, status string, expectStatus string) error {
 	ctx, span := otel.GetTracerProvider().Tracer("repo").Start(ctx, "order_repository.UpdateStatus")
 	defer span.End()
 
-	var repository = query.New(r.master)
+	if err := model.OrderStatuses.ValidateTransition(orderId, expectStatus, status); err != nil {
+		return err
+	}
+
+	var repository = query.New(transactor.Querier(ctx, r.master))
 
 	startTime := time.Now()
 	_, err := repository.UpdateStatus(ctx, query.UpdateStatusParams{
@@ -119,6 +244,7 @@ func (r *OrderRepository) UpdateStatus(ctx context.Context, orderId int64, statu
 		Status:    status,
 		Topic:     r.statusTopic,
 		OldStatus: expectStatus,
+		EmitEvent: model.OrderStatuses.EmitsEvent(status),
 	})
 	sre.TrackDbRequest("order_update_status", "update", err, startTime)
 	if err != nil {
@@ -144,3 +270,59 @@ func (r *OrderRepository) UpdateStatus(ctx context.Context, orderId int64, statu
 
 	return nil
 }
+
+// ClaimExpired implements order_service.OrderRepository.
+// Orders locked by a concurrent claim are skipped, so the method is safe to run from several replicas.
+func (r *OrderRepository) ClaimExpired(ctx context.Context, from string, to string, ttl time.Duration, limit int32) ([]int64, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("repo").Start(ctx, "order_repository.ClaimExpired")
+	defer span.End()
+
+	if err := model.OrderStatuses.ValidateTransition(0, from, to); err != nil {
+		return nil, err
+	}
+
+	var repository = query.New(transactor.Querier(ctx, r.master))
+
+	startTime := time.Now()
+	orderIds, err := repository.ClaimExpired(ctx, query.ClaimExpiredParams{
+		ExpiredStatus: from,
+		Status:        to,
+		TtlSeconds:    ttl.Seconds(),
+		BatchSize:     limit,
+	})
+	sre.TrackDbRequest("order_claim_expired", "update", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim expired db orders: %w", err)
+	}
+
+	return orderIds, nil
+}
+
+// GetStuck implements order_service.OrderRepository.
+// The returned orders are locked until the end of the unit of work, orders locked by others are skipped.
+func (r *OrderRepository) GetStuck(ctx context.Context, statuses []string, stuckFor time.Duration,
+	skip []int64, limit int32) ([]int64, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("repo").Start(ctx, "order_repository.GetStuck")
+	defer span.End()
+
+	var repository = query.New(transactor.Querier(ctx, r.master))
+
+	// a null array would filter out every order
+	if skip == nil {
+		skip = []int64{}
+	}
+
+	startTime := time.Now()
+	orderIds, err := repository.GetStuckOrderIds(ctx, query.GetStuckOrderIdsParams{
+		Statuses:     statuses,
+		StuckSeconds: stuckFor.Seconds(),
+		SkipIds:      skip,
+		BatchSize:    limit,
+	})
+	sre.TrackDbRequest("order_get_stuck", "select", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stuck db orders: %w", err)
+	}
+
+	return orderIds, nil
+}
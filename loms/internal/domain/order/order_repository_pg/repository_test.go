@@ -18,8 +18,11 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 
 	"route256/loms/internal/domain/model"
+	"route256/loms/internal/domain/notifier"
 	"route256/loms/internal/domain/order/order_repository_pg"
+	"route256/loms/internal/domain/stock/stock_repository_pg"
 	"route256/loms/internal/infra/loms_config"
+	"route256/loms/internal/infra/transactor"
 )
 
 type OrderRepositorySuite struct {
@@ -113,6 +116,23 @@ func (s *OrderRepositorySuite) TestOrderRepository_CreateOrder_Success() {
 	require.True(s.T(), orderId > 0, "Invalid order ID")
 }
 
+func (s *OrderRepositorySuite) TestOrderRepository_CreateOrder_DuplicateIdempotencyKey() {
+	createOrder := &model.CreateOrderModel{
+		UserId:         2,
+		Items:          []model.OrderItem{{Sku: 100, Count: 1}},
+		IdempotencyKey: "checkout-1",
+	}
+	orderId, err := s.repository.Create(s.ctx, createOrder)
+	require.NoError(s.T(), err, "Failed to create order")
+
+	_, err = s.repository.Create(s.ctx, createOrder)
+
+	var duplicateErr *model.ErrDuplicateIdempotencyKey
+	require.True(s.T(), errors.As(err, &duplicateErr), "Invalid error type")
+	require.Equal(s.T(), orderId, duplicateErr.OrderId)
+	require.Equal(s.T(), model.OrderStatusNew, duplicateErr.Status)
+}
+
 func (s *OrderRepositorySuite) TestOrderRepository_GetOrder_Success() {
 	orderId := s.createOrder()
 
@@ -132,20 +152,198 @@ func (s *OrderRepositorySuite) TestOrderRepository_GetOrder_NotFound() {
 
 func (s *OrderRepositorySuite) TestOrderRepository_UpdateStatus_Success() {
 	orderId := s.createOrder()
-	err := s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusAwaitingPayment, model.OrderStatusNew)
+	err := s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusReserving, model.OrderStatusNew)
+	require.NoError(s.T(), err, "Failed to update status")
 
+	err = s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusAwaitingPayment, model.OrderStatusReserving)
 	require.NoError(s.T(), err, "Failed to update status")
+
+	order, err := s.repository.GetById(s.ctx, orderId)
+	require.NoError(s.T(), err, "Failed to get order")
+	require.Len(s.T(), order.History, 3)
+	require.Equal(s.T(), model.OrderStatusReserving, order.History[2].FromStatus)
+	require.Equal(s.T(), model.OrderStatusAwaitingPayment, order.History[2].ToStatus)
+	require.False(s.T(), order.UpdatedAt.Before(order.CreatedAt))
 }
 
 func (s *OrderRepositorySuite) TestOrderRepository_UpdateStatus_Mismatch() {
 	orderId := s.createOrder()
-	err := s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusAwaitingPayment, model.OrderStatusCancelled)
+	err := s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusCancelled, model.OrderStatusCancelling)
 
 	var statusMismatch *model.ErrOrderStatusMismatch
 	require.Error(s.T(), err, "Failed to update status")
 	require.True(s.T(), errors.As(err, &statusMismatch), "Invalid error type")
 }
 
+func (s *OrderRepositorySuite) TestOrderRepository_UpdateStatus_InvalidTransition() {
+	orderId := s.createOrder()
+	err := s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusPayed, model.OrderStatusNew)
+
+	var invalidStatus *model.ErrInvalidOrderStatus
+	require.True(s.T(), errors.As(err, &invalidStatus), "Invalid error type")
+}
+
+func (s *OrderRepositorySuite) TestOrderRepository_ClaimExpired() {
+	orderId := s.createOrder()
+	require.NoError(s.T(), s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusReserving, model.OrderStatusNew))
+	require.NoError(s.T(), s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusAwaitingPayment, model.OrderStatusReserving))
+
+	orderIds, err := s.repository.ClaimExpired(s.ctx, model.OrderStatusAwaitingPayment, model.OrderStatusCancelling, -time.Second, 100)
+	require.NoError(s.T(), err, "Failed to claim expired orders")
+	require.Contains(s.T(), orderIds, orderId)
+
+	order, err := s.repository.GetById(s.ctx, orderId)
+	require.NoError(s.T(), err, "Failed to get order")
+	require.Equal(s.T(), model.OrderStatusCancelling, order.Status)
+}
+
+func (s *OrderRepositorySuite) TestOrderRepository_GetStuck() {
+	orderId := s.createOrder()
+	require.NoError(s.T(), s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusReserving, model.OrderStatusNew))
+
+	orderIds, err := s.repository.GetStuck(s.ctx, []string{model.OrderStatusReserving}, -time.Second, nil, 100)
+	require.NoError(s.T(), err, "Failed to get stuck orders")
+	require.Contains(s.T(), orderIds, orderId)
+
+	orderIds, err = s.repository.GetStuck(s.ctx, []string{model.OrderStatusReserving}, -time.Second, []int64{orderId}, 100)
+	require.NoError(s.T(), err, "Failed to get stuck orders")
+	require.NotContains(s.T(), orderIds, orderId, "skipped orders are not returned")
+
+	orderIds, err = s.repository.GetStuck(s.ctx, []string{model.OrderStatusReserving}, time.Hour, nil, 100)
+	require.NoError(s.T(), err, "Failed to get stuck orders")
+	require.NotContains(s.T(), orderIds, orderId)
+}
+
+func (s *OrderRepositorySuite) TestOrderRepository_GetStuck_SkipsLockedOrders() {
+	orderId := s.createOrder()
+	require.NoError(s.T(), s.repository.UpdateStatus(s.ctx, orderId, model.OrderStatusReserving, model.OrderStatusNew))
+
+	unitOfWork := transactor.NewPgTransactor(s.connectionPool)
+	err := unitOfWork.WithinTx(s.ctx, func(ctx context.Context) error {
+		orderIds, err := s.repository.GetStuck(ctx, []string{model.OrderStatusReserving}, -time.Second, nil, 100)
+		require.NoError(s.T(), err, "Failed to get stuck orders")
+		require.Contains(s.T(), orderIds, orderId)
+
+		return unitOfWork.WithinTx(s.ctx, func(other context.Context) error {
+			orderIds, err := s.repository.GetStuck(other, []string{model.OrderStatusReserving}, -time.Second, nil, 100)
+			require.NoError(s.T(), err, "Failed to get stuck orders")
+			require.NotContains(s.T(), orderIds, orderId, "orders locked by another unit of work are skipped")
+
+			return nil
+		})
+	})
+	require.NoError(s.T(), err)
+}
+
+// the seeded stocks are reserved by no order, the migrations keep them as the opening balance
+func (s *OrderRepositorySuite) TestStockRepository_ReconcileReserved_SeededStocks() {
+	stocks := stock_repository_pg.NewOrderRepository(s.connectionPool, s.connectionPool)
+
+	drifts, err := stocks.ReconcileReserved(s.ctx, false)
+	require.NoError(s.T(), err, "Failed to reconcile reserved")
+	require.Empty(s.T(), drifts)
+
+	available, err := stocks.GetBySkuIds(s.ctx, []int64{1076963})
+	require.NoError(s.T(), err, "Failed to get stocks")
+	require.Equal(s.T(), map[int64]uint32{1076963: 65534 - 10}, available)
+}
+
+func (s *OrderRepositorySuite) TestStockRepository_GetBySkuId_NotFound() {
+	stocks := stock_repository_pg.NewOrderRepository(s.connectionPool, s.connectionPool)
+
+	_, err := stocks.GetBySkuId(s.ctx, 99999999)
+
+	var notFound *model.ErrStockNotFound
+	require.True(s.T(), errors.As(err, &notFound), "Invalid error")
+}
+
+func (s *OrderRepositorySuite) TestOutboxRepository_RequeueDead_AllWithoutIds() {
+	orderId := s.createOrder()
+	_, err := s.connectionPool.Exec(s.ctx, "update outbox set status = 'dead' where aggregate_id = $1",
+		fmt.Sprint(orderId))
+	require.NoError(s.T(), err, "Failed to dead-letter outbox messages")
+
+	outbox := notifier.NewOutboxRepository(s.connectionPool, &loms_config.Config{})
+	requeued, err := outbox.RequeueDead(s.ctx, nil)
+	require.NoError(s.T(), err, "Failed to requeue dead outbox messages")
+	require.Positive(s.T(), requeued, "all dead messages are requeued without ids")
+
+	var dead int
+	err = s.connectionPool.QueryRow(s.ctx, "select count(*) from outbox where status = 'dead'").Scan(&dead)
+	require.NoError(s.T(), err, "Failed to count dead outbox messages")
+	require.Zero(s.T(), dead)
+}
+
+func (s *OrderRepositorySuite) TestOutboxRepository_ClaimPending_KeepsKeyOrder() {
+	const userId = 4242
+	var orderIds []int64
+	for range 2 {
+		orderId, err := s.repository.Create(s.ctx, &model.CreateOrderModel{
+			UserId: userId,
+			Items:  []model.OrderItem{{Sku: 100, Count: 1}},
+		})
+		require.NoError(s.T(), err, "Failed to create order")
+		orderIds = append(orderIds, orderId)
+	}
+
+	_, err := s.connectionPool.Exec(s.ctx, "update outbox set status = 'dead' where aggregate_id = $1",
+		fmt.Sprint(orderIds[0]))
+	require.NoError(s.T(), err, "Failed to dead-letter outbox message")
+
+	outbox := notifier.NewOutboxRepository(s.connectionPool, &loms_config.Config{
+		Outbox: loms_config.OutboxConfig{LeaseTtl: time.Minute, MaxAttempts: 3},
+	})
+	claimKey := func() []int64 {
+		var claimed []int64
+		_, err := outbox.ProcessPendingMessagesFn(s.ctx, 100, func(entities []notifier.OutboxEntity) error {
+			for _, entity := range entities {
+				if entity.Key == fmt.Sprint(userId) {
+					claimed = append(claimed, entity.ID)
+				}
+			}
+			return nil
+		})
+		require.NoError(s.T(), err, "Failed to process pending outbox messages")
+
+		return claimed
+	}
+
+	require.Empty(s.T(), claimKey(), "messages wait for the earlier dead message of the key")
+
+	_, err = s.connectionPool.Exec(s.ctx, "update outbox set status = 'pending' where aggregate_id = $1",
+		fmt.Sprint(orderIds[0]))
+	require.NoError(s.T(), err, "Failed to requeue outbox message")
+
+	claimed := claimKey()
+	require.Len(s.T(), claimed, 2, "the requeued message is claimed along with the later one")
+	require.Less(s.T(), claimed[0], claimed[1])
+}
+
+func (s *OrderRepositorySuite) TestOrderRepository_List() {
+	first := s.createOrder()
+	second := s.createOrder()
+
+	page, err := s.repository.List(s.ctx, &model.ListOrdersFilter{
+		UserId:   1,
+		Statuses: []string{model.OrderStatusNew},
+		AfterId:  first - 1,
+		Limit:    1,
+	})
+	require.NoError(s.T(), err, "Failed to list orders")
+	require.Len(s.T(), page.Orders, 1)
+	require.Equal(s.T(), first, page.Orders[0].Id)
+	require.NotEmpty(s.T(), page.Orders[0].Items)
+	require.Equal(s.T(), first, page.NextCursor)
+
+	page, err = s.repository.List(s.ctx, &model.ListOrdersFilter{UserId: 1, AfterId: page.NextCursor, Limit: 10})
+	require.NoError(s.T(), err, "Failed to list orders")
+	require.Equal(s.T(), second, page.Orders[0].Id)
+
+	page, err = s.repository.List(s.ctx, &model.ListOrdersFilter{CreatedTo: time.Now().Add(-time.Hour), Limit: 10})
+	require.NoError(s.T(), err, "Failed to list orders")
+	require.Empty(s.T(), page.Orders)
+}
+
 func TestOrderRepository(t *testing.T) {
 	t.Skip("Skipping this test as CI failing with docker")
 	suite.Run(t, new(OrderRepositorySuite))
@@ -9,6 +9,7 @@ import (
 	"route256/loms/internal/domain/model"
 	"sync"
 	mm_atomic "sync/atomic"
+	"time"
 	mm_time "time"
 
 	"github.com/gojuno/minimock/v3"
@@ -19,6 +20,13 @@ type OrderRepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
+	funcClaimExpired          func(ctx context.Context, from string, to string, ttl time.Duration, limit int32) (ia1 []int64, err error)
+	funcClaimExpiredOrigin    string
+	inspectFuncClaimExpired   func(ctx context.Context, from string, to string, ttl time.Duration, limit int32)
+	afterClaimExpiredCounter  uint64
+	beforeClaimExpiredCounter uint64
+	ClaimExpiredMock          mOrderRepositoryMockClaimExpired
+
 	funcCreate          func(ctx context.Context, model *model.CreateOrderModel) (i1 int64, err error)
 	funcCreateOrigin    string
 	inspectFuncCreate   func(ctx context.Context, model *model.CreateOrderModel)
@@ -33,6 +41,20 @@ type OrderRepositoryMock struct {
 	beforeGetByIdCounter uint64
 	GetByIdMock          mOrderRepositoryMockGetById
 
+	funcGetStuck          func(ctx context.Context, statuses []string, stuckFor time.Duration, skip []int64, limit int32) (ia1 []int64, err error)
+	funcGetStuckOrigin    string
+	inspectFuncGetStuck   func(ctx context.Context, statuses []string, stuckFor time.Duration, skip []int64, limit int32)
+	afterGetStuckCounter  uint64
+	beforeGetStuckCounter uint64
+	GetStuckMock          mOrderRepositoryMockGetStuck
+
+	funcList          func(ctx context.Context, filter *model.ListOrdersFilter) (op1 *model.OrdersPage, err error)
+	funcListOrigin    string
+	inspectFuncList   func(ctx context.Context, filter *model.ListOrdersFilter)
+	afterListCounter  uint64
+	beforeListCounter uint64
+	ListMock          mOrderRepositoryMockList
+
 	funcUpdateStatus          func(ctx context.Context, orderId int64, status string, expectStatus string) (err error)
 	funcUpdateStatusOrigin    string
 	inspectFuncUpdateStatus   func(ctx context.Context, orderId int64, status string, expectStatus string)
@@ -49,12 +71,21 @@ func NewOrderRepositoryMock(t minimock.Tester) *OrderRepositoryMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.ClaimExpiredMock = mOrderRepositoryMockClaimExpired{mock: m}
+	m.ClaimExpiredMock.callArgs = []*OrderRepositoryMockClaimExpiredParams{}
+
 	m.CreateMock = mOrderRepositoryMockCreate{mock: m}
 	m.CreateMock.callArgs = []*OrderRepositoryMockCreateParams{}
 
 	m.GetByIdMock = mOrderRepositoryMockGetById{mock: m}
 	m.GetByIdMock.callArgs = []*OrderRepositoryMockGetByIdParams{}
 
+	m.GetStuckMock = mOrderRepositoryMockGetStuck{mock: m}
+	m.GetStuckMock.callArgs = []*OrderRepositoryMockGetStuckParams{}
+
+	m.ListMock = mOrderRepositoryMockList{mock: m}
+	m.ListMock.callArgs = []*OrderRepositoryMockListParams{}
+
 	m.UpdateStatusMock = mOrderRepositoryMockUpdateStatus{mock: m}
 	m.UpdateStatusMock.callArgs = []*OrderRepositoryMockUpdateStatusParams{}
 
@@ -63,6 +94,442 @@ func NewOrderRepositoryMock(t minimock.Tester) *OrderRepositoryMock {
 	return m
 }
 
+type mOrderRepositoryMockClaimExpired struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockClaimExpiredExpectation
+	expectations       []*OrderRepositoryMockClaimExpiredExpectation
+
+	callArgs []*OrderRepositoryMockClaimExpiredParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// OrderRepositoryMockClaimExpiredExpectation specifies expectation struct of the OrderRepository.ClaimExpired
+type OrderRepositoryMockClaimExpiredExpectation struct {
+	mock               *OrderRepositoryMock
+	params             *OrderRepositoryMockClaimExpiredParams
+	paramPtrs          *OrderRepositoryMockClaimExpiredParamPtrs
+	expectationOrigins OrderRepositoryMockClaimExpiredExpectationOrigins
+	results            *OrderRepositoryMockClaimExpiredResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// OrderRepositoryMockClaimExpiredParams contains parameters of the OrderRepository.ClaimExpired
+type OrderRepositoryMockClaimExpiredParams struct {
+	ctx   context.Context
+	from  string
+	to    string
+	ttl   time.Duration
+	limit int32
+}
+
+// OrderRepositoryMockClaimExpiredParamPtrs contains pointers to parameters of the OrderRepository.ClaimExpired
+type OrderRepositoryMockClaimExpiredParamPtrs struct {
+	ctx   *context.Context
+	from  *string
+	to    *string
+	ttl   *time.Duration
+	limit *int32
+}
+
+// OrderRepositoryMockClaimExpiredResults contains results of the OrderRepository.ClaimExpired
+type OrderRepositoryMockClaimExpiredResults struct {
+	ia1 []int64
+	err error
+}
+
+// OrderRepositoryMockClaimExpiredOrigins contains origins of expectations of the OrderRepository.ClaimExpired
+type OrderRepositoryMockClaimExpiredExpectationOrigins struct {
+	origin      string
+	originCtx   string
+	originFrom  string
+	originTo    string
+	originTtl   string
+	originLimit string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) Optional() *mOrderRepositoryMockClaimExpired {
+	mmClaimExpired.optional = true
+	return mmClaimExpired
+}
+
+// Expect sets up expected params for OrderRepository.ClaimExpired
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) Expect(ctx context.Context, from string, to string, ttl time.Duration, limit int32) *mOrderRepositoryMockClaimExpired {
+	if mmClaimExpired.mock.funcClaimExpired != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Set")
+	}
+
+	if mmClaimExpired.defaultExpectation == nil {
+		mmClaimExpired.defaultExpectation = &OrderRepositoryMockClaimExpiredExpectation{}
+	}
+
+	if mmClaimExpired.defaultExpectation.paramPtrs != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by ExpectParams functions")
+	}
+
+	mmClaimExpired.defaultExpectation.params = &OrderRepositoryMockClaimExpiredParams{ctx, from, to, ttl, limit}
+	mmClaimExpired.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmClaimExpired.expectations {
+		if minimock.Equal(e.params, mmClaimExpired.defaultExpectation.params) {
+			mmClaimExpired.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmClaimExpired.defaultExpectation.params)
+		}
+	}
+
+	return mmClaimExpired
+}
+
+// ExpectCtxParam1 sets up expected param ctx for OrderRepository.ClaimExpired
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockClaimExpired {
+	if mmClaimExpired.mock.funcClaimExpired != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Set")
+	}
+
+	if mmClaimExpired.defaultExpectation == nil {
+		mmClaimExpired.defaultExpectation = &OrderRepositoryMockClaimExpiredExpectation{}
+	}
+
+	if mmClaimExpired.defaultExpectation.params != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Expect")
+	}
+
+	if mmClaimExpired.defaultExpectation.paramPtrs == nil {
+		mmClaimExpired.defaultExpectation.paramPtrs = &OrderRepositoryMockClaimExpiredParamPtrs{}
+	}
+	mmClaimExpired.defaultExpectation.paramPtrs.ctx = &ctx
+	mmClaimExpired.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmClaimExpired
+}
+
+// ExpectFromParam2 sets up expected param from for OrderRepository.ClaimExpired
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) ExpectFromParam2(from string) *mOrderRepositoryMockClaimExpired {
+	if mmClaimExpired.mock.funcClaimExpired != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Set")
+	}
+
+	if mmClaimExpired.defaultExpectation == nil {
+		mmClaimExpired.defaultExpectation = &OrderRepositoryMockClaimExpiredExpectation{}
+	}
+
+	if mmClaimExpired.defaultExpectation.params != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Expect")
+	}
+
+	if mmClaimExpired.defaultExpectation.paramPtrs == nil {
+		mmClaimExpired.defaultExpectation.paramPtrs = &OrderRepositoryMockClaimExpiredParamPtrs{}
+	}
+	mmClaimExpired.defaultExpectation.paramPtrs.from = &from
+	mmClaimExpired.defaultExpectation.expectationOrigins.originFrom = minimock.CallerInfo(1)
+
+	return mmClaimExpired
+}
+
+// ExpectToParam3 sets up expected param to for OrderRepository.ClaimExpired
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) ExpectToParam3(to string) *mOrderRepositoryMockClaimExpired {
+	if mmClaimExpired.mock.funcClaimExpired != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Set")
+	}
+
+	if mmClaimExpired.defaultExpectation == nil {
+		mmClaimExpired.defaultExpectation = &OrderRepositoryMockClaimExpiredExpectation{}
+	}
+
+	if mmClaimExpired.defaultExpectation.params != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Expect")
+	}
+
+	if mmClaimExpired.defaultExpectation.paramPtrs == nil {
+		mmClaimExpired.defaultExpectation.paramPtrs = &OrderRepositoryMockClaimExpiredParamPtrs{}
+	}
+	mmClaimExpired.defaultExpectation.paramPtrs.to = &to
+	mmClaimExpired.defaultExpectation.expectationOrigins.originTo = minimock.CallerInfo(1)
+
+	return mmClaimExpired
+}
+
+// ExpectTtlParam4 sets up expected param ttl for OrderRepository.ClaimExpired
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) ExpectTtlParam4(ttl time.Duration) *mOrderRepositoryMockClaimExpired {
+	if mmClaimExpired.mock.funcClaimExpired != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Set")
+	}
+
+	if mmClaimExpired.defaultExpectation == nil {
+		mmClaimExpired.defaultExpectation = &OrderRepositoryMockClaimExpiredExpectation{}
+	}
+
+	if mmClaimExpired.defaultExpectation.params != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Expect")
+	}
+
+	if mmClaimExpired.defaultExpectation.paramPtrs == nil {
+		mmClaimExpired.defaultExpectation.paramPtrs = &OrderRepositoryMockClaimExpiredParamPtrs{}
+	}
+	mmClaimExpired.defaultExpectation.paramPtrs.ttl = &ttl
+	mmClaimExpired.defaultExpectation.expectationOrigins.originTtl = minimock.CallerInfo(1)
+
+	return mmClaimExpired
+}
+
+// ExpectLimitParam5 sets up expected param limit for OrderRepository.ClaimExpired
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) ExpectLimitParam5(limit int32) *mOrderRepositoryMockClaimExpired {
+	if mmClaimExpired.mock.funcClaimExpired != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Set")
+	}
+
+	if mmClaimExpired.defaultExpectation == nil {
+		mmClaimExpired.defaultExpectation = &OrderRepositoryMockClaimExpiredExpectation{}
+	}
+
+	if mmClaimExpired.defaultExpectation.params != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Expect")
+	}
+
+	if mmClaimExpired.defaultExpectation.paramPtrs == nil {
+		mmClaimExpired.defaultExpectation.paramPtrs = &OrderRepositoryMockClaimExpiredParamPtrs{}
+	}
+	mmClaimExpired.defaultExpectation.paramPtrs.limit = &limit
+	mmClaimExpired.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
+
+	return mmClaimExpired
+}
+
+// Inspect accepts an inspector function that has same arguments as the OrderRepository.ClaimExpired
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) Inspect(f func(ctx context.Context, from string, to string, ttl time.Duration, limit int32)) *mOrderRepositoryMockClaimExpired {
+	if mmClaimExpired.mock.inspectFuncClaimExpired != nil {
+		mmClaimExpired.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.ClaimExpired")
+	}
+
+	mmClaimExpired.mock.inspectFuncClaimExpired = f
+
+	return mmClaimExpired
+}
+
+// Return sets up results that will be returned by OrderRepository.ClaimExpired
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) Return(ia1 []int64, err error) *OrderRepositoryMock {
+	if mmClaimExpired.mock.funcClaimExpired != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Set")
+	}
+
+	if mmClaimExpired.defaultExpectation == nil {
+		mmClaimExpired.defaultExpectation = &OrderRepositoryMockClaimExpiredExpectation{mock: mmClaimExpired.mock}
+	}
+	mmClaimExpired.defaultExpectation.results = &OrderRepositoryMockClaimExpiredResults{ia1, err}
+	mmClaimExpired.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmClaimExpired.mock
+}
+
+// Set uses given function f to mock the OrderRepository.ClaimExpired method
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) Set(f func(ctx context.Context, from string, to string, ttl time.Duration, limit int32) (ia1 []int64, err error)) *OrderRepositoryMock {
+	if mmClaimExpired.defaultExpectation != nil {
+		mmClaimExpired.mock.t.Fatalf("Default expectation is already set for the OrderRepository.ClaimExpired method")
+	}
+
+	if len(mmClaimExpired.expectations) > 0 {
+		mmClaimExpired.mock.t.Fatalf("Some expectations are already set for the OrderRepository.ClaimExpired method")
+	}
+
+	mmClaimExpired.mock.funcClaimExpired = f
+	mmClaimExpired.mock.funcClaimExpiredOrigin = minimock.CallerInfo(1)
+	return mmClaimExpired.mock
+}
+
+// When sets expectation for the OrderRepository.ClaimExpired which will trigger the result defined by the following
+// Then helper
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) When(ctx context.Context, from string, to string, ttl time.Duration, limit int32) *OrderRepositoryMockClaimExpiredExpectation {
+	if mmClaimExpired.mock.funcClaimExpired != nil {
+		mmClaimExpired.mock.t.Fatalf("OrderRepositoryMock.ClaimExpired mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockClaimExpiredExpectation{
+		mock:               mmClaimExpired.mock,
+		params:             &OrderRepositoryMockClaimExpiredParams{ctx, from, to, ttl, limit},
+		expectationOrigins: OrderRepositoryMockClaimExpiredExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmClaimExpired.expectations = append(mmClaimExpired.expectations, expectation)
+	return expectation
+}
+
+// Then sets up OrderRepository.ClaimExpired return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockClaimExpiredExpectation) Then(ia1 []int64, err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockClaimExpiredResults{ia1, err}
+	return e.mock
+}
+
+// Times sets number of times OrderRepository.ClaimExpired should be invoked
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) Times(n uint64) *mOrderRepositoryMockClaimExpired {
+	if n == 0 {
+		mmClaimExpired.mock.t.Fatalf("Times of OrderRepositoryMock.ClaimExpired mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmClaimExpired.expectedInvocations, n)
+	mmClaimExpired.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmClaimExpired
+}
+
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) invocationsDone() bool {
+	if len(mmClaimExpired.expectations) == 0 && mmClaimExpired.defaultExpectation == nil && mmClaimExpired.mock.funcClaimExpired == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmClaimExpired.mock.afterClaimExpiredCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmClaimExpired.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ClaimExpired implements OrderRepository
+func (mmClaimExpired *OrderRepositoryMock) ClaimExpired(ctx context.Context, from string, to string, ttl time.Duration, limit int32) (ia1 []int64, err error) {
+	mm_atomic.AddUint64(&mmClaimExpired.beforeClaimExpiredCounter, 1)
+	defer mm_atomic.AddUint64(&mmClaimExpired.afterClaimExpiredCounter, 1)
+
+	mmClaimExpired.t.Helper()
+
+	if mmClaimExpired.inspectFuncClaimExpired != nil {
+		mmClaimExpired.inspectFuncClaimExpired(ctx, from, to, ttl, limit)
+	}
+
+	mm_params := OrderRepositoryMockClaimExpiredParams{ctx, from, to, ttl, limit}
+
+	// Record call args
+	mmClaimExpired.ClaimExpiredMock.mutex.Lock()
+	mmClaimExpired.ClaimExpiredMock.callArgs = append(mmClaimExpired.ClaimExpiredMock.callArgs, &mm_params)
+	mmClaimExpired.ClaimExpiredMock.mutex.Unlock()
+
+	for _, e := range mmClaimExpired.ClaimExpiredMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ia1, e.results.err
+		}
+	}
+
+	if mmClaimExpired.ClaimExpiredMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmClaimExpired.ClaimExpiredMock.defaultExpectation.Counter, 1)
+		mm_want := mmClaimExpired.ClaimExpiredMock.defaultExpectation.params
+		mm_want_ptrs := mmClaimExpired.ClaimExpiredMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockClaimExpiredParams{ctx, from, to, ttl, limit}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmClaimExpired.t.Errorf("OrderRepositoryMock.ClaimExpired got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmClaimExpired.ClaimExpiredMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.from != nil && !minimock.Equal(*mm_want_ptrs.from, mm_got.from) {
+				mmClaimExpired.t.Errorf("OrderRepositoryMock.ClaimExpired got unexpected parameter from, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmClaimExpired.ClaimExpiredMock.defaultExpectation.expectationOrigins.originFrom, *mm_want_ptrs.from, mm_got.from, minimock.Diff(*mm_want_ptrs.from, mm_got.from))
+			}
+
+			if mm_want_ptrs.to != nil && !minimock.Equal(*mm_want_ptrs.to, mm_got.to) {
+				mmClaimExpired.t.Errorf("OrderRepositoryMock.ClaimExpired got unexpected parameter to, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmClaimExpired.ClaimExpiredMock.defaultExpectation.expectationOrigins.originTo, *mm_want_ptrs.to, mm_got.to, minimock.Diff(*mm_want_ptrs.to, mm_got.to))
+			}
+
+			if mm_want_ptrs.ttl != nil && !minimock.Equal(*mm_want_ptrs.ttl, mm_got.ttl) {
+				mmClaimExpired.t.Errorf("OrderRepositoryMock.ClaimExpired got unexpected parameter ttl, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmClaimExpired.ClaimExpiredMock.defaultExpectation.expectationOrigins.originTtl, *mm_want_ptrs.ttl, mm_got.ttl, minimock.Diff(*mm_want_ptrs.ttl, mm_got.ttl))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmClaimExpired.t.Errorf("OrderRepositoryMock.ClaimExpired got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmClaimExpired.ClaimExpiredMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmClaimExpired.t.Errorf("OrderRepositoryMock.ClaimExpired got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmClaimExpired.ClaimExpiredMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmClaimExpired.ClaimExpiredMock.defaultExpectation.results
+		if mm_results == nil {
+			mmClaimExpired.t.Fatal("No results are set for the OrderRepositoryMock.ClaimExpired")
+		}
+		return (*mm_results).ia1, (*mm_results).err
+	}
+	if mmClaimExpired.funcClaimExpired != nil {
+		return mmClaimExpired.funcClaimExpired(ctx, from, to, ttl, limit)
+	}
+	mmClaimExpired.t.Fatalf("Unexpected call to OrderRepositoryMock.ClaimExpired. %v %v %v %v %v", ctx, from, to, ttl, limit)
+	return
+}
+
+// ClaimExpiredAfterCounter returns a count of finished OrderRepositoryMock.ClaimExpired invocations
+func (mmClaimExpired *OrderRepositoryMock) ClaimExpiredAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimExpired.afterClaimExpiredCounter)
+}
+
+// ClaimExpiredBeforeCounter returns a count of OrderRepositoryMock.ClaimExpired invocations
+func (mmClaimExpired *OrderRepositoryMock) ClaimExpiredBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimExpired.beforeClaimExpiredCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.ClaimExpired.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmClaimExpired *mOrderRepositoryMockClaimExpired) Calls() []*OrderRepositoryMockClaimExpiredParams {
+	mmClaimExpired.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockClaimExpiredParams, len(mmClaimExpired.callArgs))
+	copy(argCopy, mmClaimExpired.callArgs)
+
+	mmClaimExpired.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockClaimExpiredDone returns true if the count of the ClaimExpired invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockClaimExpiredDone() bool {
+	if m.ClaimExpiredMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ClaimExpiredMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ClaimExpiredMock.invocationsDone()
+}
+
+// MinimockClaimExpiredInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockClaimExpiredInspect() {
+	for _, e := range m.ClaimExpiredMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.ClaimExpired at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterClaimExpiredCounter := mm_atomic.LoadUint64(&m.afterClaimExpiredCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ClaimExpiredMock.defaultExpectation != nil && afterClaimExpiredCounter < 1 {
+		if m.ClaimExpiredMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to OrderRepositoryMock.ClaimExpired at\n%s", m.ClaimExpiredMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.ClaimExpired at\n%s with params: %#v", m.ClaimExpiredMock.defaultExpectation.expectationOrigins.origin, *m.ClaimExpiredMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcClaimExpired != nil && afterClaimExpiredCounter < 1 {
+		m.t.Errorf("Expected call to OrderRepositoryMock.ClaimExpired at\n%s", m.funcClaimExpiredOrigin)
+	}
+
+	if !m.ClaimExpiredMock.invocationsDone() && afterClaimExpiredCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.ClaimExpired at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ClaimExpiredMock.expectedInvocations), m.ClaimExpiredMock.expectedInvocationsOrigin, afterClaimExpiredCounter)
+	}
+}
+
 type mOrderRepositoryMockCreate struct {
 	optional           bool
 	mock               *OrderRepositoryMock
@@ -749,58 +1216,62 @@ func (m *OrderRepositoryMock) MinimockGetByIdInspect() {
 	}
 }
 
-type mOrderRepositoryMockUpdateStatus struct {
+type mOrderRepositoryMockGetStuck struct {
 	optional           bool
 	mock               *OrderRepositoryMock
-	defaultExpectation *OrderRepositoryMockUpdateStatusExpectation
-	expectations       []*OrderRepositoryMockUpdateStatusExpectation
+	defaultExpectation *OrderRepositoryMockGetStuckExpectation
+	expectations       []*OrderRepositoryMockGetStuckExpectation
 
-	callArgs []*OrderRepositoryMockUpdateStatusParams
+	callArgs []*OrderRepositoryMockGetStuckParams
 	mutex    sync.RWMutex
 
 	expectedInvocations       uint64
 	expectedInvocationsOrigin string
 }
 
-// OrderRepositoryMockUpdateStatusExpectation specifies expectation struct of the OrderRepository.UpdateStatus
-type OrderRepositoryMockUpdateStatusExpectation struct {
+// OrderRepositoryMockGetStuckExpectation specifies expectation struct of the OrderRepository.GetStuck
+type OrderRepositoryMockGetStuckExpectation struct {
 	mock               *OrderRepositoryMock
-	params             *OrderRepositoryMockUpdateStatusParams
-	paramPtrs          *OrderRepositoryMockUpdateStatusParamPtrs
-	expectationOrigins OrderRepositoryMockUpdateStatusExpectationOrigins
-	results            *OrderRepositoryMockUpdateStatusResults
+	params             *OrderRepositoryMockGetStuckParams
+	paramPtrs          *OrderRepositoryMockGetStuckParamPtrs
+	expectationOrigins OrderRepositoryMockGetStuckExpectationOrigins
+	results            *OrderRepositoryMockGetStuckResults
 	returnOrigin       string
 	Counter            uint64
 }
 
-// OrderRepositoryMockUpdateStatusParams contains parameters of the OrderRepository.UpdateStatus
-type OrderRepositoryMockUpdateStatusParams struct {
-	ctx          context.Context
-	orderId      int64
-	status       string
-	expectStatus string
+// OrderRepositoryMockGetStuckParams contains parameters of the OrderRepository.GetStuck
+type OrderRepositoryMockGetStuckParams struct {
+	ctx      context.Context
+	statuses []string
+	stuckFor time.Duration
+	skip     []int64
+	limit    int32
 }
 
-// OrderRepositoryMockUpdateStatusParamPtrs contains pointers to parameters of the OrderRepository.UpdateStatus
-type OrderRepositoryMockUpdateStatusParamPtrs struct {
-	ctx          *context.Context
-	orderId      *int64
-	status       *string
-	expectStatus *string
+// OrderRepositoryMockGetStuckParamPtrs contains pointers to parameters of the OrderRepository.GetStuck
+type OrderRepositoryMockGetStuckParamPtrs struct {
+	ctx      *context.Context
+	statuses *[]string
+	stuckFor *time.Duration
+	skip     *[]int64
+	limit    *int32
 }
 
-// OrderRepositoryMockUpdateStatusResults contains results of the OrderRepository.UpdateStatus
-type OrderRepositoryMockUpdateStatusResults struct {
+// OrderRepositoryMockGetStuckResults contains results of the OrderRepository.GetStuck
+type OrderRepositoryMockGetStuckResults struct {
+	ia1 []int64
 	err error
 }
 
-// OrderRepositoryMockUpdateStatusOrigins contains origins of expectations of the OrderRepository.UpdateStatus
-type OrderRepositoryMockUpdateStatusExpectationOrigins struct {
-	origin             string
-	originCtx          string
-	originOrderId      string
-	originStatus       string
-	originExpectStatus string
+// OrderRepositoryMockGetStuckOrigins contains origins of expectations of the OrderRepository.GetStuck
+type OrderRepositoryMockGetStuckExpectationOrigins struct {
+	origin         string
+	originCtx      string
+	originStatuses string
+	originStuckFor string
+	originSkip     string
+	originLimit    string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -808,27 +1279,802 @@ type OrderRepositoryMockUpdateStatusExpectationOrigins struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmUpdateStatus *mOrderRepositoryMockUpdateStatus) Optional() *mOrderRepositoryMockUpdateStatus {
-	mmUpdateStatus.optional = true
-	return mmUpdateStatus
+func (mmGetStuck *mOrderRepositoryMockGetStuck) Optional() *mOrderRepositoryMockGetStuck {
+	mmGetStuck.optional = true
+	return mmGetStuck
 }
 
-// Expect sets up expected params for OrderRepository.UpdateStatus
-func (mmUpdateStatus *mOrderRepositoryMockUpdateStatus) Expect(ctx context.Context, orderId int64, status string, expectStatus string) *mOrderRepositoryMockUpdateStatus {
-	if mmUpdateStatus.mock.funcUpdateStatus != nil {
-		mmUpdateStatus.mock.t.Fatalf("OrderRepositoryMock.UpdateStatus mock is already set by Set")
+// Expect sets up expected params for OrderRepository.GetStuck
+func (mmGetStuck *mOrderRepositoryMockGetStuck) Expect(ctx context.Context, statuses []string, stuckFor time.Duration, skip []int64, limit int32) *mOrderRepositoryMockGetStuck {
+	if mmGetStuck.mock.funcGetStuck != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Set")
 	}
 
-	if mmUpdateStatus.defaultExpectation == nil {
-		mmUpdateStatus.defaultExpectation = &OrderRepositoryMockUpdateStatusExpectation{}
+	if mmGetStuck.defaultExpectation == nil {
+		mmGetStuck.defaultExpectation = &OrderRepositoryMockGetStuckExpectation{}
 	}
 
-	if mmUpdateStatus.defaultExpectation.paramPtrs != nil {
-		mmUpdateStatus.mock.t.Fatalf("OrderRepositoryMock.UpdateStatus mock is already set by ExpectParams functions")
+	if mmGetStuck.defaultExpectation.paramPtrs != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by ExpectParams functions")
 	}
 
-	mmUpdateStatus.defaultExpectation.params = &OrderRepositoryMockUpdateStatusParams{ctx, orderId, status, expectStatus}
-	mmUpdateStatus.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	mmGetStuck.defaultExpectation.params = &OrderRepositoryMockGetStuckParams{ctx, statuses, stuckFor, skip, limit}
+	mmGetStuck.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetStuck.expectations {
+		if minimock.Equal(e.params, mmGetStuck.defaultExpectation.params) {
+			mmGetStuck.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetStuck.defaultExpectation.params)
+		}
+	}
+
+	return mmGetStuck
+}
+
+// ExpectCtxParam1 sets up expected param ctx for OrderRepository.GetStuck
+func (mmGetStuck *mOrderRepositoryMockGetStuck) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockGetStuck {
+	if mmGetStuck.mock.funcGetStuck != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Set")
+	}
+
+	if mmGetStuck.defaultExpectation == nil {
+		mmGetStuck.defaultExpectation = &OrderRepositoryMockGetStuckExpectation{}
+	}
+
+	if mmGetStuck.defaultExpectation.params != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Expect")
+	}
+
+	if mmGetStuck.defaultExpectation.paramPtrs == nil {
+		mmGetStuck.defaultExpectation.paramPtrs = &OrderRepositoryMockGetStuckParamPtrs{}
+	}
+	mmGetStuck.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetStuck.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetStuck
+}
+
+// ExpectStatusesParam2 sets up expected param statuses for OrderRepository.GetStuck
+func (mmGetStuck *mOrderRepositoryMockGetStuck) ExpectStatusesParam2(statuses []string) *mOrderRepositoryMockGetStuck {
+	if mmGetStuck.mock.funcGetStuck != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Set")
+	}
+
+	if mmGetStuck.defaultExpectation == nil {
+		mmGetStuck.defaultExpectation = &OrderRepositoryMockGetStuckExpectation{}
+	}
+
+	if mmGetStuck.defaultExpectation.params != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Expect")
+	}
+
+	if mmGetStuck.defaultExpectation.paramPtrs == nil {
+		mmGetStuck.defaultExpectation.paramPtrs = &OrderRepositoryMockGetStuckParamPtrs{}
+	}
+	mmGetStuck.defaultExpectation.paramPtrs.statuses = &statuses
+	mmGetStuck.defaultExpectation.expectationOrigins.originStatuses = minimock.CallerInfo(1)
+
+	return mmGetStuck
+}
+
+// ExpectStuckForParam3 sets up expected param stuckFor for OrderRepository.GetStuck
+func (mmGetStuck *mOrderRepositoryMockGetStuck) ExpectStuckForParam3(stuckFor time.Duration) *mOrderRepositoryMockGetStuck {
+	if mmGetStuck.mock.funcGetStuck != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Set")
+	}
+
+	if mmGetStuck.defaultExpectation == nil {
+		mmGetStuck.defaultExpectation = &OrderRepositoryMockGetStuckExpectation{}
+	}
+
+	if mmGetStuck.defaultExpectation.params != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Expect")
+	}
+
+	if mmGetStuck.defaultExpectation.paramPtrs == nil {
+		mmGetStuck.defaultExpectation.paramPtrs = &OrderRepositoryMockGetStuckParamPtrs{}
+	}
+	mmGetStuck.defaultExpectation.paramPtrs.stuckFor = &stuckFor
+	mmGetStuck.defaultExpectation.expectationOrigins.originStuckFor = minimock.CallerInfo(1)
+
+	return mmGetStuck
+}
+
+// ExpectSkipParam4 sets up expected param skip for OrderRepository.GetStuck
+func (mmGetStuck *mOrderRepositoryMockGetStuck) ExpectSkipParam4(skip []int64) *mOrderRepositoryMockGetStuck {
+	if mmGetStuck.mock.funcGetStuck != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Set")
+	}
+
+	if mmGetStuck.defaultExpectation == nil {
+		mmGetStuck.defaultExpectation = &OrderRepositoryMockGetStuckExpectation{}
+	}
+
+	if mmGetStuck.defaultExpectation.params != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Expect")
+	}
+
+	if mmGetStuck.defaultExpectation.paramPtrs == nil {
+		mmGetStuck.defaultExpectation.paramPtrs = &OrderRepositoryMockGetStuckParamPtrs{}
+	}
+	mmGetStuck.defaultExpectation.paramPtrs.skip = &skip
+	mmGetStuck.defaultExpectation.expectationOrigins.originSkip = minimock.CallerInfo(1)
+
+	return mmGetStuck
+}
+
+// ExpectLimitParam5 sets up expected param limit for OrderRepository.GetStuck
+func (mmGetStuck *mOrderRepositoryMockGetStuck) ExpectLimitParam5(limit int32) *mOrderRepositoryMockGetStuck {
+	if mmGetStuck.mock.funcGetStuck != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Set")
+	}
+
+	if mmGetStuck.defaultExpectation == nil {
+		mmGetStuck.defaultExpectation = &OrderRepositoryMockGetStuckExpectation{}
+	}
+
+	if mmGetStuck.defaultExpectation.params != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Expect")
+	}
+
+	if mmGetStuck.defaultExpectation.paramPtrs == nil {
+		mmGetStuck.defaultExpectation.paramPtrs = &OrderRepositoryMockGetStuckParamPtrs{}
+	}
+	mmGetStuck.defaultExpectation.paramPtrs.limit = &limit
+	mmGetStuck.defaultExpectation.expectationOrigins.originLimit = minimock.CallerInfo(1)
+
+	return mmGetStuck
+}
+
+// Inspect accepts an inspector function that has same arguments as the OrderRepository.GetStuck
+func (mmGetStuck *mOrderRepositoryMockGetStuck) Inspect(f func(ctx context.Context, statuses []string, stuckFor time.Duration, skip []int64, limit int32)) *mOrderRepositoryMockGetStuck {
+	if mmGetStuck.mock.inspectFuncGetStuck != nil {
+		mmGetStuck.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.GetStuck")
+	}
+
+	mmGetStuck.mock.inspectFuncGetStuck = f
+
+	return mmGetStuck
+}
+
+// Return sets up results that will be returned by OrderRepository.GetStuck
+func (mmGetStuck *mOrderRepositoryMockGetStuck) Return(ia1 []int64, err error) *OrderRepositoryMock {
+	if mmGetStuck.mock.funcGetStuck != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Set")
+	}
+
+	if mmGetStuck.defaultExpectation == nil {
+		mmGetStuck.defaultExpectation = &OrderRepositoryMockGetStuckExpectation{mock: mmGetStuck.mock}
+	}
+	mmGetStuck.defaultExpectation.results = &OrderRepositoryMockGetStuckResults{ia1, err}
+	mmGetStuck.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetStuck.mock
+}
+
+// Set uses given function f to mock the OrderRepository.GetStuck method
+func (mmGetStuck *mOrderRepositoryMockGetStuck) Set(f func(ctx context.Context, statuses []string, stuckFor time.Duration, skip []int64, limit int32) (ia1 []int64, err error)) *OrderRepositoryMock {
+	if mmGetStuck.defaultExpectation != nil {
+		mmGetStuck.mock.t.Fatalf("Default expectation is already set for the OrderRepository.GetStuck method")
+	}
+
+	if len(mmGetStuck.expectations) > 0 {
+		mmGetStuck.mock.t.Fatalf("Some expectations are already set for the OrderRepository.GetStuck method")
+	}
+
+	mmGetStuck.mock.funcGetStuck = f
+	mmGetStuck.mock.funcGetStuckOrigin = minimock.CallerInfo(1)
+	return mmGetStuck.mock
+}
+
+// When sets expectation for the OrderRepository.GetStuck which will trigger the result defined by the following
+// Then helper
+func (mmGetStuck *mOrderRepositoryMockGetStuck) When(ctx context.Context, statuses []string, stuckFor time.Duration, skip []int64, limit int32) *OrderRepositoryMockGetStuckExpectation {
+	if mmGetStuck.mock.funcGetStuck != nil {
+		mmGetStuck.mock.t.Fatalf("OrderRepositoryMock.GetStuck mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockGetStuckExpectation{
+		mock:               mmGetStuck.mock,
+		params:             &OrderRepositoryMockGetStuckParams{ctx, statuses, stuckFor, skip, limit},
+		expectationOrigins: OrderRepositoryMockGetStuckExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetStuck.expectations = append(mmGetStuck.expectations, expectation)
+	return expectation
+}
+
+// Then sets up OrderRepository.GetStuck return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockGetStuckExpectation) Then(ia1 []int64, err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockGetStuckResults{ia1, err}
+	return e.mock
+}
+
+// Times sets number of times OrderRepository.GetStuck should be invoked
+func (mmGetStuck *mOrderRepositoryMockGetStuck) Times(n uint64) *mOrderRepositoryMockGetStuck {
+	if n == 0 {
+		mmGetStuck.mock.t.Fatalf("Times of OrderRepositoryMock.GetStuck mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetStuck.expectedInvocations, n)
+	mmGetStuck.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetStuck
+}
+
+func (mmGetStuck *mOrderRepositoryMockGetStuck) invocationsDone() bool {
+	if len(mmGetStuck.expectations) == 0 && mmGetStuck.defaultExpectation == nil && mmGetStuck.mock.funcGetStuck == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetStuck.mock.afterGetStuckCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetStuck.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetStuck implements OrderRepository
+func (mmGetStuck *OrderRepositoryMock) GetStuck(ctx context.Context, statuses []string, stuckFor time.Duration, skip []int64, limit int32) (ia1 []int64, err error) {
+	mm_atomic.AddUint64(&mmGetStuck.beforeGetStuckCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetStuck.afterGetStuckCounter, 1)
+
+	mmGetStuck.t.Helper()
+
+	if mmGetStuck.inspectFuncGetStuck != nil {
+		mmGetStuck.inspectFuncGetStuck(ctx, statuses, stuckFor, skip, limit)
+	}
+
+	mm_params := OrderRepositoryMockGetStuckParams{ctx, statuses, stuckFor, skip, limit}
+
+	// Record call args
+	mmGetStuck.GetStuckMock.mutex.Lock()
+	mmGetStuck.GetStuckMock.callArgs = append(mmGetStuck.GetStuckMock.callArgs, &mm_params)
+	mmGetStuck.GetStuckMock.mutex.Unlock()
+
+	for _, e := range mmGetStuck.GetStuckMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ia1, e.results.err
+		}
+	}
+
+	if mmGetStuck.GetStuckMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetStuck.GetStuckMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetStuck.GetStuckMock.defaultExpectation.params
+		mm_want_ptrs := mmGetStuck.GetStuckMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockGetStuckParams{ctx, statuses, stuckFor, skip, limit}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetStuck.t.Errorf("OrderRepositoryMock.GetStuck got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStuck.GetStuckMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.statuses != nil && !minimock.Equal(*mm_want_ptrs.statuses, mm_got.statuses) {
+				mmGetStuck.t.Errorf("OrderRepositoryMock.GetStuck got unexpected parameter statuses, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStuck.GetStuckMock.defaultExpectation.expectationOrigins.originStatuses, *mm_want_ptrs.statuses, mm_got.statuses, minimock.Diff(*mm_want_ptrs.statuses, mm_got.statuses))
+			}
+
+			if mm_want_ptrs.stuckFor != nil && !minimock.Equal(*mm_want_ptrs.stuckFor, mm_got.stuckFor) {
+				mmGetStuck.t.Errorf("OrderRepositoryMock.GetStuck got unexpected parameter stuckFor, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStuck.GetStuckMock.defaultExpectation.expectationOrigins.originStuckFor, *mm_want_ptrs.stuckFor, mm_got.stuckFor, minimock.Diff(*mm_want_ptrs.stuckFor, mm_got.stuckFor))
+			}
+
+			if mm_want_ptrs.skip != nil && !minimock.Equal(*mm_want_ptrs.skip, mm_got.skip) {
+				mmGetStuck.t.Errorf("OrderRepositoryMock.GetStuck got unexpected parameter skip, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStuck.GetStuckMock.defaultExpectation.expectationOrigins.originSkip, *mm_want_ptrs.skip, mm_got.skip, minimock.Diff(*mm_want_ptrs.skip, mm_got.skip))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetStuck.t.Errorf("OrderRepositoryMock.GetStuck got unexpected parameter limit, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetStuck.GetStuckMock.defaultExpectation.expectationOrigins.originLimit, *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetStuck.t.Errorf("OrderRepositoryMock.GetStuck got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetStuck.GetStuckMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetStuck.GetStuckMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetStuck.t.Fatal("No results are set for the OrderRepositoryMock.GetStuck")
+		}
+		return (*mm_results).ia1, (*mm_results).err
+	}
+	if mmGetStuck.funcGetStuck != nil {
+		return mmGetStuck.funcGetStuck(ctx, statuses, stuckFor, skip, limit)
+	}
+	mmGetStuck.t.Fatalf("Unexpected call to OrderRepositoryMock.GetStuck. %v %v %v %v %v", ctx, statuses, stuckFor, skip, limit)
+	return
+}
+
+// GetStuckAfterCounter returns a count of finished OrderRepositoryMock.GetStuck invocations
+func (mmGetStuck *OrderRepositoryMock) GetStuckAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStuck.afterGetStuckCounter)
+}
+
+// GetStuckBeforeCounter returns a count of OrderRepositoryMock.GetStuck invocations
+func (mmGetStuck *OrderRepositoryMock) GetStuckBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStuck.beforeGetStuckCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.GetStuck.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetStuck *mOrderRepositoryMockGetStuck) Calls() []*OrderRepositoryMockGetStuckParams {
+	mmGetStuck.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockGetStuckParams, len(mmGetStuck.callArgs))
+	copy(argCopy, mmGetStuck.callArgs)
+
+	mmGetStuck.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetStuckDone returns true if the count of the GetStuck invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockGetStuckDone() bool {
+	if m.GetStuckMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetStuckMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetStuckMock.invocationsDone()
+}
+
+// MinimockGetStuckInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockGetStuckInspect() {
+	for _, e := range m.GetStuckMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.GetStuck at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetStuckCounter := mm_atomic.LoadUint64(&m.afterGetStuckCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetStuckMock.defaultExpectation != nil && afterGetStuckCounter < 1 {
+		if m.GetStuckMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to OrderRepositoryMock.GetStuck at\n%s", m.GetStuckMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.GetStuck at\n%s with params: %#v", m.GetStuckMock.defaultExpectation.expectationOrigins.origin, *m.GetStuckMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetStuck != nil && afterGetStuckCounter < 1 {
+		m.t.Errorf("Expected call to OrderRepositoryMock.GetStuck at\n%s", m.funcGetStuckOrigin)
+	}
+
+	if !m.GetStuckMock.invocationsDone() && afterGetStuckCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.GetStuck at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetStuckMock.expectedInvocations), m.GetStuckMock.expectedInvocationsOrigin, afterGetStuckCounter)
+	}
+}
+
+type mOrderRepositoryMockList struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockListExpectation
+	expectations       []*OrderRepositoryMockListExpectation
+
+	callArgs []*OrderRepositoryMockListParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// OrderRepositoryMockListExpectation specifies expectation struct of the OrderRepository.List
+type OrderRepositoryMockListExpectation struct {
+	mock               *OrderRepositoryMock
+	params             *OrderRepositoryMockListParams
+	paramPtrs          *OrderRepositoryMockListParamPtrs
+	expectationOrigins OrderRepositoryMockListExpectationOrigins
+	results            *OrderRepositoryMockListResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// OrderRepositoryMockListParams contains parameters of the OrderRepository.List
+type OrderRepositoryMockListParams struct {
+	ctx    context.Context
+	filter *model.ListOrdersFilter
+}
+
+// OrderRepositoryMockListParamPtrs contains pointers to parameters of the OrderRepository.List
+type OrderRepositoryMockListParamPtrs struct {
+	ctx    *context.Context
+	filter **model.ListOrdersFilter
+}
+
+// OrderRepositoryMockListResults contains results of the OrderRepository.List
+type OrderRepositoryMockListResults struct {
+	op1 *model.OrdersPage
+	err error
+}
+
+// OrderRepositoryMockListOrigins contains origins of expectations of the OrderRepository.List
+type OrderRepositoryMockListExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originFilter string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmList *mOrderRepositoryMockList) Optional() *mOrderRepositoryMockList {
+	mmList.optional = true
+	return mmList
+}
+
+// Expect sets up expected params for OrderRepository.List
+func (mmList *mOrderRepositoryMockList) Expect(ctx context.Context, filter *model.ListOrdersFilter) *mOrderRepositoryMockList {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("OrderRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &OrderRepositoryMockListExpectation{}
+	}
+
+	if mmList.defaultExpectation.paramPtrs != nil {
+		mmList.mock.t.Fatalf("OrderRepositoryMock.List mock is already set by ExpectParams functions")
+	}
+
+	mmList.defaultExpectation.params = &OrderRepositoryMockListParams{ctx, filter}
+	mmList.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmList.expectations {
+		if minimock.Equal(e.params, mmList.defaultExpectation.params) {
+			mmList.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmList.defaultExpectation.params)
+		}
+	}
+
+	return mmList
+}
+
+// ExpectCtxParam1 sets up expected param ctx for OrderRepository.List
+func (mmList *mOrderRepositoryMockList) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockList {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("OrderRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &OrderRepositoryMockListExpectation{}
+	}
+
+	if mmList.defaultExpectation.params != nil {
+		mmList.mock.t.Fatalf("OrderRepositoryMock.List mock is already set by Expect")
+	}
+
+	if mmList.defaultExpectation.paramPtrs == nil {
+		mmList.defaultExpectation.paramPtrs = &OrderRepositoryMockListParamPtrs{}
+	}
+	mmList.defaultExpectation.paramPtrs.ctx = &ctx
+	mmList.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmList
+}
+
+// ExpectFilterParam2 sets up expected param filter for OrderRepository.List
+func (mmList *mOrderRepositoryMockList) ExpectFilterParam2(filter *model.ListOrdersFilter) *mOrderRepositoryMockList {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("OrderRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &OrderRepositoryMockListExpectation{}
+	}
+
+	if mmList.defaultExpectation.params != nil {
+		mmList.mock.t.Fatalf("OrderRepositoryMock.List mock is already set by Expect")
+	}
+
+	if mmList.defaultExpectation.paramPtrs == nil {
+		mmList.defaultExpectation.paramPtrs = &OrderRepositoryMockListParamPtrs{}
+	}
+	mmList.defaultExpectation.paramPtrs.filter = &filter
+	mmList.defaultExpectation.expectationOrigins.originFilter = minimock.CallerInfo(1)
+
+	return mmList
+}
+
+// Inspect accepts an inspector function that has same arguments as the OrderRepository.List
+func (mmList *mOrderRepositoryMockList) Inspect(f func(ctx context.Context, filter *model.ListOrdersFilter)) *mOrderRepositoryMockList {
+	if mmList.mock.inspectFuncList != nil {
+		mmList.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.List")
+	}
+
+	mmList.mock.inspectFuncList = f
+
+	return mmList
+}
+
+// Return sets up results that will be returned by OrderRepository.List
+func (mmList *mOrderRepositoryMockList) Return(op1 *model.OrdersPage, err error) *OrderRepositoryMock {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("OrderRepositoryMock.List mock is already set by Set")
+	}
+
+	if mmList.defaultExpectation == nil {
+		mmList.defaultExpectation = &OrderRepositoryMockListExpectation{mock: mmList.mock}
+	}
+	mmList.defaultExpectation.results = &OrderRepositoryMockListResults{op1, err}
+	mmList.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmList.mock
+}
+
+// Set uses given function f to mock the OrderRepository.List method
+func (mmList *mOrderRepositoryMockList) Set(f func(ctx context.Context, filter *model.ListOrdersFilter) (op1 *model.OrdersPage, err error)) *OrderRepositoryMock {
+	if mmList.defaultExpectation != nil {
+		mmList.mock.t.Fatalf("Default expectation is already set for the OrderRepository.List method")
+	}
+
+	if len(mmList.expectations) > 0 {
+		mmList.mock.t.Fatalf("Some expectations are already set for the OrderRepository.List method")
+	}
+
+	mmList.mock.funcList = f
+	mmList.mock.funcListOrigin = minimock.CallerInfo(1)
+	return mmList.mock
+}
+
+// When sets expectation for the OrderRepository.List which will trigger the result defined by the following
+// Then helper
+func (mmList *mOrderRepositoryMockList) When(ctx context.Context, filter *model.ListOrdersFilter) *OrderRepositoryMockListExpectation {
+	if mmList.mock.funcList != nil {
+		mmList.mock.t.Fatalf("OrderRepositoryMock.List mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockListExpectation{
+		mock:               mmList.mock,
+		params:             &OrderRepositoryMockListParams{ctx, filter},
+		expectationOrigins: OrderRepositoryMockListExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmList.expectations = append(mmList.expectations, expectation)
+	return expectation
+}
+
+// Then sets up OrderRepository.List return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockListExpectation) Then(op1 *model.OrdersPage, err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockListResults{op1, err}
+	return e.mock
+}
+
+// Times sets number of times OrderRepository.List should be invoked
+func (mmList *mOrderRepositoryMockList) Times(n uint64) *mOrderRepositoryMockList {
+	if n == 0 {
+		mmList.mock.t.Fatalf("Times of OrderRepositoryMock.List mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmList.expectedInvocations, n)
+	mmList.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmList
+}
+
+func (mmList *mOrderRepositoryMockList) invocationsDone() bool {
+	if len(mmList.expectations) == 0 && mmList.defaultExpectation == nil && mmList.mock.funcList == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmList.mock.afterListCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmList.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// List implements OrderRepository
+func (mmList *OrderRepositoryMock) List(ctx context.Context, filter *model.ListOrdersFilter) (op1 *model.OrdersPage, err error) {
+	mm_atomic.AddUint64(&mmList.beforeListCounter, 1)
+	defer mm_atomic.AddUint64(&mmList.afterListCounter, 1)
+
+	mmList.t.Helper()
+
+	if mmList.inspectFuncList != nil {
+		mmList.inspectFuncList(ctx, filter)
+	}
+
+	mm_params := OrderRepositoryMockListParams{ctx, filter}
+
+	// Record call args
+	mmList.ListMock.mutex.Lock()
+	mmList.ListMock.callArgs = append(mmList.ListMock.callArgs, &mm_params)
+	mmList.ListMock.mutex.Unlock()
+
+	for _, e := range mmList.ListMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.op1, e.results.err
+		}
+	}
+
+	if mmList.ListMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmList.ListMock.defaultExpectation.Counter, 1)
+		mm_want := mmList.ListMock.defaultExpectation.params
+		mm_want_ptrs := mmList.ListMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockListParams{ctx, filter}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmList.t.Errorf("OrderRepositoryMock.List got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmList.ListMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.filter != nil && !minimock.Equal(*mm_want_ptrs.filter, mm_got.filter) {
+				mmList.t.Errorf("OrderRepositoryMock.List got unexpected parameter filter, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmList.ListMock.defaultExpectation.expectationOrigins.originFilter, *mm_want_ptrs.filter, mm_got.filter, minimock.Diff(*mm_want_ptrs.filter, mm_got.filter))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmList.t.Errorf("OrderRepositoryMock.List got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmList.ListMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmList.ListMock.defaultExpectation.results
+		if mm_results == nil {
+			mmList.t.Fatal("No results are set for the OrderRepositoryMock.List")
+		}
+		return (*mm_results).op1, (*mm_results).err
+	}
+	if mmList.funcList != nil {
+		return mmList.funcList(ctx, filter)
+	}
+	mmList.t.Fatalf("Unexpected call to OrderRepositoryMock.List. %v %v", ctx, filter)
+	return
+}
+
+// ListAfterCounter returns a count of finished OrderRepositoryMock.List invocations
+func (mmList *OrderRepositoryMock) ListAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmList.afterListCounter)
+}
+
+// ListBeforeCounter returns a count of OrderRepositoryMock.List invocations
+func (mmList *OrderRepositoryMock) ListBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmList.beforeListCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.List.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmList *mOrderRepositoryMockList) Calls() []*OrderRepositoryMockListParams {
+	mmList.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockListParams, len(mmList.callArgs))
+	copy(argCopy, mmList.callArgs)
+
+	mmList.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListDone returns true if the count of the List invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockListDone() bool {
+	if m.ListMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListMock.invocationsDone()
+}
+
+// MinimockListInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockListInspect() {
+	for _, e := range m.ListMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.List at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterListCounter := mm_atomic.LoadUint64(&m.afterListCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListMock.defaultExpectation != nil && afterListCounter < 1 {
+		if m.ListMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to OrderRepositoryMock.List at\n%s", m.ListMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.List at\n%s with params: %#v", m.ListMock.defaultExpectation.expectationOrigins.origin, *m.ListMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcList != nil && afterListCounter < 1 {
+		m.t.Errorf("Expected call to OrderRepositoryMock.List at\n%s", m.funcListOrigin)
+	}
+
+	if !m.ListMock.invocationsDone() && afterListCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.List at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ListMock.expectedInvocations), m.ListMock.expectedInvocationsOrigin, afterListCounter)
+	}
+}
+
+type mOrderRepositoryMockUpdateStatus struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockUpdateStatusExpectation
+	expectations       []*OrderRepositoryMockUpdateStatusExpectation
+
+	callArgs []*OrderRepositoryMockUpdateStatusParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// OrderRepositoryMockUpdateStatusExpectation specifies expectation struct of the OrderRepository.UpdateStatus
+type OrderRepositoryMockUpdateStatusExpectation struct {
+	mock               *OrderRepositoryMock
+	params             *OrderRepositoryMockUpdateStatusParams
+	paramPtrs          *OrderRepositoryMockUpdateStatusParamPtrs
+	expectationOrigins OrderRepositoryMockUpdateStatusExpectationOrigins
+	results            *OrderRepositoryMockUpdateStatusResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// OrderRepositoryMockUpdateStatusParams contains parameters of the OrderRepository.UpdateStatus
+type OrderRepositoryMockUpdateStatusParams struct {
+	ctx          context.Context
+	orderId      int64
+	status       string
+	expectStatus string
+}
+
+// OrderRepositoryMockUpdateStatusParamPtrs contains pointers to parameters of the OrderRepository.UpdateStatus
+type OrderRepositoryMockUpdateStatusParamPtrs struct {
+	ctx          *context.Context
+	orderId      *int64
+	status       *string
+	expectStatus *string
+}
+
+// OrderRepositoryMockUpdateStatusResults contains results of the OrderRepository.UpdateStatus
+type OrderRepositoryMockUpdateStatusResults struct {
+	err error
+}
+
+// OrderRepositoryMockUpdateStatusOrigins contains origins of expectations of the OrderRepository.UpdateStatus
+type OrderRepositoryMockUpdateStatusExpectationOrigins struct {
+	origin             string
+	originCtx          string
+	originOrderId      string
+	originStatus       string
+	originExpectStatus string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmUpdateStatus *mOrderRepositoryMockUpdateStatus) Optional() *mOrderRepositoryMockUpdateStatus {
+	mmUpdateStatus.optional = true
+	return mmUpdateStatus
+}
+
+// Expect sets up expected params for OrderRepository.UpdateStatus
+func (mmUpdateStatus *mOrderRepositoryMockUpdateStatus) Expect(ctx context.Context, orderId int64, status string, expectStatus string) *mOrderRepositoryMockUpdateStatus {
+	if mmUpdateStatus.mock.funcUpdateStatus != nil {
+		mmUpdateStatus.mock.t.Fatalf("OrderRepositoryMock.UpdateStatus mock is already set by Set")
+	}
+
+	if mmUpdateStatus.defaultExpectation == nil {
+		mmUpdateStatus.defaultExpectation = &OrderRepositoryMockUpdateStatusExpectation{}
+	}
+
+	if mmUpdateStatus.defaultExpectation.paramPtrs != nil {
+		mmUpdateStatus.mock.t.Fatalf("OrderRepositoryMock.UpdateStatus mock is already set by ExpectParams functions")
+	}
+
+	mmUpdateStatus.defaultExpectation.params = &OrderRepositoryMockUpdateStatusParams{ctx, orderId, status, expectStatus}
+	mmUpdateStatus.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmUpdateStatus.expectations {
 		if minimock.Equal(e.params, mmUpdateStatus.defaultExpectation.params) {
 			mmUpdateStatus.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpdateStatus.defaultExpectation.params)
@@ -1157,10 +2403,16 @@ func (m *OrderRepositoryMock) MinimockUpdateStatusInspect() {
 func (m *OrderRepositoryMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
+			m.MinimockClaimExpiredInspect()
+
 			m.MinimockCreateInspect()
 
 			m.MinimockGetByIdInspect()
 
+			m.MinimockGetStuckInspect()
+
+			m.MinimockListInspect()
+
 			m.MinimockUpdateStatusInspect()
 		}
 	})
@@ -1185,7 +2437,10 @@ func (m *OrderRepositoryMock) MinimockWait(timeout mm_time.Duration) {
 func (m *OrderRepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
+		m.MinimockClaimExpiredDone() &&
 		m.MinimockCreateDone() &&
 		m.MinimockGetByIdDone() &&
+		m.MinimockGetStuckDone() &&
+		m.MinimockListDone() &&
 		m.MinimockUpdateStatusDone()
 }
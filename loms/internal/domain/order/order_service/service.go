@@ -2,24 +2,38 @@ package order_service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"route256/loms/internal/domain/model"
+	"route256/loms/internal/infra/logger"
+	"route256/loms/internal/infra/sre"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"go.opentelemetry.io/otel"
 )
 
-//go:generate minimock -i OrderRepository,StockRepository -p order_service_test,order_service_test
+//go:generate minimock -i OrderRepository,StockRepository,Transactor -p order_service_test,order_service_test,order_service_test
 type OrderRepository interface {
 	Create(ctx context.Context, model *model.CreateOrderModel) (int64, error)
 	GetById(ctx context.Context, orderId int64) (*model.OrderModel, error)
+	List(ctx context.Context, filter *model.ListOrdersFilter) (*model.OrdersPage, error)
 	UpdateStatus(ctx context.Context, orderId int64, status string, expectStatus string) error
+	ClaimExpired(ctx context.Context, from string, to string, ttl time.Duration, limit int32) ([]int64, error)
+	GetStuck(ctx context.Context, statuses []string, stuckFor time.Duration, skip []int64, limit int32) ([]int64, error)
 }
 
 type StockRepository interface {
-	Reserve(ctx context.Context, items []model.OrderItem) error
-	RemoveReserved(ctx context.Context, items []model.OrderItem) error
-	CancelReserved(ctx context.Context, items []model.OrderItem) error
+	Reserve(ctx context.Context, orderId int64, items []model.OrderItem) error
+	RemoveReserved(ctx context.Context, orderId int64, items []model.OrderItem) error
+	CancelReserved(ctx context.Context, orderId int64, items []model.OrderItem) error
+	GetOpenReservations(ctx context.Context, orderId int64) ([]model.OrderItem, error)
+}
+
+// Transactor is the unit of work shared by the repositories, the repository calls made with
+// the context passed to txFunc are committed or rolled back together.
+type Transactor interface {
+	WithinTx(ctx context.Context, txFunc func(ctx context.Context) error) error
 }
 
 type NotifierProducer interface {
@@ -29,16 +43,19 @@ type NotifierProducer interface {
 type OrderService struct {
 	orderRepository OrderRepository
 	stockRepository StockRepository
+	transactor      Transactor
 	validator       *validator.Validate
 }
 
 func NewOrderService(
 	orderRepository OrderRepository,
 	stockRepository StockRepository,
+	transactor Transactor,
 ) *OrderService {
 	return &OrderService{
 		orderRepository: orderRepository,
 		stockRepository: stockRepository,
+		transactor:      transactor,
 		validator:       validator.New(validator.WithRequiredStructEnabled()),
 	}
 }
@@ -51,33 +68,64 @@ func (s *OrderService) CreateOrder(ctx context.Context, order *model.CreateOrder
 		return 0, fmt.Errorf("createOrder: failed to validate CreateOrderModel, %w", err)
 	}
 
-	orderId, err := s.orderRepository.Create(ctx, order)
-	if err != nil {
-		return 0, fmt.Errorf("createOrder: failed to create order, %w", err)
-	}
+	// the failed reservation is committed together with the failed status of the order
+	var orderId int64
+	var reserveErr error
+	err := s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		orderId, err = s.orderRepository.Create(ctx, order)
+		if err != nil {
+			return err
+		}
 
-	err = s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusReserving, model.OrderStatusNew)
-	if err != nil {
-		return 0, fmt.Errorf("createOrder: failed to set reserving status, %w", err)
-	}
+		err = s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusReserving, model.OrderStatuses.Initial())
+		if err != nil {
+			return fmt.Errorf("failed to set reserving status, %w", err)
+		}
 
-	err = s.stockRepository.Reserve(ctx, order.Items)
-	if err != nil {
-		if errS := s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusFailed, model.OrderStatusReserving); errS != nil {
-			return 0, fmt.Errorf("createOrder: status update & reserve fail, %w, %w", err, errS)
+		reserveErr = s.stockRepository.Reserve(ctx, orderId, order.Items)
+		if reserveErr != nil {
+			if err := s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusFailed, model.OrderStatusReserving); err != nil {
+				return fmt.Errorf("status update & reserve fail, %w, %w", reserveErr, err)
+			}
+
+			return nil
 		}
 
-		return 0, &model.ErrReservedStockFailed{OrderId: orderId}
+		err = s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusAwaitingPayment, model.OrderStatusReserving)
+		if err != nil {
+			return fmt.Errorf("failed to set awaiting payment status, %w", err)
+		}
+
+		return nil
+	})
+
+	var duplicateErr *model.ErrDuplicateIdempotencyKey
+	if errors.As(err, &duplicateErr) {
+		return replayOrder(duplicateErr)
 	}
 
-	err = s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusAwaitingPayment, model.OrderStatusReserving)
 	if err != nil {
-		return 0, fmt.Errorf("createOrder: failed to set awaiting payment status, %w", err)
+		return 0, fmt.Errorf("createOrder: failed to create order, %w", err)
+	}
+
+	if reserveErr != nil {
+		return 0, &model.ErrReservedStockFailed{OrderId: orderId}
 	}
 
 	return orderId, nil
 }
 
+// replayOrder answers a repeated create request the same way the original one was answered.
+// The original request may still be in progress, its order id is returned in this case as well.
+func replayOrder(duplicateErr *model.ErrDuplicateIdempotencyKey) (int64, error) {
+	if duplicateErr.Status == model.OrderStatusFailed {
+		return 0, &model.ErrReservedStockFailed{OrderId: duplicateErr.OrderId}
+	}
+
+	return duplicateErr.OrderId, nil
+}
+
 func (s *OrderService) OrderInfo(ctx context.Context, orderId int64) (*model.OrderModel, error) {
 	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "order_service.OrderInfo")
 	defer span.End()
@@ -94,6 +142,33 @@ func (s *OrderService) OrderInfo(ctx context.Context, orderId int64) (*model.Ord
 	return result, nil
 }
 
+func (s *OrderService) ListOrders(ctx context.Context, filter *model.ListOrdersFilter) (*model.OrdersPage, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "order_service.ListOrders")
+	defer span.End()
+
+	if err := s.validator.Struct(filter); err != nil {
+		return nil, fmt.Errorf("listOrders: failed to validate ListOrdersFilter, %w", err)
+	}
+
+	for _, status := range filter.Statuses {
+		if !model.OrderStatuses.IsKnown(status) {
+			return nil, fmt.Errorf("listOrders: %w", &model.ErrUnknownOrderStatus{Status: status})
+		}
+	}
+
+	var pageFilter = *filter
+	if pageFilter.Limit == 0 {
+		pageFilter.Limit = model.DefaultOrdersPageSize
+	}
+
+	page, err := s.orderRepository.List(ctx, &pageFilter)
+	if err != nil {
+		return nil, fmt.Errorf("listOrders: failed to list orders, %w", err)
+	}
+
+	return page, nil
+}
+
 func (s *OrderService) PayOrder(ctx context.Context, orderId int64) error {
 	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "order_service.PayOrder")
 	defer span.End()
@@ -111,25 +186,29 @@ func (s *OrderService) PayOrder(ctx context.Context, orderId int64) error {
 		return nil
 	}
 
-	err = s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusPaying, model.OrderStatusAwaitingPayment)
-	if err != nil {
-		return fmt.Errorf("payOrder: failed to update order status, %w", err)
+	if err := model.OrderStatuses.ValidateTransition(orderId, order.Status, model.OrderStatusPaying); err != nil {
+		return fmt.Errorf("payOrder: %w", err)
 	}
 
-	err = s.stockRepository.RemoveReserved(ctx, order.Items)
-	if err != nil {
-		if errS := s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusAwaitingPayment, model.OrderStatusPaying); errS != nil {
-			return fmt.Errorf("payOrder: status update & remove reserved fail, %w, %w", err, errS)
+	return s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusPaying, model.OrderStatusAwaitingPayment)
+		if err != nil {
+			return fmt.Errorf("payOrder: failed to update order status, %w", err)
 		}
-		return fmt.Errorf("payOrder: failed to remove reserved stock, %w", err)
-	}
 
-	err = s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusPayed, model.OrderStatusPaying)
-	if err != nil {
-		return fmt.Errorf("payOrder: failed to update order status, %w", err)
-	}
+		// a failure rolls the order back to awaiting payment together with the paying status
+		err = s.stockRepository.RemoveReserved(ctx, orderId, order.Items)
+		if err != nil {
+			return fmt.Errorf("payOrder: failed to remove reserved stock, %w", err)
+		}
 
-	return nil
+		err = s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusPayed, model.OrderStatusPaying)
+		if err != nil {
+			return fmt.Errorf("payOrder: failed to update order status, %w", err)
+		}
+
+		return nil
+	})
 }
 
 func (s *OrderService) CancelOrder(ctx context.Context, orderId int64) error {
@@ -149,24 +228,170 @@ func (s *OrderService) CancelOrder(ctx context.Context, orderId int64) error {
 		return nil
 	}
 
-	err = s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusCancelling, model.OrderStatusAwaitingPayment)
-	if err != nil {
-		return fmt.Errorf("cancelOrder: failed to update order status, %w", err)
+	if err := model.OrderStatuses.ValidateTransition(orderId, order.Status, model.OrderStatusCancelling); err != nil {
+		return fmt.Errorf("cancelOrder: %w", err)
 	}
 
-	err = s.stockRepository.CancelReserved(ctx, order.Items)
+	return s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusCancelling, model.OrderStatusAwaitingPayment)
+		if err != nil {
+			return fmt.Errorf("cancelOrder: failed to update order status, %w", err)
+		}
+
+		return s.completeCancellation(ctx, order)
+	})
+}
+
+// CancelExpiredOrders cancels up to limit orders that have been awaiting payment for longer than ttl
+// and returns the number of cancelled orders. Orders are cancelled the same way as by CancelOrder.
+func (s *OrderService) CancelExpiredOrders(ctx context.Context, ttl time.Duration, limit int32) (int, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "order_service.CancelExpiredOrders")
+	defer span.End()
+
+	orderIds, err := s.orderRepository.ClaimExpired(ctx,
+		model.OrderStatusAwaitingPayment, model.OrderStatusCancelling, ttl, limit)
 	if err != nil {
-		if errS := s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusAwaitingPayment, model.OrderStatusCancelling); errS != nil {
-			return fmt.Errorf("cancelOrder: status update & cancel reserved fail, %w, %w", err, errS)
+		return 0, fmt.Errorf("cancelExpiredOrders: failed to claim expired orders, %w", err)
+	}
+
+	var cancelled int
+	var errs []error
+	for _, orderId := range orderIds {
+		err := s.cancelClaimedOrder(ctx, orderId)
+		sre.TrackExpiredOrder(err)
+		if err != nil {
+			logger.Warn("Failed to cancel expired order", "orderId", orderId, "error", err)
+			errs = append(errs, err)
+			continue
 		}
 
+		cancelled++
+	}
+
+	if len(errs) > 0 {
+		return cancelled, fmt.Errorf("cancelExpiredOrders: %w", errors.Join(errs...))
+	}
+
+	return cancelled, nil
+}
+
+func (s *OrderService) cancelClaimedOrder(ctx context.Context, orderId int64) error {
+	order, err := s.orderRepository.GetById(ctx, orderId)
+	if err != nil {
+		return fmt.Errorf("cancelOrder: failed to get order by id, %w", err)
+	}
+
+	return s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		return s.completeCancellation(ctx, order)
+	})
+}
+
+// completeCancellation releases the reserved stock of the order in cancelling status and marks it cancelled.
+// A failure rolls back the unit of work, orders left cancelling are resolved by the recovery.
+func (s *OrderService) completeCancellation(ctx context.Context, order *model.OrderModel) error {
+	err := s.stockRepository.CancelReserved(ctx, order.Id, order.Items)
+	if err != nil {
 		return fmt.Errorf("cancelOrder: failed to cancel reserved stock, %w", err)
 	}
 
-	err = s.orderRepository.UpdateStatus(ctx, orderId, model.OrderStatusCancelled, model.OrderStatusCancelling)
+	err = s.orderRepository.UpdateStatus(ctx, order.Id, model.OrderStatusCancelled, model.OrderStatusCancelling)
 	if err != nil {
 		return fmt.Errorf("cancelOrder: failed to update order status, %w", err)
 	}
 
 	return nil
 }
+
+// RecoverStuckOrders resolves up to limit orders left in internal statuses for longer than stuckFor,
+// e.g. after a crash between the steps of an operation, and returns the number of resolved orders.
+// Every order is locked and resolved in its own unit of work, so concurrent recoveries skip it.
+// The open reservations of the order decide the outcome: reserving orders fail and cancelling orders
+// are cancelled releasing the reservations, paying orders are payed if nothing is reserved anymore
+// and return to awaiting payment otherwise.
+func (s *OrderService) RecoverStuckOrders(ctx context.Context, stuckFor time.Duration, limit int32) (int, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "order_service.RecoverStuckOrders")
+	defer span.End()
+
+	var recovered int
+	var failed []int64
+	var errs []error
+	for recovered+len(failed) < int(limit) {
+		var orderId int64
+		var status string
+		err := s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+			orderIds, err := s.orderRepository.GetStuck(ctx, model.OrderStatuses.InternalStatuses(), stuckFor, failed, 1)
+			if err != nil {
+				return fmt.Errorf("failed to get stuck orders, %w", err)
+			}
+
+			if len(orderIds) == 0 {
+				return nil
+			}
+
+			orderId = orderIds[0]
+			status, err = s.recoverOrder(ctx, orderId)
+			return err
+		})
+		if orderId == 0 {
+			errs = append(errs, err)
+			break
+		}
+
+		sre.TrackRecoveredOrder(status, err)
+		if err != nil {
+			logger.Warn("Failed to recover stuck order", "orderId", orderId, "error", err)
+			errs = append(errs, err)
+			failed = append(failed, orderId)
+			continue
+		}
+
+		recovered++
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return recovered, fmt.Errorf("recoverStuckOrders: %w", err)
+	}
+
+	return recovered, nil
+}
+
+// recoverOrder moves the locked stuck order to the status decided by its open reservations and returns
+// the status the order was stuck in.
+func (s *OrderService) recoverOrder(ctx context.Context, orderId int64) (string, error) {
+	order, err := s.orderRepository.GetById(ctx, orderId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get order by id, %w", err)
+	}
+
+	reserved, err := s.stockRepository.GetOpenReservations(ctx, orderId)
+	if err != nil {
+		return order.Status, err
+	}
+
+	var target string
+	var release bool
+	switch order.Status {
+	case model.OrderStatusReserving:
+		target, release = model.OrderStatusFailed, true
+	case model.OrderStatusCancelling:
+		target, release = model.OrderStatusCancelled, true
+	case model.OrderStatusPaying:
+		target = model.OrderStatusPayed
+		if len(reserved) > 0 {
+			target = model.OrderStatusAwaitingPayment
+		}
+	default:
+		return order.Status, fmt.Errorf("order %d is not stuck, status: %s", orderId, order.Status)
+	}
+
+	err = s.orderRepository.UpdateStatus(ctx, orderId, target, order.Status)
+	if err != nil {
+		return order.Status, err
+	}
+
+	if release && len(reserved) > 0 {
+		return order.Status, s.stockRepository.CancelReserved(ctx, orderId, reserved)
+	}
+
+	return order.Status, nil
+}
@@ -3,10 +3,14 @@ package order_service_test
 import (
 	"context"
 	"errors"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"route256/loms/internal/domain/model"
 	"route256/loms/internal/domain/order/order_service"
+	"route256/loms/internal/infra/transactor"
 
 	"github.com/gojuno/minimock/v3"
 	"github.com/stretchr/testify/require"
@@ -33,7 +37,8 @@ func TestOrderService_CreateOrder(t *testing.T) {
 				orderRepo: NewOrderRepositoryMock(mc).
 					CreateMock.Return(123, nil).
 					UpdateStatusMock.Return(nil),
-				stockRepo: NewStockRepositoryMock(mc).ReserveMock.Return(nil),
+				stockRepo: NewStockRepositoryMock(mc).
+					ReserveMock.When(minimock.AnyContext, 123, []model.OrderItem{{Sku: 1, Count: 1}}).Then(nil),
 			},
 			order: &model.CreateOrderModel{
 				UserId: 1, Items: []model.OrderItem{{Sku: 1, Count: 1}},
@@ -77,12 +82,38 @@ func TestOrderService_CreateOrder(t *testing.T) {
 			},
 			want: 0, wantErr: true,
 		},
+		{
+			name: "should return original order on idempotency key replay",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).CreateMock.Return(0, &model.ErrDuplicateIdempotencyKey{
+					OrderId: 42, Status: model.OrderStatusAwaitingPayment,
+				}),
+				stockRepo: NewStockRepositoryMock(mc),
+			},
+			order: &model.CreateOrderModel{
+				UserId: 1, Items: []model.OrderItem{{Sku: 1, Count: 1}}, IdempotencyKey: "key",
+			},
+			want: 42, wantErr: false,
+		},
+		{
+			name: "should return reserve error on replay of failed order",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).CreateMock.Return(0, &model.ErrDuplicateIdempotencyKey{
+					OrderId: 42, Status: model.OrderStatusFailed,
+				}),
+				stockRepo: NewStockRepositoryMock(mc),
+			},
+			order: &model.CreateOrderModel{
+				UserId: 1, Items: []model.OrderItem{{Sku: 1, Count: 1}}, IdempotencyKey: "key",
+			},
+			want: 0, wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			s := order_service.NewOrderService(tt.deps.orderRepo, tt.deps.stockRepo)
+			s := order_service.NewOrderService(tt.deps.orderRepo, tt.deps.stockRepo, transactor.NoopTransactor{})
 
 			got, err := s.CreateOrder(context.Background(), tt.order)
 
@@ -96,6 +127,45 @@ func TestOrderService_CreateOrder(t *testing.T) {
 	}
 }
 
+func TestOrderService_CreateOrder_SingleTransaction(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+
+	type txKey struct{}
+	inTx := func(ctx context.Context) bool {
+		return ctx.Value(txKey{}) != nil
+	}
+
+	orderRepo := NewOrderRepositoryMock(mc).
+		CreateMock.Set(func(ctx context.Context, _ *model.CreateOrderModel) (int64, error) {
+		require.True(t, inTx(ctx))
+		return 1, nil
+	}).
+		UpdateStatusMock.Set(func(ctx context.Context, _ int64, _ string, _ string) error {
+		require.True(t, inTx(ctx))
+		return nil
+	})
+	stockRepo := NewStockRepositoryMock(mc).
+		ReserveMock.Set(func(ctx context.Context, _ int64, _ []model.OrderItem) error {
+		require.True(t, inTx(ctx))
+		return errors.New("out of stock")
+	})
+	unitOfWork := NewTransactorMock(mc).
+		WithinTxMock.Set(func(ctx context.Context, txFunc func(ctx context.Context) error) error {
+		return txFunc(context.WithValue(ctx, txKey{}, true))
+	})
+
+	s := order_service.NewOrderService(orderRepo, stockRepo, unitOfWork)
+	_, err := s.CreateOrder(context.Background(), &model.CreateOrderModel{
+		UserId: 1, Items: []model.OrderItem{{Sku: 1, Count: 1}},
+	})
+
+	var reserveErr *model.ErrReservedStockFailed
+	require.ErrorAs(t, err, &reserveErr)
+	require.Equal(t, uint64(1), unitOfWork.WithinTxAfterCounter())
+	require.Equal(t, uint64(2), orderRepo.UpdateStatusAfterCounter())
+}
+
 func TestOrderService_OrderInfo(t *testing.T) {
 	t.Parallel()
 	mc := minimock.NewController(t)
@@ -149,7 +219,7 @@ func TestOrderService_OrderInfo(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			s := order_service.NewOrderService(tt.deps.orderRepo, nil)
+			s := order_service.NewOrderService(tt.deps.orderRepo, nil, transactor.NoopTransactor{})
 			got, err := s.OrderInfo(context.Background(), tt.orderId)
 
 			if (err != nil) != tt.wantErr {
@@ -209,14 +279,25 @@ func TestOrderService_PayOrder(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "should return error if and rollback status if remove reserved fails",
+			name: "should reject payment of the cancelled order",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).
+					GetByIdMock.Return(&model.OrderModel{
+					Id: 1, UserId: 1, Status: model.OrderStatusCancelled,
+					Items: []model.OrderItem{{Sku: 1, Count: 1}}}, nil),
+				stockRepo: NewStockRepositoryMock(mc),
+			},
+			orderId: 1,
+			wantErr: true,
+		},
+		{
+			name: "should return error without compensating the status if remove reserved fails",
 			deps: deps{
 				orderRepo: NewOrderRepositoryMock(mc).
 					GetByIdMock.Return(&model.OrderModel{
 					Id: 1, UserId: 1, Status: model.OrderStatusAwaitingPayment,
 					Items: []model.OrderItem{{Sku: 1, Count: 1}}}, nil).
 					UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusPaying, model.OrderStatusAwaitingPayment).
-					Then(nil).UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusAwaitingPayment, model.OrderStatusPaying).
 					Then(nil),
 				stockRepo: NewStockRepositoryMock(mc).
 					RemoveReservedMock.Return(errors.New("error")),
@@ -245,7 +326,7 @@ func TestOrderService_PayOrder(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			s := order_service.NewOrderService(tt.deps.orderRepo, tt.deps.stockRepo)
+			s := order_service.NewOrderService(tt.deps.orderRepo, tt.deps.stockRepo, transactor.NoopTransactor{})
 			err := s.PayOrder(context.Background(), tt.orderId)
 
 			if (err != nil) != tt.wantErr {
@@ -316,14 +397,25 @@ func TestOrderService_CancelOrder(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "should return error and rollback status if remove reserved fails",
+			name: "should reject cancellation of the payed order",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).
+					GetByIdMock.Return(&model.OrderModel{
+					Id: 1, UserId: 1, Status: model.OrderStatusPayed,
+					Items: []model.OrderItem{{Sku: 1, Count: 1}}}, nil),
+				stockRepo: NewStockRepositoryMock(mc),
+			},
+			orderId: 1,
+			wantErr: true,
+		},
+		{
+			name: "should return error without compensating the status if cancel reserved fails",
 			deps: deps{
 				orderRepo: NewOrderRepositoryMock(mc).
 					GetByIdMock.Return(&model.OrderModel{
 					Id: 1, UserId: 1, Status: model.OrderStatusAwaitingPayment,
 					Items: []model.OrderItem{{Sku: 1, Count: 1}}}, nil).
 					UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusCancelling, model.OrderStatusAwaitingPayment).
-					Then(nil).UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusAwaitingPayment, model.OrderStatusCancelling).
 					Then(nil),
 				stockRepo: NewStockRepositoryMock(mc).
 					CancelReservedMock.Return(errors.New("error")),
@@ -352,7 +444,7 @@ func TestOrderService_CancelOrder(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			s := order_service.NewOrderService(tt.deps.orderRepo, tt.deps.stockRepo)
+			s := order_service.NewOrderService(tt.deps.orderRepo, tt.deps.stockRepo, transactor.NoopTransactor{})
 			err := s.CancelOrder(context.Background(), tt.orderId)
 
 			if (err != nil) != tt.wantErr {
@@ -361,3 +453,284 @@ func TestOrderService_CancelOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderService_CancelExpiredOrders(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+
+	type deps struct {
+		orderRepo *OrderRepositoryMock
+		stockRepo *StockRepositoryMock
+	}
+	tests := []struct {
+		name    string
+		deps    deps
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "should cancel claimed orders and release their stock",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).
+					ClaimExpiredMock.When(minimock.AnyContext, model.OrderStatusAwaitingPayment,
+					model.OrderStatusCancelling, time.Minute, 10).Then([]int64{1}, nil).
+					GetByIdMock.Return(&model.OrderModel{
+					Id: 1, UserId: 1, Status: model.OrderStatusCancelling,
+					Items: []model.OrderItem{{Sku: 1, Count: 1}}}, nil).
+					UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusCancelled, model.OrderStatusCancelling).
+					Then(nil),
+				stockRepo: NewStockRepositoryMock(mc).
+					CancelReservedMock.When(minimock.AnyContext, 1, []model.OrderItem{{Sku: 1, Count: 1}}).Then(nil),
+			},
+			want: 1,
+		},
+		{
+			name: "should do nothing if there are no expired orders",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).
+					ClaimExpiredMock.Return(nil, nil),
+				stockRepo: NewStockRepositoryMock(mc),
+			},
+			want: 0,
+		},
+		{
+			name: "should return error if claim fails",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).
+					ClaimExpiredMock.Return(nil, errors.New("error")),
+				stockRepo: NewStockRepositoryMock(mc),
+			},
+			wantErr: true,
+		},
+		{
+			name: "should leave the order to the rollback if cancel reserved fails",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).
+					ClaimExpiredMock.Return([]int64{1}, nil).
+					GetByIdMock.Return(&model.OrderModel{
+					Id: 1, UserId: 1, Status: model.OrderStatusCancelling,
+					Items: []model.OrderItem{{Sku: 1, Count: 1}}}, nil),
+				stockRepo: NewStockRepositoryMock(mc).
+					CancelReservedMock.Return(errors.New("error")),
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := order_service.NewOrderService(tt.deps.orderRepo, tt.deps.stockRepo, transactor.NoopTransactor{})
+			got, err := s.CancelExpiredOrders(context.Background(), time.Minute, 10)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OrderService.CancelExpiredOrders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOrderService_ListOrders(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+
+	var page = &model.OrdersPage{Orders: []model.OrderModel{{Id: 3, UserId: 1, Status: model.OrderStatusPayed}}}
+	tests := []struct {
+		name      string
+		orderRepo *OrderRepositoryMock
+		filter    *model.ListOrdersFilter
+		want      *model.OrdersPage
+		wantErr   bool
+	}{
+		{
+			name: "should use default page size if limit is not set",
+			orderRepo: NewOrderRepositoryMock(mc).
+				ListMock.When(minimock.AnyContext, &model.ListOrdersFilter{UserId: 1, Limit: model.DefaultOrdersPageSize}).
+				Then(page, nil),
+			filter: &model.ListOrdersFilter{UserId: 1},
+			want:   page,
+		},
+		{
+			name:      "should reject unknown status",
+			orderRepo: NewOrderRepositoryMock(mc),
+			filter:    &model.ListOrdersFilter{Statuses: []string{"lost"}},
+			wantErr:   true,
+		},
+		{
+			name:      "should reject too large page",
+			orderRepo: NewOrderRepositoryMock(mc),
+			filter:    &model.ListOrdersFilter{Limit: 1000},
+			wantErr:   true,
+		},
+		{
+			name: "should return error if list fails",
+			orderRepo: NewOrderRepositoryMock(mc).
+				ListMock.Return(nil, errors.New("error")),
+			filter:  &model.ListOrdersFilter{Limit: 5},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := order_service.NewOrderService(tt.orderRepo, NewStockRepositoryMock(mc), transactor.NoopTransactor{})
+			got, err := s.ListOrders(context.Background(), tt.filter)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OrderService.ListOrders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestOrderService_RecoverStuckOrders(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+
+	type deps struct {
+		orderRepo *OrderRepositoryMock
+		stockRepo *StockRepositoryMock
+	}
+
+	var items = []model.OrderItem{{Sku: 1, Count: 1}}
+	// stuckOrders hands out the orders one by one like the locking repository does, skipped orders
+	// are handed out again only if they are not skipped
+	stuckOrders := func(orderIds ...int64) func(context.Context, []string, time.Duration, []int64, int32) ([]int64, error) {
+		var mtx sync.Mutex
+		var handed = make(map[int64]bool)
+		return func(_ context.Context, statuses []string, stuckFor time.Duration, skip []int64, limit int32) ([]int64, error) {
+			mtx.Lock()
+			defer mtx.Unlock()
+
+			if !slices.Equal(statuses, model.OrderStatuses.InternalStatuses()) || stuckFor != time.Minute || limit != 1 {
+				return nil, errors.New("unexpected stuck orders request")
+			}
+
+			for _, orderId := range orderIds {
+				if !handed[orderId] && !slices.Contains(skip, orderId) {
+					handed[orderId] = true
+					return []int64{orderId}, nil
+				}
+			}
+
+			return nil, nil
+		}
+	}
+	stuckOrder := func(status string) *OrderRepositoryMock {
+		return NewOrderRepositoryMock(mc).
+			GetStuckMock.Set(stuckOrders(1)).
+			GetByIdMock.Return(&model.OrderModel{Id: 1, UserId: 1, Status: status, Items: items}, nil)
+	}
+
+	tests := []struct {
+		name    string
+		deps    deps
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "should fail reserving order and release its reservations",
+			deps: deps{
+				orderRepo: stuckOrder(model.OrderStatusReserving).
+					UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusFailed, model.OrderStatusReserving).
+					Then(nil),
+				stockRepo: NewStockRepositoryMock(mc).
+					GetOpenReservationsMock.Return(items, nil).
+					CancelReservedMock.When(minimock.AnyContext, 1, items).Then(nil),
+			},
+			want: 1,
+		},
+		{
+			name: "should complete paying order without open reservations",
+			deps: deps{
+				orderRepo: stuckOrder(model.OrderStatusPaying).
+					UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusPayed, model.OrderStatusPaying).
+					Then(nil),
+				stockRepo: NewStockRepositoryMock(mc).
+					GetOpenReservationsMock.Return(nil, nil),
+			},
+			want: 1,
+		},
+		{
+			name: "should return paying order with open reservations to awaiting payment",
+			deps: deps{
+				orderRepo: stuckOrder(model.OrderStatusPaying).
+					UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusAwaitingPayment, model.OrderStatusPaying).
+					Then(nil),
+				stockRepo: NewStockRepositoryMock(mc).
+					GetOpenReservationsMock.Return(items, nil),
+			},
+			want: 1,
+		},
+		{
+			name: "should cancel cancelling order with released reservations",
+			deps: deps{
+				orderRepo: stuckOrder(model.OrderStatusCancelling).
+					UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusCancelled, model.OrderStatusCancelling).
+					Then(nil),
+				stockRepo: NewStockRepositoryMock(mc).
+					GetOpenReservationsMock.Return(nil, nil),
+			},
+			want: 1,
+		},
+		{
+			name: "should return error if the order was recovered concurrently",
+			deps: deps{
+				orderRepo: stuckOrder(model.OrderStatusCancelling).
+					UpdateStatusMock.Return(&model.ErrOrderStatusMismatch{OrderId: 1}),
+				stockRepo: NewStockRepositoryMock(mc).
+					GetOpenReservationsMock.Return(items, nil),
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "should not pick an order failed to recover again in the same run",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).
+					GetStuckMock.Set(stuckOrders(1, 2)).
+					GetByIdMock.When(minimock.AnyContext, 1).
+					Then(&model.OrderModel{Id: 1, UserId: 1, Status: model.OrderStatusPaying, Items: items}, nil).
+					GetByIdMock.When(minimock.AnyContext, 2).
+					Then(&model.OrderModel{Id: 2, UserId: 1, Status: model.OrderStatusPaying, Items: items}, nil).
+					UpdateStatusMock.When(minimock.AnyContext, 1, model.OrderStatusPayed, model.OrderStatusPaying).
+					Then(&model.ErrOrderStatusMismatch{OrderId: 1}).
+					UpdateStatusMock.When(minimock.AnyContext, 2, model.OrderStatusPayed, model.OrderStatusPaying).
+					Then(nil),
+				stockRepo: NewStockRepositoryMock(mc).
+					GetOpenReservationsMock.Return(nil, nil),
+			},
+			want:    1,
+			wantErr: true,
+		},
+		{
+			name: "should return error if stuck orders can not be listed",
+			deps: deps{
+				orderRepo: NewOrderRepositoryMock(mc).
+					GetStuckMock.Return(nil, errors.New("error")),
+				stockRepo: NewStockRepositoryMock(mc),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := order_service.NewOrderService(tt.deps.orderRepo, tt.deps.stockRepo, transactor.NoopTransactor{})
+			got, err := s.RecoverStuckOrders(context.Background(), time.Minute, 10)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("OrderService.RecoverStuckOrders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
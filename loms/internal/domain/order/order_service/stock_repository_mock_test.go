@@ -19,23 +19,30 @@ type StockRepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
-	funcCancelReserved          func(ctx context.Context, items []model.OrderItem) (err error)
+	funcCancelReserved          func(ctx context.Context, orderId int64, items []model.OrderItem) (err error)
 	funcCancelReservedOrigin    string
-	inspectFuncCancelReserved   func(ctx context.Context, items []model.OrderItem)
+	inspectFuncCancelReserved   func(ctx context.Context, orderId int64, items []model.OrderItem)
 	afterCancelReservedCounter  uint64
 	beforeCancelReservedCounter uint64
 	CancelReservedMock          mStockRepositoryMockCancelReserved
 
-	funcRemoveReserved          func(ctx context.Context, items []model.OrderItem) (err error)
+	funcGetOpenReservations          func(ctx context.Context, orderId int64) (oa1 []model.OrderItem, err error)
+	funcGetOpenReservationsOrigin    string
+	inspectFuncGetOpenReservations   func(ctx context.Context, orderId int64)
+	afterGetOpenReservationsCounter  uint64
+	beforeGetOpenReservationsCounter uint64
+	GetOpenReservationsMock          mStockRepositoryMockGetOpenReservations
+
+	funcRemoveReserved          func(ctx context.Context, orderId int64, items []model.OrderItem) (err error)
 	funcRemoveReservedOrigin    string
-	inspectFuncRemoveReserved   func(ctx context.Context, items []model.OrderItem)
+	inspectFuncRemoveReserved   func(ctx context.Context, orderId int64, items []model.OrderItem)
 	afterRemoveReservedCounter  uint64
 	beforeRemoveReservedCounter uint64
 	RemoveReservedMock          mStockRepositoryMockRemoveReserved
 
-	funcReserve          func(ctx context.Context, items []model.OrderItem) (err error)
+	funcReserve          func(ctx context.Context, orderId int64, items []model.OrderItem) (err error)
 	funcReserveOrigin    string
-	inspectFuncReserve   func(ctx context.Context, items []model.OrderItem)
+	inspectFuncReserve   func(ctx context.Context, orderId int64, items []model.OrderItem)
 	afterReserveCounter  uint64
 	beforeReserveCounter uint64
 	ReserveMock          mStockRepositoryMockReserve
@@ -52,6 +59,9 @@ func NewStockRepositoryMock(t minimock.Tester) *StockRepositoryMock {
 	m.CancelReservedMock = mStockRepositoryMockCancelReserved{mock: m}
 	m.CancelReservedMock.callArgs = []*StockRepositoryMockCancelReservedParams{}
 
+	m.GetOpenReservationsMock = mStockRepositoryMockGetOpenReservations{mock: m}
+	m.GetOpenReservationsMock.callArgs = []*StockRepositoryMockGetOpenReservationsParams{}
+
 	m.RemoveReservedMock = mStockRepositoryMockRemoveReserved{mock: m}
 	m.RemoveReservedMock.callArgs = []*StockRepositoryMockRemoveReservedParams{}
 
@@ -89,14 +99,16 @@ type StockRepositoryMockCancelReservedExpectation struct {
 
 // StockRepositoryMockCancelReservedParams contains parameters of the StockRepository.CancelReserved
 type StockRepositoryMockCancelReservedParams struct {
-	ctx   context.Context
-	items []model.OrderItem
+	ctx     context.Context
+	orderId int64
+	items   []model.OrderItem
 }
 
 // StockRepositoryMockCancelReservedParamPtrs contains pointers to parameters of the StockRepository.CancelReserved
 type StockRepositoryMockCancelReservedParamPtrs struct {
-	ctx   *context.Context
-	items *[]model.OrderItem
+	ctx     *context.Context
+	orderId *int64
+	items   *[]model.OrderItem
 }
 
 // StockRepositoryMockCancelReservedResults contains results of the StockRepository.CancelReserved
@@ -106,9 +118,10 @@ type StockRepositoryMockCancelReservedResults struct {
 
 // StockRepositoryMockCancelReservedOrigins contains origins of expectations of the StockRepository.CancelReserved
 type StockRepositoryMockCancelReservedExpectationOrigins struct {
-	origin      string
-	originCtx   string
-	originItems string
+	origin        string
+	originCtx     string
+	originOrderId string
+	originItems   string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -122,7 +135,7 @@ func (mmCancelReserved *mStockRepositoryMockCancelReserved) Optional() *mStockRe
 }
 
 // Expect sets up expected params for StockRepository.CancelReserved
-func (mmCancelReserved *mStockRepositoryMockCancelReserved) Expect(ctx context.Context, items []model.OrderItem) *mStockRepositoryMockCancelReserved {
+func (mmCancelReserved *mStockRepositoryMockCancelReserved) Expect(ctx context.Context, orderId int64, items []model.OrderItem) *mStockRepositoryMockCancelReserved {
 	if mmCancelReserved.mock.funcCancelReserved != nil {
 		mmCancelReserved.mock.t.Fatalf("StockRepositoryMock.CancelReserved mock is already set by Set")
 	}
@@ -135,7 +148,7 @@ func (mmCancelReserved *mStockRepositoryMockCancelReserved) Expect(ctx context.C
 		mmCancelReserved.mock.t.Fatalf("StockRepositoryMock.CancelReserved mock is already set by ExpectParams functions")
 	}
 
-	mmCancelReserved.defaultExpectation.params = &StockRepositoryMockCancelReservedParams{ctx, items}
+	mmCancelReserved.defaultExpectation.params = &StockRepositoryMockCancelReservedParams{ctx, orderId, items}
 	mmCancelReserved.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmCancelReserved.expectations {
 		if minimock.Equal(e.params, mmCancelReserved.defaultExpectation.params) {
@@ -169,8 +182,31 @@ func (mmCancelReserved *mStockRepositoryMockCancelReserved) ExpectCtxParam1(ctx
 	return mmCancelReserved
 }
 
-// ExpectItemsParam2 sets up expected param items for StockRepository.CancelReserved
-func (mmCancelReserved *mStockRepositoryMockCancelReserved) ExpectItemsParam2(items []model.OrderItem) *mStockRepositoryMockCancelReserved {
+// ExpectOrderIdParam2 sets up expected param orderId for StockRepository.CancelReserved
+func (mmCancelReserved *mStockRepositoryMockCancelReserved) ExpectOrderIdParam2(orderId int64) *mStockRepositoryMockCancelReserved {
+	if mmCancelReserved.mock.funcCancelReserved != nil {
+		mmCancelReserved.mock.t.Fatalf("StockRepositoryMock.CancelReserved mock is already set by Set")
+	}
+
+	if mmCancelReserved.defaultExpectation == nil {
+		mmCancelReserved.defaultExpectation = &StockRepositoryMockCancelReservedExpectation{}
+	}
+
+	if mmCancelReserved.defaultExpectation.params != nil {
+		mmCancelReserved.mock.t.Fatalf("StockRepositoryMock.CancelReserved mock is already set by Expect")
+	}
+
+	if mmCancelReserved.defaultExpectation.paramPtrs == nil {
+		mmCancelReserved.defaultExpectation.paramPtrs = &StockRepositoryMockCancelReservedParamPtrs{}
+	}
+	mmCancelReserved.defaultExpectation.paramPtrs.orderId = &orderId
+	mmCancelReserved.defaultExpectation.expectationOrigins.originOrderId = minimock.CallerInfo(1)
+
+	return mmCancelReserved
+}
+
+// ExpectItemsParam3 sets up expected param items for StockRepository.CancelReserved
+func (mmCancelReserved *mStockRepositoryMockCancelReserved) ExpectItemsParam3(items []model.OrderItem) *mStockRepositoryMockCancelReserved {
 	if mmCancelReserved.mock.funcCancelReserved != nil {
 		mmCancelReserved.mock.t.Fatalf("StockRepositoryMock.CancelReserved mock is already set by Set")
 	}
@@ -193,7 +229,7 @@ func (mmCancelReserved *mStockRepositoryMockCancelReserved) ExpectItemsParam2(it
 }
 
 // Inspect accepts an inspector function that has same arguments as the StockRepository.CancelReserved
-func (mmCancelReserved *mStockRepositoryMockCancelReserved) Inspect(f func(ctx context.Context, items []model.OrderItem)) *mStockRepositoryMockCancelReserved {
+func (mmCancelReserved *mStockRepositoryMockCancelReserved) Inspect(f func(ctx context.Context, orderId int64, items []model.OrderItem)) *mStockRepositoryMockCancelReserved {
 	if mmCancelReserved.mock.inspectFuncCancelReserved != nil {
 		mmCancelReserved.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.CancelReserved")
 	}
@@ -218,7 +254,7 @@ func (mmCancelReserved *mStockRepositoryMockCancelReserved) Return(err error) *S
 }
 
 // Set uses given function f to mock the StockRepository.CancelReserved method
-func (mmCancelReserved *mStockRepositoryMockCancelReserved) Set(f func(ctx context.Context, items []model.OrderItem) (err error)) *StockRepositoryMock {
+func (mmCancelReserved *mStockRepositoryMockCancelReserved) Set(f func(ctx context.Context, orderId int64, items []model.OrderItem) (err error)) *StockRepositoryMock {
 	if mmCancelReserved.defaultExpectation != nil {
 		mmCancelReserved.mock.t.Fatalf("Default expectation is already set for the StockRepository.CancelReserved method")
 	}
@@ -234,14 +270,14 @@ func (mmCancelReserved *mStockRepositoryMockCancelReserved) Set(f func(ctx conte
 
 // When sets expectation for the StockRepository.CancelReserved which will trigger the result defined by the following
 // Then helper
-func (mmCancelReserved *mStockRepositoryMockCancelReserved) When(ctx context.Context, items []model.OrderItem) *StockRepositoryMockCancelReservedExpectation {
+func (mmCancelReserved *mStockRepositoryMockCancelReserved) When(ctx context.Context, orderId int64, items []model.OrderItem) *StockRepositoryMockCancelReservedExpectation {
 	if mmCancelReserved.mock.funcCancelReserved != nil {
 		mmCancelReserved.mock.t.Fatalf("StockRepositoryMock.CancelReserved mock is already set by Set")
 	}
 
 	expectation := &StockRepositoryMockCancelReservedExpectation{
 		mock:               mmCancelReserved.mock,
-		params:             &StockRepositoryMockCancelReservedParams{ctx, items},
+		params:             &StockRepositoryMockCancelReservedParams{ctx, orderId, items},
 		expectationOrigins: StockRepositoryMockCancelReservedExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmCancelReserved.expectations = append(mmCancelReserved.expectations, expectation)
@@ -276,17 +312,17 @@ func (mmCancelReserved *mStockRepositoryMockCancelReserved) invocationsDone() bo
 }
 
 // CancelReserved implements StockRepository
-func (mmCancelReserved *StockRepositoryMock) CancelReserved(ctx context.Context, items []model.OrderItem) (err error) {
+func (mmCancelReserved *StockRepositoryMock) CancelReserved(ctx context.Context, orderId int64, items []model.OrderItem) (err error) {
 	mm_atomic.AddUint64(&mmCancelReserved.beforeCancelReservedCounter, 1)
 	defer mm_atomic.AddUint64(&mmCancelReserved.afterCancelReservedCounter, 1)
 
 	mmCancelReserved.t.Helper()
 
 	if mmCancelReserved.inspectFuncCancelReserved != nil {
-		mmCancelReserved.inspectFuncCancelReserved(ctx, items)
+		mmCancelReserved.inspectFuncCancelReserved(ctx, orderId, items)
 	}
 
-	mm_params := StockRepositoryMockCancelReservedParams{ctx, items}
+	mm_params := StockRepositoryMockCancelReservedParams{ctx, orderId, items}
 
 	// Record call args
 	mmCancelReserved.CancelReservedMock.mutex.Lock()
@@ -305,7 +341,7 @@ func (mmCancelReserved *StockRepositoryMock) CancelReserved(ctx context.Context,
 		mm_want := mmCancelReserved.CancelReservedMock.defaultExpectation.params
 		mm_want_ptrs := mmCancelReserved.CancelReservedMock.defaultExpectation.paramPtrs
 
-		mm_got := StockRepositoryMockCancelReservedParams{ctx, items}
+		mm_got := StockRepositoryMockCancelReservedParams{ctx, orderId, items}
 
 		if mm_want_ptrs != nil {
 
@@ -314,6 +350,11 @@ func (mmCancelReserved *StockRepositoryMock) CancelReserved(ctx context.Context,
 					mmCancelReserved.CancelReservedMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
+			if mm_want_ptrs.orderId != nil && !minimock.Equal(*mm_want_ptrs.orderId, mm_got.orderId) {
+				mmCancelReserved.t.Errorf("StockRepositoryMock.CancelReserved got unexpected parameter orderId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmCancelReserved.CancelReservedMock.defaultExpectation.expectationOrigins.originOrderId, *mm_want_ptrs.orderId, mm_got.orderId, minimock.Diff(*mm_want_ptrs.orderId, mm_got.orderId))
+			}
+
 			if mm_want_ptrs.items != nil && !minimock.Equal(*mm_want_ptrs.items, mm_got.items) {
 				mmCancelReserved.t.Errorf("StockRepositoryMock.CancelReserved got unexpected parameter items, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 					mmCancelReserved.CancelReservedMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
@@ -331,9 +372,9 @@ func (mmCancelReserved *StockRepositoryMock) CancelReserved(ctx context.Context,
 		return (*mm_results).err
 	}
 	if mmCancelReserved.funcCancelReserved != nil {
-		return mmCancelReserved.funcCancelReserved(ctx, items)
+		return mmCancelReserved.funcCancelReserved(ctx, orderId, items)
 	}
-	mmCancelReserved.t.Fatalf("Unexpected call to StockRepositoryMock.CancelReserved. %v %v", ctx, items)
+	mmCancelReserved.t.Fatalf("Unexpected call to StockRepositoryMock.CancelReserved. %v %v %v", ctx, orderId, items)
 	return
 }
 
@@ -405,6 +446,349 @@ func (m *StockRepositoryMock) MinimockCancelReservedInspect() {
 	}
 }
 
+type mStockRepositoryMockGetOpenReservations struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockGetOpenReservationsExpectation
+	expectations       []*StockRepositoryMockGetOpenReservationsExpectation
+
+	callArgs []*StockRepositoryMockGetOpenReservationsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// StockRepositoryMockGetOpenReservationsExpectation specifies expectation struct of the StockRepository.GetOpenReservations
+type StockRepositoryMockGetOpenReservationsExpectation struct {
+	mock               *StockRepositoryMock
+	params             *StockRepositoryMockGetOpenReservationsParams
+	paramPtrs          *StockRepositoryMockGetOpenReservationsParamPtrs
+	expectationOrigins StockRepositoryMockGetOpenReservationsExpectationOrigins
+	results            *StockRepositoryMockGetOpenReservationsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// StockRepositoryMockGetOpenReservationsParams contains parameters of the StockRepository.GetOpenReservations
+type StockRepositoryMockGetOpenReservationsParams struct {
+	ctx     context.Context
+	orderId int64
+}
+
+// StockRepositoryMockGetOpenReservationsParamPtrs contains pointers to parameters of the StockRepository.GetOpenReservations
+type StockRepositoryMockGetOpenReservationsParamPtrs struct {
+	ctx     *context.Context
+	orderId *int64
+}
+
+// StockRepositoryMockGetOpenReservationsResults contains results of the StockRepository.GetOpenReservations
+type StockRepositoryMockGetOpenReservationsResults struct {
+	oa1 []model.OrderItem
+	err error
+}
+
+// StockRepositoryMockGetOpenReservationsOrigins contains origins of expectations of the StockRepository.GetOpenReservations
+type StockRepositoryMockGetOpenReservationsExpectationOrigins struct {
+	origin        string
+	originCtx     string
+	originOrderId string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) Optional() *mStockRepositoryMockGetOpenReservations {
+	mmGetOpenReservations.optional = true
+	return mmGetOpenReservations
+}
+
+// Expect sets up expected params for StockRepository.GetOpenReservations
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) Expect(ctx context.Context, orderId int64) *mStockRepositoryMockGetOpenReservations {
+	if mmGetOpenReservations.mock.funcGetOpenReservations != nil {
+		mmGetOpenReservations.mock.t.Fatalf("StockRepositoryMock.GetOpenReservations mock is already set by Set")
+	}
+
+	if mmGetOpenReservations.defaultExpectation == nil {
+		mmGetOpenReservations.defaultExpectation = &StockRepositoryMockGetOpenReservationsExpectation{}
+	}
+
+	if mmGetOpenReservations.defaultExpectation.paramPtrs != nil {
+		mmGetOpenReservations.mock.t.Fatalf("StockRepositoryMock.GetOpenReservations mock is already set by ExpectParams functions")
+	}
+
+	mmGetOpenReservations.defaultExpectation.params = &StockRepositoryMockGetOpenReservationsParams{ctx, orderId}
+	mmGetOpenReservations.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetOpenReservations.expectations {
+		if minimock.Equal(e.params, mmGetOpenReservations.defaultExpectation.params) {
+			mmGetOpenReservations.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetOpenReservations.defaultExpectation.params)
+		}
+	}
+
+	return mmGetOpenReservations
+}
+
+// ExpectCtxParam1 sets up expected param ctx for StockRepository.GetOpenReservations
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockGetOpenReservations {
+	if mmGetOpenReservations.mock.funcGetOpenReservations != nil {
+		mmGetOpenReservations.mock.t.Fatalf("StockRepositoryMock.GetOpenReservations mock is already set by Set")
+	}
+
+	if mmGetOpenReservations.defaultExpectation == nil {
+		mmGetOpenReservations.defaultExpectation = &StockRepositoryMockGetOpenReservationsExpectation{}
+	}
+
+	if mmGetOpenReservations.defaultExpectation.params != nil {
+		mmGetOpenReservations.mock.t.Fatalf("StockRepositoryMock.GetOpenReservations mock is already set by Expect")
+	}
+
+	if mmGetOpenReservations.defaultExpectation.paramPtrs == nil {
+		mmGetOpenReservations.defaultExpectation.paramPtrs = &StockRepositoryMockGetOpenReservationsParamPtrs{}
+	}
+	mmGetOpenReservations.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetOpenReservations.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetOpenReservations
+}
+
+// ExpectOrderIdParam2 sets up expected param orderId for StockRepository.GetOpenReservations
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) ExpectOrderIdParam2(orderId int64) *mStockRepositoryMockGetOpenReservations {
+	if mmGetOpenReservations.mock.funcGetOpenReservations != nil {
+		mmGetOpenReservations.mock.t.Fatalf("StockRepositoryMock.GetOpenReservations mock is already set by Set")
+	}
+
+	if mmGetOpenReservations.defaultExpectation == nil {
+		mmGetOpenReservations.defaultExpectation = &StockRepositoryMockGetOpenReservationsExpectation{}
+	}
+
+	if mmGetOpenReservations.defaultExpectation.params != nil {
+		mmGetOpenReservations.mock.t.Fatalf("StockRepositoryMock.GetOpenReservations mock is already set by Expect")
+	}
+
+	if mmGetOpenReservations.defaultExpectation.paramPtrs == nil {
+		mmGetOpenReservations.defaultExpectation.paramPtrs = &StockRepositoryMockGetOpenReservationsParamPtrs{}
+	}
+	mmGetOpenReservations.defaultExpectation.paramPtrs.orderId = &orderId
+	mmGetOpenReservations.defaultExpectation.expectationOrigins.originOrderId = minimock.CallerInfo(1)
+
+	return mmGetOpenReservations
+}
+
+// Inspect accepts an inspector function that has same arguments as the StockRepository.GetOpenReservations
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) Inspect(f func(ctx context.Context, orderId int64)) *mStockRepositoryMockGetOpenReservations {
+	if mmGetOpenReservations.mock.inspectFuncGetOpenReservations != nil {
+		mmGetOpenReservations.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.GetOpenReservations")
+	}
+
+	mmGetOpenReservations.mock.inspectFuncGetOpenReservations = f
+
+	return mmGetOpenReservations
+}
+
+// Return sets up results that will be returned by StockRepository.GetOpenReservations
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) Return(oa1 []model.OrderItem, err error) *StockRepositoryMock {
+	if mmGetOpenReservations.mock.funcGetOpenReservations != nil {
+		mmGetOpenReservations.mock.t.Fatalf("StockRepositoryMock.GetOpenReservations mock is already set by Set")
+	}
+
+	if mmGetOpenReservations.defaultExpectation == nil {
+		mmGetOpenReservations.defaultExpectation = &StockRepositoryMockGetOpenReservationsExpectation{mock: mmGetOpenReservations.mock}
+	}
+	mmGetOpenReservations.defaultExpectation.results = &StockRepositoryMockGetOpenReservationsResults{oa1, err}
+	mmGetOpenReservations.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetOpenReservations.mock
+}
+
+// Set uses given function f to mock the StockRepository.GetOpenReservations method
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) Set(f func(ctx context.Context, orderId int64) (oa1 []model.OrderItem, err error)) *StockRepositoryMock {
+	if mmGetOpenReservations.defaultExpectation != nil {
+		mmGetOpenReservations.mock.t.Fatalf("Default expectation is already set for the StockRepository.GetOpenReservations method")
+	}
+
+	if len(mmGetOpenReservations.expectations) > 0 {
+		mmGetOpenReservations.mock.t.Fatalf("Some expectations are already set for the StockRepository.GetOpenReservations method")
+	}
+
+	mmGetOpenReservations.mock.funcGetOpenReservations = f
+	mmGetOpenReservations.mock.funcGetOpenReservationsOrigin = minimock.CallerInfo(1)
+	return mmGetOpenReservations.mock
+}
+
+// When sets expectation for the StockRepository.GetOpenReservations which will trigger the result defined by the following
+// Then helper
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) When(ctx context.Context, orderId int64) *StockRepositoryMockGetOpenReservationsExpectation {
+	if mmGetOpenReservations.mock.funcGetOpenReservations != nil {
+		mmGetOpenReservations.mock.t.Fatalf("StockRepositoryMock.GetOpenReservations mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockGetOpenReservationsExpectation{
+		mock:               mmGetOpenReservations.mock,
+		params:             &StockRepositoryMockGetOpenReservationsParams{ctx, orderId},
+		expectationOrigins: StockRepositoryMockGetOpenReservationsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetOpenReservations.expectations = append(mmGetOpenReservations.expectations, expectation)
+	return expectation
+}
+
+// Then sets up StockRepository.GetOpenReservations return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockGetOpenReservationsExpectation) Then(oa1 []model.OrderItem, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockGetOpenReservationsResults{oa1, err}
+	return e.mock
+}
+
+// Times sets number of times StockRepository.GetOpenReservations should be invoked
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) Times(n uint64) *mStockRepositoryMockGetOpenReservations {
+	if n == 0 {
+		mmGetOpenReservations.mock.t.Fatalf("Times of StockRepositoryMock.GetOpenReservations mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetOpenReservations.expectedInvocations, n)
+	mmGetOpenReservations.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetOpenReservations
+}
+
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) invocationsDone() bool {
+	if len(mmGetOpenReservations.expectations) == 0 && mmGetOpenReservations.defaultExpectation == nil && mmGetOpenReservations.mock.funcGetOpenReservations == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetOpenReservations.mock.afterGetOpenReservationsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetOpenReservations.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetOpenReservations implements StockRepository
+func (mmGetOpenReservations *StockRepositoryMock) GetOpenReservations(ctx context.Context, orderId int64) (oa1 []model.OrderItem, err error) {
+	mm_atomic.AddUint64(&mmGetOpenReservations.beforeGetOpenReservationsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetOpenReservations.afterGetOpenReservationsCounter, 1)
+
+	mmGetOpenReservations.t.Helper()
+
+	if mmGetOpenReservations.inspectFuncGetOpenReservations != nil {
+		mmGetOpenReservations.inspectFuncGetOpenReservations(ctx, orderId)
+	}
+
+	mm_params := StockRepositoryMockGetOpenReservationsParams{ctx, orderId}
+
+	// Record call args
+	mmGetOpenReservations.GetOpenReservationsMock.mutex.Lock()
+	mmGetOpenReservations.GetOpenReservationsMock.callArgs = append(mmGetOpenReservations.GetOpenReservationsMock.callArgs, &mm_params)
+	mmGetOpenReservations.GetOpenReservationsMock.mutex.Unlock()
+
+	for _, e := range mmGetOpenReservations.GetOpenReservationsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.oa1, e.results.err
+		}
+	}
+
+	if mmGetOpenReservations.GetOpenReservationsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetOpenReservations.GetOpenReservationsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetOpenReservations.GetOpenReservationsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetOpenReservations.GetOpenReservationsMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockGetOpenReservationsParams{ctx, orderId}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetOpenReservations.t.Errorf("StockRepositoryMock.GetOpenReservations got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetOpenReservations.GetOpenReservationsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.orderId != nil && !minimock.Equal(*mm_want_ptrs.orderId, mm_got.orderId) {
+				mmGetOpenReservations.t.Errorf("StockRepositoryMock.GetOpenReservations got unexpected parameter orderId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetOpenReservations.GetOpenReservationsMock.defaultExpectation.expectationOrigins.originOrderId, *mm_want_ptrs.orderId, mm_got.orderId, minimock.Diff(*mm_want_ptrs.orderId, mm_got.orderId))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetOpenReservations.t.Errorf("StockRepositoryMock.GetOpenReservations got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetOpenReservations.GetOpenReservationsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetOpenReservations.GetOpenReservationsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetOpenReservations.t.Fatal("No results are set for the StockRepositoryMock.GetOpenReservations")
+		}
+		return (*mm_results).oa1, (*mm_results).err
+	}
+	if mmGetOpenReservations.funcGetOpenReservations != nil {
+		return mmGetOpenReservations.funcGetOpenReservations(ctx, orderId)
+	}
+	mmGetOpenReservations.t.Fatalf("Unexpected call to StockRepositoryMock.GetOpenReservations. %v %v", ctx, orderId)
+	return
+}
+
+// GetOpenReservationsAfterCounter returns a count of finished StockRepositoryMock.GetOpenReservations invocations
+func (mmGetOpenReservations *StockRepositoryMock) GetOpenReservationsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetOpenReservations.afterGetOpenReservationsCounter)
+}
+
+// GetOpenReservationsBeforeCounter returns a count of StockRepositoryMock.GetOpenReservations invocations
+func (mmGetOpenReservations *StockRepositoryMock) GetOpenReservationsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetOpenReservations.beforeGetOpenReservationsCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.GetOpenReservations.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetOpenReservations *mStockRepositoryMockGetOpenReservations) Calls() []*StockRepositoryMockGetOpenReservationsParams {
+	mmGetOpenReservations.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockGetOpenReservationsParams, len(mmGetOpenReservations.callArgs))
+	copy(argCopy, mmGetOpenReservations.callArgs)
+
+	mmGetOpenReservations.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetOpenReservationsDone returns true if the count of the GetOpenReservations invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockGetOpenReservationsDone() bool {
+	if m.GetOpenReservationsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetOpenReservationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetOpenReservationsMock.invocationsDone()
+}
+
+// MinimockGetOpenReservationsInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockGetOpenReservationsInspect() {
+	for _, e := range m.GetOpenReservationsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetOpenReservations at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetOpenReservationsCounter := mm_atomic.LoadUint64(&m.afterGetOpenReservationsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetOpenReservationsMock.defaultExpectation != nil && afterGetOpenReservationsCounter < 1 {
+		if m.GetOpenReservationsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetOpenReservations at\n%s", m.GetOpenReservationsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetOpenReservations at\n%s with params: %#v", m.GetOpenReservationsMock.defaultExpectation.expectationOrigins.origin, *m.GetOpenReservationsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetOpenReservations != nil && afterGetOpenReservationsCounter < 1 {
+		m.t.Errorf("Expected call to StockRepositoryMock.GetOpenReservations at\n%s", m.funcGetOpenReservationsOrigin)
+	}
+
+	if !m.GetOpenReservationsMock.invocationsDone() && afterGetOpenReservationsCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.GetOpenReservations at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetOpenReservationsMock.expectedInvocations), m.GetOpenReservationsMock.expectedInvocationsOrigin, afterGetOpenReservationsCounter)
+	}
+}
+
 type mStockRepositoryMockRemoveReserved struct {
 	optional           bool
 	mock               *StockRepositoryMock
@@ -431,14 +815,16 @@ type StockRepositoryMockRemoveReservedExpectation struct {
 
 // StockRepositoryMockRemoveReservedParams contains parameters of the StockRepository.RemoveReserved
 type StockRepositoryMockRemoveReservedParams struct {
-	ctx   context.Context
-	items []model.OrderItem
+	ctx     context.Context
+	orderId int64
+	items   []model.OrderItem
 }
 
 // StockRepositoryMockRemoveReservedParamPtrs contains pointers to parameters of the StockRepository.RemoveReserved
 type StockRepositoryMockRemoveReservedParamPtrs struct {
-	ctx   *context.Context
-	items *[]model.OrderItem
+	ctx     *context.Context
+	orderId *int64
+	items   *[]model.OrderItem
 }
 
 // StockRepositoryMockRemoveReservedResults contains results of the StockRepository.RemoveReserved
@@ -448,9 +834,10 @@ type StockRepositoryMockRemoveReservedResults struct {
 
 // StockRepositoryMockRemoveReservedOrigins contains origins of expectations of the StockRepository.RemoveReserved
 type StockRepositoryMockRemoveReservedExpectationOrigins struct {
-	origin      string
-	originCtx   string
-	originItems string
+	origin        string
+	originCtx     string
+	originOrderId string
+	originItems   string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -464,7 +851,7 @@ func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Optional() *mStockRe
 }
 
 // Expect sets up expected params for StockRepository.RemoveReserved
-func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Expect(ctx context.Context, items []model.OrderItem) *mStockRepositoryMockRemoveReserved {
+func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Expect(ctx context.Context, orderId int64, items []model.OrderItem) *mStockRepositoryMockRemoveReserved {
 	if mmRemoveReserved.mock.funcRemoveReserved != nil {
 		mmRemoveReserved.mock.t.Fatalf("StockRepositoryMock.RemoveReserved mock is already set by Set")
 	}
@@ -477,7 +864,7 @@ func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Expect(ctx context.C
 		mmRemoveReserved.mock.t.Fatalf("StockRepositoryMock.RemoveReserved mock is already set by ExpectParams functions")
 	}
 
-	mmRemoveReserved.defaultExpectation.params = &StockRepositoryMockRemoveReservedParams{ctx, items}
+	mmRemoveReserved.defaultExpectation.params = &StockRepositoryMockRemoveReservedParams{ctx, orderId, items}
 	mmRemoveReserved.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmRemoveReserved.expectations {
 		if minimock.Equal(e.params, mmRemoveReserved.defaultExpectation.params) {
@@ -511,8 +898,31 @@ func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) ExpectCtxParam1(ctx
 	return mmRemoveReserved
 }
 
-// ExpectItemsParam2 sets up expected param items for StockRepository.RemoveReserved
-func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) ExpectItemsParam2(items []model.OrderItem) *mStockRepositoryMockRemoveReserved {
+// ExpectOrderIdParam2 sets up expected param orderId for StockRepository.RemoveReserved
+func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) ExpectOrderIdParam2(orderId int64) *mStockRepositoryMockRemoveReserved {
+	if mmRemoveReserved.mock.funcRemoveReserved != nil {
+		mmRemoveReserved.mock.t.Fatalf("StockRepositoryMock.RemoveReserved mock is already set by Set")
+	}
+
+	if mmRemoveReserved.defaultExpectation == nil {
+		mmRemoveReserved.defaultExpectation = &StockRepositoryMockRemoveReservedExpectation{}
+	}
+
+	if mmRemoveReserved.defaultExpectation.params != nil {
+		mmRemoveReserved.mock.t.Fatalf("StockRepositoryMock.RemoveReserved mock is already set by Expect")
+	}
+
+	if mmRemoveReserved.defaultExpectation.paramPtrs == nil {
+		mmRemoveReserved.defaultExpectation.paramPtrs = &StockRepositoryMockRemoveReservedParamPtrs{}
+	}
+	mmRemoveReserved.defaultExpectation.paramPtrs.orderId = &orderId
+	mmRemoveReserved.defaultExpectation.expectationOrigins.originOrderId = minimock.CallerInfo(1)
+
+	return mmRemoveReserved
+}
+
+// ExpectItemsParam3 sets up expected param items for StockRepository.RemoveReserved
+func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) ExpectItemsParam3(items []model.OrderItem) *mStockRepositoryMockRemoveReserved {
 	if mmRemoveReserved.mock.funcRemoveReserved != nil {
 		mmRemoveReserved.mock.t.Fatalf("StockRepositoryMock.RemoveReserved mock is already set by Set")
 	}
@@ -535,7 +945,7 @@ func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) ExpectItemsParam2(it
 }
 
 // Inspect accepts an inspector function that has same arguments as the StockRepository.RemoveReserved
-func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Inspect(f func(ctx context.Context, items []model.OrderItem)) *mStockRepositoryMockRemoveReserved {
+func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Inspect(f func(ctx context.Context, orderId int64, items []model.OrderItem)) *mStockRepositoryMockRemoveReserved {
 	if mmRemoveReserved.mock.inspectFuncRemoveReserved != nil {
 		mmRemoveReserved.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.RemoveReserved")
 	}
@@ -560,7 +970,7 @@ func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Return(err error) *S
 }
 
 // Set uses given function f to mock the StockRepository.RemoveReserved method
-func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Set(f func(ctx context.Context, items []model.OrderItem) (err error)) *StockRepositoryMock {
+func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Set(f func(ctx context.Context, orderId int64, items []model.OrderItem) (err error)) *StockRepositoryMock {
 	if mmRemoveReserved.defaultExpectation != nil {
 		mmRemoveReserved.mock.t.Fatalf("Default expectation is already set for the StockRepository.RemoveReserved method")
 	}
@@ -576,14 +986,14 @@ func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) Set(f func(ctx conte
 
 // When sets expectation for the StockRepository.RemoveReserved which will trigger the result defined by the following
 // Then helper
-func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) When(ctx context.Context, items []model.OrderItem) *StockRepositoryMockRemoveReservedExpectation {
+func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) When(ctx context.Context, orderId int64, items []model.OrderItem) *StockRepositoryMockRemoveReservedExpectation {
 	if mmRemoveReserved.mock.funcRemoveReserved != nil {
 		mmRemoveReserved.mock.t.Fatalf("StockRepositoryMock.RemoveReserved mock is already set by Set")
 	}
 
 	expectation := &StockRepositoryMockRemoveReservedExpectation{
 		mock:               mmRemoveReserved.mock,
-		params:             &StockRepositoryMockRemoveReservedParams{ctx, items},
+		params:             &StockRepositoryMockRemoveReservedParams{ctx, orderId, items},
 		expectationOrigins: StockRepositoryMockRemoveReservedExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmRemoveReserved.expectations = append(mmRemoveReserved.expectations, expectation)
@@ -618,17 +1028,17 @@ func (mmRemoveReserved *mStockRepositoryMockRemoveReserved) invocationsDone() bo
 }
 
 // RemoveReserved implements StockRepository
-func (mmRemoveReserved *StockRepositoryMock) RemoveReserved(ctx context.Context, items []model.OrderItem) (err error) {
+func (mmRemoveReserved *StockRepositoryMock) RemoveReserved(ctx context.Context, orderId int64, items []model.OrderItem) (err error) {
 	mm_atomic.AddUint64(&mmRemoveReserved.beforeRemoveReservedCounter, 1)
 	defer mm_atomic.AddUint64(&mmRemoveReserved.afterRemoveReservedCounter, 1)
 
 	mmRemoveReserved.t.Helper()
 
 	if mmRemoveReserved.inspectFuncRemoveReserved != nil {
-		mmRemoveReserved.inspectFuncRemoveReserved(ctx, items)
+		mmRemoveReserved.inspectFuncRemoveReserved(ctx, orderId, items)
 	}
 
-	mm_params := StockRepositoryMockRemoveReservedParams{ctx, items}
+	mm_params := StockRepositoryMockRemoveReservedParams{ctx, orderId, items}
 
 	// Record call args
 	mmRemoveReserved.RemoveReservedMock.mutex.Lock()
@@ -647,7 +1057,7 @@ func (mmRemoveReserved *StockRepositoryMock) RemoveReserved(ctx context.Context,
 		mm_want := mmRemoveReserved.RemoveReservedMock.defaultExpectation.params
 		mm_want_ptrs := mmRemoveReserved.RemoveReservedMock.defaultExpectation.paramPtrs
 
-		mm_got := StockRepositoryMockRemoveReservedParams{ctx, items}
+		mm_got := StockRepositoryMockRemoveReservedParams{ctx, orderId, items}
 
 		if mm_want_ptrs != nil {
 
@@ -656,6 +1066,11 @@ func (mmRemoveReserved *StockRepositoryMock) RemoveReserved(ctx context.Context,
 					mmRemoveReserved.RemoveReservedMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
+			if mm_want_ptrs.orderId != nil && !minimock.Equal(*mm_want_ptrs.orderId, mm_got.orderId) {
+				mmRemoveReserved.t.Errorf("StockRepositoryMock.RemoveReserved got unexpected parameter orderId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmRemoveReserved.RemoveReservedMock.defaultExpectation.expectationOrigins.originOrderId, *mm_want_ptrs.orderId, mm_got.orderId, minimock.Diff(*mm_want_ptrs.orderId, mm_got.orderId))
+			}
+
 			if mm_want_ptrs.items != nil && !minimock.Equal(*mm_want_ptrs.items, mm_got.items) {
 				mmRemoveReserved.t.Errorf("StockRepositoryMock.RemoveReserved got unexpected parameter items, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 					mmRemoveReserved.RemoveReservedMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
@@ -673,9 +1088,9 @@ func (mmRemoveReserved *StockRepositoryMock) RemoveReserved(ctx context.Context,
 		return (*mm_results).err
 	}
 	if mmRemoveReserved.funcRemoveReserved != nil {
-		return mmRemoveReserved.funcRemoveReserved(ctx, items)
+		return mmRemoveReserved.funcRemoveReserved(ctx, orderId, items)
 	}
-	mmRemoveReserved.t.Fatalf("Unexpected call to StockRepositoryMock.RemoveReserved. %v %v", ctx, items)
+	mmRemoveReserved.t.Fatalf("Unexpected call to StockRepositoryMock.RemoveReserved. %v %v %v", ctx, orderId, items)
 	return
 }
 
@@ -773,14 +1188,16 @@ type StockRepositoryMockReserveExpectation struct {
 
 // StockRepositoryMockReserveParams contains parameters of the StockRepository.Reserve
 type StockRepositoryMockReserveParams struct {
-	ctx   context.Context
-	items []model.OrderItem
+	ctx     context.Context
+	orderId int64
+	items   []model.OrderItem
 }
 
 // StockRepositoryMockReserveParamPtrs contains pointers to parameters of the StockRepository.Reserve
 type StockRepositoryMockReserveParamPtrs struct {
-	ctx   *context.Context
-	items *[]model.OrderItem
+	ctx     *context.Context
+	orderId *int64
+	items   *[]model.OrderItem
 }
 
 // StockRepositoryMockReserveResults contains results of the StockRepository.Reserve
@@ -790,9 +1207,10 @@ type StockRepositoryMockReserveResults struct {
 
 // StockRepositoryMockReserveOrigins contains origins of expectations of the StockRepository.Reserve
 type StockRepositoryMockReserveExpectationOrigins struct {
-	origin      string
-	originCtx   string
-	originItems string
+	origin        string
+	originCtx     string
+	originOrderId string
+	originItems   string
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
@@ -806,7 +1224,7 @@ func (mmReserve *mStockRepositoryMockReserve) Optional() *mStockRepositoryMockRe
 }
 
 // Expect sets up expected params for StockRepository.Reserve
-func (mmReserve *mStockRepositoryMockReserve) Expect(ctx context.Context, items []model.OrderItem) *mStockRepositoryMockReserve {
+func (mmReserve *mStockRepositoryMockReserve) Expect(ctx context.Context, orderId int64, items []model.OrderItem) *mStockRepositoryMockReserve {
 	if mmReserve.mock.funcReserve != nil {
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
 	}
@@ -819,7 +1237,7 @@ func (mmReserve *mStockRepositoryMockReserve) Expect(ctx context.Context, items
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by ExpectParams functions")
 	}
 
-	mmReserve.defaultExpectation.params = &StockRepositoryMockReserveParams{ctx, items}
+	mmReserve.defaultExpectation.params = &StockRepositoryMockReserveParams{ctx, orderId, items}
 	mmReserve.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
 	for _, e := range mmReserve.expectations {
 		if minimock.Equal(e.params, mmReserve.defaultExpectation.params) {
@@ -853,8 +1271,31 @@ func (mmReserve *mStockRepositoryMockReserve) ExpectCtxParam1(ctx context.Contex
 	return mmReserve
 }
 
-// ExpectItemsParam2 sets up expected param items for StockRepository.Reserve
-func (mmReserve *mStockRepositoryMockReserve) ExpectItemsParam2(items []model.OrderItem) *mStockRepositoryMockReserve {
+// ExpectOrderIdParam2 sets up expected param orderId for StockRepository.Reserve
+func (mmReserve *mStockRepositoryMockReserve) ExpectOrderIdParam2(orderId int64) *mStockRepositoryMockReserve {
+	if mmReserve.mock.funcReserve != nil {
+		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
+	}
+
+	if mmReserve.defaultExpectation == nil {
+		mmReserve.defaultExpectation = &StockRepositoryMockReserveExpectation{}
+	}
+
+	if mmReserve.defaultExpectation.params != nil {
+		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Expect")
+	}
+
+	if mmReserve.defaultExpectation.paramPtrs == nil {
+		mmReserve.defaultExpectation.paramPtrs = &StockRepositoryMockReserveParamPtrs{}
+	}
+	mmReserve.defaultExpectation.paramPtrs.orderId = &orderId
+	mmReserve.defaultExpectation.expectationOrigins.originOrderId = minimock.CallerInfo(1)
+
+	return mmReserve
+}
+
+// ExpectItemsParam3 sets up expected param items for StockRepository.Reserve
+func (mmReserve *mStockRepositoryMockReserve) ExpectItemsParam3(items []model.OrderItem) *mStockRepositoryMockReserve {
 	if mmReserve.mock.funcReserve != nil {
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
 	}
@@ -877,7 +1318,7 @@ func (mmReserve *mStockRepositoryMockReserve) ExpectItemsParam2(items []model.Or
 }
 
 // Inspect accepts an inspector function that has same arguments as the StockRepository.Reserve
-func (mmReserve *mStockRepositoryMockReserve) Inspect(f func(ctx context.Context, items []model.OrderItem)) *mStockRepositoryMockReserve {
+func (mmReserve *mStockRepositoryMockReserve) Inspect(f func(ctx context.Context, orderId int64, items []model.OrderItem)) *mStockRepositoryMockReserve {
 	if mmReserve.mock.inspectFuncReserve != nil {
 		mmReserve.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.Reserve")
 	}
@@ -902,7 +1343,7 @@ func (mmReserve *mStockRepositoryMockReserve) Return(err error) *StockRepository
 }
 
 // Set uses given function f to mock the StockRepository.Reserve method
-func (mmReserve *mStockRepositoryMockReserve) Set(f func(ctx context.Context, items []model.OrderItem) (err error)) *StockRepositoryMock {
+func (mmReserve *mStockRepositoryMockReserve) Set(f func(ctx context.Context, orderId int64, items []model.OrderItem) (err error)) *StockRepositoryMock {
 	if mmReserve.defaultExpectation != nil {
 		mmReserve.mock.t.Fatalf("Default expectation is already set for the StockRepository.Reserve method")
 	}
@@ -918,14 +1359,14 @@ func (mmReserve *mStockRepositoryMockReserve) Set(f func(ctx context.Context, it
 
 // When sets expectation for the StockRepository.Reserve which will trigger the result defined by the following
 // Then helper
-func (mmReserve *mStockRepositoryMockReserve) When(ctx context.Context, items []model.OrderItem) *StockRepositoryMockReserveExpectation {
+func (mmReserve *mStockRepositoryMockReserve) When(ctx context.Context, orderId int64, items []model.OrderItem) *StockRepositoryMockReserveExpectation {
 	if mmReserve.mock.funcReserve != nil {
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
 	}
 
 	expectation := &StockRepositoryMockReserveExpectation{
 		mock:               mmReserve.mock,
-		params:             &StockRepositoryMockReserveParams{ctx, items},
+		params:             &StockRepositoryMockReserveParams{ctx, orderId, items},
 		expectationOrigins: StockRepositoryMockReserveExpectationOrigins{origin: minimock.CallerInfo(1)},
 	}
 	mmReserve.expectations = append(mmReserve.expectations, expectation)
@@ -960,17 +1401,17 @@ func (mmReserve *mStockRepositoryMockReserve) invocationsDone() bool {
 }
 
 // Reserve implements StockRepository
-func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, items []model.OrderItem) (err error) {
+func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, orderId int64, items []model.OrderItem) (err error) {
 	mm_atomic.AddUint64(&mmReserve.beforeReserveCounter, 1)
 	defer mm_atomic.AddUint64(&mmReserve.afterReserveCounter, 1)
 
 	mmReserve.t.Helper()
 
 	if mmReserve.inspectFuncReserve != nil {
-		mmReserve.inspectFuncReserve(ctx, items)
+		mmReserve.inspectFuncReserve(ctx, orderId, items)
 	}
 
-	mm_params := StockRepositoryMockReserveParams{ctx, items}
+	mm_params := StockRepositoryMockReserveParams{ctx, orderId, items}
 
 	// Record call args
 	mmReserve.ReserveMock.mutex.Lock()
@@ -989,7 +1430,7 @@ func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, items []model
 		mm_want := mmReserve.ReserveMock.defaultExpectation.params
 		mm_want_ptrs := mmReserve.ReserveMock.defaultExpectation.paramPtrs
 
-		mm_got := StockRepositoryMockReserveParams{ctx, items}
+		mm_got := StockRepositoryMockReserveParams{ctx, orderId, items}
 
 		if mm_want_ptrs != nil {
 
@@ -998,6 +1439,11 @@ func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, items []model
 					mmReserve.ReserveMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
+			if mm_want_ptrs.orderId != nil && !minimock.Equal(*mm_want_ptrs.orderId, mm_got.orderId) {
+				mmReserve.t.Errorf("StockRepositoryMock.Reserve got unexpected parameter orderId, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReserve.ReserveMock.defaultExpectation.expectationOrigins.originOrderId, *mm_want_ptrs.orderId, mm_got.orderId, minimock.Diff(*mm_want_ptrs.orderId, mm_got.orderId))
+			}
+
 			if mm_want_ptrs.items != nil && !minimock.Equal(*mm_want_ptrs.items, mm_got.items) {
 				mmReserve.t.Errorf("StockRepositoryMock.Reserve got unexpected parameter items, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
 					mmReserve.ReserveMock.defaultExpectation.expectationOrigins.originItems, *mm_want_ptrs.items, mm_got.items, minimock.Diff(*mm_want_ptrs.items, mm_got.items))
@@ -1015,9 +1461,9 @@ func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, items []model
 		return (*mm_results).err
 	}
 	if mmReserve.funcReserve != nil {
-		return mmReserve.funcReserve(ctx, items)
+		return mmReserve.funcReserve(ctx, orderId, items)
 	}
-	mmReserve.t.Fatalf("Unexpected call to StockRepositoryMock.Reserve. %v %v", ctx, items)
+	mmReserve.t.Fatalf("Unexpected call to StockRepositoryMock.Reserve. %v %v %v", ctx, orderId, items)
 	return
 }
 
@@ -1095,6 +1541,8 @@ func (m *StockRepositoryMock) MinimockFinish() {
 		if !m.minimockDone() {
 			m.MinimockCancelReservedInspect()
 
+			m.MinimockGetOpenReservationsInspect()
+
 			m.MinimockRemoveReservedInspect()
 
 			m.MinimockReserveInspect()
@@ -1122,6 +1570,7 @@ func (m *StockRepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockCancelReservedDone() &&
+		m.MinimockGetOpenReservationsDone() &&
 		m.MinimockRemoveReservedDone() &&
 		m.MinimockReserveDone()
 }
@@ -0,0 +1,416 @@
+// Code generated by http://github.com/gojuno/minimock (v3.4.5). DO NOT EDIT.
+
+package order_service_test
+
+//go:generate minimock -i route256/loms/internal/domain/order/order_service.Transactor -o transactor_mock_test.go -n TransactorMock -p order_service_test
+
+import (
+	"context"
+	"sync"
+	mm_atomic "sync/atomic"
+	mm_time "time"
+
+	"github.com/gojuno/minimock/v3"
+)
+
+// TransactorMock implements Transactor
+type TransactorMock struct {
+	t          minimock.Tester
+	finishOnce sync.Once
+
+	funcWithinTx          func(ctx context.Context, txFunc func(ctx context.Context) error) (err error)
+	funcWithinTxOrigin    string
+	inspectFuncWithinTx   func(ctx context.Context, txFunc func(ctx context.Context) error)
+	afterWithinTxCounter  uint64
+	beforeWithinTxCounter uint64
+	WithinTxMock          mTransactorMockWithinTx
+}
+
+// NewTransactorMock returns a mock for Transactor
+func NewTransactorMock(t minimock.Tester) *TransactorMock {
+	m := &TransactorMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.WithinTxMock = mTransactorMockWithinTx{mock: m}
+	m.WithinTxMock.callArgs = []*TransactorMockWithinTxParams{}
+
+	t.Cleanup(m.MinimockFinish)
+
+	return m
+}
+
+type mTransactorMockWithinTx struct {
+	optional           bool
+	mock               *TransactorMock
+	defaultExpectation *TransactorMockWithinTxExpectation
+	expectations       []*TransactorMockWithinTxExpectation
+
+	callArgs []*TransactorMockWithinTxParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// TransactorMockWithinTxExpectation specifies expectation struct of the Transactor.WithinTx
+type TransactorMockWithinTxExpectation struct {
+	mock               *TransactorMock
+	params             *TransactorMockWithinTxParams
+	paramPtrs          *TransactorMockWithinTxParamPtrs
+	expectationOrigins TransactorMockWithinTxExpectationOrigins
+	results            *TransactorMockWithinTxResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// TransactorMockWithinTxParams contains parameters of the Transactor.WithinTx
+type TransactorMockWithinTxParams struct {
+	ctx    context.Context
+	txFunc func(ctx context.Context) error
+}
+
+// TransactorMockWithinTxParamPtrs contains pointers to parameters of the Transactor.WithinTx
+type TransactorMockWithinTxParamPtrs struct {
+	ctx    *context.Context
+	txFunc *func(ctx context.Context) error
+}
+
+// TransactorMockWithinTxResults contains results of the Transactor.WithinTx
+type TransactorMockWithinTxResults struct {
+	err error
+}
+
+// TransactorMockWithinTxOrigins contains origins of expectations of the Transactor.WithinTx
+type TransactorMockWithinTxExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originTxFunc string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmWithinTx *mTransactorMockWithinTx) Optional() *mTransactorMockWithinTx {
+	mmWithinTx.optional = true
+	return mmWithinTx
+}
+
+// Expect sets up expected params for Transactor.WithinTx
+func (mmWithinTx *mTransactorMockWithinTx) Expect(ctx context.Context, txFunc func(ctx context.Context) error) *mTransactorMockWithinTx {
+	if mmWithinTx.mock.funcWithinTx != nil {
+		mmWithinTx.mock.t.Fatalf("TransactorMock.WithinTx mock is already set by Set")
+	}
+
+	if mmWithinTx.defaultExpectation == nil {
+		mmWithinTx.defaultExpectation = &TransactorMockWithinTxExpectation{}
+	}
+
+	if mmWithinTx.defaultExpectation.paramPtrs != nil {
+		mmWithinTx.mock.t.Fatalf("TransactorMock.WithinTx mock is already set by ExpectParams functions")
+	}
+
+	mmWithinTx.defaultExpectation.params = &TransactorMockWithinTxParams{ctx, txFunc}
+	mmWithinTx.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmWithinTx.expectations {
+		if minimock.Equal(e.params, mmWithinTx.defaultExpectation.params) {
+			mmWithinTx.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmWithinTx.defaultExpectation.params)
+		}
+	}
+
+	return mmWithinTx
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Transactor.WithinTx
+func (mmWithinTx *mTransactorMockWithinTx) ExpectCtxParam1(ctx context.Context) *mTransactorMockWithinTx {
+	if mmWithinTx.mock.funcWithinTx != nil {
+		mmWithinTx.mock.t.Fatalf("TransactorMock.WithinTx mock is already set by Set")
+	}
+
+	if mmWithinTx.defaultExpectation == nil {
+		mmWithinTx.defaultExpectation = &TransactorMockWithinTxExpectation{}
+	}
+
+	if mmWithinTx.defaultExpectation.params != nil {
+		mmWithinTx.mock.t.Fatalf("TransactorMock.WithinTx mock is already set by Expect")
+	}
+
+	if mmWithinTx.defaultExpectation.paramPtrs == nil {
+		mmWithinTx.defaultExpectation.paramPtrs = &TransactorMockWithinTxParamPtrs{}
+	}
+	mmWithinTx.defaultExpectation.paramPtrs.ctx = &ctx
+	mmWithinTx.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmWithinTx
+}
+
+// ExpectTxFuncParam2 sets up expected param txFunc for Transactor.WithinTx
+func (mmWithinTx *mTransactorMockWithinTx) ExpectTxFuncParam2(txFunc func(ctx context.Context) error) *mTransactorMockWithinTx {
+	if mmWithinTx.mock.funcWithinTx != nil {
+		mmWithinTx.mock.t.Fatalf("TransactorMock.WithinTx mock is already set by Set")
+	}
+
+	if mmWithinTx.defaultExpectation == nil {
+		mmWithinTx.defaultExpectation = &TransactorMockWithinTxExpectation{}
+	}
+
+	if mmWithinTx.defaultExpectation.params != nil {
+		mmWithinTx.mock.t.Fatalf("TransactorMock.WithinTx mock is already set by Expect")
+	}
+
+	if mmWithinTx.defaultExpectation.paramPtrs == nil {
+		mmWithinTx.defaultExpectation.paramPtrs = &TransactorMockWithinTxParamPtrs{}
+	}
+	mmWithinTx.defaultExpectation.paramPtrs.txFunc = &txFunc
+	mmWithinTx.defaultExpectation.expectationOrigins.originTxFunc = minimock.CallerInfo(1)
+
+	return mmWithinTx
+}
+
+// Inspect accepts an inspector function that has same arguments as the Transactor.WithinTx
+func (mmWithinTx *mTransactorMockWithinTx) Inspect(f func(ctx context.Context, txFunc func(ctx context.Context) error)) *mTransactorMockWithinTx {
+	if mmWithinTx.mock.inspectFuncWithinTx != nil {
+		mmWithinTx.mock.t.Fatalf("Inspect function is already set for TransactorMock.WithinTx")
+	}
+
+	mmWithinTx.mock.inspectFuncWithinTx = f
+
+	return mmWithinTx
+}
+
+// Return sets up results that will be returned by Transactor.WithinTx
+func (mmWithinTx *mTransactorMockWithinTx) Return(err error) *TransactorMock {
+	if mmWithinTx.mock.funcWithinTx != nil {
+		mmWithinTx.mock.t.Fatalf("TransactorMock.WithinTx mock is already set by Set")
+	}
+
+	if mmWithinTx.defaultExpectation == nil {
+		mmWithinTx.defaultExpectation = &TransactorMockWithinTxExpectation{mock: mmWithinTx.mock}
+	}
+	mmWithinTx.defaultExpectation.results = &TransactorMockWithinTxResults{err}
+	mmWithinTx.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmWithinTx.mock
+}
+
+// Set uses given function f to mock the Transactor.WithinTx method
+func (mmWithinTx *mTransactorMockWithinTx) Set(f func(ctx context.Context, txFunc func(ctx context.Context) error) (err error)) *TransactorMock {
+	if mmWithinTx.defaultExpectation != nil {
+		mmWithinTx.mock.t.Fatalf("Default expectation is already set for the Transactor.WithinTx method")
+	}
+
+	if len(mmWithinTx.expectations) > 0 {
+		mmWithinTx.mock.t.Fatalf("Some expectations are already set for the Transactor.WithinTx method")
+	}
+
+	mmWithinTx.mock.funcWithinTx = f
+	mmWithinTx.mock.funcWithinTxOrigin = minimock.CallerInfo(1)
+	return mmWithinTx.mock
+}
+
+// When sets expectation for the Transactor.WithinTx which will trigger the result defined by the following
+// Then helper
+func (mmWithinTx *mTransactorMockWithinTx) When(ctx context.Context, txFunc func(ctx context.Context) error) *TransactorMockWithinTxExpectation {
+	if mmWithinTx.mock.funcWithinTx != nil {
+		mmWithinTx.mock.t.Fatalf("TransactorMock.WithinTx mock is already set by Set")
+	}
+
+	expectation := &TransactorMockWithinTxExpectation{
+		mock:               mmWithinTx.mock,
+		params:             &TransactorMockWithinTxParams{ctx, txFunc},
+		expectationOrigins: TransactorMockWithinTxExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmWithinTx.expectations = append(mmWithinTx.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Transactor.WithinTx return parameters for the expectation previously defined by the When method
+func (e *TransactorMockWithinTxExpectation) Then(err error) *TransactorMock {
+	e.results = &TransactorMockWithinTxResults{err}
+	return e.mock
+}
+
+// Times sets number of times Transactor.WithinTx should be invoked
+func (mmWithinTx *mTransactorMockWithinTx) Times(n uint64) *mTransactorMockWithinTx {
+	if n == 0 {
+		mmWithinTx.mock.t.Fatalf("Times of TransactorMock.WithinTx mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmWithinTx.expectedInvocations, n)
+	mmWithinTx.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmWithinTx
+}
+
+func (mmWithinTx *mTransactorMockWithinTx) invocationsDone() bool {
+	if len(mmWithinTx.expectations) == 0 && mmWithinTx.defaultExpectation == nil && mmWithinTx.mock.funcWithinTx == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmWithinTx.mock.afterWithinTxCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmWithinTx.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// WithinTx implements Transactor
+func (mmWithinTx *TransactorMock) WithinTx(ctx context.Context, txFunc func(ctx context.Context) error) (err error) {
+	mm_atomic.AddUint64(&mmWithinTx.beforeWithinTxCounter, 1)
+	defer mm_atomic.AddUint64(&mmWithinTx.afterWithinTxCounter, 1)
+
+	mmWithinTx.t.Helper()
+
+	if mmWithinTx.inspectFuncWithinTx != nil {
+		mmWithinTx.inspectFuncWithinTx(ctx, txFunc)
+	}
+
+	mm_params := TransactorMockWithinTxParams{ctx, txFunc}
+
+	// Record call args
+	mmWithinTx.WithinTxMock.mutex.Lock()
+	mmWithinTx.WithinTxMock.callArgs = append(mmWithinTx.WithinTxMock.callArgs, &mm_params)
+	mmWithinTx.WithinTxMock.mutex.Unlock()
+
+	for _, e := range mmWithinTx.WithinTxMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmWithinTx.WithinTxMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmWithinTx.WithinTxMock.defaultExpectation.Counter, 1)
+		mm_want := mmWithinTx.WithinTxMock.defaultExpectation.params
+		mm_want_ptrs := mmWithinTx.WithinTxMock.defaultExpectation.paramPtrs
+
+		mm_got := TransactorMockWithinTxParams{ctx, txFunc}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmWithinTx.t.Errorf("TransactorMock.WithinTx got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmWithinTx.WithinTxMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.txFunc != nil && !minimock.Equal(*mm_want_ptrs.txFunc, mm_got.txFunc) {
+				mmWithinTx.t.Errorf("TransactorMock.WithinTx got unexpected parameter txFunc, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmWithinTx.WithinTxMock.defaultExpectation.expectationOrigins.originTxFunc, *mm_want_ptrs.txFunc, mm_got.txFunc, minimock.Diff(*mm_want_ptrs.txFunc, mm_got.txFunc))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmWithinTx.t.Errorf("TransactorMock.WithinTx got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmWithinTx.WithinTxMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmWithinTx.WithinTxMock.defaultExpectation.results
+		if mm_results == nil {
+			mmWithinTx.t.Fatal("No results are set for the TransactorMock.WithinTx")
+		}
+		return (*mm_results).err
+	}
+	if mmWithinTx.funcWithinTx != nil {
+		return mmWithinTx.funcWithinTx(ctx, txFunc)
+	}
+	mmWithinTx.t.Fatalf("Unexpected call to TransactorMock.WithinTx. %v %v", ctx, txFunc)
+	return
+}
+
+// WithinTxAfterCounter returns a count of finished TransactorMock.WithinTx invocations
+func (mmWithinTx *TransactorMock) WithinTxAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmWithinTx.afterWithinTxCounter)
+}
+
+// WithinTxBeforeCounter returns a count of TransactorMock.WithinTx invocations
+func (mmWithinTx *TransactorMock) WithinTxBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmWithinTx.beforeWithinTxCounter)
+}
+
+// Calls returns a list of arguments used in each call to TransactorMock.WithinTx.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmWithinTx *mTransactorMockWithinTx) Calls() []*TransactorMockWithinTxParams {
+	mmWithinTx.mutex.RLock()
+
+	argCopy := make([]*TransactorMockWithinTxParams, len(mmWithinTx.callArgs))
+	copy(argCopy, mmWithinTx.callArgs)
+
+	mmWithinTx.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockWithinTxDone returns true if the count of the WithinTx invocations corresponds
+// the number of defined expectations
+func (m *TransactorMock) MinimockWithinTxDone() bool {
+	if m.WithinTxMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.WithinTxMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.WithinTxMock.invocationsDone()
+}
+
+// MinimockWithinTxInspect logs each unmet expectation
+func (m *TransactorMock) MinimockWithinTxInspect() {
+	for _, e := range m.WithinTxMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to TransactorMock.WithinTx at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterWithinTxCounter := mm_atomic.LoadUint64(&m.afterWithinTxCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.WithinTxMock.defaultExpectation != nil && afterWithinTxCounter < 1 {
+		if m.WithinTxMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to TransactorMock.WithinTx at\n%s", m.WithinTxMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to TransactorMock.WithinTx at\n%s with params: %#v", m.WithinTxMock.defaultExpectation.expectationOrigins.origin, *m.WithinTxMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcWithinTx != nil && afterWithinTxCounter < 1 {
+		m.t.Errorf("Expected call to TransactorMock.WithinTx at\n%s", m.funcWithinTxOrigin)
+	}
+
+	if !m.WithinTxMock.invocationsDone() && afterWithinTxCounter > 0 {
+		m.t.Errorf("Expected %d calls to TransactorMock.WithinTx at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.WithinTxMock.expectedInvocations), m.WithinTxMock.expectedInvocationsOrigin, afterWithinTxCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *TransactorMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockWithinTxInspect()
+		}
+	})
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *TransactorMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *TransactorMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockWithinTxDone()
+}
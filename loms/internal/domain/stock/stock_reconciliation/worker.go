@@ -0,0 +1,83 @@
+package stock_reconciliation
+
+import (
+	"context"
+	"route256/loms/internal/domain/model"
+	"route256/loms/internal/infra/logger"
+	"route256/loms/internal/infra/loms_config"
+	"sync"
+	"time"
+)
+
+type StockService interface {
+	ReconcileReserved(ctx context.Context, repair bool) ([]model.ReservedDrift, error)
+}
+
+// ReconciliationWorker periodically checks that the reserved stock counters match open reservations.
+type ReconciliationWorker struct {
+	cfg      loms_config.StockReconciliationConfig
+	service  StockService
+	stopChan chan struct{}
+	wg       *sync.WaitGroup
+}
+
+func NewReconciliationWorker(cfg *loms_config.Config, service StockService) *ReconciliationWorker {
+	worker := &ReconciliationWorker{
+		cfg:      cfg.StockReconciliation,
+		service:  service,
+		stopChan: make(chan struct{}),
+		wg:       &sync.WaitGroup{},
+	}
+
+	worker.run()
+
+	logger.Info("Stock reconciliation worker started", "repair", cfg.StockReconciliation.Repair)
+	return worker
+}
+
+func (w *ReconciliationWorker) Close() error {
+	logger.Info("Closing stock reconciliation worker...")
+
+	close(w.stopChan)
+	w.wg.Wait()
+
+	return nil
+}
+
+func (w *ReconciliationWorker) run() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+
+		ticker := time.NewTicker(w.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-w.stopChan:
+				return
+			case <-ticker.C:
+				w.reconcile(ctx)
+			}
+		}
+	}()
+
+	go func() {
+		<-w.stopChan
+		cancel()
+	}()
+}
+
+func (w *ReconciliationWorker) reconcile(ctx context.Context) {
+	drifts, err := w.service.ReconcileReserved(ctx, w.cfg.Repair)
+	for _, drift := range drifts {
+		logger.Warn("Stock reserved drift", "sku", drift.Sku, "reserved", drift.Reserved,
+			"expected", drift.Expected, "repaired", drift.Repaired)
+	}
+
+	if err != nil {
+		logger.Warn("Failed to reconcile reserved stocks", "error", err)
+	}
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"embed"
 	"encoding/json"
+	"errors"
+	"maps"
+	"math"
+	"slices"
 	"sync"
+	"time"
 
 	"route256/loms/internal/domain/model"
 	"route256/loms/internal/infra/logger"
@@ -16,6 +21,9 @@ var stockData embed.FS
 type StockRepository struct {
 	mtx    sync.RWMutex
 	Stocks map[int64]*model.StockModel
+	Ledger []model.StockLedgerEntry
+	// Reservations are kept in the order of creation
+	Reservations []model.StockReservation
 }
 
 func NewStockRepository() *StockRepository {
@@ -30,18 +38,32 @@ func NewStockRepository() *StockRepository {
 	}
 
 	stockMap := make(map[int64]*model.StockModel)
+	var reservations []model.StockReservation
 	for _, stock := range stocks {
 		stockMap[stock.Sku] = &stock
+		if stock.Reserved > 0 {
+			reservations = append(reservations, model.StockReservation{
+				Sku:   stock.Sku,
+				Count: stock.Reserved,
+				State: model.ReservationStateReserved,
+			})
+		}
 	}
 
-	return &StockRepository{Stocks: stockMap}
+	// the seeded reserved counters are held by no order, they are the opening balance of the reservations
+	return &StockRepository{Stocks: stockMap, Reservations: reservations}
 }
 
 // RemoveReserved implements order_service.StockRepository.
-func (o *StockRepository) RemoveReserved(_ context.Context, items []model.OrderItem) error {
+func (o *StockRepository) RemoveReserved(_ context.Context, orderId int64, items []model.OrderItem) error {
 	o.mtx.Lock()
 	defer o.mtx.Unlock()
 
+	items, err := model.MergeStockItems(items)
+	if err != nil {
+		return err
+	}
+
 	if err := o.validateStocksDecreaseCapacity(items); err != nil {
 		return err
 	}
@@ -53,14 +75,20 @@ func (o *StockRepository) RemoveReserved(_ context.Context, items []model.OrderI
 		}
 	}
 
+	o.closeReservations(orderId, model.ReservationStateRemoved)
 	return nil
 }
 
 // CancelReserve implements order_service.StockRepository.
-func (o *StockRepository) CancelReserved(_ context.Context, items []model.OrderItem) error {
+func (o *StockRepository) CancelReserved(_ context.Context, orderId int64, items []model.OrderItem) error {
 	o.mtx.Lock()
 	defer o.mtx.Unlock()
 
+	items, err := model.MergeStockItems(items)
+	if err != nil {
+		return err
+	}
+
 	if err := o.validateStocksDecreaseCapacity(items); err != nil {
 		return err
 	}
@@ -71,14 +99,20 @@ func (o *StockRepository) CancelReserved(_ context.Context, items []model.OrderI
 		}
 	}
 
+	o.closeReservations(orderId, model.ReservationStateCancelled)
 	return nil
 }
 
 // Reserve implements order_service.StockRepository.
-func (o *StockRepository) Reserve(_ context.Context, items []model.OrderItem) error {
+func (o *StockRepository) Reserve(_ context.Context, orderId int64, items []model.OrderItem) error {
 	o.mtx.Lock()
 	defer o.mtx.Unlock()
 
+	items, err := model.MergeStockItems(items)
+	if err != nil {
+		return err
+	}
+
 	for _, item := range items {
 		stock, ok := o.Stocks[item.Sku]
 		if !ok {
@@ -97,6 +131,13 @@ func (o *StockRepository) Reserve(_ context.Context, items []model.OrderItem) er
 		if stock, ok := o.Stocks[item.Sku]; ok {
 			stock.Reserved = stock.Reserved + item.Count
 		}
+
+		o.Reservations = append(o.Reservations, model.StockReservation{
+			OrderId: orderId,
+			Sku:     item.Sku,
+			Count:   item.Count,
+			State:   model.ReservationStateReserved,
+		})
 	}
 
 	return nil
@@ -114,6 +155,134 @@ func (o *StockRepository) GetBySkuId(_ context.Context, sku int64) (uint32, erro
 	return 0, &model.ErrStockNotFound{Sku: sku}
 }
 
+// ChangeTotalCount implements stock_service.StockRepository.
+func (o *StockRepository) ChangeTotalCount(_ context.Context, change *model.StockChange) (*model.StockModel, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	// setting the total count is idempotent, so unknown stocks are created by it
+	stock, ok := o.Stocks[change.Sku]
+	if !ok && change.Operation == model.StockOperationSet {
+		stock = &model.StockModel{Sku: change.Sku}
+	} else if !ok {
+		return nil, &model.ErrStockNotFound{Sku: change.Sku}
+	}
+
+	totalCount := int64(stock.TotalCount) + change.Delta
+	if change.Operation == model.StockOperationSet {
+		totalCount = int64(change.TotalCount)
+	}
+
+	delta := totalCount - int64(stock.TotalCount)
+	if totalCount < int64(stock.Reserved) || totalCount > math.MaxUint32 {
+		return nil, &model.ErrStockOutOfBounds{
+			Sku:        change.Sku,
+			Reserved:   stock.Reserved,
+			TotalCount: stock.TotalCount,
+			Change:     uint32(min(max(delta, -delta), math.MaxUint32)),
+		}
+	}
+
+	// an unchanged stock is not written, so setting an unknown stock to zero creates nothing
+	if delta == 0 {
+		result := *stock
+		return &result, nil
+	}
+
+	o.Stocks[change.Sku] = stock
+
+	o.Ledger = append(o.Ledger, model.StockLedgerEntry{
+		Sku:         change.Sku,
+		Operation:   change.Operation,
+		Delta:       delta,
+		TotalBefore: stock.TotalCount,
+		TotalAfter:  uint32(totalCount),
+		Reason:      change.Reason,
+		CreatedAt:   time.Now(),
+	})
+	stock.TotalCount = uint32(totalCount)
+
+	result := *stock
+	return &result, nil
+}
+
+// GetBySkuIds implements stock_service.StockRepository.
+func (o *StockRepository) GetBySkuIds(_ context.Context, skus []int64) (map[int64]uint32, error) {
+	o.mtx.RLock()
+	defer o.mtx.RUnlock()
+
+	var available = make(map[int64]uint32, len(skus))
+	for _, sku := range skus {
+		if stock, ok := o.Stocks[sku]; ok {
+			available[sku] = stock.TotalCount - stock.Reserved
+		}
+	}
+
+	return available, nil
+}
+
+// GetOpenReservations implements order_service.StockRepository.
+func (o *StockRepository) GetOpenReservations(_ context.Context, orderId int64) ([]model.OrderItem, error) {
+	o.mtx.RLock()
+	defer o.mtx.RUnlock()
+
+	var items = make([]model.OrderItem, 0)
+	for _, reservation := range o.Reservations {
+		if reservation.OrderId == orderId && reservation.State == model.ReservationStateReserved {
+			items = append(items, model.OrderItem{Sku: reservation.Sku, Count: reservation.Count})
+		}
+	}
+
+	return items, nil
+}
+
+// ReconcileReserved implements stock_service.StockRepository.
+func (o *StockRepository) ReconcileReserved(_ context.Context, repair bool) ([]model.ReservedDrift, error) {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	var expected = make(map[int64]uint32, len(o.Stocks))
+	for _, reservation := range o.Reservations {
+		if reservation.State == model.ReservationStateReserved {
+			expected[reservation.Sku] += reservation.Count
+		}
+	}
+
+	var errs []error
+	var drifts []model.ReservedDrift
+	for _, sku := range slices.Sorted(maps.Keys(o.Stocks)) {
+		stock := o.Stocks[sku]
+		if stock.Reserved == expected[sku] {
+			continue
+		}
+
+		drift := model.ReservedDrift{Sku: sku, Reserved: stock.Reserved, Expected: expected[sku]}
+		if repair && expected[sku] > stock.TotalCount {
+			errs = append(errs, &model.ErrStockOutOfBounds{
+				Sku:        sku,
+				Reserved:   stock.Reserved,
+				TotalCount: stock.TotalCount,
+				Change:     expected[sku] - stock.Reserved,
+			})
+		} else if repair {
+			stock.Reserved = expected[sku]
+			drift.Repaired = true
+		}
+
+		drifts = append(drifts, drift)
+	}
+
+	return drifts, errors.Join(errs...)
+}
+
+func (o *StockRepository) closeReservations(orderId int64, state string) {
+	for i := range o.Reservations {
+		if o.Reservations[i].OrderId == orderId && o.Reservations[i].State == model.ReservationStateReserved {
+			o.Reservations[i].State = state
+		}
+	}
+}
+
 func (o *StockRepository) validateStocksDecreaseCapacity(items []model.OrderItem) error {
 	for _, item := range items {
 		stock, ok := o.Stocks[item.Sku]
@@ -2,6 +2,7 @@ package stock_repository_test
 
 import (
 	"context"
+	"math"
 	"route256/loms/internal/domain/model"
 	"route256/loms/internal/domain/stock/stock_repository"
 	"testing"
@@ -53,7 +54,7 @@ func TestStockRepository_RemoveReserved(t *testing.T) {
 			t.Parallel()
 			o := stock_repository.NewStockRepositoryForTest(stocks)
 
-			if err := o.RemoveReserved(context.Background(), tt.args.items); (err != nil) != tt.wantErr {
+			if err := o.RemoveReserved(context.Background(), 1, tt.args.items); (err != nil) != tt.wantErr {
 				t.Errorf("OrderRepository.RemoveReserved() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -92,13 +93,30 @@ func TestStockRepository_Reserve(t *testing.T) {
 			}},
 			wantErr: true,
 		},
+		{
+			name: "should merge duplicate skus before checking bounds",
+			args: args{items: []model.OrderItem{
+				{Sku: 1, Count: 3},
+				{Sku: 3, Count: 1},
+				{Sku: 1, Count: 3},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "should fail if merged count overflows",
+			args: args{items: []model.OrderItem{
+				{Sku: 2, Count: math.MaxUint32},
+				{Sku: 2, Count: 1},
+			}},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			o := stock_repository.NewStockRepositoryForTest(stocks)
 
-			if err := o.Reserve(context.Background(), tt.args.items); (err != nil) != tt.wantErr {
+			if err := o.Reserve(context.Background(), 1, tt.args.items); (err != nil) != tt.wantErr {
 				t.Errorf("OrderRepository.Reserve() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -173,7 +191,7 @@ func TestStockRepository_CancelReserved(t *testing.T) {
 			t.Parallel()
 			o := stock_repository.NewStockRepositoryForTest(stocks)
 
-			err := o.CancelReserved(context.Background(), []model.OrderItem{tt.arg})
+			err := o.CancelReserved(context.Background(), 1, []model.OrderItem{tt.arg})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("OrderRepository.CancelReserved() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -188,3 +206,200 @@ func TestStockRepository_CancelReserved(t *testing.T) {
 		})
 	}
 }
+
+func TestStockRepository_ChangeTotalCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		change    model.StockChange
+		wantTotal uint32
+		wantDelta int64
+		wantErr   error
+	}{
+		{
+			name:      "should add stocks",
+			change:    model.StockChange{Sku: 1, Operation: model.StockOperationAdd, Delta: 5, Reason: "restock"},
+			wantTotal: 15,
+			wantDelta: 5,
+		},
+		{
+			name:      "should write stocks off",
+			change:    model.StockChange{Sku: 1, Operation: model.StockOperationAdjust, Delta: -4, Reason: "damaged"},
+			wantTotal: 6,
+			wantDelta: -4,
+		},
+		{
+			name:      "should set total count",
+			change:    model.StockChange{Sku: 3, Operation: model.StockOperationSet, TotalCount: 12, Reason: "inventory"},
+			wantTotal: 12,
+			wantDelta: -18,
+		},
+		{
+			name:    "should not write off reserved stocks",
+			change:  model.StockChange{Sku: 1, Operation: model.StockOperationAdjust, Delta: -5, Reason: "damaged"},
+			wantErr: &model.ErrStockOutOfBounds{},
+		},
+		{
+			name:    "should not overflow total count",
+			change:  model.StockChange{Sku: 2, Operation: model.StockOperationAdd, Delta: math.MaxUint32},
+			wantErr: &model.ErrStockOutOfBounds{},
+		},
+		{
+			name:      "should create stock on set if not found",
+			change:    model.StockChange{Sku: 99, Operation: model.StockOperationSet, TotalCount: 7, Reason: "import"},
+			wantTotal: 7,
+			wantDelta: 7,
+		},
+		{
+			name:    "should fail on adjust if stock not found",
+			change:  model.StockChange{Sku: 99, Operation: model.StockOperationAdjust, Delta: 1},
+			wantErr: &model.ErrStockNotFound{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			o := stock_repository.NewStockRepositoryForTest(stocks)
+
+			stock, err := o.ChangeTotalCount(context.Background(), &tt.change)
+			if tt.wantErr != nil {
+				require.IsType(t, tt.wantErr, err)
+				require.Empty(t, o.Ledger)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantTotal, stock.TotalCount)
+			require.Len(t, o.Ledger, 1)
+			require.Equal(t, tt.wantDelta, o.Ledger[0].Delta)
+			require.Equal(t, tt.wantTotal, o.Ledger[0].TotalAfter)
+			require.Equal(t, tt.change.Reason, o.Ledger[0].Reason)
+		})
+	}
+}
+
+func TestStockRepository_ChangeTotalCount_SetIsIdempotent(t *testing.T) {
+	t.Parallel()
+	o := stock_repository.NewStockRepositoryForTest(stocks)
+	change := &model.StockChange{Sku: 2, Operation: model.StockOperationSet, TotalCount: 25, Reason: "import"}
+
+	first, err := o.ChangeTotalCount(context.Background(), change)
+	require.NoError(t, err)
+
+	second, err := o.ChangeTotalCount(context.Background(), change)
+	require.NoError(t, err)
+
+	require.Equal(t, first, second)
+	require.Len(t, o.Ledger, 1)
+}
+
+func TestStockRepository_ChangeTotalCount_SetUnknownToZero(t *testing.T) {
+	t.Parallel()
+	o := stock_repository.NewStockRepositoryForTest(stocks)
+	change := &model.StockChange{Sku: 99, Operation: model.StockOperationSet, TotalCount: 0, Reason: "import"}
+
+	stock, err := o.ChangeTotalCount(context.Background(), change)
+	require.NoError(t, err)
+	require.Equal(t, &model.StockModel{Sku: 99}, stock)
+
+	require.NotContains(t, o.Stocks, int64(99), "unchanged stock is not created")
+	require.Empty(t, o.Ledger)
+}
+
+func TestStockRepository_GetBySkuIds(t *testing.T) {
+	t.Parallel()
+	o := stock_repository.NewStockRepositoryForTest(stocks)
+
+	available, err := o.GetBySkuIds(context.Background(), []int64{1, 3, 99})
+
+	require.NoError(t, err)
+	require.Equal(t, map[int64]uint32{1: 4, 3: 20}, available)
+}
+
+func TestStockRepository_RemoveReserved_MergesDuplicates(t *testing.T) {
+	t.Parallel()
+	o := stock_repository.NewStockRepositoryForTest(stocks)
+
+	err := o.RemoveReserved(context.Background(), 1, []model.OrderItem{
+		{Sku: 3, Count: 4},
+		{Sku: 1, Count: 2},
+		{Sku: 3, Count: 6},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, &model.StockModel{Sku: 3, TotalCount: 20, Reserved: 0}, o.Stocks[3])
+	require.Equal(t, &model.StockModel{Sku: 1, TotalCount: 8, Reserved: 4}, o.Stocks[1])
+}
+
+func TestStockRepository_Reservations(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	o := stock_repository.NewStockRepositoryForTest(`[{"sku": 1, "total_count": 10, "reserved": 0}]`)
+
+	require.NoError(t, o.Reserve(ctx, 1, []model.OrderItem{{Sku: 1, Count: 2}}))
+	require.NoError(t, o.Reserve(ctx, 2, []model.OrderItem{{Sku: 1, Count: 3}}))
+	require.NoError(t, o.Reserve(ctx, 3, []model.OrderItem{{Sku: 1, Count: 4}}))
+	require.NoError(t, o.RemoveReserved(ctx, 1, []model.OrderItem{{Sku: 1, Count: 2}}))
+	require.NoError(t, o.CancelReserved(ctx, 2, []model.OrderItem{{Sku: 1, Count: 3}}))
+
+	require.Equal(t, []model.StockReservation{
+		{OrderId: 1, Sku: 1, Count: 2, State: model.ReservationStateRemoved},
+		{OrderId: 2, Sku: 1, Count: 3, State: model.ReservationStateCancelled},
+		{OrderId: 3, Sku: 1, Count: 4, State: model.ReservationStateReserved},
+	}, o.Reservations)
+
+	drifts, err := o.ReconcileReserved(ctx, false)
+	require.NoError(t, err)
+	require.Empty(t, drifts)
+}
+
+func TestStockRepository_ReconcileReserved(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("should report drift without repair", func(t *testing.T) {
+		t.Parallel()
+		o := stock_repository.NewStockRepositoryForTest(stocks)
+		require.NoError(t, o.Reserve(ctx, 1, []model.OrderItem{{Sku: 2, Count: 5}}))
+
+		drifts, err := o.ReconcileReserved(ctx, false)
+
+		require.NoError(t, err)
+		require.Equal(t, []model.ReservedDrift{
+			{Sku: 1, Reserved: 6, Expected: 0},
+			{Sku: 3, Reserved: 10, Expected: 0},
+		}, drifts)
+		require.Equal(t, uint32(6), o.Stocks[1].Reserved)
+	})
+
+	t.Run("should recompute reserved on repair", func(t *testing.T) {
+		t.Parallel()
+		o := stock_repository.NewStockRepositoryForTest(stocks)
+		require.NoError(t, o.Reserve(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1}}))
+
+		drifts, err := o.ReconcileReserved(ctx, true)
+
+		require.NoError(t, err)
+		require.Equal(t, []model.ReservedDrift{
+			{Sku: 1, Reserved: 7, Expected: 1, Repaired: true},
+			{Sku: 3, Reserved: 10, Expected: 0, Repaired: true},
+		}, drifts)
+		require.Equal(t, uint32(1), o.Stocks[1].Reserved)
+		require.Equal(t, uint32(0), o.Stocks[3].Reserved)
+	})
+
+	t.Run("should keep the seeded reserved as the opening balance", func(t *testing.T) {
+		t.Parallel()
+		o := stock_repository.NewStockRepository()
+		require.Equal(t, uint32(10), o.Stocks[1076963].Reserved)
+		require.NoError(t, o.Reserve(ctx, 1, []model.OrderItem{{Sku: 1076963, Count: 2}}))
+		require.NoError(t, o.CancelReserved(ctx, 1, []model.OrderItem{{Sku: 1076963, Count: 2}}))
+
+		drifts, err := o.ReconcileReserved(ctx, true)
+
+		require.NoError(t, err)
+		require.Empty(t, drifts)
+		require.Equal(t, uint32(10), o.Stocks[1076963].Reserved)
+	})
+}
@@ -9,6 +9,139 @@ import (
 	"context"
 )
 
+const closeReservations = `-- name: CloseReservations :execrows
+update stock_reservations
+set state = $1,
+    updated_at = now()
+where order_id = $2::bigint
+    and state = $3
+`
+
+type CloseReservationsParams struct {
+	ToState   string
+	OrderID   int64
+	FromState string
+}
+
+func (q *Queries) CloseReservations(ctx context.Context, arg CloseReservationsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, closeReservations, arg.ToState, arg.OrderID, arg.FromState)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getExpectedReserved = `-- name: GetExpectedReserved :one
+select coalesce(sum(count), 0)::bigint as expected
+from stock_reservations
+where sku = $1
+    and state = 'reserved'
+`
+
+func (q *Queries) GetExpectedReserved(ctx context.Context, sku int64) (int64, error) {
+	row := q.db.QueryRow(ctx, getExpectedReserved, sku)
+	var expected int64
+	err := row.Scan(&expected)
+	return expected, err
+}
+
+const getOpenReservations = `-- name: GetOpenReservations :many
+select sku,
+    count
+from stock_reservations
+where order_id = $1::bigint
+    and state = 'reserved'
+order by sku
+`
+
+type GetOpenReservationsRow struct {
+	Sku   int64
+	Count int64
+}
+
+func (q *Queries) GetOpenReservations(ctx context.Context, orderID int64) ([]GetOpenReservationsRow, error) {
+	rows, err := q.db.Query(ctx, getOpenReservations, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOpenReservationsRow
+	for rows.Next() {
+		var i GetOpenReservationsRow
+		if err := rows.Scan(&i.Sku, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReservedDrift = `-- name: GetReservedDrift :many
+select s.sku,
+    s.reserved,
+    coalesce(r.expected, 0)::bigint as expected
+from stocks s
+    left join (
+        select sku,
+            sum(count) as expected
+        from stock_reservations
+        where state = 'reserved'
+        group by sku
+    ) r on r.sku = s.sku
+where s.reserved <> coalesce(r.expected, 0)
+`
+
+type GetReservedDriftRow struct {
+	Sku      int64
+	Reserved int64
+	Expected int64
+}
+
+func (q *Queries) GetReservedDrift(ctx context.Context) ([]GetReservedDriftRow, error) {
+	rows, err := q.db.Query(ctx, getReservedDrift)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReservedDriftRow
+	for rows.Next() {
+		var i GetReservedDriftRow
+		if err := rows.Scan(&i.Sku, &i.Reserved, &i.Expected); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStockForUpdate = `-- name: GetStockForUpdate :one
+select sku,
+    total_count,
+    reserved
+from stocks
+where sku = $1
+for update
+`
+
+type GetStockForUpdateRow struct {
+	Sku        int64
+	TotalCount int64
+	Reserved   int64
+}
+
+func (q *Queries) GetStockForUpdate(ctx context.Context, sku int64) (GetStockForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getStockForUpdate, sku)
+	var i GetStockForUpdateRow
+	err := row.Scan(&i.Sku, &i.TotalCount, &i.Reserved)
+	return i, err
+}
+
 const getStocksBySkuId = `-- name: GetStocksBySkuId :one
 select sku,
     total_count,
@@ -29,3 +162,162 @@ func (q *Queries) GetStocksBySkuId(ctx context.Context, sku int64) (GetStocksByS
 	err := row.Scan(&i.Sku, &i.TotalCount, &i.Reserved)
 	return i, err
 }
+
+const getStocksBySkuIds = `-- name: GetStocksBySkuIds :many
+select sku,
+    total_count,
+    reserved
+from stocks
+where sku = any($1::bigint[])
+`
+
+type GetStocksBySkuIdsRow struct {
+	Sku        int64
+	TotalCount int64
+	Reserved   int64
+}
+
+func (q *Queries) GetStocksBySkuIds(ctx context.Context, skus []int64) ([]GetStocksBySkuIdsRow, error) {
+	rows, err := q.db.Query(ctx, getStocksBySkuIds, skus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStocksBySkuIdsRow
+	for rows.Next() {
+		var i GetStocksBySkuIdsRow
+		if err := rows.Scan(&i.Sku, &i.TotalCount, &i.Reserved); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const insertLedgerEntry = `-- name: InsertLedgerEntry :exec
+insert into stock_ledger (sku, operation, delta, total_before, total_after, reason)
+values ($1, $2, $3, $4, $5, $6)
+`
+
+type InsertLedgerEntryParams struct {
+	Sku         int64
+	Operation   string
+	Delta       int64
+	TotalBefore int64
+	TotalAfter  int64
+	Reason      string
+}
+
+func (q *Queries) InsertLedgerEntry(ctx context.Context, arg InsertLedgerEntryParams) error {
+	_, err := q.db.Exec(ctx, insertLedgerEntry,
+		arg.Sku,
+		arg.Operation,
+		arg.Delta,
+		arg.TotalBefore,
+		arg.TotalAfter,
+		arg.Reason,
+	)
+	return err
+}
+
+const insertReservations = `-- name: InsertReservations :exec
+insert into stock_reservations (order_id, sku, count, state)
+select $1::bigint,
+    unnest($2::bigint[]),
+    unnest($3::bigint[]),
+    $4
+`
+
+type InsertReservationsParams struct {
+	OrderID int64
+	Skus    []int64
+	Counts  []int64
+	State   string
+}
+
+func (q *Queries) InsertReservations(ctx context.Context, arg InsertReservationsParams) error {
+	_, err := q.db.Exec(ctx, insertReservations,
+		arg.OrderID,
+		arg.Skus,
+		arg.Counts,
+		arg.State,
+	)
+	return err
+}
+
+const lockStocks = `-- name: LockStocks :many
+select sku
+from stocks
+where sku = any($1::bigint[])
+order by sku
+for update
+`
+
+func (q *Queries) LockStocks(ctx context.Context, skus []int64) ([]int64, error) {
+	rows, err := q.db.Query(ctx, lockStocks, skus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var sku int64
+		if err := rows.Scan(&sku); err != nil {
+			return nil, err
+		}
+		items = append(items, sku)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setReserved = `-- name: SetReserved :exec
+update stocks
+set reserved = $2,
+    updated_at = now()
+where sku = $1
+`
+
+type SetReservedParams struct {
+	Sku      int64
+	Reserved int64
+}
+
+func (q *Queries) SetReserved(ctx context.Context, arg SetReservedParams) error {
+	_, err := q.db.Exec(ctx, setReserved, arg.Sku, arg.Reserved)
+	return err
+}
+
+const upsertTotalCount = `-- name: UpsertTotalCount :one
+insert into stocks (sku, total_count, reserved)
+values ($1, $2, 0)
+on conflict (sku) do update
+set total_count = excluded.total_count,
+    updated_at = now()
+returning sku,
+    total_count,
+    reserved
+`
+
+type UpsertTotalCountParams struct {
+	Sku        int64
+	TotalCount int64
+}
+
+type UpsertTotalCountRow struct {
+	Sku        int64
+	TotalCount int64
+	Reserved   int64
+}
+
+func (q *Queries) UpsertTotalCount(ctx context.Context, arg UpsertTotalCountParams) (UpsertTotalCountRow, error) {
+	row := q.db.QueryRow(ctx, upsertTotalCount, arg.Sku, arg.TotalCount)
+	var i UpsertTotalCountRow
+	err := row.Scan(&i.Sku, &i.TotalCount, &i.Reserved)
+	return i, err
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"route256/loms/internal/domain/model"
 	"route256/loms/internal/domain/stock/stock_repository_pg/query"
 	"route256/loms/internal/infra/sre"
+	"route256/loms/internal/infra/transactor"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -19,6 +21,14 @@ const (
 	ConstrainGteTotalReserved = "stocks_totalCount_gte_reserved"
 	ConstrainTotalUint32      = "stocks_totalCount_uint32"
 	ConstrainReservedUint32   = "stocks_reserved_uint32"
+
+	ErrCodeDeadlockDetected     = "40P01"
+	ErrCodeSerializationFailure = "40001"
+)
+
+const (
+	retryMaxAttempts = 3
+	retryBaseDelay   = 10 * time.Millisecond
 )
 
 type StockRepository struct {
@@ -34,11 +44,11 @@ func NewOrderRepository(master *pgxpool.Pool, replica *pgxpool.Pool) *StockRepos
 }
 
 // CancelReserved implements app.StockRepository.
-func (r *StockRepository) CancelReserved(ctx context.Context, items []model.OrderItem) error {
+func (r *StockRepository) CancelReserved(ctx context.Context, orderId int64, items []model.OrderItem) error {
 	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_repository.CancelReserved")
 	defer span.End()
 
-	return r.modifyReserved(ctx, items, true)
+	return r.modifyReserved(ctx, orderId, items, true)
 }
 
 // GetBySkuId implements app.StockRepository.
@@ -51,6 +61,9 @@ func (r *StockRepository) GetBySkuId(ctx context.Context, sku int64) (uint32, er
 	startTime := time.Now()
 	stocks, err := repository.GetStocksBySkuId(ctx, sku)
 	sre.TrackDbRequest("stock_get_by_sku", "select", err, startTime)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, &model.ErrStockNotFound{Sku: sku}
+	}
 	if err != nil {
 		return 0, fmt.Errorf("failed to get stocks by sku id: %w", err)
 	}
@@ -58,81 +71,404 @@ func (r *StockRepository) GetBySkuId(ctx context.Context, sku int64) (uint32, er
 	return uint32(stocks.TotalCount - stocks.Reserved), nil
 }
 
+// GetBySkuIds implements stock_service.StockRepository.
+func (r *StockRepository) GetBySkuIds(ctx context.Context, skus []int64) (map[int64]uint32, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_repository.GetBySkuIds")
+	defer span.End()
+
+	repository := query.New(r.replica)
+
+	startTime := time.Now()
+	stocks, err := repository.GetStocksBySkuIds(ctx, skus)
+	sre.TrackDbRequest("stock_get_by_skus", "select", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stocks by sku ids: %w", err)
+	}
+
+	var available = make(map[int64]uint32, len(stocks))
+	for _, stock := range stocks {
+		available[stock.Sku] = uint32(stock.TotalCount - stock.Reserved)
+	}
+
+	return available, nil
+}
+
 // RemoveReserved implements app.StockRepository.
-func (r *StockRepository) RemoveReserved(ctx context.Context, items []model.OrderItem) error {
+func (r *StockRepository) RemoveReserved(ctx context.Context, orderId int64, items []model.OrderItem) error {
 	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_repository.RemoveReserved")
 	defer span.End()
 
-	return pgx.BeginTxFunc(ctx, r.master, pgx.TxOptions{}, func(tx pgx.Tx) error {
-		repository := query.New(tx)
+	items, err := model.MergeStockItems(items)
+	if err != nil {
+		return err
+	}
 
-		var requests = make([]query.RemoveReserveParams, 0, len(items))
+	return withRetry(ctx, "stock_remove_reserved", func() error {
+		return transactor.BeginFunc(ctx, r.master, func(tx pgx.Tx) error {
+			repository := query.New(tx)
+			if err := lockStocks(ctx, repository, items); err != nil {
+				return err
+			}
 
-		for _, item := range items {
-			requests = append(requests, query.RemoveReserveParams{
-				Sku:   item.Sku,
-				Count: int64(item.Count),
-			})
-		}
+			var requests = make([]query.RemoveReserveParams, 0, len(items))
 
-		startTime := time.Now()
-		result := repository.RemoveReserve(ctx, requests)
-		var errResult error = nil
-		result.Exec(func(i int, err error) {
-			if errResult == nil && err != nil {
-				errResult = handleRemoveReservedErr(err, i, items, repository)
+			for _, item := range items {
+				requests = append(requests, query.RemoveReserveParams{
+					Sku:   item.Sku,
+					Count: int64(item.Count),
+				})
 			}
-		})
-		sre.TrackDbRequest("stock_remove_reserved", "update", errResult, startTime)
 
-		return errResult
+			startTime := time.Now()
+			result := repository.RemoveReserve(ctx, requests)
+			var errResult error = nil
+			result.Exec(func(i int, err error) {
+				if errResult == nil && err != nil {
+					errResult = handleRemoveReservedErr(err, i, items, repository)
+				}
+			})
+			sre.TrackDbRequest("stock_remove_reserved", "update", errResult, startTime)
+			if errResult != nil {
+				return errResult
+			}
+
+			return closeReservations(ctx, repository, orderId, model.ReservationStateRemoved)
+		})
 	})
 }
 
 // Reserve implements app.StockRepository.
-func (r *StockRepository) Reserve(ctx context.Context, items []model.OrderItem) error {
+func (r *StockRepository) Reserve(ctx context.Context, orderId int64, items []model.OrderItem) error {
 	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_repository.Reserve")
 	defer span.End()
 
-	return r.modifyReserved(ctx, items, false)
+	return r.modifyReserved(ctx, orderId, items, false)
+}
+
+// GetOpenReservations implements order_service.StockRepository.
+func (r *StockRepository) GetOpenReservations(ctx context.Context, orderId int64) ([]model.OrderItem, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_repository.GetOpenReservations")
+	defer span.End()
+
+	repository := query.New(transactor.Querier(ctx, r.master))
+
+	startTime := time.Now()
+	rows, err := repository.GetOpenReservations(ctx, orderId)
+	sre.TrackDbRequest("stock_reservations_get_open", "select", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open reservations of order %d: %w", orderId, err)
+	}
+
+	var items = make([]model.OrderItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, model.OrderItem{Sku: row.Sku, Count: uint32(row.Count)})
+	}
+
+	return items, nil
+}
+
+// ReconcileReserved implements stock_service.StockRepository.
+func (r *StockRepository) ReconcileReserved(ctx context.Context, repair bool) ([]model.ReservedDrift, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_repository.ReconcileReserved")
+	defer span.End()
+
+	startTime := time.Now()
+	rows, err := query.New(r.master).GetReservedDrift(ctx)
+	sre.TrackDbRequest("stock_reserved_drift", "select", err, startTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reserved drift: %w", err)
+	}
+
+	var errs []error
+	var drifts = make([]model.ReservedDrift, 0, len(rows))
+	for _, row := range rows {
+		drift := model.ReservedDrift{
+			Sku:      row.Sku,
+			Reserved: uint32(row.Reserved),
+			Expected: uint32(row.Expected),
+		}
+
+		if repair {
+			repaired, err := r.repairReserved(ctx, row.Sku)
+			if err != nil {
+				errs = append(errs, err)
+				drifts = append(drifts, drift)
+				continue
+			}
+
+			// the drift was transient, the counter matched once the stock was locked
+			if repaired.Reserved == repaired.Expected {
+				continue
+			}
+
+			drift = repaired
+		}
+
+		drifts = append(drifts, drift)
+	}
+
+	return drifts, errors.Join(errs...)
+}
+
+// repairReserved recomputes the reserved counter under the stock lock, so reservations
+// committed after the drift was detected are taken into account.
+func (r *StockRepository) repairReserved(ctx context.Context, sku int64) (model.ReservedDrift, error) {
+	var drift = model.ReservedDrift{Sku: sku}
+
+	startTime := time.Now()
+	err := transactor.BeginFunc(ctx, r.master, func(tx pgx.Tx) error {
+		repository := query.New(tx)
+
+		stock, err := repository.GetStockForUpdate(ctx, sku)
+		if err != nil {
+			return fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		expected, err := repository.GetExpectedReserved(ctx, sku)
+		if err != nil {
+			return fmt.Errorf("failed to get expected reserved: %w", err)
+		}
+
+		drift.Reserved = uint32(stock.Reserved)
+		drift.Expected = uint32(expected)
+		if stock.Reserved == expected {
+			return nil
+		}
+
+		err = repository.SetReserved(ctx, query.SetReservedParams{Sku: sku, Reserved: expected})
+		if err != nil {
+			err = checkForKnownConstrainErr(err, sku, absUint32(expected-stock.Reserved), query.New(r.master))
+			return fmt.Errorf("failed to set reserved: %w", err)
+		}
+
+		drift.Repaired = true
+		return nil
+	})
+	sre.TrackDbRequest("stock_reserved_repair", "update", err, startTime)
+	if err != nil {
+		return drift, fmt.Errorf("failed to repair reserved of sku %d: %w", sku, err)
+	}
+
+	return drift, nil
+}
+
+// ChangeTotalCount implements stock_service.StockRepository.
+func (r *StockRepository) ChangeTotalCount(ctx context.Context, change *model.StockChange) (*model.StockModel, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_repository.ChangeTotalCount")
+	defer span.End()
+
+	var stock *model.StockModel
+	startTime := time.Now()
+	err := transactor.BeginFunc(ctx, r.master, func(tx pgx.Tx) error {
+		repository := query.New(tx)
+
+		// setting the total count is idempotent, so unknown stocks are created by it
+		current, err := repository.GetStockForUpdate(ctx, change.Sku)
+		if errors.Is(err, pgx.ErrNoRows) && change.Operation == model.StockOperationSet {
+			current = query.GetStockForUpdateRow{Sku: change.Sku}
+		} else if errors.Is(err, pgx.ErrNoRows) {
+			return &model.ErrStockNotFound{Sku: change.Sku}
+		} else if err != nil {
+			return fmt.Errorf("failed to lock stock: %w", err)
+		}
+
+		totalCount := current.TotalCount + change.Delta
+		if change.Operation == model.StockOperationSet {
+			totalCount = int64(change.TotalCount)
+		}
+
+		// an unchanged stock is not written, so setting an unknown stock to zero creates nothing
+		if totalCount == current.TotalCount {
+			stock = &model.StockModel{
+				Sku:        change.Sku,
+				TotalCount: uint32(current.TotalCount),
+				Reserved:   uint32(current.Reserved),
+			}
+			return nil
+		}
+
+		updated, err := repository.UpsertTotalCount(ctx, query.UpsertTotalCountParams{
+			Sku:        change.Sku,
+			TotalCount: totalCount,
+		})
+		if err != nil {
+			// the transaction is aborted by the constraint violation, so the actual
+			// stock is read outside of it
+			err = checkForKnownConstrainErr(err, change.Sku, absUint32(totalCount-current.TotalCount), query.New(r.master))
+			return fmt.Errorf("failed to change total count: %w", err)
+		}
+
+		stock = &model.StockModel{
+			Sku:        updated.Sku,
+			TotalCount: uint32(updated.TotalCount),
+			Reserved:   uint32(updated.Reserved),
+		}
+
+		err = repository.InsertLedgerEntry(ctx, query.InsertLedgerEntryParams{
+			Sku:         change.Sku,
+			Operation:   change.Operation,
+			Delta:       updated.TotalCount - current.TotalCount,
+			TotalBefore: current.TotalCount,
+			TotalAfter:  updated.TotalCount,
+			Reason:      change.Reason,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write stock ledger: %w", err)
+		}
+
+		return nil
+	})
+	sre.TrackDbRequest("stock_change_total", "update", err, startTime)
+	if err != nil {
+		return nil, err
+	}
+
+	return stock, nil
 }
 
-func (r *StockRepository) modifyReserved(ctx context.Context, items []model.OrderItem, cancel bool) error {
+func (r *StockRepository) modifyReserved(ctx context.Context, orderId int64, items []model.OrderItem, cancel bool) error {
 	var operationName = "stock_reserve_cancel"
 	if !cancel {
 		operationName = "stock_reserve"
 	}
 
-	return pgx.BeginTxFunc(ctx, r.master, pgx.TxOptions{}, func(tx pgx.Tx) error {
-		repository := query.New(tx)
+	items, err := model.MergeStockItems(items)
+	if err != nil {
+		return err
+	}
 
-		var requests = make([]query.ReserveParams, 0, len(items))
+	return withRetry(ctx, operationName, func() error {
+		return transactor.BeginFunc(ctx, r.master, func(tx pgx.Tx) error {
+			repository := query.New(tx)
+			if err := lockStocks(ctx, repository, items); err != nil {
+				return err
+			}
 
-		for _, item := range items {
-			var modifier int64 = 1
-			if cancel {
-				modifier = -1
+			var requests = make([]query.ReserveParams, 0, len(items))
+
+			for _, item := range items {
+				var modifier int64 = 1
+				if cancel {
+					modifier = -1
+				}
+
+				requests = append(requests, query.ReserveParams{
+					Sku:   item.Sku,
+					Count: int64(item.Count) * modifier,
+				})
 			}
 
-			requests = append(requests, query.ReserveParams{
-				Sku:   item.Sku,
-				Count: int64(item.Count) * modifier,
-			})
-		}
+			startTime := time.Now()
+			result := repository.Reserve(ctx, requests)
+			var errResult error = nil
 
-		startTime := time.Now()
-		result := repository.Reserve(ctx, requests)
-		var errResult error = nil
+			result.QueryRow(func(i int, updated int64, err error) {
+				if errResult == nil && err != nil {
+					errResult = handleReservedError(err, i, cancel, items, repository)
+				}
+			})
+			sre.TrackDbRequest(operationName, "update", errResult, startTime)
+			if errResult != nil {
+				return errResult
+			}
 
-		result.QueryRow(func(i int, updated int64, err error) {
-			if errResult == nil && err != nil {
-				errResult = handleReservedError(err, i, cancel, items, repository)
+			if cancel {
+				return closeReservations(ctx, repository, orderId, model.ReservationStateCancelled)
 			}
+
+			return insertReservations(ctx, repository, orderId, items)
 		})
-		sre.TrackDbRequest(operationName, "update", errResult, startTime)
+	})
+}
+
+// insertReservations records the reservations of the order next to the reserved counter.
+func insertReservations(ctx context.Context, queries *query.Queries, orderId int64, items []model.OrderItem) error {
+	var skus = make([]int64, 0, len(items))
+	var counts = make([]int64, 0, len(items))
+	for _, item := range items {
+		skus = append(skus, item.Sku)
+		counts = append(counts, int64(item.Count))
+	}
+
+	startTime := time.Now()
+	err := queries.InsertReservations(ctx, query.InsertReservationsParams{
+		OrderID: orderId,
+		Skus:    skus,
+		Counts:  counts,
+		State:   model.ReservationStateReserved,
+	})
+	sre.TrackDbRequest("stock_reservations_insert", "insert", err, startTime)
+	if err != nil {
+		return fmt.Errorf("failed to insert reservations of order %d: %w", orderId, err)
+	}
+
+	return nil
+}
 
-		return errResult
+// closeReservations moves the open reservations of the order to the final state.
+// Orders reserved before the reservations were recorded have nothing to close,
+// the reconciliation reports such stocks.
+func closeReservations(ctx context.Context, queries *query.Queries, orderId int64, state string) error {
+	startTime := time.Now()
+	_, err := queries.CloseReservations(ctx, query.CloseReservationsParams{
+		OrderID:   orderId,
+		FromState: model.ReservationStateReserved,
+		ToState:   state,
 	})
+	sre.TrackDbRequest("stock_reservations_close", "update", err, startTime)
+	if err != nil {
+		return fmt.Errorf("failed to close reservations of order %d: %w", orderId, err)
+	}
+
+	return nil
+}
+
+// lockStocks locks the stock rows of the items sorted by sku, so concurrent
+// transactions wait for each other instead of deadlocking.
+func lockStocks(ctx context.Context, queries *query.Queries, items []model.OrderItem) error {
+	var skus = make([]int64, 0, len(items))
+	for _, item := range items {
+		skus = append(skus, item.Sku)
+	}
+
+	startTime := time.Now()
+	_, err := queries.LockStocks(ctx, skus)
+	sre.TrackDbRequest("stock_lock", "select", err, startTime)
+	if err != nil {
+		return fmt.Errorf("failed to lock stocks: %w", err)
+	}
+
+	return nil
+}
+
+// withRetry repeats the transaction aborted by a deadlock or a serialization failure. Inside of
+// a unit of work the savepoint is retried, its rollback releases the stock locks taken so far.
+func withRetry(ctx context.Context, action string, txFunc func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := txFunc()
+
+		code, retryable := retryableErrCode(err)
+		if !retryable || attempt >= retryMaxAttempts {
+			return err
+		}
+
+		sre.TrackDbRetry(action, code)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(retryBaseDelay * time.Duration(attempt)):
+		}
+	}
+}
+
+func retryableErrCode(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) &&
+		(pgErr.Code == ErrCodeDeadlockDetected || pgErr.Code == ErrCodeSerializationFailure) {
+		return pgErr.Code, true
+	}
+
+	return "", false
 }
 
 func handleRemoveReservedErr(err error, i int, items []model.OrderItem, queries *query.Queries) error {
@@ -140,7 +476,7 @@ func handleRemoveReservedErr(err error, i int, items []model.OrderItem, queries
 		return &model.ErrStockNotFound{Sku: items[i].Sku}
 	}
 
-	err = checkForKnownConstrainErr(err, &items[i], queries)
+	err = checkForKnownConstrainErr(err, items[i].Sku, items[i].Count, queries)
 	return fmt.Errorf("failed to remove reserved in batch %w", err)
 }
 
@@ -149,7 +485,7 @@ func handleReservedError(err error, i int, cancel bool, items []model.OrderItem,
 		return &model.ErrStockNotFound{Sku: items[i].Sku}
 	}
 
-	err = checkForKnownConstrainErr(err, &items[i], queries)
+	err = checkForKnownConstrainErr(err, items[i].Sku, items[i].Count, queries)
 	if cancel {
 		return fmt.Errorf("failed to cancel reserved in batch %w", err)
 	}
@@ -157,31 +493,43 @@ func handleReservedError(err error, i int, cancel bool, items []model.OrderItem,
 	return fmt.Errorf("failed to reserve in batch %w", err)
 }
 
-func checkForKnownConstrainErr(err error, item *model.OrderItem, queries *query.Queries) error {
+func checkForKnownConstrainErr(err error, sku int64, change uint32, queries *query.Queries) error {
 	var pgErr *pgconn.PgError = nil
 	if errors.As(err, &pgErr) {
 		if pgErr.ConstraintName == ConstrainGteTotalReserved ||
 			pgErr.ConstraintName == ConstrainTotalUint32 ||
 			pgErr.ConstraintName == ConstrainReservedUint32 {
 
-			stock, err := queries.GetStocksBySkuId(context.Background(), item.Sku)
+			stock, err := queries.GetStocksBySkuId(context.Background(), sku)
 			if err != nil {
 				return fmt.Errorf("%w, %w", &model.ErrStockOutOfBounds{
-					Sku:        item.Sku,
+					Sku:        sku,
 					Reserved:   0,
 					TotalCount: 0,
-					Change:     item.Count,
+					Change:     change,
 				}, err)
 			}
 
 			return &model.ErrStockOutOfBounds{
-				Sku:        item.Sku,
+				Sku:        sku,
 				Reserved:   uint32(stock.Reserved),
 				TotalCount: uint32(stock.TotalCount),
-				Change:     item.Count,
+				Change:     change,
 			}
 		}
 	}
 
 	return err
 }
+
+func absUint32(value int64) uint32 {
+	if value < 0 {
+		value = -value
+	}
+
+	if value > math.MaxUint32 {
+		return math.MaxUint32
+	}
+
+	return uint32(value)
+}
@@ -15,3 +15,87 @@ returning sku;
 update stocks
 set reserved = reserved - @count, total_count = total_count - @count
 where sku = $1;
+
+-- name: GetStockForUpdate :one
+select sku,
+    total_count,
+    reserved
+from stocks
+where sku = $1
+for update;
+
+-- name: UpsertTotalCount :one
+insert into stocks (sku, total_count, reserved)
+values ($1, $2, 0)
+on conflict (sku) do update
+set total_count = excluded.total_count,
+    updated_at = now()
+returning sku,
+    total_count,
+    reserved;
+
+-- name: InsertLedgerEntry :exec
+insert into stock_ledger (sku, operation, delta, total_before, total_after, reason)
+values ($1, $2, $3, $4, $5, $6);
+
+-- name: GetStocksBySkuIds :many
+select sku,
+    total_count,
+    reserved
+from stocks
+where sku = any(@skus::bigint[]);
+
+-- name: LockStocks :many
+select sku
+from stocks
+where sku = any(@skus::bigint[])
+order by sku
+for update;
+
+-- name: InsertReservations :exec
+insert into stock_reservations (order_id, sku, count, state)
+select @order_id::bigint,
+    unnest(@skus::bigint[]),
+    unnest(@counts::bigint[]),
+    @state;
+
+-- name: CloseReservations :execrows
+update stock_reservations
+set state = @to_state,
+    updated_at = now()
+where order_id = @order_id::bigint
+    and state = @from_state;
+
+-- name: GetReservedDrift :many
+select s.sku,
+    s.reserved,
+    coalesce(r.expected, 0)::bigint as expected
+from stocks s
+    left join (
+        select sku,
+            sum(count) as expected
+        from stock_reservations
+        where state = 'reserved'
+        group by sku
+    ) r on r.sku = s.sku
+where s.reserved <> coalesce(r.expected, 0);
+
+-- name: GetExpectedReserved :one
+select coalesce(sum(count), 0)::bigint as expected
+from stock_reservations
+where sku = $1
+    and state = 'reserved';
+
+-- name: SetReserved :exec
+update stocks
+set reserved = @reserved,
+    updated_at = now()
+where sku = $1;
+
+-- name: GetOpenReservations :many
+select sku,
+    count
+from stock_reservations
+where order_id = @order_id::bigint
+    and state = 'reserved'
+order by sku;
@@ -4,14 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"route256/loms/internal/domain/model"
+	"route256/loms/internal/infra/sre"
 
 	"go.opentelemetry.io/otel"
 )
 
+//go:generate minimock -i StockRepository -p stock_service_test
+
 type StockRepository interface {
 	GetBySkuId(ctx context.Context, sku int64) (uint32, error)
+	GetBySkuIds(ctx context.Context, skus []int64) (map[int64]uint32, error)
+	ChangeTotalCount(ctx context.Context, change *model.StockChange) (*model.StockModel, error)
+	ReconcileReserved(ctx context.Context, repair bool) ([]model.ReservedDrift, error)
 }
 
+// MaxStocksInfoBatchSize limits the number of skus requested in one batch.
+const MaxStocksInfoBatchSize = 100
+
 type StockService struct {
 	repository StockRepository
 }
@@ -36,3 +46,132 @@ func (s *StockService) StocksInfo(ctx context.Context, skuId int64) (uint32, err
 
 	return count, nil
 }
+
+// StocksInfoBatch implements controllers.StockService.
+// Duplicate skus are answered once, in the order of their first occurrence.
+func (s *StockService) StocksInfoBatch(ctx context.Context, skuIds []int64) ([]model.StockAvailability, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_service.StocksInfoBatch")
+	defer span.End()
+
+	if len(skuIds) == 0 || len(skuIds) > MaxStocksInfoBatchSize {
+		return nil, &model.ErrInvalidStocksBatch{Reason: fmt.Sprintf("number of skus must be between 1 and %d, having: %d",
+			MaxStocksInfoBatchSize, len(skuIds))}
+	}
+
+	var unique = make([]int64, 0, len(skuIds))
+	var seen = make(map[int64]struct{}, len(skuIds))
+	for _, skuId := range skuIds {
+		if skuId < 1 {
+			return nil, &model.ErrInvalidStocksBatch{Reason: fmt.Sprintf("SkuId must be greater than 0, having: %d", skuId)}
+		}
+
+		if _, ok := seen[skuId]; !ok {
+			seen[skuId] = struct{}{}
+			unique = append(unique, skuId)
+		}
+	}
+
+	available, err := s.repository.GetBySkuIds(ctx, unique)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stocks by skus, %w", err)
+	}
+
+	var stocks = make([]model.StockAvailability, 0, len(unique))
+	for _, skuId := range unique {
+		count, found := available[skuId]
+		stocks = append(stocks, model.StockAvailability{
+			Sku:   skuId,
+			Count: count,
+			Found: found,
+		})
+	}
+
+	return stocks, nil
+}
+
+// AddStock implements controllers.StockAdminService.
+func (s *StockService) AddStock(ctx context.Context, sku int64, count uint32, reason string) (*model.StockModel, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_service.AddStock")
+	defer span.End()
+
+	if count == 0 {
+		return nil, &model.ErrInvalidStockChange{Sku: sku, Reason: "count must be greater than 0"}
+	}
+
+	return s.changeTotalCount(ctx, &model.StockChange{
+		Sku:       sku,
+		Operation: model.StockOperationAdd,
+		Delta:     int64(count),
+		Reason:    reason,
+	})
+}
+
+// AdjustStock implements controllers.StockAdminService.
+func (s *StockService) AdjustStock(ctx context.Context, sku int64, delta int64, reason string) (*model.StockModel, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_service.AdjustStock")
+	defer span.End()
+
+	if delta == 0 {
+		return nil, &model.ErrInvalidStockChange{Sku: sku, Reason: "delta must not be 0"}
+	}
+
+	if reason == "" {
+		return nil, &model.ErrInvalidStockChange{Sku: sku, Reason: "reason is required for adjustments"}
+	}
+
+	return s.changeTotalCount(ctx, &model.StockChange{
+		Sku:       sku,
+		Operation: model.StockOperationAdjust,
+		Delta:     delta,
+		Reason:    reason,
+	})
+}
+
+// SetStock implements controllers.StockAdminService.
+func (s *StockService) SetStock(ctx context.Context, sku int64, totalCount uint32, reason string) (*model.StockModel, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_service.SetStock")
+	defer span.End()
+
+	return s.changeTotalCount(ctx, &model.StockChange{
+		Sku:        sku,
+		Operation:  model.StockOperationSet,
+		TotalCount: totalCount,
+		Reason:     reason,
+	})
+}
+
+func (s *StockService) changeTotalCount(ctx context.Context, change *model.StockChange) (*model.StockModel, error) {
+	if change.Sku < 1 {
+		return nil, &model.ErrInvalidStockChange{Sku: change.Sku, Reason: "sku must be greater than 0"}
+	}
+
+	stock, err := s.repository.ChangeTotalCount(ctx, change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s stock: %w", change.Operation, err)
+	}
+
+	return stock, nil
+}
+
+// ReconcileReserved compares the reserved counters with open reservations and returns the drifted stocks.
+// The counters are recomputed from open reservations when repair is set.
+func (s *StockService) ReconcileReserved(ctx context.Context, repair bool) ([]model.ReservedDrift, error) {
+	ctx, span := otel.GetTracerProvider().Tracer("").Start(ctx, "stock_service.ReconcileReserved")
+	defer span.End()
+
+	drifts, err := s.repository.ReconcileReserved(ctx, repair)
+
+	var repaired int
+	for _, drift := range drifts {
+		if drift.Repaired {
+			repaired++
+		}
+	}
+	sre.TrackReservedDrift(len(drifts)-repaired, repaired)
+
+	if err != nil {
+		return drifts, fmt.Errorf("failed to reconcile reserved stocks, %w", err)
+	}
+
+	return drifts, nil
+}
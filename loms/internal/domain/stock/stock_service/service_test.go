@@ -0,0 +1,87 @@
+package stock_service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"route256/loms/internal/domain/model"
+	"route256/loms/internal/domain/stock/stock_service"
+
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockService_StocksInfoBatch(t *testing.T) {
+	t.Parallel()
+	mc := minimock.NewController(t)
+
+	tests := []struct {
+		name        string
+		repo        *StockRepositoryMock
+		skuIds      []int64
+		want        []model.StockAvailability
+		wantErr     bool
+		wantInvalid bool
+	}{
+		{
+			name: "should answer duplicate skus once in the order of the first occurrence",
+			repo: NewStockRepositoryMock(mc).
+				GetBySkuIdsMock.Expect(minimock.AnyContext, []int64{3, 1, 2}).
+				Return(map[int64]uint32{1: 10, 3: 30}, nil),
+			skuIds: []int64{3, 1, 3, 2, 1},
+			want: []model.StockAvailability{
+				{Sku: 3, Count: 30, Found: true},
+				{Sku: 1, Count: 10, Found: true},
+				{Sku: 2, Count: 0, Found: false},
+			},
+		},
+		{
+			name:        "should fail on an empty batch",
+			repo:        NewStockRepositoryMock(mc),
+			skuIds:      []int64{},
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name:        "should fail on a too large batch",
+			repo:        NewStockRepositoryMock(mc),
+			skuIds:      make([]int64, stock_service.MaxStocksInfoBatchSize+1),
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name:        "should fail on an invalid sku",
+			repo:        NewStockRepositoryMock(mc),
+			skuIds:      []int64{1, 0},
+			wantErr:     true,
+			wantInvalid: true,
+		},
+		{
+			name: "should fail if repository fails",
+			repo: NewStockRepositoryMock(mc).
+				GetBySkuIdsMock.Return(nil, errors.New("db error")),
+			skuIds:  []int64{1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			service := stock_service.NewStockService(tt.repo)
+
+			got, err := service.StocksInfoBatch(context.Background(), tt.skuIds)
+			if tt.wantErr {
+				require.Error(t, err)
+				if tt.wantInvalid {
+					var invalidErr *model.ErrInvalidStocksBatch
+					require.ErrorAs(t, err, &invalidErr)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
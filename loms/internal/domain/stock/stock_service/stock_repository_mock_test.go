@@ -0,0 +1,1486 @@
+// Code generated by http://github.com/gojuno/minimock (v3.4.5). DO NOT EDIT.
+
+package stock_service_test
+
+//go:generate minimock -i route256/loms/internal/domain/stock/stock_service.StockRepository -o stock_repository_mock_test.go -n StockRepositoryMock -p stock_service_test
+
+import (
+	"context"
+	"route256/loms/internal/domain/model"
+	"sync"
+	mm_atomic "sync/atomic"
+	mm_time "time"
+
+	"github.com/gojuno/minimock/v3"
+)
+
+// StockRepositoryMock implements StockRepository
+type StockRepositoryMock struct {
+	t          minimock.Tester
+	finishOnce sync.Once
+
+	funcChangeTotalCount          func(ctx context.Context, change *model.StockChange) (sp1 *model.StockModel, err error)
+	funcChangeTotalCountOrigin    string
+	inspectFuncChangeTotalCount   func(ctx context.Context, change *model.StockChange)
+	afterChangeTotalCountCounter  uint64
+	beforeChangeTotalCountCounter uint64
+	ChangeTotalCountMock          mStockRepositoryMockChangeTotalCount
+
+	funcGetBySkuId          func(ctx context.Context, sku int64) (u1 uint32, err error)
+	funcGetBySkuIdOrigin    string
+	inspectFuncGetBySkuId   func(ctx context.Context, sku int64)
+	afterGetBySkuIdCounter  uint64
+	beforeGetBySkuIdCounter uint64
+	GetBySkuIdMock          mStockRepositoryMockGetBySkuId
+
+	funcGetBySkuIds          func(ctx context.Context, skus []int64) (m1 map[int64]uint32, err error)
+	funcGetBySkuIdsOrigin    string
+	inspectFuncGetBySkuIds   func(ctx context.Context, skus []int64)
+	afterGetBySkuIdsCounter  uint64
+	beforeGetBySkuIdsCounter uint64
+	GetBySkuIdsMock          mStockRepositoryMockGetBySkuIds
+
+	funcReconcileReserved          func(ctx context.Context, repair bool) (ra1 []model.ReservedDrift, err error)
+	funcReconcileReservedOrigin    string
+	inspectFuncReconcileReserved   func(ctx context.Context, repair bool)
+	afterReconcileReservedCounter  uint64
+	beforeReconcileReservedCounter uint64
+	ReconcileReservedMock          mStockRepositoryMockReconcileReserved
+}
+
+// NewStockRepositoryMock returns a mock for StockRepository
+func NewStockRepositoryMock(t minimock.Tester) *StockRepositoryMock {
+	m := &StockRepositoryMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.ChangeTotalCountMock = mStockRepositoryMockChangeTotalCount{mock: m}
+	m.ChangeTotalCountMock.callArgs = []*StockRepositoryMockChangeTotalCountParams{}
+
+	m.GetBySkuIdMock = mStockRepositoryMockGetBySkuId{mock: m}
+	m.GetBySkuIdMock.callArgs = []*StockRepositoryMockGetBySkuIdParams{}
+
+	m.GetBySkuIdsMock = mStockRepositoryMockGetBySkuIds{mock: m}
+	m.GetBySkuIdsMock.callArgs = []*StockRepositoryMockGetBySkuIdsParams{}
+
+	m.ReconcileReservedMock = mStockRepositoryMockReconcileReserved{mock: m}
+	m.ReconcileReservedMock.callArgs = []*StockRepositoryMockReconcileReservedParams{}
+
+	t.Cleanup(m.MinimockFinish)
+
+	return m
+}
+
+type mStockRepositoryMockChangeTotalCount struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockChangeTotalCountExpectation
+	expectations       []*StockRepositoryMockChangeTotalCountExpectation
+
+	callArgs []*StockRepositoryMockChangeTotalCountParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// StockRepositoryMockChangeTotalCountExpectation specifies expectation struct of the StockRepository.ChangeTotalCount
+type StockRepositoryMockChangeTotalCountExpectation struct {
+	mock               *StockRepositoryMock
+	params             *StockRepositoryMockChangeTotalCountParams
+	paramPtrs          *StockRepositoryMockChangeTotalCountParamPtrs
+	expectationOrigins StockRepositoryMockChangeTotalCountExpectationOrigins
+	results            *StockRepositoryMockChangeTotalCountResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// StockRepositoryMockChangeTotalCountParams contains parameters of the StockRepository.ChangeTotalCount
+type StockRepositoryMockChangeTotalCountParams struct {
+	ctx    context.Context
+	change *model.StockChange
+}
+
+// StockRepositoryMockChangeTotalCountParamPtrs contains pointers to parameters of the StockRepository.ChangeTotalCount
+type StockRepositoryMockChangeTotalCountParamPtrs struct {
+	ctx    *context.Context
+	change **model.StockChange
+}
+
+// StockRepositoryMockChangeTotalCountResults contains results of the StockRepository.ChangeTotalCount
+type StockRepositoryMockChangeTotalCountResults struct {
+	sp1 *model.StockModel
+	err error
+}
+
+// StockRepositoryMockChangeTotalCountOrigins contains origins of expectations of the StockRepository.ChangeTotalCount
+type StockRepositoryMockChangeTotalCountExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originChange string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) Optional() *mStockRepositoryMockChangeTotalCount {
+	mmChangeTotalCount.optional = true
+	return mmChangeTotalCount
+}
+
+// Expect sets up expected params for StockRepository.ChangeTotalCount
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) Expect(ctx context.Context, change *model.StockChange) *mStockRepositoryMockChangeTotalCount {
+	if mmChangeTotalCount.mock.funcChangeTotalCount != nil {
+		mmChangeTotalCount.mock.t.Fatalf("StockRepositoryMock.ChangeTotalCount mock is already set by Set")
+	}
+
+	if mmChangeTotalCount.defaultExpectation == nil {
+		mmChangeTotalCount.defaultExpectation = &StockRepositoryMockChangeTotalCountExpectation{}
+	}
+
+	if mmChangeTotalCount.defaultExpectation.paramPtrs != nil {
+		mmChangeTotalCount.mock.t.Fatalf("StockRepositoryMock.ChangeTotalCount mock is already set by ExpectParams functions")
+	}
+
+	mmChangeTotalCount.defaultExpectation.params = &StockRepositoryMockChangeTotalCountParams{ctx, change}
+	mmChangeTotalCount.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmChangeTotalCount.expectations {
+		if minimock.Equal(e.params, mmChangeTotalCount.defaultExpectation.params) {
+			mmChangeTotalCount.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmChangeTotalCount.defaultExpectation.params)
+		}
+	}
+
+	return mmChangeTotalCount
+}
+
+// ExpectCtxParam1 sets up expected param ctx for StockRepository.ChangeTotalCount
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockChangeTotalCount {
+	if mmChangeTotalCount.mock.funcChangeTotalCount != nil {
+		mmChangeTotalCount.mock.t.Fatalf("StockRepositoryMock.ChangeTotalCount mock is already set by Set")
+	}
+
+	if mmChangeTotalCount.defaultExpectation == nil {
+		mmChangeTotalCount.defaultExpectation = &StockRepositoryMockChangeTotalCountExpectation{}
+	}
+
+	if mmChangeTotalCount.defaultExpectation.params != nil {
+		mmChangeTotalCount.mock.t.Fatalf("StockRepositoryMock.ChangeTotalCount mock is already set by Expect")
+	}
+
+	if mmChangeTotalCount.defaultExpectation.paramPtrs == nil {
+		mmChangeTotalCount.defaultExpectation.paramPtrs = &StockRepositoryMockChangeTotalCountParamPtrs{}
+	}
+	mmChangeTotalCount.defaultExpectation.paramPtrs.ctx = &ctx
+	mmChangeTotalCount.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmChangeTotalCount
+}
+
+// ExpectChangeParam2 sets up expected param change for StockRepository.ChangeTotalCount
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) ExpectChangeParam2(change *model.StockChange) *mStockRepositoryMockChangeTotalCount {
+	if mmChangeTotalCount.mock.funcChangeTotalCount != nil {
+		mmChangeTotalCount.mock.t.Fatalf("StockRepositoryMock.ChangeTotalCount mock is already set by Set")
+	}
+
+	if mmChangeTotalCount.defaultExpectation == nil {
+		mmChangeTotalCount.defaultExpectation = &StockRepositoryMockChangeTotalCountExpectation{}
+	}
+
+	if mmChangeTotalCount.defaultExpectation.params != nil {
+		mmChangeTotalCount.mock.t.Fatalf("StockRepositoryMock.ChangeTotalCount mock is already set by Expect")
+	}
+
+	if mmChangeTotalCount.defaultExpectation.paramPtrs == nil {
+		mmChangeTotalCount.defaultExpectation.paramPtrs = &StockRepositoryMockChangeTotalCountParamPtrs{}
+	}
+	mmChangeTotalCount.defaultExpectation.paramPtrs.change = &change
+	mmChangeTotalCount.defaultExpectation.expectationOrigins.originChange = minimock.CallerInfo(1)
+
+	return mmChangeTotalCount
+}
+
+// Inspect accepts an inspector function that has same arguments as the StockRepository.ChangeTotalCount
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) Inspect(f func(ctx context.Context, change *model.StockChange)) *mStockRepositoryMockChangeTotalCount {
+	if mmChangeTotalCount.mock.inspectFuncChangeTotalCount != nil {
+		mmChangeTotalCount.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.ChangeTotalCount")
+	}
+
+	mmChangeTotalCount.mock.inspectFuncChangeTotalCount = f
+
+	return mmChangeTotalCount
+}
+
+// Return sets up results that will be returned by StockRepository.ChangeTotalCount
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) Return(sp1 *model.StockModel, err error) *StockRepositoryMock {
+	if mmChangeTotalCount.mock.funcChangeTotalCount != nil {
+		mmChangeTotalCount.mock.t.Fatalf("StockRepositoryMock.ChangeTotalCount mock is already set by Set")
+	}
+
+	if mmChangeTotalCount.defaultExpectation == nil {
+		mmChangeTotalCount.defaultExpectation = &StockRepositoryMockChangeTotalCountExpectation{mock: mmChangeTotalCount.mock}
+	}
+	mmChangeTotalCount.defaultExpectation.results = &StockRepositoryMockChangeTotalCountResults{sp1, err}
+	mmChangeTotalCount.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmChangeTotalCount.mock
+}
+
+// Set uses given function f to mock the StockRepository.ChangeTotalCount method
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) Set(f func(ctx context.Context, change *model.StockChange) (sp1 *model.StockModel, err error)) *StockRepositoryMock {
+	if mmChangeTotalCount.defaultExpectation != nil {
+		mmChangeTotalCount.mock.t.Fatalf("Default expectation is already set for the StockRepository.ChangeTotalCount method")
+	}
+
+	if len(mmChangeTotalCount.expectations) > 0 {
+		mmChangeTotalCount.mock.t.Fatalf("Some expectations are already set for the StockRepository.ChangeTotalCount method")
+	}
+
+	mmChangeTotalCount.mock.funcChangeTotalCount = f
+	mmChangeTotalCount.mock.funcChangeTotalCountOrigin = minimock.CallerInfo(1)
+	return mmChangeTotalCount.mock
+}
+
+// When sets expectation for the StockRepository.ChangeTotalCount which will trigger the result defined by the following
+// Then helper
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) When(ctx context.Context, change *model.StockChange) *StockRepositoryMockChangeTotalCountExpectation {
+	if mmChangeTotalCount.mock.funcChangeTotalCount != nil {
+		mmChangeTotalCount.mock.t.Fatalf("StockRepositoryMock.ChangeTotalCount mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockChangeTotalCountExpectation{
+		mock:               mmChangeTotalCount.mock,
+		params:             &StockRepositoryMockChangeTotalCountParams{ctx, change},
+		expectationOrigins: StockRepositoryMockChangeTotalCountExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmChangeTotalCount.expectations = append(mmChangeTotalCount.expectations, expectation)
+	return expectation
+}
+
+// Then sets up StockRepository.ChangeTotalCount return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockChangeTotalCountExpectation) Then(sp1 *model.StockModel, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockChangeTotalCountResults{sp1, err}
+	return e.mock
+}
+
+// Times sets number of times StockRepository.ChangeTotalCount should be invoked
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) Times(n uint64) *mStockRepositoryMockChangeTotalCount {
+	if n == 0 {
+		mmChangeTotalCount.mock.t.Fatalf("Times of StockRepositoryMock.ChangeTotalCount mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmChangeTotalCount.expectedInvocations, n)
+	mmChangeTotalCount.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmChangeTotalCount
+}
+
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) invocationsDone() bool {
+	if len(mmChangeTotalCount.expectations) == 0 && mmChangeTotalCount.defaultExpectation == nil && mmChangeTotalCount.mock.funcChangeTotalCount == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmChangeTotalCount.mock.afterChangeTotalCountCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmChangeTotalCount.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ChangeTotalCount implements StockRepository
+func (mmChangeTotalCount *StockRepositoryMock) ChangeTotalCount(ctx context.Context, change *model.StockChange) (sp1 *model.StockModel, err error) {
+	mm_atomic.AddUint64(&mmChangeTotalCount.beforeChangeTotalCountCounter, 1)
+	defer mm_atomic.AddUint64(&mmChangeTotalCount.afterChangeTotalCountCounter, 1)
+
+	mmChangeTotalCount.t.Helper()
+
+	if mmChangeTotalCount.inspectFuncChangeTotalCount != nil {
+		mmChangeTotalCount.inspectFuncChangeTotalCount(ctx, change)
+	}
+
+	mm_params := StockRepositoryMockChangeTotalCountParams{ctx, change}
+
+	// Record call args
+	mmChangeTotalCount.ChangeTotalCountMock.mutex.Lock()
+	mmChangeTotalCount.ChangeTotalCountMock.callArgs = append(mmChangeTotalCount.ChangeTotalCountMock.callArgs, &mm_params)
+	mmChangeTotalCount.ChangeTotalCountMock.mutex.Unlock()
+
+	for _, e := range mmChangeTotalCount.ChangeTotalCountMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sp1, e.results.err
+		}
+	}
+
+	if mmChangeTotalCount.ChangeTotalCountMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmChangeTotalCount.ChangeTotalCountMock.defaultExpectation.Counter, 1)
+		mm_want := mmChangeTotalCount.ChangeTotalCountMock.defaultExpectation.params
+		mm_want_ptrs := mmChangeTotalCount.ChangeTotalCountMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockChangeTotalCountParams{ctx, change}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmChangeTotalCount.t.Errorf("StockRepositoryMock.ChangeTotalCount got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmChangeTotalCount.ChangeTotalCountMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.change != nil && !minimock.Equal(*mm_want_ptrs.change, mm_got.change) {
+				mmChangeTotalCount.t.Errorf("StockRepositoryMock.ChangeTotalCount got unexpected parameter change, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmChangeTotalCount.ChangeTotalCountMock.defaultExpectation.expectationOrigins.originChange, *mm_want_ptrs.change, mm_got.change, minimock.Diff(*mm_want_ptrs.change, mm_got.change))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmChangeTotalCount.t.Errorf("StockRepositoryMock.ChangeTotalCount got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmChangeTotalCount.ChangeTotalCountMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmChangeTotalCount.ChangeTotalCountMock.defaultExpectation.results
+		if mm_results == nil {
+			mmChangeTotalCount.t.Fatal("No results are set for the StockRepositoryMock.ChangeTotalCount")
+		}
+		return (*mm_results).sp1, (*mm_results).err
+	}
+	if mmChangeTotalCount.funcChangeTotalCount != nil {
+		return mmChangeTotalCount.funcChangeTotalCount(ctx, change)
+	}
+	mmChangeTotalCount.t.Fatalf("Unexpected call to StockRepositoryMock.ChangeTotalCount. %v %v", ctx, change)
+	return
+}
+
+// ChangeTotalCountAfterCounter returns a count of finished StockRepositoryMock.ChangeTotalCount invocations
+func (mmChangeTotalCount *StockRepositoryMock) ChangeTotalCountAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmChangeTotalCount.afterChangeTotalCountCounter)
+}
+
+// ChangeTotalCountBeforeCounter returns a count of StockRepositoryMock.ChangeTotalCount invocations
+func (mmChangeTotalCount *StockRepositoryMock) ChangeTotalCountBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmChangeTotalCount.beforeChangeTotalCountCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.ChangeTotalCount.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmChangeTotalCount *mStockRepositoryMockChangeTotalCount) Calls() []*StockRepositoryMockChangeTotalCountParams {
+	mmChangeTotalCount.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockChangeTotalCountParams, len(mmChangeTotalCount.callArgs))
+	copy(argCopy, mmChangeTotalCount.callArgs)
+
+	mmChangeTotalCount.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockChangeTotalCountDone returns true if the count of the ChangeTotalCount invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockChangeTotalCountDone() bool {
+	if m.ChangeTotalCountMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ChangeTotalCountMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ChangeTotalCountMock.invocationsDone()
+}
+
+// MinimockChangeTotalCountInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockChangeTotalCountInspect() {
+	for _, e := range m.ChangeTotalCountMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.ChangeTotalCount at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterChangeTotalCountCounter := mm_atomic.LoadUint64(&m.afterChangeTotalCountCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ChangeTotalCountMock.defaultExpectation != nil && afterChangeTotalCountCounter < 1 {
+		if m.ChangeTotalCountMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to StockRepositoryMock.ChangeTotalCount at\n%s", m.ChangeTotalCountMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.ChangeTotalCount at\n%s with params: %#v", m.ChangeTotalCountMock.defaultExpectation.expectationOrigins.origin, *m.ChangeTotalCountMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcChangeTotalCount != nil && afterChangeTotalCountCounter < 1 {
+		m.t.Errorf("Expected call to StockRepositoryMock.ChangeTotalCount at\n%s", m.funcChangeTotalCountOrigin)
+	}
+
+	if !m.ChangeTotalCountMock.invocationsDone() && afterChangeTotalCountCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.ChangeTotalCount at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ChangeTotalCountMock.expectedInvocations), m.ChangeTotalCountMock.expectedInvocationsOrigin, afterChangeTotalCountCounter)
+	}
+}
+
+type mStockRepositoryMockGetBySkuId struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockGetBySkuIdExpectation
+	expectations       []*StockRepositoryMockGetBySkuIdExpectation
+
+	callArgs []*StockRepositoryMockGetBySkuIdParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// StockRepositoryMockGetBySkuIdExpectation specifies expectation struct of the StockRepository.GetBySkuId
+type StockRepositoryMockGetBySkuIdExpectation struct {
+	mock               *StockRepositoryMock
+	params             *StockRepositoryMockGetBySkuIdParams
+	paramPtrs          *StockRepositoryMockGetBySkuIdParamPtrs
+	expectationOrigins StockRepositoryMockGetBySkuIdExpectationOrigins
+	results            *StockRepositoryMockGetBySkuIdResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// StockRepositoryMockGetBySkuIdParams contains parameters of the StockRepository.GetBySkuId
+type StockRepositoryMockGetBySkuIdParams struct {
+	ctx context.Context
+	sku int64
+}
+
+// StockRepositoryMockGetBySkuIdParamPtrs contains pointers to parameters of the StockRepository.GetBySkuId
+type StockRepositoryMockGetBySkuIdParamPtrs struct {
+	ctx *context.Context
+	sku *int64
+}
+
+// StockRepositoryMockGetBySkuIdResults contains results of the StockRepository.GetBySkuId
+type StockRepositoryMockGetBySkuIdResults struct {
+	u1  uint32
+	err error
+}
+
+// StockRepositoryMockGetBySkuIdOrigins contains origins of expectations of the StockRepository.GetBySkuId
+type StockRepositoryMockGetBySkuIdExpectationOrigins struct {
+	origin    string
+	originCtx string
+	originSku string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) Optional() *mStockRepositoryMockGetBySkuId {
+	mmGetBySkuId.optional = true
+	return mmGetBySkuId
+}
+
+// Expect sets up expected params for StockRepository.GetBySkuId
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) Expect(ctx context.Context, sku int64) *mStockRepositoryMockGetBySkuId {
+	if mmGetBySkuId.mock.funcGetBySkuId != nil {
+		mmGetBySkuId.mock.t.Fatalf("StockRepositoryMock.GetBySkuId mock is already set by Set")
+	}
+
+	if mmGetBySkuId.defaultExpectation == nil {
+		mmGetBySkuId.defaultExpectation = &StockRepositoryMockGetBySkuIdExpectation{}
+	}
+
+	if mmGetBySkuId.defaultExpectation.paramPtrs != nil {
+		mmGetBySkuId.mock.t.Fatalf("StockRepositoryMock.GetBySkuId mock is already set by ExpectParams functions")
+	}
+
+	mmGetBySkuId.defaultExpectation.params = &StockRepositoryMockGetBySkuIdParams{ctx, sku}
+	mmGetBySkuId.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetBySkuId.expectations {
+		if minimock.Equal(e.params, mmGetBySkuId.defaultExpectation.params) {
+			mmGetBySkuId.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetBySkuId.defaultExpectation.params)
+		}
+	}
+
+	return mmGetBySkuId
+}
+
+// ExpectCtxParam1 sets up expected param ctx for StockRepository.GetBySkuId
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockGetBySkuId {
+	if mmGetBySkuId.mock.funcGetBySkuId != nil {
+		mmGetBySkuId.mock.t.Fatalf("StockRepositoryMock.GetBySkuId mock is already set by Set")
+	}
+
+	if mmGetBySkuId.defaultExpectation == nil {
+		mmGetBySkuId.defaultExpectation = &StockRepositoryMockGetBySkuIdExpectation{}
+	}
+
+	if mmGetBySkuId.defaultExpectation.params != nil {
+		mmGetBySkuId.mock.t.Fatalf("StockRepositoryMock.GetBySkuId mock is already set by Expect")
+	}
+
+	if mmGetBySkuId.defaultExpectation.paramPtrs == nil {
+		mmGetBySkuId.defaultExpectation.paramPtrs = &StockRepositoryMockGetBySkuIdParamPtrs{}
+	}
+	mmGetBySkuId.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetBySkuId.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetBySkuId
+}
+
+// ExpectSkuParam2 sets up expected param sku for StockRepository.GetBySkuId
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) ExpectSkuParam2(sku int64) *mStockRepositoryMockGetBySkuId {
+	if mmGetBySkuId.mock.funcGetBySkuId != nil {
+		mmGetBySkuId.mock.t.Fatalf("StockRepositoryMock.GetBySkuId mock is already set by Set")
+	}
+
+	if mmGetBySkuId.defaultExpectation == nil {
+		mmGetBySkuId.defaultExpectation = &StockRepositoryMockGetBySkuIdExpectation{}
+	}
+
+	if mmGetBySkuId.defaultExpectation.params != nil {
+		mmGetBySkuId.mock.t.Fatalf("StockRepositoryMock.GetBySkuId mock is already set by Expect")
+	}
+
+	if mmGetBySkuId.defaultExpectation.paramPtrs == nil {
+		mmGetBySkuId.defaultExpectation.paramPtrs = &StockRepositoryMockGetBySkuIdParamPtrs{}
+	}
+	mmGetBySkuId.defaultExpectation.paramPtrs.sku = &sku
+	mmGetBySkuId.defaultExpectation.expectationOrigins.originSku = minimock.CallerInfo(1)
+
+	return mmGetBySkuId
+}
+
+// Inspect accepts an inspector function that has same arguments as the StockRepository.GetBySkuId
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) Inspect(f func(ctx context.Context, sku int64)) *mStockRepositoryMockGetBySkuId {
+	if mmGetBySkuId.mock.inspectFuncGetBySkuId != nil {
+		mmGetBySkuId.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.GetBySkuId")
+	}
+
+	mmGetBySkuId.mock.inspectFuncGetBySkuId = f
+
+	return mmGetBySkuId
+}
+
+// Return sets up results that will be returned by StockRepository.GetBySkuId
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) Return(u1 uint32, err error) *StockRepositoryMock {
+	if mmGetBySkuId.mock.funcGetBySkuId != nil {
+		mmGetBySkuId.mock.t.Fatalf("StockRepositoryMock.GetBySkuId mock is already set by Set")
+	}
+
+	if mmGetBySkuId.defaultExpectation == nil {
+		mmGetBySkuId.defaultExpectation = &StockRepositoryMockGetBySkuIdExpectation{mock: mmGetBySkuId.mock}
+	}
+	mmGetBySkuId.defaultExpectation.results = &StockRepositoryMockGetBySkuIdResults{u1, err}
+	mmGetBySkuId.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetBySkuId.mock
+}
+
+// Set uses given function f to mock the StockRepository.GetBySkuId method
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) Set(f func(ctx context.Context, sku int64) (u1 uint32, err error)) *StockRepositoryMock {
+	if mmGetBySkuId.defaultExpectation != nil {
+		mmGetBySkuId.mock.t.Fatalf("Default expectation is already set for the StockRepository.GetBySkuId method")
+	}
+
+	if len(mmGetBySkuId.expectations) > 0 {
+		mmGetBySkuId.mock.t.Fatalf("Some expectations are already set for the StockRepository.GetBySkuId method")
+	}
+
+	mmGetBySkuId.mock.funcGetBySkuId = f
+	mmGetBySkuId.mock.funcGetBySkuIdOrigin = minimock.CallerInfo(1)
+	return mmGetBySkuId.mock
+}
+
+// When sets expectation for the StockRepository.GetBySkuId which will trigger the result defined by the following
+// Then helper
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) When(ctx context.Context, sku int64) *StockRepositoryMockGetBySkuIdExpectation {
+	if mmGetBySkuId.mock.funcGetBySkuId != nil {
+		mmGetBySkuId.mock.t.Fatalf("StockRepositoryMock.GetBySkuId mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockGetBySkuIdExpectation{
+		mock:               mmGetBySkuId.mock,
+		params:             &StockRepositoryMockGetBySkuIdParams{ctx, sku},
+		expectationOrigins: StockRepositoryMockGetBySkuIdExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetBySkuId.expectations = append(mmGetBySkuId.expectations, expectation)
+	return expectation
+}
+
+// Then sets up StockRepository.GetBySkuId return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockGetBySkuIdExpectation) Then(u1 uint32, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockGetBySkuIdResults{u1, err}
+	return e.mock
+}
+
+// Times sets number of times StockRepository.GetBySkuId should be invoked
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) Times(n uint64) *mStockRepositoryMockGetBySkuId {
+	if n == 0 {
+		mmGetBySkuId.mock.t.Fatalf("Times of StockRepositoryMock.GetBySkuId mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetBySkuId.expectedInvocations, n)
+	mmGetBySkuId.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetBySkuId
+}
+
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) invocationsDone() bool {
+	if len(mmGetBySkuId.expectations) == 0 && mmGetBySkuId.defaultExpectation == nil && mmGetBySkuId.mock.funcGetBySkuId == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetBySkuId.mock.afterGetBySkuIdCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetBySkuId.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetBySkuId implements StockRepository
+func (mmGetBySkuId *StockRepositoryMock) GetBySkuId(ctx context.Context, sku int64) (u1 uint32, err error) {
+	mm_atomic.AddUint64(&mmGetBySkuId.beforeGetBySkuIdCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetBySkuId.afterGetBySkuIdCounter, 1)
+
+	mmGetBySkuId.t.Helper()
+
+	if mmGetBySkuId.inspectFuncGetBySkuId != nil {
+		mmGetBySkuId.inspectFuncGetBySkuId(ctx, sku)
+	}
+
+	mm_params := StockRepositoryMockGetBySkuIdParams{ctx, sku}
+
+	// Record call args
+	mmGetBySkuId.GetBySkuIdMock.mutex.Lock()
+	mmGetBySkuId.GetBySkuIdMock.callArgs = append(mmGetBySkuId.GetBySkuIdMock.callArgs, &mm_params)
+	mmGetBySkuId.GetBySkuIdMock.mutex.Unlock()
+
+	for _, e := range mmGetBySkuId.GetBySkuIdMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.u1, e.results.err
+		}
+	}
+
+	if mmGetBySkuId.GetBySkuIdMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetBySkuId.GetBySkuIdMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetBySkuId.GetBySkuIdMock.defaultExpectation.params
+		mm_want_ptrs := mmGetBySkuId.GetBySkuIdMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockGetBySkuIdParams{ctx, sku}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetBySkuId.t.Errorf("StockRepositoryMock.GetBySkuId got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetBySkuId.GetBySkuIdMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.sku != nil && !minimock.Equal(*mm_want_ptrs.sku, mm_got.sku) {
+				mmGetBySkuId.t.Errorf("StockRepositoryMock.GetBySkuId got unexpected parameter sku, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetBySkuId.GetBySkuIdMock.defaultExpectation.expectationOrigins.originSku, *mm_want_ptrs.sku, mm_got.sku, minimock.Diff(*mm_want_ptrs.sku, mm_got.sku))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetBySkuId.t.Errorf("StockRepositoryMock.GetBySkuId got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetBySkuId.GetBySkuIdMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetBySkuId.GetBySkuIdMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetBySkuId.t.Fatal("No results are set for the StockRepositoryMock.GetBySkuId")
+		}
+		return (*mm_results).u1, (*mm_results).err
+	}
+	if mmGetBySkuId.funcGetBySkuId != nil {
+		return mmGetBySkuId.funcGetBySkuId(ctx, sku)
+	}
+	mmGetBySkuId.t.Fatalf("Unexpected call to StockRepositoryMock.GetBySkuId. %v %v", ctx, sku)
+	return
+}
+
+// GetBySkuIdAfterCounter returns a count of finished StockRepositoryMock.GetBySkuId invocations
+func (mmGetBySkuId *StockRepositoryMock) GetBySkuIdAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetBySkuId.afterGetBySkuIdCounter)
+}
+
+// GetBySkuIdBeforeCounter returns a count of StockRepositoryMock.GetBySkuId invocations
+func (mmGetBySkuId *StockRepositoryMock) GetBySkuIdBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetBySkuId.beforeGetBySkuIdCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.GetBySkuId.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetBySkuId *mStockRepositoryMockGetBySkuId) Calls() []*StockRepositoryMockGetBySkuIdParams {
+	mmGetBySkuId.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockGetBySkuIdParams, len(mmGetBySkuId.callArgs))
+	copy(argCopy, mmGetBySkuId.callArgs)
+
+	mmGetBySkuId.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetBySkuIdDone returns true if the count of the GetBySkuId invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockGetBySkuIdDone() bool {
+	if m.GetBySkuIdMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetBySkuIdMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetBySkuIdMock.invocationsDone()
+}
+
+// MinimockGetBySkuIdInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockGetBySkuIdInspect() {
+	for _, e := range m.GetBySkuIdMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetBySkuId at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetBySkuIdCounter := mm_atomic.LoadUint64(&m.afterGetBySkuIdCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetBySkuIdMock.defaultExpectation != nil && afterGetBySkuIdCounter < 1 {
+		if m.GetBySkuIdMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetBySkuId at\n%s", m.GetBySkuIdMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetBySkuId at\n%s with params: %#v", m.GetBySkuIdMock.defaultExpectation.expectationOrigins.origin, *m.GetBySkuIdMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetBySkuId != nil && afterGetBySkuIdCounter < 1 {
+		m.t.Errorf("Expected call to StockRepositoryMock.GetBySkuId at\n%s", m.funcGetBySkuIdOrigin)
+	}
+
+	if !m.GetBySkuIdMock.invocationsDone() && afterGetBySkuIdCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.GetBySkuId at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetBySkuIdMock.expectedInvocations), m.GetBySkuIdMock.expectedInvocationsOrigin, afterGetBySkuIdCounter)
+	}
+}
+
+type mStockRepositoryMockGetBySkuIds struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockGetBySkuIdsExpectation
+	expectations       []*StockRepositoryMockGetBySkuIdsExpectation
+
+	callArgs []*StockRepositoryMockGetBySkuIdsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// StockRepositoryMockGetBySkuIdsExpectation specifies expectation struct of the StockRepository.GetBySkuIds
+type StockRepositoryMockGetBySkuIdsExpectation struct {
+	mock               *StockRepositoryMock
+	params             *StockRepositoryMockGetBySkuIdsParams
+	paramPtrs          *StockRepositoryMockGetBySkuIdsParamPtrs
+	expectationOrigins StockRepositoryMockGetBySkuIdsExpectationOrigins
+	results            *StockRepositoryMockGetBySkuIdsResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// StockRepositoryMockGetBySkuIdsParams contains parameters of the StockRepository.GetBySkuIds
+type StockRepositoryMockGetBySkuIdsParams struct {
+	ctx  context.Context
+	skus []int64
+}
+
+// StockRepositoryMockGetBySkuIdsParamPtrs contains pointers to parameters of the StockRepository.GetBySkuIds
+type StockRepositoryMockGetBySkuIdsParamPtrs struct {
+	ctx  *context.Context
+	skus *[]int64
+}
+
+// StockRepositoryMockGetBySkuIdsResults contains results of the StockRepository.GetBySkuIds
+type StockRepositoryMockGetBySkuIdsResults struct {
+	m1  map[int64]uint32
+	err error
+}
+
+// StockRepositoryMockGetBySkuIdsOrigins contains origins of expectations of the StockRepository.GetBySkuIds
+type StockRepositoryMockGetBySkuIdsExpectationOrigins struct {
+	origin     string
+	originCtx  string
+	originSkus string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) Optional() *mStockRepositoryMockGetBySkuIds {
+	mmGetBySkuIds.optional = true
+	return mmGetBySkuIds
+}
+
+// Expect sets up expected params for StockRepository.GetBySkuIds
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) Expect(ctx context.Context, skus []int64) *mStockRepositoryMockGetBySkuIds {
+	if mmGetBySkuIds.mock.funcGetBySkuIds != nil {
+		mmGetBySkuIds.mock.t.Fatalf("StockRepositoryMock.GetBySkuIds mock is already set by Set")
+	}
+
+	if mmGetBySkuIds.defaultExpectation == nil {
+		mmGetBySkuIds.defaultExpectation = &StockRepositoryMockGetBySkuIdsExpectation{}
+	}
+
+	if mmGetBySkuIds.defaultExpectation.paramPtrs != nil {
+		mmGetBySkuIds.mock.t.Fatalf("StockRepositoryMock.GetBySkuIds mock is already set by ExpectParams functions")
+	}
+
+	mmGetBySkuIds.defaultExpectation.params = &StockRepositoryMockGetBySkuIdsParams{ctx, skus}
+	mmGetBySkuIds.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmGetBySkuIds.expectations {
+		if minimock.Equal(e.params, mmGetBySkuIds.defaultExpectation.params) {
+			mmGetBySkuIds.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetBySkuIds.defaultExpectation.params)
+		}
+	}
+
+	return mmGetBySkuIds
+}
+
+// ExpectCtxParam1 sets up expected param ctx for StockRepository.GetBySkuIds
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockGetBySkuIds {
+	if mmGetBySkuIds.mock.funcGetBySkuIds != nil {
+		mmGetBySkuIds.mock.t.Fatalf("StockRepositoryMock.GetBySkuIds mock is already set by Set")
+	}
+
+	if mmGetBySkuIds.defaultExpectation == nil {
+		mmGetBySkuIds.defaultExpectation = &StockRepositoryMockGetBySkuIdsExpectation{}
+	}
+
+	if mmGetBySkuIds.defaultExpectation.params != nil {
+		mmGetBySkuIds.mock.t.Fatalf("StockRepositoryMock.GetBySkuIds mock is already set by Expect")
+	}
+
+	if mmGetBySkuIds.defaultExpectation.paramPtrs == nil {
+		mmGetBySkuIds.defaultExpectation.paramPtrs = &StockRepositoryMockGetBySkuIdsParamPtrs{}
+	}
+	mmGetBySkuIds.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetBySkuIds.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmGetBySkuIds
+}
+
+// ExpectSkusParam2 sets up expected param skus for StockRepository.GetBySkuIds
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) ExpectSkusParam2(skus []int64) *mStockRepositoryMockGetBySkuIds {
+	if mmGetBySkuIds.mock.funcGetBySkuIds != nil {
+		mmGetBySkuIds.mock.t.Fatalf("StockRepositoryMock.GetBySkuIds mock is already set by Set")
+	}
+
+	if mmGetBySkuIds.defaultExpectation == nil {
+		mmGetBySkuIds.defaultExpectation = &StockRepositoryMockGetBySkuIdsExpectation{}
+	}
+
+	if mmGetBySkuIds.defaultExpectation.params != nil {
+		mmGetBySkuIds.mock.t.Fatalf("StockRepositoryMock.GetBySkuIds mock is already set by Expect")
+	}
+
+	if mmGetBySkuIds.defaultExpectation.paramPtrs == nil {
+		mmGetBySkuIds.defaultExpectation.paramPtrs = &StockRepositoryMockGetBySkuIdsParamPtrs{}
+	}
+	mmGetBySkuIds.defaultExpectation.paramPtrs.skus = &skus
+	mmGetBySkuIds.defaultExpectation.expectationOrigins.originSkus = minimock.CallerInfo(1)
+
+	return mmGetBySkuIds
+}
+
+// Inspect accepts an inspector function that has same arguments as the StockRepository.GetBySkuIds
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) Inspect(f func(ctx context.Context, skus []int64)) *mStockRepositoryMockGetBySkuIds {
+	if mmGetBySkuIds.mock.inspectFuncGetBySkuIds != nil {
+		mmGetBySkuIds.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.GetBySkuIds")
+	}
+
+	mmGetBySkuIds.mock.inspectFuncGetBySkuIds = f
+
+	return mmGetBySkuIds
+}
+
+// Return sets up results that will be returned by StockRepository.GetBySkuIds
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) Return(m1 map[int64]uint32, err error) *StockRepositoryMock {
+	if mmGetBySkuIds.mock.funcGetBySkuIds != nil {
+		mmGetBySkuIds.mock.t.Fatalf("StockRepositoryMock.GetBySkuIds mock is already set by Set")
+	}
+
+	if mmGetBySkuIds.defaultExpectation == nil {
+		mmGetBySkuIds.defaultExpectation = &StockRepositoryMockGetBySkuIdsExpectation{mock: mmGetBySkuIds.mock}
+	}
+	mmGetBySkuIds.defaultExpectation.results = &StockRepositoryMockGetBySkuIdsResults{m1, err}
+	mmGetBySkuIds.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmGetBySkuIds.mock
+}
+
+// Set uses given function f to mock the StockRepository.GetBySkuIds method
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) Set(f func(ctx context.Context, skus []int64) (m1 map[int64]uint32, err error)) *StockRepositoryMock {
+	if mmGetBySkuIds.defaultExpectation != nil {
+		mmGetBySkuIds.mock.t.Fatalf("Default expectation is already set for the StockRepository.GetBySkuIds method")
+	}
+
+	if len(mmGetBySkuIds.expectations) > 0 {
+		mmGetBySkuIds.mock.t.Fatalf("Some expectations are already set for the StockRepository.GetBySkuIds method")
+	}
+
+	mmGetBySkuIds.mock.funcGetBySkuIds = f
+	mmGetBySkuIds.mock.funcGetBySkuIdsOrigin = minimock.CallerInfo(1)
+	return mmGetBySkuIds.mock
+}
+
+// When sets expectation for the StockRepository.GetBySkuIds which will trigger the result defined by the following
+// Then helper
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) When(ctx context.Context, skus []int64) *StockRepositoryMockGetBySkuIdsExpectation {
+	if mmGetBySkuIds.mock.funcGetBySkuIds != nil {
+		mmGetBySkuIds.mock.t.Fatalf("StockRepositoryMock.GetBySkuIds mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockGetBySkuIdsExpectation{
+		mock:               mmGetBySkuIds.mock,
+		params:             &StockRepositoryMockGetBySkuIdsParams{ctx, skus},
+		expectationOrigins: StockRepositoryMockGetBySkuIdsExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmGetBySkuIds.expectations = append(mmGetBySkuIds.expectations, expectation)
+	return expectation
+}
+
+// Then sets up StockRepository.GetBySkuIds return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockGetBySkuIdsExpectation) Then(m1 map[int64]uint32, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockGetBySkuIdsResults{m1, err}
+	return e.mock
+}
+
+// Times sets number of times StockRepository.GetBySkuIds should be invoked
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) Times(n uint64) *mStockRepositoryMockGetBySkuIds {
+	if n == 0 {
+		mmGetBySkuIds.mock.t.Fatalf("Times of StockRepositoryMock.GetBySkuIds mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetBySkuIds.expectedInvocations, n)
+	mmGetBySkuIds.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmGetBySkuIds
+}
+
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) invocationsDone() bool {
+	if len(mmGetBySkuIds.expectations) == 0 && mmGetBySkuIds.defaultExpectation == nil && mmGetBySkuIds.mock.funcGetBySkuIds == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetBySkuIds.mock.afterGetBySkuIdsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetBySkuIds.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetBySkuIds implements StockRepository
+func (mmGetBySkuIds *StockRepositoryMock) GetBySkuIds(ctx context.Context, skus []int64) (m1 map[int64]uint32, err error) {
+	mm_atomic.AddUint64(&mmGetBySkuIds.beforeGetBySkuIdsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetBySkuIds.afterGetBySkuIdsCounter, 1)
+
+	mmGetBySkuIds.t.Helper()
+
+	if mmGetBySkuIds.inspectFuncGetBySkuIds != nil {
+		mmGetBySkuIds.inspectFuncGetBySkuIds(ctx, skus)
+	}
+
+	mm_params := StockRepositoryMockGetBySkuIdsParams{ctx, skus}
+
+	// Record call args
+	mmGetBySkuIds.GetBySkuIdsMock.mutex.Lock()
+	mmGetBySkuIds.GetBySkuIdsMock.callArgs = append(mmGetBySkuIds.GetBySkuIdsMock.callArgs, &mm_params)
+	mmGetBySkuIds.GetBySkuIdsMock.mutex.Unlock()
+
+	for _, e := range mmGetBySkuIds.GetBySkuIdsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.m1, e.results.err
+		}
+	}
+
+	if mmGetBySkuIds.GetBySkuIdsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetBySkuIds.GetBySkuIdsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetBySkuIds.GetBySkuIdsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetBySkuIds.GetBySkuIdsMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockGetBySkuIdsParams{ctx, skus}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetBySkuIds.t.Errorf("StockRepositoryMock.GetBySkuIds got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetBySkuIds.GetBySkuIdsMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.skus != nil && !minimock.Equal(*mm_want_ptrs.skus, mm_got.skus) {
+				mmGetBySkuIds.t.Errorf("StockRepositoryMock.GetBySkuIds got unexpected parameter skus, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmGetBySkuIds.GetBySkuIdsMock.defaultExpectation.expectationOrigins.originSkus, *mm_want_ptrs.skus, mm_got.skus, minimock.Diff(*mm_want_ptrs.skus, mm_got.skus))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetBySkuIds.t.Errorf("StockRepositoryMock.GetBySkuIds got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmGetBySkuIds.GetBySkuIdsMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetBySkuIds.GetBySkuIdsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetBySkuIds.t.Fatal("No results are set for the StockRepositoryMock.GetBySkuIds")
+		}
+		return (*mm_results).m1, (*mm_results).err
+	}
+	if mmGetBySkuIds.funcGetBySkuIds != nil {
+		return mmGetBySkuIds.funcGetBySkuIds(ctx, skus)
+	}
+	mmGetBySkuIds.t.Fatalf("Unexpected call to StockRepositoryMock.GetBySkuIds. %v %v", ctx, skus)
+	return
+}
+
+// GetBySkuIdsAfterCounter returns a count of finished StockRepositoryMock.GetBySkuIds invocations
+func (mmGetBySkuIds *StockRepositoryMock) GetBySkuIdsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetBySkuIds.afterGetBySkuIdsCounter)
+}
+
+// GetBySkuIdsBeforeCounter returns a count of StockRepositoryMock.GetBySkuIds invocations
+func (mmGetBySkuIds *StockRepositoryMock) GetBySkuIdsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetBySkuIds.beforeGetBySkuIdsCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.GetBySkuIds.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetBySkuIds *mStockRepositoryMockGetBySkuIds) Calls() []*StockRepositoryMockGetBySkuIdsParams {
+	mmGetBySkuIds.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockGetBySkuIdsParams, len(mmGetBySkuIds.callArgs))
+	copy(argCopy, mmGetBySkuIds.callArgs)
+
+	mmGetBySkuIds.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetBySkuIdsDone returns true if the count of the GetBySkuIds invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockGetBySkuIdsDone() bool {
+	if m.GetBySkuIdsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetBySkuIdsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetBySkuIdsMock.invocationsDone()
+}
+
+// MinimockGetBySkuIdsInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockGetBySkuIdsInspect() {
+	for _, e := range m.GetBySkuIdsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetBySkuIds at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterGetBySkuIdsCounter := mm_atomic.LoadUint64(&m.afterGetBySkuIdsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetBySkuIdsMock.defaultExpectation != nil && afterGetBySkuIdsCounter < 1 {
+		if m.GetBySkuIdsMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetBySkuIds at\n%s", m.GetBySkuIdsMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetBySkuIds at\n%s with params: %#v", m.GetBySkuIdsMock.defaultExpectation.expectationOrigins.origin, *m.GetBySkuIdsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetBySkuIds != nil && afterGetBySkuIdsCounter < 1 {
+		m.t.Errorf("Expected call to StockRepositoryMock.GetBySkuIds at\n%s", m.funcGetBySkuIdsOrigin)
+	}
+
+	if !m.GetBySkuIdsMock.invocationsDone() && afterGetBySkuIdsCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.GetBySkuIds at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.GetBySkuIdsMock.expectedInvocations), m.GetBySkuIdsMock.expectedInvocationsOrigin, afterGetBySkuIdsCounter)
+	}
+}
+
+type mStockRepositoryMockReconcileReserved struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockReconcileReservedExpectation
+	expectations       []*StockRepositoryMockReconcileReservedExpectation
+
+	callArgs []*StockRepositoryMockReconcileReservedParams
+	mutex    sync.RWMutex
+
+	expectedInvocations       uint64
+	expectedInvocationsOrigin string
+}
+
+// StockRepositoryMockReconcileReservedExpectation specifies expectation struct of the StockRepository.ReconcileReserved
+type StockRepositoryMockReconcileReservedExpectation struct {
+	mock               *StockRepositoryMock
+	params             *StockRepositoryMockReconcileReservedParams
+	paramPtrs          *StockRepositoryMockReconcileReservedParamPtrs
+	expectationOrigins StockRepositoryMockReconcileReservedExpectationOrigins
+	results            *StockRepositoryMockReconcileReservedResults
+	returnOrigin       string
+	Counter            uint64
+}
+
+// StockRepositoryMockReconcileReservedParams contains parameters of the StockRepository.ReconcileReserved
+type StockRepositoryMockReconcileReservedParams struct {
+	ctx    context.Context
+	repair bool
+}
+
+// StockRepositoryMockReconcileReservedParamPtrs contains pointers to parameters of the StockRepository.ReconcileReserved
+type StockRepositoryMockReconcileReservedParamPtrs struct {
+	ctx    *context.Context
+	repair *bool
+}
+
+// StockRepositoryMockReconcileReservedResults contains results of the StockRepository.ReconcileReserved
+type StockRepositoryMockReconcileReservedResults struct {
+	ra1 []model.ReservedDrift
+	err error
+}
+
+// StockRepositoryMockReconcileReservedOrigins contains origins of expectations of the StockRepository.ReconcileReserved
+type StockRepositoryMockReconcileReservedExpectationOrigins struct {
+	origin       string
+	originCtx    string
+	originRepair string
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) Optional() *mStockRepositoryMockReconcileReserved {
+	mmReconcileReserved.optional = true
+	return mmReconcileReserved
+}
+
+// Expect sets up expected params for StockRepository.ReconcileReserved
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) Expect(ctx context.Context, repair bool) *mStockRepositoryMockReconcileReserved {
+	if mmReconcileReserved.mock.funcReconcileReserved != nil {
+		mmReconcileReserved.mock.t.Fatalf("StockRepositoryMock.ReconcileReserved mock is already set by Set")
+	}
+
+	if mmReconcileReserved.defaultExpectation == nil {
+		mmReconcileReserved.defaultExpectation = &StockRepositoryMockReconcileReservedExpectation{}
+	}
+
+	if mmReconcileReserved.defaultExpectation.paramPtrs != nil {
+		mmReconcileReserved.mock.t.Fatalf("StockRepositoryMock.ReconcileReserved mock is already set by ExpectParams functions")
+	}
+
+	mmReconcileReserved.defaultExpectation.params = &StockRepositoryMockReconcileReservedParams{ctx, repair}
+	mmReconcileReserved.defaultExpectation.expectationOrigins.origin = minimock.CallerInfo(1)
+	for _, e := range mmReconcileReserved.expectations {
+		if minimock.Equal(e.params, mmReconcileReserved.defaultExpectation.params) {
+			mmReconcileReserved.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReconcileReserved.defaultExpectation.params)
+		}
+	}
+
+	return mmReconcileReserved
+}
+
+// ExpectCtxParam1 sets up expected param ctx for StockRepository.ReconcileReserved
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockReconcileReserved {
+	if mmReconcileReserved.mock.funcReconcileReserved != nil {
+		mmReconcileReserved.mock.t.Fatalf("StockRepositoryMock.ReconcileReserved mock is already set by Set")
+	}
+
+	if mmReconcileReserved.defaultExpectation == nil {
+		mmReconcileReserved.defaultExpectation = &StockRepositoryMockReconcileReservedExpectation{}
+	}
+
+	if mmReconcileReserved.defaultExpectation.params != nil {
+		mmReconcileReserved.mock.t.Fatalf("StockRepositoryMock.ReconcileReserved mock is already set by Expect")
+	}
+
+	if mmReconcileReserved.defaultExpectation.paramPtrs == nil {
+		mmReconcileReserved.defaultExpectation.paramPtrs = &StockRepositoryMockReconcileReservedParamPtrs{}
+	}
+	mmReconcileReserved.defaultExpectation.paramPtrs.ctx = &ctx
+	mmReconcileReserved.defaultExpectation.expectationOrigins.originCtx = minimock.CallerInfo(1)
+
+	return mmReconcileReserved
+}
+
+// ExpectRepairParam2 sets up expected param repair for StockRepository.ReconcileReserved
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) ExpectRepairParam2(repair bool) *mStockRepositoryMockReconcileReserved {
+	if mmReconcileReserved.mock.funcReconcileReserved != nil {
+		mmReconcileReserved.mock.t.Fatalf("StockRepositoryMock.ReconcileReserved mock is already set by Set")
+	}
+
+	if mmReconcileReserved.defaultExpectation == nil {
+		mmReconcileReserved.defaultExpectation = &StockRepositoryMockReconcileReservedExpectation{}
+	}
+
+	if mmReconcileReserved.defaultExpectation.params != nil {
+		mmReconcileReserved.mock.t.Fatalf("StockRepositoryMock.ReconcileReserved mock is already set by Expect")
+	}
+
+	if mmReconcileReserved.defaultExpectation.paramPtrs == nil {
+		mmReconcileReserved.defaultExpectation.paramPtrs = &StockRepositoryMockReconcileReservedParamPtrs{}
+	}
+	mmReconcileReserved.defaultExpectation.paramPtrs.repair = &repair
+	mmReconcileReserved.defaultExpectation.expectationOrigins.originRepair = minimock.CallerInfo(1)
+
+	return mmReconcileReserved
+}
+
+// Inspect accepts an inspector function that has same arguments as the StockRepository.ReconcileReserved
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) Inspect(f func(ctx context.Context, repair bool)) *mStockRepositoryMockReconcileReserved {
+	if mmReconcileReserved.mock.inspectFuncReconcileReserved != nil {
+		mmReconcileReserved.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.ReconcileReserved")
+	}
+
+	mmReconcileReserved.mock.inspectFuncReconcileReserved = f
+
+	return mmReconcileReserved
+}
+
+// Return sets up results that will be returned by StockRepository.ReconcileReserved
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) Return(ra1 []model.ReservedDrift, err error) *StockRepositoryMock {
+	if mmReconcileReserved.mock.funcReconcileReserved != nil {
+		mmReconcileReserved.mock.t.Fatalf("StockRepositoryMock.ReconcileReserved mock is already set by Set")
+	}
+
+	if mmReconcileReserved.defaultExpectation == nil {
+		mmReconcileReserved.defaultExpectation = &StockRepositoryMockReconcileReservedExpectation{mock: mmReconcileReserved.mock}
+	}
+	mmReconcileReserved.defaultExpectation.results = &StockRepositoryMockReconcileReservedResults{ra1, err}
+	mmReconcileReserved.defaultExpectation.returnOrigin = minimock.CallerInfo(1)
+	return mmReconcileReserved.mock
+}
+
+// Set uses given function f to mock the StockRepository.ReconcileReserved method
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) Set(f func(ctx context.Context, repair bool) (ra1 []model.ReservedDrift, err error)) *StockRepositoryMock {
+	if mmReconcileReserved.defaultExpectation != nil {
+		mmReconcileReserved.mock.t.Fatalf("Default expectation is already set for the StockRepository.ReconcileReserved method")
+	}
+
+	if len(mmReconcileReserved.expectations) > 0 {
+		mmReconcileReserved.mock.t.Fatalf("Some expectations are already set for the StockRepository.ReconcileReserved method")
+	}
+
+	mmReconcileReserved.mock.funcReconcileReserved = f
+	mmReconcileReserved.mock.funcReconcileReservedOrigin = minimock.CallerInfo(1)
+	return mmReconcileReserved.mock
+}
+
+// When sets expectation for the StockRepository.ReconcileReserved which will trigger the result defined by the following
+// Then helper
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) When(ctx context.Context, repair bool) *StockRepositoryMockReconcileReservedExpectation {
+	if mmReconcileReserved.mock.funcReconcileReserved != nil {
+		mmReconcileReserved.mock.t.Fatalf("StockRepositoryMock.ReconcileReserved mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockReconcileReservedExpectation{
+		mock:               mmReconcileReserved.mock,
+		params:             &StockRepositoryMockReconcileReservedParams{ctx, repair},
+		expectationOrigins: StockRepositoryMockReconcileReservedExpectationOrigins{origin: minimock.CallerInfo(1)},
+	}
+	mmReconcileReserved.expectations = append(mmReconcileReserved.expectations, expectation)
+	return expectation
+}
+
+// Then sets up StockRepository.ReconcileReserved return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockReconcileReservedExpectation) Then(ra1 []model.ReservedDrift, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockReconcileReservedResults{ra1, err}
+	return e.mock
+}
+
+// Times sets number of times StockRepository.ReconcileReserved should be invoked
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) Times(n uint64) *mStockRepositoryMockReconcileReserved {
+	if n == 0 {
+		mmReconcileReserved.mock.t.Fatalf("Times of StockRepositoryMock.ReconcileReserved mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmReconcileReserved.expectedInvocations, n)
+	mmReconcileReserved.expectedInvocationsOrigin = minimock.CallerInfo(1)
+	return mmReconcileReserved
+}
+
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) invocationsDone() bool {
+	if len(mmReconcileReserved.expectations) == 0 && mmReconcileReserved.defaultExpectation == nil && mmReconcileReserved.mock.funcReconcileReserved == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmReconcileReserved.mock.afterReconcileReservedCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmReconcileReserved.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ReconcileReserved implements StockRepository
+func (mmReconcileReserved *StockRepositoryMock) ReconcileReserved(ctx context.Context, repair bool) (ra1 []model.ReservedDrift, err error) {
+	mm_atomic.AddUint64(&mmReconcileReserved.beforeReconcileReservedCounter, 1)
+	defer mm_atomic.AddUint64(&mmReconcileReserved.afterReconcileReservedCounter, 1)
+
+	mmReconcileReserved.t.Helper()
+
+	if mmReconcileReserved.inspectFuncReconcileReserved != nil {
+		mmReconcileReserved.inspectFuncReconcileReserved(ctx, repair)
+	}
+
+	mm_params := StockRepositoryMockReconcileReservedParams{ctx, repair}
+
+	// Record call args
+	mmReconcileReserved.ReconcileReservedMock.mutex.Lock()
+	mmReconcileReserved.ReconcileReservedMock.callArgs = append(mmReconcileReserved.ReconcileReservedMock.callArgs, &mm_params)
+	mmReconcileReserved.ReconcileReservedMock.mutex.Unlock()
+
+	for _, e := range mmReconcileReserved.ReconcileReservedMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ra1, e.results.err
+		}
+	}
+
+	if mmReconcileReserved.ReconcileReservedMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmReconcileReserved.ReconcileReservedMock.defaultExpectation.Counter, 1)
+		mm_want := mmReconcileReserved.ReconcileReservedMock.defaultExpectation.params
+		mm_want_ptrs := mmReconcileReserved.ReconcileReservedMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockReconcileReservedParams{ctx, repair}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmReconcileReserved.t.Errorf("StockRepositoryMock.ReconcileReserved got unexpected parameter ctx, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReconcileReserved.ReconcileReservedMock.defaultExpectation.expectationOrigins.originCtx, *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.repair != nil && !minimock.Equal(*mm_want_ptrs.repair, mm_got.repair) {
+				mmReconcileReserved.t.Errorf("StockRepositoryMock.ReconcileReserved got unexpected parameter repair, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+					mmReconcileReserved.ReconcileReservedMock.defaultExpectation.expectationOrigins.originRepair, *mm_want_ptrs.repair, mm_got.repair, minimock.Diff(*mm_want_ptrs.repair, mm_got.repair))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmReconcileReserved.t.Errorf("StockRepositoryMock.ReconcileReserved got unexpected parameters, expected at\n%s:\nwant: %#v\n got: %#v%s\n",
+				mmReconcileReserved.ReconcileReservedMock.defaultExpectation.expectationOrigins.origin, *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmReconcileReserved.ReconcileReservedMock.defaultExpectation.results
+		if mm_results == nil {
+			mmReconcileReserved.t.Fatal("No results are set for the StockRepositoryMock.ReconcileReserved")
+		}
+		return (*mm_results).ra1, (*mm_results).err
+	}
+	if mmReconcileReserved.funcReconcileReserved != nil {
+		return mmReconcileReserved.funcReconcileReserved(ctx, repair)
+	}
+	mmReconcileReserved.t.Fatalf("Unexpected call to StockRepositoryMock.ReconcileReserved. %v %v", ctx, repair)
+	return
+}
+
+// ReconcileReservedAfterCounter returns a count of finished StockRepositoryMock.ReconcileReserved invocations
+func (mmReconcileReserved *StockRepositoryMock) ReconcileReservedAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReconcileReserved.afterReconcileReservedCounter)
+}
+
+// ReconcileReservedBeforeCounter returns a count of StockRepositoryMock.ReconcileReserved invocations
+func (mmReconcileReserved *StockRepositoryMock) ReconcileReservedBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReconcileReserved.beforeReconcileReservedCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.ReconcileReserved.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmReconcileReserved *mStockRepositoryMockReconcileReserved) Calls() []*StockRepositoryMockReconcileReservedParams {
+	mmReconcileReserved.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockReconcileReservedParams, len(mmReconcileReserved.callArgs))
+	copy(argCopy, mmReconcileReserved.callArgs)
+
+	mmReconcileReserved.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockReconcileReservedDone returns true if the count of the ReconcileReserved invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockReconcileReservedDone() bool {
+	if m.ReconcileReservedMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ReconcileReservedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ReconcileReservedMock.invocationsDone()
+}
+
+// MinimockReconcileReservedInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockReconcileReservedInspect() {
+	for _, e := range m.ReconcileReservedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.ReconcileReserved at\n%s with params: %#v", e.expectationOrigins.origin, *e.params)
+		}
+	}
+
+	afterReconcileReservedCounter := mm_atomic.LoadUint64(&m.afterReconcileReservedCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReconcileReservedMock.defaultExpectation != nil && afterReconcileReservedCounter < 1 {
+		if m.ReconcileReservedMock.defaultExpectation.params == nil {
+			m.t.Errorf("Expected call to StockRepositoryMock.ReconcileReserved at\n%s", m.ReconcileReservedMock.defaultExpectation.returnOrigin)
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.ReconcileReserved at\n%s with params: %#v", m.ReconcileReservedMock.defaultExpectation.expectationOrigins.origin, *m.ReconcileReservedMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReconcileReserved != nil && afterReconcileReservedCounter < 1 {
+		m.t.Errorf("Expected call to StockRepositoryMock.ReconcileReserved at\n%s", m.funcReconcileReservedOrigin)
+	}
+
+	if !m.ReconcileReservedMock.invocationsDone() && afterReconcileReservedCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.ReconcileReserved at\n%s but found %d calls",
+			mm_atomic.LoadUint64(&m.ReconcileReservedMock.expectedInvocations), m.ReconcileReservedMock.expectedInvocationsOrigin, afterReconcileReservedCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *StockRepositoryMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockChangeTotalCountInspect()
+
+			m.MinimockGetBySkuIdInspect()
+
+			m.MinimockGetBySkuIdsInspect()
+
+			m.MinimockReconcileReservedInspect()
+		}
+	})
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *StockRepositoryMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *StockRepositoryMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockChangeTotalCountDone() &&
+		m.MinimockGetBySkuIdDone() &&
+		m.MinimockGetBySkuIdsDone() &&
+		m.MinimockReconcileReservedDone()
+}
@@ -2,6 +2,7 @@ package loms_config
 
 import (
 	"os"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
@@ -36,12 +37,57 @@ type JaegerConfig struct {
 	Port string `yaml:"port" validate:"required,number,gt=0,lte=65535"`
 }
 
+type OrderExpirationConfig struct {
+	PaymentTtl time.Duration `yaml:"payment_ttl" validate:"gt=0"`
+	Interval   time.Duration `yaml:"interval" validate:"gt=0"`
+	BatchSize  int32         `yaml:"batch_size" validate:"gt=0"`
+}
+
+type OrderRecoveryConfig struct {
+	// StuckAfter is the time an order may spend in an internal status before it is recovered
+	StuckAfter time.Duration `yaml:"stuck_after" validate:"gt=0"`
+	Interval   time.Duration `yaml:"interval" validate:"gt=0"`
+	BatchSize  int32         `yaml:"batch_size" validate:"gt=0"`
+}
+
+type StockReconciliationConfig struct {
+	Interval time.Duration `yaml:"interval" validate:"gt=0"`
+	// Repair recomputes the reserved counters from open reservations, drift is only reported otherwise
+	Repair bool `yaml:"repair"`
+}
+
+type OutboxConfig struct {
+	// LeaseTtl is the time an instance owns claimed messages, it must outlast a kafka send
+	LeaseTtl time.Duration `yaml:"lease_ttl" validate:"gt=0"`
+	// ListenPoll is the poll interval while outbox notifications are listened, kafka.poll is used otherwise
+	ListenPoll time.Duration `yaml:"listen_poll" validate:"gt=0"`
+	// MaxAttempts is the number of delivery attempts before a message is dead-lettered
+	MaxAttempts int32         `yaml:"max_attempts" validate:"gt=0"`
+	BackoffBase time.Duration `yaml:"backoff_base" validate:"gt=0"`
+	BackoffMax  time.Duration `yaml:"backoff_max" validate:"gtefield=BackoffBase"`
+}
+
+type OutboxRetentionConfig struct {
+	// RetentionDays is the number of days sent messages are kept in the outbox
+	RetentionDays int32         `yaml:"retention_days" validate:"gt=0"`
+	Interval      time.Duration `yaml:"interval" validate:"gt=0"`
+	BatchSize     int32         `yaml:"batch_size" validate:"gt=0"`
+	// Archive moves purged messages to the outbox archive instead of deleting them
+	Archive bool `yaml:"archive"`
+}
+
 type Config struct {
 	Server    ServerConfig   `yaml:"service"`
 	MasterDb  DatabaseConfig `yaml:"db_master"`
 	ReplicaDb DatabaseConfig `yaml:"db_replica"`
 	Kafka     KafkaConfig    `yaml:"kafka"`
 	Jaeger    JaegerConfig   `yaml:"jaeger"`
+	Outbox    OutboxConfig   `yaml:"outbox"`
+
+	OrderExpiration     OrderExpirationConfig     `yaml:"order_expiration"`
+	OrderRecovery       OrderRecoveryConfig       `yaml:"order_recovery"`
+	StockReconciliation StockReconciliationConfig `yaml:"stock_reconciliation"`
+	OutboxRetention     OutboxRetentionConfig     `yaml:"outbox_retention"`
 }
 
 func LoadLomsConfig(filename string) (*Config, error) {
@@ -70,6 +70,67 @@ var (
 		},
 		[]string{"action", "status"},
 	)
+	TotalDatabaseRetries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "loms_database_total_retries",
+			Help: "Total number of transactions retried after a deadlock or a serialization failure",
+		},
+		[]string{"action", "code"},
+	)
+	StockReservedDrift = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "loms_stock_reserved_drift_skus",
+			Help: "Number of skus with the reserved counter not matching open reservations",
+		},
+	)
+	TotalRepairedReserved = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "loms_stock_reserved_total_repairs",
+			Help: "Total number of reserved counters recomputed from open reservations",
+		},
+	)
+	TotalRecoveredOrders = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "loms_recovered_total_orders",
+			Help: "Total number of orders resolved after being stuck in an internal status",
+		},
+		[]string{"from_status", "status"},
+	)
+	TotalOutboxFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "loms_outbox_total_failures",
+			Help: "Total number of outbox messages failed to be delivered",
+		},
+		[]string{"outcome"},
+	)
+	TotalOutboxPurged = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "loms_outbox_total_purged",
+			Help: "Total number of sent outbox messages removed by the retention worker",
+		},
+		[]string{"mode"},
+	)
+	OutboxBacklogSize = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "loms_outbox_backlog_messages",
+			Help: "Number of outbox messages not sent yet",
+		},
+		[]string{"status"},
+	)
+	OutboxBacklogAge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "loms_outbox_backlog_oldest_age_seconds",
+			Help: "Age of the oldest outbox message not sent yet",
+		},
+		[]string{"status"},
+	)
+	TotalExpiredOrders = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "loms_expired_total_orders",
+			Help: "Total number of orders cancelled after the payment timeout",
+		},
+		[]string{"status"},
+	)
 )
 
 func TrackDbRequest(action, category string, err error, start time.Time) {
@@ -92,6 +153,13 @@ func TrackDbRequest(action, category string, err error, start time.Time) {
 	}).Observe(duration.Seconds())
 }
 
+func TrackDbRetry(action, code string) {
+	TotalDatabaseRetries.With(prometheus.Labels{
+		"action": action,
+		"code":   code,
+	}).Inc()
+}
+
 func TrackExternalRequest(action string, err error, startTime time.Time) {
 	duration := time.Since(startTime)
 
@@ -138,3 +206,45 @@ func TrackHttpRequest(method, path string, statusCode int, startTime time.Time)
 		"code":   strconv.Itoa(statusCode),
 	}).Observe(duration.Seconds())
 }
+
+func TrackExpiredOrder(err error) {
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+
+	TotalExpiredOrders.With(prometheus.Labels{
+		"status": status,
+	}).Inc()
+}
+
+func TrackReservedDrift(drifted, repaired int) {
+	StockReservedDrift.Set(float64(drifted))
+	TotalRepairedReserved.Add(float64(repaired))
+}
+
+func TrackRecoveredOrder(fromStatus string, err error) {
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+
+	TotalRecoveredOrders.With(prometheus.Labels{
+		"from_status": fromStatus,
+		"status":      status,
+	}).Inc()
+}
+
+func TrackOutboxFailures(retried, dead int) {
+	TotalOutboxFailures.With(prometheus.Labels{"outcome": "retry"}).Add(float64(retried))
+	TotalOutboxFailures.With(prometheus.Labels{"outcome": "dead"}).Add(float64(dead))
+}
+
+func TrackOutboxPurged(mode string, purged int64) {
+	TotalOutboxPurged.With(prometheus.Labels{"mode": mode}).Add(float64(purged))
+}
+
+func TrackOutboxBacklog(status string, size int64, oldestAge time.Duration) {
+	OutboxBacklogSize.With(prometheus.Labels{"status": status}).Set(float64(size))
+	OutboxBacklogAge.With(prometheus.Labels{"status": status}).Set(oldestAge.Seconds())
+}
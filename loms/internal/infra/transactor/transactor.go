@@ -0,0 +1,74 @@
+package transactor
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// DBTX is satisfied by both the pool and the transaction, it covers the sqlc generated DBTX interfaces.
+type DBTX interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+	SendBatch(context.Context, *pgx.Batch) pgx.BatchResults
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// PgTransactor is the unit of work of the pg repositories. The transaction is passed to the
+// repositories through the context, so changes of several repositories are committed at once.
+type PgTransactor struct {
+	pool *pgxpool.Pool
+}
+
+func NewPgTransactor(pool *pgxpool.Pool) *PgTransactor {
+	return &PgTransactor{pool: pool}
+}
+
+// WithinTx runs txFunc in a transaction, the transaction is rolled back if txFunc fails.
+// Nested calls join the outer transaction.
+func (t *PgTransactor) WithinTx(ctx context.Context, txFunc func(ctx context.Context) error) error {
+	if InTx(ctx) {
+		return txFunc(ctx)
+	}
+
+	return pgx.BeginTxFunc(ctx, t.pool, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		return txFunc(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// NoopTransactor runs the function as is, the in-memory repositories are not transactional.
+type NoopTransactor struct{}
+
+func (NoopTransactor) WithinTx(ctx context.Context, txFunc func(ctx context.Context) error) error {
+	return txFunc(ctx)
+}
+
+// InTx reports whether the context carries a transaction of the unit of work.
+func InTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return ok
+}
+
+// Querier returns the transaction of the context or the pool outside of the unit of work.
+func Querier(ctx context.Context, pool *pgxpool.Pool) DBTX {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return pool
+}
+
+// BeginFunc runs txFunc in a transaction of the pool or in a savepoint of the context transaction,
+// so a failed repository call rolls back only its own changes.
+func BeginFunc(ctx context.Context, pool *pgxpool.Pool, txFunc func(tx pgx.Tx) error) error {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return pgx.BeginFunc(ctx, tx, txFunc)
+	}
+
+	return pgx.BeginTxFunc(ctx, pool, pgx.TxOptions{}, txFunc)
+}
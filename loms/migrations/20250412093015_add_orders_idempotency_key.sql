@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table orders
+add column idempotency_key text,
+    add constraint orders_user_id_idempotency_key_unique unique (user_id, idempotency_key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table orders drop constraint orders_user_id_idempotency_key_unique,
+    drop column idempotency_key;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+create index orders_awaiting_payment_updated_at_idx on orders (updated_at)
+where status = 'awaiting payment';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index orders_awaiting_payment_updated_at_idx;
+-- +goose StatementEnd
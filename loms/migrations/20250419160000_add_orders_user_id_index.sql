@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+create index orders_user_id_id_idx on orders (user_id, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index orders_user_id_id_idx;
+-- +goose StatementEnd
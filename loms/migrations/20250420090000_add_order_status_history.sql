@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+create table order_status_history (
+    id bigserial primary key,
+    order_id bigint not null references orders (id),
+    from_status text not null,
+    to_status text not null,
+    created_at timestamp default now() not null
+);
+
+create index order_status_history_order_id_idx on order_status_history (order_id, id);
+
+insert into order_status_history (order_id, from_status, to_status, created_at)
+select id,
+    '',
+    status,
+    updated_at
+from orders;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table order_status_history;
+-- +goose StatementEnd
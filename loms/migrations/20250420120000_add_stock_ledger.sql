@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+create table stock_ledger (
+    id bigserial primary key,
+    sku bigint not null,
+    operation text not null,
+    delta bigint not null,
+    total_before bigint not null,
+    total_after bigint not null,
+    reason text not null,
+    created_at timestamp default now() not null
+);
+
+create index stock_ledger_sku_idx on stock_ledger (sku, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table stock_ledger;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+-- reservations were applied to every duplicate row, so the duplicates are
+-- merged into the oldest row keeping the largest counters
+with merged as (
+    select sku,
+        min(id) as id,
+        max(total_count) as total_count,
+        max(reserved) as reserved
+    from stocks
+    group by sku
+    having count(*) > 1
+)
+update stocks s
+set total_count = m.total_count,
+    reserved = m.reserved,
+    updated_at = now()
+from merged m
+where s.id = m.id;
+
+delete from stocks s using stocks d
+where s.sku = d.sku
+    and s.id > d.id;
+
+alter table stocks
+add constraint stocks_sku_unique unique (sku);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table stocks drop constraint stocks_sku_unique;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+create table stock_reservations (
+    id bigserial primary key,
+    order_id bigint not null references orders (id),
+    sku bigint not null,
+    count bigint not null check (count > 0),
+    state text not null,
+    created_at timestamp default now() not null,
+    updated_at timestamp default now() not null,
+    constraint stock_reservations_order_sku_unique unique (order_id, sku)
+);
+
+create index stock_reservations_open_sku_idx on stock_reservations (sku)
+where state = 'reserved';
+
+-- orders holding stocks at the moment of migration
+insert into stock_reservations (order_id, sku, count, state)
+select i.order_id,
+    i.sku,
+    sum(i.quantity),
+    'reserved'
+from order_items i
+    join orders o on o.id = i.order_id
+where o.status in ('awaiting payment', 'paying', 'cancelling')
+group by i.order_id,
+    i.sku;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table stock_reservations;
+-- +goose StatementEnd
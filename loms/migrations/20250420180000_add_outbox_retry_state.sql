@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table outbox
+    add column attempts int not null default 0,
+    add column next_attempt_at timestamp with time zone not null default now(),
+    add column last_error text;
+
+-- failed batches were never retried, give them another chance
+update outbox
+set status = 'pending',
+    next_attempt_at = now(),
+    updated_at = now()
+where status = 'failed';
+
+drop index idx_outbox_pending;
+
+create index idx_outbox_pending on outbox (next_attempt_at)
+where status = 'pending';
+
+create index idx_outbox_dead on outbox (updated_at)
+where status = 'dead';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index idx_outbox_dead;
+
+drop index idx_outbox_pending;
+
+create index idx_outbox_pending on outbox (status, updated_at)
+where status = 'pending';
+
+update outbox
+set status = 'failed'
+where status = 'dead';
+
+alter table outbox
+    drop column last_error,
+    drop column next_attempt_at,
+    drop column attempts;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table outbox
+    add column lease_until timestamp with time zone,
+    add column owner text;
+
+create index idx_outbox_in_flight on outbox (lease_until)
+where status = 'in_flight';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index idx_outbox_in_flight;
+
+update outbox
+set status = 'pending',
+    updated_at = now()
+where status = 'in_flight';
+
+alter table outbox
+    drop column owner,
+    drop column lease_until;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+create table outbox_archive (
+    id bigint primary key,
+    aggregate_id text not null,
+    aggregate_type text not null,
+    event_type text not null,
+    key text not null,
+    payload jsonb not null,
+    topic text not null,
+    attempts int not null,
+    created_at timestamp with time zone,
+    sent_at timestamp with time zone,
+    archived_at timestamp with time zone default now() not null
+);
+
+create index idx_outbox_sent on outbox (updated_at)
+where status = 'sent';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index idx_outbox_sent;
+
+drop table outbox_archive;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+-- wakes up the outbox relay listening on the channel once the inserting transaction commits,
+-- notifications of one transaction are folded into one by postgres
+create function notify_outbox_pending() returns trigger as $$
+begin
+    perform pg_notify('outbox_pending', '');
+    return null;
+end;
+$$ language plpgsql;
+
+create trigger outbox_pending_notify
+after insert on outbox
+for each row
+when (new.status = 'pending')
+execute function notify_outbox_pending();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger outbox_pending_notify on outbox;
+
+drop function notify_outbox_pending();
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- reservations without an order are the opening balance held before the reservations were recorded
+alter table stock_reservations
+    alter column order_id drop not null;
+
+insert into stock_reservations (order_id, sku, count, state)
+select null,
+    s.sku,
+    s.reserved - coalesce(r.reserved, 0),
+    'reserved'
+from stocks s
+    left join (
+        select sku,
+            sum(count) as reserved
+        from stock_reservations
+        where state = 'reserved'
+        group by sku
+    ) r on r.sku = s.sku
+where s.reserved > coalesce(r.reserved, 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+delete from stock_reservations
+where order_id is null;
+
+alter table stock_reservations
+    alter column order_id set not null;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- messages requeued by an admin or returned to pending after a failed attempt wake up the relay as well
+create trigger outbox_requeued_notify
+after update of status on outbox
+for each row
+when (new.status = 'pending' and old.status <> 'pending')
+execute function notify_outbox_pending();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger outbox_requeued_notify on outbox;
+-- +goose StatementEnd
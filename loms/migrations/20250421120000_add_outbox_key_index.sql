@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- earlier undelivered messages of a key are looked up on every claim
+create index idx_outbox_undelivered_key on outbox (key, id)
+where status <> 'sent';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index idx_outbox_undelivered_key;
+-- +goose StatementEnd